@@ -0,0 +1,162 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/emc-protocol/edge-matrix/application/proto"
+)
+
+// HeaderApp is the header of the edge call naming the app of the edge node it is addressed to,
+// the call is served by the default app of the edge node if not set
+const HeaderApp = "Emc-App"
+
+const (
+	// MaxHostedApps is the maximum number of apps hosted by an edge node
+	MaxHostedApps = 16
+
+	// defaultAppGuageMax is the max limit of the slots of an app
+	defaultAppGuageMax = 200
+)
+
+var (
+	ErrUnknownApp       = errors.New("app not hosted by the edge node")
+	ErrNoApps           = errors.New("no app hosted by the edge node")
+	ErrInvalidAppConfig = errors.New("invalid app, expected name=url")
+	ErrInvalidAppName   = errors.New("invalid app name")
+	ErrDuplicateApp     = errors.New("duplicate app name")
+	ErrTooManyApps      = fmt.Errorf("more than %d apps", MaxHostedApps)
+)
+
+// AppConfig is an app hosted by the edge node, served at its url
+type AppConfig struct {
+	Name string
+	Url  string
+}
+
+// ParseAppConfig parses the app given as name=url
+func ParseAppConfig(raw string) (*AppConfig, error) {
+	name, url, ok := strings.Cut(raw, "=")
+	if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(url) == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAppConfig, raw)
+	}
+
+	return &AppConfig{Name: strings.TrimSpace(name), Url: strings.TrimSpace(url)}, nil
+}
+
+// ValidateAppConfigs checks the apps hosted by the edge node are named uniquely,
+// only the default app, which is the first one, may be unnamed
+func ValidateAppConfigs(apps []*AppConfig) error {
+	if len(apps) == 0 {
+		return ErrNoApps
+	}
+
+	if len(apps) > MaxHostedApps {
+		return ErrTooManyApps
+	}
+
+	names := make(map[string]bool, len(apps))
+
+	for i, app := range apps {
+		if app.Name == "" && i > 0 {
+			return ErrInvalidAppName
+		}
+
+		if names[app.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateApp, app.Name)
+		}
+
+		names[app.Name] = true
+	}
+
+	return nil
+}
+
+// HostedApp is the status of an app hosted by an edge node, advertised with the status of the node
+type HostedApp struct {
+	// Name addresses the app in the edge calls
+	Name string `json:"name"`
+
+	AppOrigin string `json:"appOrigin"`
+	ModelHash string `json:"modelHash"`
+
+	// amount of slots currently occupying the app, and its max limit
+	GuageHeight uint64 `json:"slots"`
+	GuageMax    uint64 `json:"maxSlots"`
+}
+
+func (a *HostedApp) Copy() *HostedApp {
+	newApp := *a
+
+	return &newApp
+}
+
+// FindHostedApp returns the app of the given name, or the default app, which is the first one, if the name is empty
+func FindHostedApp(apps []*HostedApp, name string) *HostedApp {
+	if name == "" {
+		if len(apps) == 0 {
+			return nil
+		}
+
+		return apps[0]
+	}
+
+	for _, app := range apps {
+		if app.Name == name {
+			return app
+		}
+	}
+
+	return nil
+}
+
+// HostedAppsToProto converts the hosted apps to their protobuf messages
+func HostedAppsToProto(apps []*HostedApp) []*proto.HostedApp {
+	msgs := make([]*proto.HostedApp, len(apps))
+
+	for i, app := range apps {
+		msgs[i] = &proto.HostedApp{
+			Name:        app.Name,
+			AppOrigin:   app.AppOrigin,
+			ModelHash:   app.ModelHash,
+			GuageHeight: app.GuageHeight,
+			GuageMax:    app.GuageMax,
+		}
+	}
+
+	return msgs
+}
+
+// HostedAppsFromProto converts and validates the received hosted apps, which are named uniquely as the configured ones,
+// empty without error if the peer reports none, as the nodes hosting a single app
+func HostedAppsFromProto(msgs []*proto.HostedApp) ([]*HostedApp, error) {
+	if len(msgs) > MaxHostedApps {
+		return nil, ErrTooManyApps
+	}
+
+	apps := make([]*HostedApp, len(msgs))
+	names := make(map[string]bool, len(msgs))
+
+	for i, msg := range msgs {
+		if msg.Name == "" && i > 0 {
+			return nil, ErrInvalidAppName
+		}
+
+		if names[msg.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateApp, msg.Name)
+		}
+
+		names[msg.Name] = true
+
+		apps[i] = &HostedApp{
+			Name:        msg.Name,
+			AppOrigin:   msg.AppOrigin,
+			ModelHash:   msg.ModelHash,
+			GuageHeight: msg.GuageHeight,
+			GuageMax:    msg.GuageMax,
+		}
+	}
+
+	return apps, nil
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAppConfig(t *testing.T) {
+	t.Parallel()
+
+	app, err := ParseAppConfig(" llama = http://127.0.0.1:9528 ")
+	require.NoError(t, err)
+	assert.Equal(t, &AppConfig{Name: "llama", Url: "http://127.0.0.1:9528"}, app)
+
+	for _, raw := range []string{"", "llama", "=http://127.0.0.1:9528", "llama="} {
+		_, err := ParseAppConfig(raw)
+		assert.ErrorIs(t, err, ErrInvalidAppConfig, raw)
+	}
+}
+
+func TestValidateAppConfigs(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateAppConfigs([]*AppConfig{{Name: ""}, {Name: "llama"}, {Name: "sdxl"}}))
+
+	assert.ErrorIs(t, ValidateAppConfigs(nil), ErrNoApps)
+	assert.ErrorIs(t, ValidateAppConfigs([]*AppConfig{{Name: "sd"}, {Name: ""}}), ErrInvalidAppName)
+	assert.ErrorIs(t, ValidateAppConfigs([]*AppConfig{{Name: "sd"}, {Name: "sd"}}), ErrDuplicateApp)
+	assert.ErrorIs(t, ValidateAppConfigs(make([]*AppConfig, MaxHostedApps+1)), ErrTooManyApps)
+}
+
+func TestHostedApps_Proto(t *testing.T) {
+	t.Parallel()
+
+	apps := []*HostedApp{
+		{Name: "", AppOrigin: "sd", ModelHash: "model-1", GuageHeight: 3, GuageMax: 200},
+		{Name: "llama", AppOrigin: "llm", ModelHash: "model-2", GuageMax: 200},
+	}
+
+	decoded, err := HostedAppsFromProto(HostedAppsToProto(apps))
+	require.NoError(t, err)
+	assert.Equal(t, apps, decoded)
+
+	msgs := HostedAppsToProto([]*HostedApp{{Name: "llama"}, {Name: "llama"}})
+	_, err = HostedAppsFromProto(msgs)
+	assert.ErrorIs(t, err, ErrDuplicateApp)
+
+	msgs = HostedAppsToProto([]*HostedApp{{Name: "llama"}, {Name: ""}})
+	_, err = HostedAppsFromProto(msgs)
+	assert.ErrorIs(t, err, ErrInvalidAppName)
+}
+
+func TestAppPeer_App(t *testing.T) {
+	t.Parallel()
+
+	peer := &AppPeer{
+		ID:        "a",
+		AppOrigin: "sd",
+		ModelHash: "model-1",
+		Apps: []*HostedApp{
+			{Name: "", AppOrigin: "sd", ModelHash: "model-1"},
+			{Name: "llama", AppOrigin: "llm", ModelHash: "model-2"},
+		},
+	}
+
+	assert.Equal(t, "model-1", peer.App("").ModelHash)
+	assert.Equal(t, "model-2", peer.App("llama").ModelHash)
+	assert.Nil(t, peer.App("sdxl"))
+
+	// the nodes prior to the hosting of several apps advertise their single app in the legacy fields
+	legacy := &AppPeer{ID: "b", Name: "sd", AppOrigin: "sd", ModelHash: "model-3", Guage_height: 5, Guage_max: 200}
+
+	assert.Equal(t, []*HostedApp{{Name: "sd", AppOrigin: "sd", ModelHash: "model-3", GuageHeight: 5, GuageMax: 200}}, legacy.HostedApps())
+	assert.Equal(t, "model-3", legacy.App("").ModelHash)
+}
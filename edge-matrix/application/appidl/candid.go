@@ -0,0 +1,618 @@
+package appidl
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// candidPrimitives are the JSON schemas of the Candid primitive types,
+// the values of the Candid types are encoded in JSON the way the IC agents do
+var candidPrimitives = map[string]func() *Type{
+	"nat":       unsignedInteger,
+	"nat8":      unsignedInteger,
+	"nat16":     unsignedInteger,
+	"nat32":     unsignedInteger,
+	"nat64":     unsignedInteger,
+	"int":       func() *Type { return &Type{Type: TypeInteger} },
+	"int8":      func() *Type { return &Type{Type: TypeInteger} },
+	"int16":     func() *Type { return &Type{Type: TypeInteger} },
+	"int32":     func() *Type { return &Type{Type: TypeInteger} },
+	"int64":     func() *Type { return &Type{Type: TypeInteger} },
+	"float32":   func() *Type { return &Type{Type: TypeNumber} },
+	"float64":   func() *Type { return &Type{Type: TypeNumber} },
+	"bool":      func() *Type { return &Type{Type: TypeBoolean} },
+	"text":      func() *Type { return &Type{Type: TypeString} },
+	"principal": func() *Type { return &Type{Type: TypeString} },
+	"blob":      func() *Type { return &Type{Type: TypeString} },
+	"null":      func() *Type { return &Type{Type: TypeNull} },
+	"reserved":  func() *Type { return &Type{} },
+	"empty":     func() *Type { return &Type{} },
+}
+
+func unsignedInteger() *Type {
+	zero := float64(0)
+
+	return &Type{Type: TypeInteger, Minimum: &zero}
+}
+
+// candidFunc is a parsed Candid function type
+type candidFunc struct {
+	args    []*Type
+	results []*Type
+}
+
+// candidParser parses the Candid service description of an app,
+// each method of the service is called with a POST on the path of its name
+type candidParser struct {
+	tokens []string
+	pos    int
+
+	schema   *Schema
+	funcs    map[string]*candidFunc
+	services map[string]map[string]*candidFunc
+}
+
+func parseCandid(src string) (*Schema, error) {
+	tokens, err := tokenizeCandid(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &candidParser{
+		tokens: tokens,
+		schema: &Schema{
+			Format:      FormatCandid,
+			Definitions: map[string]*Type{},
+		},
+		funcs:    map[string]*candidFunc{},
+		services: map[string]map[string]*candidFunc{},
+	}
+
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("invalid Candid IDL: %w", err)
+	}
+
+	return p.schema, nil
+}
+
+// tokenizeCandid splits the source into identifiers, numbers, quoted names and symbols, skipping the comments
+func tokenizeCandid(src string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+
+			i += end + 4
+
+		case strings.HasPrefix(src[i:], "->"):
+			tokens = append(tokens, "->")
+			i += 2
+
+		case strings.ContainsRune("(){}:;,=", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated text")
+			}
+
+			tokens = append(tokens, src[i:i+end+2])
+			i += end + 2
+
+		case c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+
+			tokens = append(tokens, src[start:i])
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *candidParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *candidParser) next() string {
+	tok := p.peek()
+	p.pos++
+
+	return tok
+}
+
+func (p *candidParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("expected %q, got %q", tok, got)
+	}
+
+	return nil
+}
+
+// accept consumes the token if it is the next one
+func (p *candidParser) accept(tok string) bool {
+	if p.peek() == tok {
+		p.pos++
+
+		return true
+	}
+
+	return false
+}
+
+// name parses an identifier or a quoted name
+func (p *candidParser) name() (string, error) {
+	tok := p.next()
+
+	if strings.HasPrefix(tok, `"`) {
+		return strings.Trim(tok, `"`), nil
+	}
+
+	if !isCandidIdent(tok) {
+		return "", fmt.Errorf("expected a name, got %q", tok)
+	}
+
+	return tok, nil
+}
+
+func isCandidIdent(tok string) bool {
+	if tok == "" || unicode.IsDigit(rune(tok[0])) {
+		return false
+	}
+
+	return !strings.ContainsAny(tok, `(){}:;,="`) && tok != "->"
+}
+
+func (p *candidParser) parse() error {
+	for p.peek() != "" {
+		switch p.next() {
+		case "type":
+			if err := p.parseTypeDef(); err != nil {
+				return err
+			}
+
+		case "import":
+			// the imported definitions are not available
+			p.next()
+
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+
+		case ";":
+
+		default:
+			return fmt.Errorf("unexpected %q", p.tokens[p.pos-1])
+		}
+	}
+
+	return nil
+}
+
+// parseTypeDef parses "type id = datatype", function and service types are kept aside for the methods
+func (p *candidParser) parseTypeDef() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	if err := p.expect("="); err != nil {
+		return err
+	}
+
+	switch {
+	case p.accept("func"):
+		fn, err := p.parseFuncType()
+		if err != nil {
+			return err
+		}
+
+		p.funcs[name] = fn
+
+	case p.peek() == "service":
+		p.next()
+
+		methods, err := p.parseServiceMethods()
+		if err != nil {
+			return err
+		}
+
+		p.services[name] = methods
+
+	default:
+		typ, err := p.parseDataType()
+		if err != nil {
+			return err
+		}
+
+		p.schema.Definitions[name] = typ
+	}
+
+	return nil
+}
+
+// parseService parses "service id? : (init args ->)? (methods | id)"
+func (p *candidParser) parseService() error {
+	if p.peek() != ":" {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+
+		p.schema.Service = name
+	}
+
+	if err := p.expect(":"); err != nil {
+		return err
+	}
+
+	// the init arguments of the service are not called through the edge node
+	if p.peek() == "(" {
+		if _, err := p.parseTupleType(); err != nil {
+			return err
+		}
+
+		if err := p.expect("->"); err != nil {
+			return err
+		}
+	}
+
+	var (
+		methods map[string]*candidFunc
+		err     error
+	)
+
+	if p.peek() == "{" {
+		if methods, err = p.parseServiceMethods(); err != nil {
+			return err
+		}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		if methods, ok = p.services[name]; !ok {
+			return fmt.Errorf("undefined service type %s", name)
+		}
+	}
+
+	p.addMethods(methods)
+
+	return nil
+}
+
+// parseServiceMethods parses "{ (name : (functype | id) ;)* }"
+func (p *candidParser) parseServiceMethods() (map[string]*candidFunc, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	methods := map[string]*candidFunc{}
+
+	for !p.accept("}") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		var fn *candidFunc
+
+		if p.peek() == "(" {
+			if fn, err = p.parseFuncType(); err != nil {
+				return nil, err
+			}
+		} else {
+			ref, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+
+			var ok bool
+			if fn, ok = p.funcs[ref]; !ok {
+				return nil, fmt.Errorf("undefined function type %s", ref)
+			}
+		}
+
+		methods[name] = fn
+
+		p.accept(";")
+	}
+
+	return methods, nil
+}
+
+// addMethods adds the service methods to the schema in the order of their names
+func (p *candidParser) addMethods(methods map[string]*candidFunc) {
+	names := make([]string, 0, len(methods))
+	for name := range methods {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fn := methods[name]
+
+		p.schema.Methods = append(p.schema.Methods, &Method{
+			Name:     name,
+			Path:     "/" + name,
+			Method:   http.MethodPost,
+			Body:     tupleSchema(fn.args),
+			Response: tupleSchema(fn.results),
+		})
+	}
+}
+
+// tupleSchema is the schema of the arguments or the results of a function,
+// a single value is passed as is and several values as an array
+func tupleSchema(types []*Type) *Type {
+	switch len(types) {
+	case 0:
+		return nil
+	case 1:
+		return types[0]
+	default:
+		return &Type{Type: TypeArray, PrefixItems: types}
+	}
+}
+
+// parseFuncType parses "(args) -> (results) annotations"
+func (p *candidParser) parseFuncType() (*candidFunc, error) {
+	args, err := p.parseTupleType()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("->"); err != nil {
+		return nil, err
+	}
+
+	results, err := p.parseTupleType()
+	if err != nil {
+		return nil, err
+	}
+
+	// query, composite_query and oneway
+	for p.peek() == "query" || p.peek() == "composite_query" || p.peek() == "oneway" {
+		p.next()
+	}
+
+	return &candidFunc{args: args, results: results}, nil
+}
+
+// parseTupleType parses "( (name :)? datatype, ... )"
+func (p *candidParser) parseTupleType() ([]*Type, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var types []*Type
+
+	for !p.accept(")") {
+		// skip the argument name
+		if p.pos+1 < len(p.tokens) && p.tokens[p.pos+1] == ":" {
+			p.pos += 2
+		}
+
+		typ, err := p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, typ)
+
+		if !p.accept(",") && p.peek() != ")" {
+			return nil, fmt.Errorf("expected \",\" or \")\", got %q", p.peek())
+		}
+	}
+
+	return types, nil
+}
+
+func (p *candidParser) parseDataType() (*Type, error) {
+	tok := p.next()
+
+	if primitive, ok := candidPrimitives[tok]; ok {
+		return primitive(), nil
+	}
+
+	switch tok {
+	case "opt":
+		typ, err := p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+
+		typ.Nullable = true
+
+		return typ, nil
+
+	case "vec":
+		typ, err := p.parseDataType()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Type{Type: TypeArray, Items: typ}, nil
+
+	case "record":
+		return p.parseRecord()
+
+	case "variant":
+		return p.parseVariant()
+
+	case "func":
+		if _, err := p.parseFuncType(); err != nil {
+			return nil, err
+		}
+
+		// the function reference is passed as text
+		return &Type{Type: TypeString}, nil
+
+	case "service":
+		if _, err := p.parseServiceMethods(); err != nil {
+			return nil, err
+		}
+
+		// the service reference is its principal
+		return &Type{Type: TypeString}, nil
+	}
+
+	if !isCandidIdent(tok) {
+		return nil, fmt.Errorf("expected a type, got %q", tok)
+	}
+
+	// the function and service references are passed as text
+	if _, ok := p.funcs[tok]; ok {
+		return &Type{Type: TypeString}, nil
+	}
+
+	if _, ok := p.services[tok]; ok {
+		return &Type{Type: TypeString}, nil
+	}
+
+	return refTo(tok), nil
+}
+
+// candidField is a field of a record or a variant
+type candidField struct {
+	name string
+	typ  *Type
+}
+
+// parseFields parses "{ (name : datatype | name | datatype) ; ... }",
+// the unnamed fields are numbered, and the field without type is null in variants
+func (p *candidParser) parseFields(variant bool) ([]*candidField, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var fields []*candidField
+
+	for index := 0; !p.accept("}"); index++ {
+		field := &candidField{name: strconv.Itoa(index)}
+
+		switch {
+		case p.pos+1 < len(p.tokens) && p.tokens[p.pos+1] == ":":
+			name, err := p.name()
+			if err != nil {
+				// the field id is a number
+				name = p.tokens[p.pos-1]
+			}
+
+			field.name = name
+			p.next()
+
+			if field.typ, err = p.parseDataType(); err != nil {
+				return nil, err
+			}
+
+		case variant:
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+
+			field.name = name
+			field.typ = &Type{Type: TypeNull}
+
+		default:
+			typ, err := p.parseDataType()
+			if err != nil {
+				return nil, err
+			}
+
+			field.typ = typ
+		}
+
+		fields = append(fields, field)
+
+		if !p.accept(";") && p.peek() != "}" {
+			return nil, fmt.Errorf("expected \";\" or \"}\", got %q", p.peek())
+		}
+	}
+
+	return fields, nil
+}
+
+// parseRecord parses a record into an object, requiring its non optional fields
+func (p *candidParser) parseRecord() (*Type, error) {
+	fields, err := p.parseFields(false)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := &Type{
+		Type:       TypeObject,
+		Properties: map[string]*Type{},
+	}
+
+	for _, field := range fields {
+		typ.Properties[field.name] = field.typ
+
+		if !field.typ.Nullable {
+			typ.Required = append(typ.Required, field.name)
+		}
+	}
+
+	return typ, nil
+}
+
+// parseVariant parses a variant into the alternatives of objects holding a single tag
+func (p *candidParser) parseVariant() (*Type, error) {
+	fields, err := p.parseFields(true)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := false
+	typ := &Type{}
+
+	for _, field := range fields {
+		typ.OneOf = append(typ.OneOf, &Type{
+			Type:                 TypeObject,
+			Properties:           map[string]*Type{field.name: field.typ},
+			Required:             []string{field.name},
+			AdditionalProperties: &closed,
+		})
+	}
+
+	return typ, nil
+}
@@ -0,0 +1,90 @@
+package appidl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCandid = `
+// the image generation app
+type Sampler = variant { euler; ddim; custom : text };
+
+type Request = record {
+	prompt : text;
+	"negative_prompt" : opt text;
+	steps : nat32;
+	sampler : Sampler;
+	size : record { nat; nat };
+};
+
+type Image = record { data : blob; seed : int64 };
+
+/* the service */
+service sd : (text) -> {
+	txt2img : (Request) -> (vec Image);
+	version : () -> (text) query;
+	scale : (image : Image, factor : float64) -> (Image);
+}
+`
+
+func TestParse_Candid(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(testCandid))
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatCandid, schema.Format)
+	assert.Equal(t, "sd", schema.Service)
+
+	require.Len(t, schema.Methods, 3)
+
+	// the methods are sorted by name
+	scale, txt2img, version := schema.Methods[0], schema.Methods[1], schema.Methods[2]
+
+	assert.Equal(t, "/txt2img", txt2img.Path)
+	assert.Equal(t, "POST", txt2img.Method)
+	assert.Equal(t, refTo("Request"), txt2img.Body)
+	assert.Equal(t, &Type{Type: TypeArray, Items: refTo("Image")}, txt2img.Response)
+
+	assert.Nil(t, version.Body)
+	assert.Equal(t, &Type{Type: TypeString}, version.Response)
+
+	// several arguments are passed as an array
+	assert.Equal(t, TypeArray, scale.Body.Type)
+	assert.Len(t, scale.Body.PrefixItems, 2)
+
+	request := schema.Definitions["Request"]
+	assert.Equal(t, TypeObject, request.Type)
+	assert.Equal(t, []string{"prompt", "steps", "sampler", "size"}, request.Required)
+	assert.True(t, request.Properties["negative_prompt"].Nullable)
+	assert.Equal(t, TypeInteger, request.Properties["steps"].Type)
+	assert.Equal(t, float64(0), *request.Properties["steps"].Minimum)
+
+	// the tuple fields are numbered
+	assert.Contains(t, request.Properties["size"].Properties, "0")
+	assert.Contains(t, request.Properties["size"].Properties, "1")
+
+	sampler := schema.Definitions["Sampler"]
+	require.Len(t, sampler.OneOf, 3)
+	assert.Equal(t, &Type{Type: TypeNull}, sampler.OneOf[0].Properties["euler"])
+	assert.Equal(t, &Type{Type: TypeString}, sampler.OneOf[2].Properties["custom"])
+}
+
+func TestParse_CandidServiceType(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(`
+		type Echo = func (text) -> (text);
+		type App = service { echo : Echo; ping : () -> () };
+		service : App
+	`))
+	require.NoError(t, err)
+
+	require.Len(t, schema.Methods, 2)
+	assert.Equal(t, "/echo", schema.Methods[0].Path)
+	assert.Equal(t, &Type{Type: TypeString}, schema.Methods[0].Body)
+	assert.Nil(t, schema.Methods[1].Body)
+	assert.Nil(t, schema.Methods[1].Response)
+}
@@ -0,0 +1,285 @@
+package appidl
+
+import (
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// clientHeader is the generated code shared by all the clients, calling the app through the JSON-RPC of a node
+const clientHeader = `// Code generated by edge-matrix app gen-client. DO NOT EDIT.
+
+package %s
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+
+	"github.com/emc-protocol/edge-matrix/helper/rpc"
+)
+
+// Client calls the methods of the %s app served by an edge node, by edge call telegrams
+type Client struct {
+	rpcClient  *rpc.JsonRpcClient
+	peerID     string
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewClient creates the client of the app served by the edge node peerID, signing the telegrams with privateKey
+func NewClient(rpcClient *rpc.JsonRpcClient, peerID string, privateKey *ecdsa.PrivateKey) *Client {
+	return &Client{
+		rpcClient:  rpcClient,
+		peerID:     peerID,
+		privateKey: privateKey,
+	}
+}
+
+func (c *Client) call(method, path string, body, result interface{}) error {
+	resp, err := c.rpcClient.SendEdgeCall(c.peerID, method, path, body, c.privateKey)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, result)
+}
+`
+
+const rawType = "json.RawMessage"
+
+// clientGenerator generates the Go types of the schema and the client methods
+type clientGenerator struct {
+	schema *Schema
+	buf    strings.Builder
+
+	// names are the declared type names
+	names map[string]bool
+
+	// defNames are the type names of the definitions
+	defNames map[string]string
+}
+
+// GenerateClient generates the source of the typed Go client of the app described by the schema
+func GenerateClient(schema *Schema, pkg string) ([]byte, error) {
+	service := schema.Service
+	if service == "" {
+		service = pkg
+	}
+
+	g := &clientGenerator{
+		schema:   schema,
+		names:    map[string]bool{"Client": true},
+		defNames: map[string]string{},
+	}
+
+	fmt.Fprintf(&g.buf, clientHeader, pkg, service)
+
+	g.generateDefinitions()
+
+	methodNames := map[string]bool{"call": true}
+
+	for _, method := range schema.Methods {
+		name := uniqueName(methodNames, goName(method.Name))
+		methodNames[name] = true
+
+		g.generateMethod(name, method)
+	}
+
+	src, err := format.Source([]byte(g.buf.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated client: %w", err)
+	}
+
+	return src, nil
+}
+
+// generateDefinitions declares a type for each definition,
+// the names are reserved first as the definitions may reference each other
+func (g *clientGenerator) generateDefinitions() {
+	defs := make([]string, 0, len(g.schema.Definitions))
+	for name := range g.schema.Definitions {
+		defs = append(defs, name)
+	}
+
+	sort.Strings(defs)
+
+	for _, def := range defs {
+		g.defNames[def] = g.declareName(goName(def))
+	}
+
+	for _, def := range defs {
+		typ := g.schema.Definitions[def]
+
+		if isStruct(typ) {
+			g.generateStruct(g.defNames[def], typ)
+		} else {
+			// aliased to keep the JSON methods of json.RawMessage
+			fmt.Fprintf(&g.buf, "\ntype %s = %s\n", g.defNames[def], g.goType(typ, g.defNames[def]))
+		}
+	}
+}
+
+func (g *clientGenerator) generateMethod(name string, method *Method) {
+	// the types are declared before the method,
+	// the methods without body schema accept any body unless they are Candid methods without arguments
+	params, body := "", "nil"
+	if method.Body != nil || (g.schema.Format != FormatCandid && method.Method != http.MethodGet) {
+		params, body = "body "+g.goType(method.Body, name+"Request"), "body"
+	}
+
+	resType := g.goType(method.Response, name+"Response")
+
+	fmt.Fprintf(&g.buf, "\n// %s calls %s %s of the app\n", name, method.Method, method.Path)
+	fmt.Fprintf(&g.buf, "func (c *Client) %s(%s) (%s, error) {\n", name, params, resType)
+	fmt.Fprintf(&g.buf, "\tvar res %s\n\n", resType)
+	fmt.Fprintf(&g.buf, "\terr := c.call(%q, %q, %s, &res)\n\n", method.Method, method.Path, body)
+	fmt.Fprintf(&g.buf, "\treturn res, err\n}\n")
+}
+
+// generateStruct declares the struct of an object, after the structs of its properties
+func (g *clientGenerator) generateStruct(name string, typ *Type) {
+	props := make([]string, 0, len(typ.Properties))
+	for prop := range typ.Properties {
+		props = append(props, prop)
+	}
+
+	sort.Strings(props)
+
+	required := make(map[string]bool, len(typ.Required))
+	for _, prop := range typ.Required {
+		required[prop] = true
+	}
+
+	fieldNames := map[string]bool{}
+	fields := make([]string, len(props))
+
+	for i, prop := range props {
+		fieldName := uniqueName(fieldNames, goName(prop))
+		fieldNames[fieldName] = true
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+
+		fields[i] = fmt.Sprintf("\t%s %s `json:%q`\n", fieldName, g.goType(typ.Properties[prop], name+fieldName), tag)
+	}
+
+	fmt.Fprintf(&g.buf, "\ntype %s struct {\n%s}\n", name, strings.Join(fields, ""))
+}
+
+// goType returns the Go type of the values of the type, declaring the structs of its objects
+func (g *clientGenerator) goType(typ *Type, hint string) string {
+	if typ == nil {
+		return rawType
+	}
+
+	var goType string
+
+	switch {
+	case typ.Ref != "":
+		name := strings.TrimPrefix(typ.Ref, refPrefix)
+
+		goType = g.defNames[name]
+		if def, ok := g.schema.Definitions[name]; ok && isStruct(def) {
+			return "*" + goType
+		}
+
+	case isStruct(typ):
+		name := g.declareName(hint)
+		g.generateStruct(name, typ)
+
+		return "*" + name
+
+	case len(typ.OneOf) != 0:
+		return rawType
+
+	case typ.Type == TypeString:
+		goType = "string"
+
+	case typ.Type == TypeInteger && typ.Minimum != nil && *typ.Minimum >= 0:
+		goType = "uint64"
+
+	case typ.Type == TypeInteger:
+		goType = "int64"
+
+	case typ.Type == TypeNumber:
+		goType = "float64"
+
+	case typ.Type == TypeBoolean:
+		goType = "bool"
+
+	case typ.Type == TypeArray && typ.Items != nil && len(typ.PrefixItems) == 0:
+		return "[]" + g.goType(typ.Items, hint+"Item")
+
+	case typ.Type == TypeArray:
+		return "[]" + rawType
+
+	case typ.Type == TypeObject:
+		return "map[string]" + rawType
+
+	default:
+		return rawType
+	}
+
+	if typ.Nullable {
+		return "*" + goType
+	}
+
+	return goType
+}
+
+// declareName reserves a unique type name
+func (g *clientGenerator) declareName(name string) string {
+	name = uniqueName(g.names, name)
+	g.names[name] = true
+
+	return name
+}
+
+// isStruct returns true if the type is an object with declared properties
+func isStruct(typ *Type) bool {
+	return typ.Ref == "" && len(typ.OneOf) == 0 && typ.Type == TypeObject && len(typ.Properties) != 0
+}
+
+// uniqueName suffixes the name with a number if it is already taken
+func uniqueName(taken map[string]bool, name string) string {
+	unique := name
+
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+
+	return unique
+}
+
+// goName converts the name to an exported Go identifier, such as "v1/completions" to "V1Completions"
+func goName(name string) string {
+	var b strings.Builder
+
+	upper := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	ident := b.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "X" + ident
+	}
+
+	return ident
+}
@@ -0,0 +1,72 @@
+package appidl
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateClient(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(testCandid))
+	require.NoError(t, err)
+
+	src, err := GenerateClient(schema, "sdclient")
+	require.NoError(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "client.go", src, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sdclient", file.Name.Name)
+
+	code := string(src)
+
+	// the definitions
+	assert.Contains(t, code, "type Request struct {")
+	assert.Contains(t, code, "\tNegativePrompt *string      `json:\"negative_prompt,omitempty\"`")
+	assert.Contains(t, code, "\tSteps          uint64       `json:\"steps\"`")
+	assert.Contains(t, code, "\tSampler        Sampler      `json:\"sampler\"`")
+	assert.Contains(t, code, "\tSize           *RequestSize `json:\"size\"`")
+	assert.Contains(t, code, "type RequestSize struct {\n\tX0 uint64 `json:\"0\"`")
+	assert.Contains(t, code, "type Sampler = json.RawMessage")
+	assert.Contains(t, code, "\tSeed int64  `json:\"seed\"`")
+
+	// the methods
+	assert.Contains(t, code, "func (c *Client) Txt2img(body *Request) ([]*Image, error) {")
+	assert.Contains(t, code, "func (c *Client) Version() (string, error) {")
+	assert.Contains(t, code, "func (c *Client) Scale(body []json.RawMessage) (*Image, error) {")
+	assert.Contains(t, code, `err := c.call("POST", "/txt2img", body, &res)`)
+}
+
+func TestGenerateClient_InlineTypes(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(`[{
+		"name": "v1/completions",
+		"path": "/v1/completions",
+		"body": {
+			"type": "object",
+			"properties": {"prompt": {"type": "string"}, "options": {"type": "object", "properties": {"n": {"type": "integer"}}}}
+		},
+		"response": {"type": "object", "properties": {"choices": {"type": "array", "items": {"type": "string"}}}}
+	}, {
+		"name": "echo",
+		"path": "/echo"
+	}]`))
+	require.NoError(t, err)
+
+	src, err := GenerateClient(schema, "client")
+	require.NoError(t, err)
+
+	code := string(src)
+
+	assert.Contains(t, code, "type V1CompletionsRequestOptions struct {")
+	assert.Contains(t, code, "type V1CompletionsRequest struct {")
+	assert.Contains(t, code, "type V1CompletionsResponse struct {")
+	assert.Contains(t, code, "func (c *Client) V1Completions(body *V1CompletionsRequest) (*V1CompletionsResponse, error) {")
+	assert.Contains(t, code, "func (c *Client) Echo(body json.RawMessage) (json.RawMessage, error) {")
+}
@@ -0,0 +1,255 @@
+package appidl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// FormatJSON is the format of the IDL describing the methods with JSON schemas
+	FormatJSON = "json"
+
+	// FormatCandid is the format of the IDL written as a Candid service
+	FormatCandid = "candid"
+)
+
+// JSON schema types of the values
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNull    = "null"
+)
+
+const refPrefix = "#/definitions/"
+
+var (
+	ErrEmptyIdl = errors.New("empty IDL")
+)
+
+// Schema is the parsed IDL of an edge app
+type Schema struct {
+	// Format is the format of the parsed IDL
+	Format string `json:"format"`
+
+	// Service is the name of the app service, if declared
+	Service string `json:"service,omitempty"`
+
+	// Methods are the methods served by the app
+	Methods []*Method `json:"methods"`
+
+	// Definitions are the named types referenced by $ref
+	Definitions map[string]*Type `json:"definitions,omitempty"`
+}
+
+// Method is a method of the app, called through the /api endpoint of the edge node
+type Method struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+
+	// Method is the HTTP method of the call, POST if not set
+	Method string `json:"method,omitempty"`
+
+	// Body and Response are the schemas of the call body and of the app response,
+	// any value is accepted if not set
+	Body     *Type `json:"body,omitempty"`
+	Response *Type `json:"response,omitempty"`
+}
+
+// Type is the subset of the JSON schema describing a value
+type Type struct {
+	Ref  string `json:"$ref,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Nullable accepts null besides the values of the type
+	Nullable bool `json:"nullable,omitempty"`
+
+	Properties           map[string]*Type `json:"properties,omitempty"`
+	Required             []string         `json:"required,omitempty"`
+	AdditionalProperties *bool            `json:"additionalProperties,omitempty"`
+
+	Items       *Type   `json:"items,omitempty"`
+	PrefixItems []*Type `json:"prefixItems,omitempty"`
+
+	Enum    []interface{} `json:"enum,omitempty"`
+	OneOf   []*Type       `json:"oneOf,omitempty"`
+	Minimum *float64      `json:"minimum,omitempty"`
+}
+
+// Parse parses the IDL published by an app, either in JSON or in Candid
+func Parse(data []byte) (*Schema, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, ErrEmptyIdl
+	}
+
+	var (
+		schema *Schema
+		err    error
+	)
+
+	if data[0] == '{' || data[0] == '[' {
+		schema, err = parseJSON(data)
+	} else {
+		schema, err = parseCandid(string(data))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schema.check(); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// parseJSON parses the JSON IDL, which is either the schema object or the bare list of methods
+func parseJSON(data []byte) (*Schema, error) {
+	schema := &Schema{}
+
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &schema.Methods); err != nil {
+			return nil, fmt.Errorf("invalid JSON IDL: %w", err)
+		}
+	} else if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON IDL: %w", err)
+	}
+
+	// the schema served by the JSON-RPC keeps the format of the parsed IDL
+	if schema.Format == "" {
+		schema.Format = FormatJSON
+	}
+
+	return schema, nil
+}
+
+// check normalizes the methods and ensures they are unique and their references are defined
+func (s *Schema) check() error {
+	seen := make(map[string]bool, len(s.Methods))
+
+	for _, method := range s.Methods {
+		if method.Path == "" {
+			return fmt.Errorf("method %q has no path", method.Name)
+		}
+
+		if method.Method == "" {
+			method.Method = http.MethodPost
+		}
+
+		method.Method = strings.ToUpper(method.Method)
+
+		key := method.Method + " " + method.Path
+		if seen[key] {
+			return fmt.Errorf("duplicate method %s", key)
+		}
+
+		seen[key] = true
+
+		for _, typ := range []*Type{method.Body, method.Response} {
+			if err := s.checkRefs(typ); err != nil {
+				return fmt.Errorf("method %q: %w", method.Name, err)
+			}
+		}
+	}
+
+	for name, typ := range s.Definitions {
+		if err := s.checkRefs(typ); err != nil {
+			return fmt.Errorf("definition %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) checkRefs(typ *Type) error {
+	if typ == nil {
+		return nil
+	}
+
+	if typ.Ref != "" {
+		if _, err := s.resolve(typ); err != nil {
+			return err
+		}
+
+		// the definition is checked on its own
+		return nil
+	}
+
+	children := append([]*Type{typ.Items}, typ.PrefixItems...)
+	children = append(children, typ.OneOf...)
+
+	for _, prop := range typ.Properties {
+		children = append(children, prop)
+	}
+
+	for _, child := range children {
+		if err := s.checkRefs(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the definition referenced by the type, or the type itself if it is not a reference
+func (s *Schema) resolve(typ *Type) (*Type, error) {
+	for seen := 0; typ.Ref != ""; seen++ {
+		if seen > len(s.Definitions) {
+			return nil, fmt.Errorf("circular reference %s", typ.Ref)
+		}
+
+		name := strings.TrimPrefix(typ.Ref, refPrefix)
+
+		def, ok := s.Definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined reference %s", typ.Ref)
+		}
+
+		typ = def
+	}
+
+	return typ, nil
+}
+
+// IsEmpty returns true if the schema describes no method, in which case no call is validated
+func (s *Schema) IsEmpty() bool {
+	return s == nil || len(s.Methods) == 0
+}
+
+// FindMethod returns the method served on the path, ignoring the query,
+// and false if no method is served on it with the given HTTP method
+func (s *Schema) FindMethod(httpMethod, path string) (*Method, bool) {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+
+	var found *Method
+
+	for _, method := range s.Methods {
+		if method.Path != path {
+			continue
+		}
+
+		found = method
+
+		if strings.EqualFold(method.Method, httpMethod) {
+			return method, true
+		}
+	}
+
+	return found, false
+}
+
+// refTo returns the type referencing the named definition
+func refTo(name string) *Type {
+	return &Type{Ref: refPrefix + name}
+}
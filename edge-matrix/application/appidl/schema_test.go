@@ -0,0 +1,94 @@
+package appidl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_JSON(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(`{
+		"service": "sd",
+		"methods": [
+			{
+				"name": "txt2img",
+				"path": "/sdapi/v1/txt2img",
+				"body": {"$ref": "#/definitions/Prompt"}
+			},
+			{"name": "models", "path": "/sdapi/v1/sd-models", "method": "get"}
+		],
+		"definitions": {
+			"Prompt": {
+				"type": "object",
+				"properties": {"prompt": {"type": "string"}},
+				"required": ["prompt"]
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, FormatJSON, schema.Format)
+	assert.Equal(t, "sd", schema.Service)
+	require.Len(t, schema.Methods, 2)
+	assert.Equal(t, "POST", schema.Methods[0].Method)
+	assert.Equal(t, "GET", schema.Methods[1].Method)
+
+	method, ok := schema.FindMethod("GET", "/sdapi/v1/sd-models?limit=1")
+	assert.True(t, ok)
+	assert.Equal(t, "models", method.Name)
+
+	method, ok = schema.FindMethod("GET", "/sdapi/v1/txt2img")
+	assert.False(t, ok)
+	assert.Equal(t, "txt2img", method.Name)
+}
+
+func TestParse_MethodList(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(`[{"name": "echo", "path": "/echo"}]`))
+	require.NoError(t, err)
+
+	assert.Len(t, schema.Methods, 1)
+	assert.False(t, schema.IsEmpty())
+
+	// the default IDL of the apps without one
+	schema, err = Parse([]byte(`[]`))
+	require.NoError(t, err)
+
+	assert.True(t, schema.IsEmpty())
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		idl  string
+	}{
+		{"empty", "  "},
+		{"invalid JSON", `{"methods": [`},
+		{"method without path", `[{"name": "echo"}]`},
+		{"duplicate method", `[{"name": "a", "path": "/a"}, {"name": "b", "path": "/a"}]`},
+		{"undefined reference", `[{"name": "a", "path": "/a", "body": {"$ref": "#/definitions/Missing"}}]`},
+		{
+			"circular reference",
+			`{"methods": [{"name": "a", "path": "/a", "body": {"$ref": "#/definitions/A"}}],
+			"definitions": {"A": {"$ref": "#/definitions/B"}, "B": {"$ref": "#/definitions/A"}}}`,
+		},
+		{"invalid Candid", `service : { echo : (text) -> ; }`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.idl))
+			assert.Error(t, err)
+		})
+	}
+}
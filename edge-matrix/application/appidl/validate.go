@@ -0,0 +1,318 @@
+package appidl
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Codes of the validation errors
+const (
+	CodeUnknownPath      = "unknown_path"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeInvalidJSON      = "invalid_json"
+	CodeType             = "type"
+	CodeRequired         = "required"
+	CodeUnknownField     = "unknown_field"
+	CodeEnum             = "enum"
+	CodeMinimum          = "minimum"
+	CodeLength           = "length"
+	CodeOneOf            = "one_of"
+)
+
+// bodyField is the field of the validation errors of the call body
+const bodyField = "body"
+
+// ValidationError is a violation of the app IDL by an edge call
+type ValidationError struct {
+	// Field is the path of the invalid value, such as "path" or "body.items[1].name"
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors are all the violations of the app IDL by an edge call
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Validate validates the HTTP method, the path and the JSON body of an edge call against the schema,
+// and returns the ValidationErrors if the call is invalid
+func (s *Schema) Validate(httpMethod, path string, body json.RawMessage) error {
+	if s.IsEmpty() {
+		return nil
+	}
+
+	method, ok := s.FindMethod(httpMethod, path)
+	if method == nil {
+		return ValidationErrors{{
+			Field:   "path",
+			Code:    CodeUnknownPath,
+			Message: fmt.Sprintf("no method is served on %s", path),
+		}}
+	}
+
+	if !ok {
+		return ValidationErrors{{
+			Field:   "method",
+			Code:    CodeMethodNotAllowed,
+			Message: fmt.Sprintf("%s is served by %s, not %s", path, method.Method, httpMethod),
+		}}
+	}
+
+	// GET calls have no body
+	if method.Body == nil || strings.EqualFold(httpMethod, "GET") {
+		return nil
+	}
+
+	var value interface{}
+
+	if len(body) != 0 {
+		decoder := json.NewDecoder(strings.NewReader(string(body)))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&value); err != nil {
+			return ValidationErrors{{
+				Field:   bodyField,
+				Code:    CodeInvalidJSON,
+				Message: err.Error(),
+			}}
+		}
+	}
+
+	v := &validator{schema: s}
+	v.validate(bodyField, method.Body, value)
+
+	if len(v.errs) != 0 {
+		return v.errs
+	}
+
+	return nil
+}
+
+// validator collects the violations of a value
+type validator struct {
+	schema *Schema
+	errs   ValidationErrors
+}
+
+func (v *validator) fail(field, code, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Field:   field,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(field string, typ *Type, value interface{}) {
+	// a reference may be nullable itself
+	if value == nil && typ.Nullable {
+		return
+	}
+
+	typ, err := v.schema.resolve(typ)
+	if err != nil {
+		v.fail(field, CodeType, err.Error())
+
+		return
+	}
+
+	if value == nil && (typ.Nullable || typ.Type == TypeNull || isAny(typ)) {
+		return
+	}
+
+	if len(typ.OneOf) != 0 {
+		v.validateOneOf(field, typ, value)
+
+		return
+	}
+
+	if typ.Type != "" && !v.validateType(field, typ, value) {
+		return
+	}
+
+	if len(typ.Enum) != 0 && !inEnum(typ.Enum, value) {
+		v.fail(field, CodeEnum, "must be one of %v", typ.Enum)
+	}
+}
+
+// validateType validates the value is of the type and its content, and returns false if it is not of the type
+func (v *validator) validateType(field string, typ *Type, value interface{}) bool {
+	switch typ.Type {
+	case TypeString:
+		if _, ok := value.(string); !ok {
+			return v.mismatch(field, typ, value)
+		}
+
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return v.mismatch(field, typ, value)
+		}
+
+	case TypeNull:
+		return v.mismatch(field, typ, value)
+
+	case TypeInteger, TypeNumber:
+		num, ok := value.(json.Number)
+		if !ok {
+			return v.mismatch(field, typ, value)
+		}
+
+		f, err := num.Float64()
+		if err != nil || (typ.Type == TypeInteger && f != math.Trunc(f)) {
+			return v.mismatch(field, typ, value)
+		}
+
+		if typ.Minimum != nil && f < *typ.Minimum {
+			v.fail(field, CodeMinimum, "must be at least %v", *typ.Minimum)
+		}
+
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return v.mismatch(field, typ, value)
+		}
+
+		v.validateArray(field, typ, items)
+
+	case TypeObject:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return v.mismatch(field, typ, value)
+		}
+
+		v.validateObject(field, typ, obj)
+
+	default:
+		v.fail(field, CodeType, "unsupported type %s", typ.Type)
+
+		return false
+	}
+
+	return true
+}
+
+func (v *validator) mismatch(field string, typ *Type, value interface{}) bool {
+	v.fail(field, CodeType, "expected %s, got %s", typ.Type, jsonTypeOf(value))
+
+	return false
+}
+
+func (v *validator) validateArray(field string, typ *Type, items []interface{}) {
+	if len(typ.PrefixItems) != 0 && len(items) != len(typ.PrefixItems) {
+		v.fail(field, CodeLength, "expected %d items, got %d", len(typ.PrefixItems), len(items))
+
+		return
+	}
+
+	for i, item := range items {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+
+		if len(typ.PrefixItems) != 0 {
+			v.validate(itemField, typ.PrefixItems[i], item)
+		} else if typ.Items != nil {
+			v.validate(itemField, typ.Items, item)
+		}
+	}
+}
+
+func (v *validator) validateObject(field string, typ *Type, obj map[string]interface{}) {
+	for _, name := range typ.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(field+"."+name, CodeRequired, "is required")
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+
+	// report the errors in a stable order
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := typ.Properties[name]
+		if !ok {
+			if typ.AdditionalProperties != nil && !*typ.AdditionalProperties {
+				v.fail(field+"."+name, CodeUnknownField, "is not declared")
+			}
+
+			continue
+		}
+
+		v.validate(field+"."+name, prop, obj[name])
+	}
+}
+
+// validateOneOf validates the value matches exactly one of the alternatives
+func (v *validator) validateOneOf(field string, typ *Type, value interface{}) {
+	matches := 0
+
+	for _, alt := range typ.OneOf {
+		sub := &validator{schema: v.schema}
+		sub.validate(field, alt, value)
+
+		if len(sub.errs) == 0 {
+			matches++
+		}
+	}
+
+	if matches != 1 {
+		v.fail(field, CodeOneOf, "must match exactly one of %d alternatives, matched %d", len(typ.OneOf), matches)
+	}
+}
+
+// isAny returns true if the type accepts any value
+func isAny(typ *Type) bool {
+	return typ.Type == "" && len(typ.OneOf) == 0 && len(typ.Enum) == 0
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if num, ok := value.(json.Number); ok {
+			if f, err := num.Float64(); err == nil && reflect.DeepEqual(e, f) {
+				return true
+			}
+		}
+
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return TypeNull
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case json.Number:
+		return TypeNumber
+	case []interface{}:
+		return TypeArray
+	case map[string]interface{}:
+		return TypeObject
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,149 @@
+package appidl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(testCandid))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		errs   ValidationErrors
+	}{
+		{
+			name:   "valid call",
+			method: "POST",
+			path:   "/txt2img",
+			body:   `{"prompt": "a cat", "steps": 20, "sampler": {"euler": null}, "size": {"0": 512, "1": 512}}`,
+		},
+		{
+			name:   "optional field set",
+			method: "POST",
+			path:   "/txt2img",
+			body: `{"prompt": "a cat", "negative_prompt": "a dog", "steps": 20,
+				"sampler": {"custom": "dpm"}, "size": {"0": 512, "1": 512}, "extra": true}`,
+		},
+		{
+			name:   "call without body",
+			method: "POST",
+			path:   "/version",
+		},
+		{
+			name:   "unknown path",
+			method: "POST",
+			path:   "/img2img",
+			errs:   ValidationErrors{{Field: "path", Code: CodeUnknownPath, Message: "no method is served on /img2img"}},
+		},
+		{
+			name:   "method not allowed",
+			method: "PUT",
+			path:   "/version",
+			errs:   ValidationErrors{{Field: "method", Code: CodeMethodNotAllowed, Message: "/version is served by POST, not PUT"}},
+		},
+		{
+			name:   "invalid JSON",
+			method: "POST",
+			path:   "/txt2img",
+			body:   `{"prompt": `,
+			errs:   ValidationErrors{{Field: "body", Code: CodeInvalidJSON, Message: "unexpected EOF"}},
+		},
+		{
+			name:   "invalid fields",
+			method: "POST",
+			path:   "/txt2img",
+			body:   `{"prompt": 1, "steps": -1.5, "sampler": {"euler": null, "ddim": null}, "size": {"0": 512}}`,
+			errs: ValidationErrors{
+				{Field: "body.prompt", Code: CodeType, Message: "expected string, got number"},
+				{Field: "body.sampler", Code: CodeOneOf, Message: "must match exactly one of 3 alternatives, matched 0"},
+				{Field: "body.size.1", Code: CodeRequired, Message: "is required"},
+				{Field: "body.steps", Code: CodeType, Message: "expected integer, got number"},
+			},
+		},
+		{
+			name:   "negative nat",
+			method: "POST",
+			path:   "/scale",
+			body:   `[{"data": "", "seed": -1}, 2]`,
+		},
+		{
+			name:   "invalid arguments",
+			method: "POST",
+			path:   "/scale",
+			body:   `[{"data": "", "seed": 1}]`,
+			errs:   ValidationErrors{{Field: "body", Code: CodeLength, Message: "expected 2 items, got 1"}},
+		},
+		{
+			name:   "invalid argument",
+			method: "POST",
+			path:   "/scale",
+			body:   `[{"data": 1, "seed": 1}, "2"]`,
+			errs: ValidationErrors{
+				{Field: "body[0].data", Code: CodeType, Message: "expected string, got number"},
+				{Field: "body[1]", Code: CodeType, Message: "expected number, got string"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := schema.Validate(tt.method, tt.path, json.RawMessage(tt.body))
+			if tt.errs == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Equal(t, tt.errs, err)
+		})
+	}
+}
+
+func TestSchema_ValidateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := Parse([]byte(`[{
+		"name": "completions",
+		"path": "/v1/completions",
+		"body": {
+			"type": "object",
+			"properties": {
+				"model": {"type": "string", "enum": ["small", "large"]},
+				"max_tokens": {"type": "integer", "minimum": 1},
+				"stream": {"type": "boolean"}
+			},
+			"required": ["model"],
+			"additionalProperties": false
+		}
+	}]`))
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate("POST", "/v1/completions", []byte(`{"model": "small", "max_tokens": 16}`)))
+
+	err = schema.Validate("POST", "/v1/completions", []byte(`{"model": "medium", "max_tokens": 0, "temperature": 1}`))
+	assert.Equal(t, ValidationErrors{
+		{Field: "body.max_tokens", Code: CodeMinimum, Message: "must be at least 1"},
+		{Field: "body.model", Code: CodeEnum, Message: "must be one of [small large]"},
+		{Field: "body.temperature", Code: CodeUnknownField, Message: "is not declared"},
+	}, err)
+
+	// GET calls have no body
+	assert.Equal(t, CodeMethodNotAllowed, schema.Validate("GET", "/v1/completions", nil).(ValidationErrors)[0].Code)
+
+	// the empty schema accepts any call
+	assert.NoError(t, (&Schema{}).Validate("POST", "/any", []byte(`{`)))
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -24,6 +25,8 @@ type Application struct {
 	CpuInfo string
 	// gpu info
 	GpuInfo string
+	// hardware capabilities, nil if not reported
+	Capabilities *capability.Capabilities
 
 	// app startup time
 	StartupTime uint64
@@ -35,6 +38,12 @@ type Application struct {
 	GuageMax uint64
 	// average e power value
 	AveragePower float32
+
+	// the app stopped publishing its status or failed a liveness probe
+	Offline bool
+
+	// apps hosted by the node, the default app first, empty for the nodes hosting a single app
+	Apps []*HostedApp
 }
 
 func (a *Application) Copy() *Application {
@@ -55,6 +64,15 @@ func (a *Application) Copy() *Application {
 		GpuInfo:      a.GpuInfo,
 		ModelHash:    a.ModelHash,
 		AveragePower: a.AveragePower,
+		Offline:      a.Offline,
+		Capabilities: a.Capabilities,
+	}
+
+	if len(a.Apps) > 0 {
+		newApp.Apps = make([]*HostedApp, len(a.Apps))
+		for i, app := range a.Apps {
+			newApp.Apps[i] = app.Copy()
+		}
 	}
 
 	return newApp
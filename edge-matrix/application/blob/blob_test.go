@@ -0,0 +1,192 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+
+	return data
+}
+
+func putBlob(t *testing.T, store *Store, data []byte, chunkSize uint64) types.Hash {
+	t.Helper()
+
+	m, chunks := NewManifest(data, chunkSize)
+
+	hash, err := store.PutManifest(m)
+	require.NoError(t, err)
+
+	for _, chunk := range chunks {
+		_, err := store.PutChunk(chunk)
+		require.NoError(t, err)
+	}
+
+	return hash
+}
+
+func TestManifest(t *testing.T) {
+	t.Parallel()
+
+	m, chunks := NewManifest(testData(2500), 1000)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, uint64(500), m.ChunkLength(2))
+	assert.NoError(t, m.Validate())
+
+	decoded := &Manifest{}
+	require.NoError(t, decoded.UnmarshalRLP(m.MarshalRLP()))
+	assert.Equal(t, m, decoded)
+	assert.Equal(t, m.Hash(), decoded.Hash())
+
+	// the chunks must cover the size
+	m.Size = 3500
+	assert.ErrorIs(t, m.Validate(), ErrInvalidManifest)
+
+	_, err := NewMemoryStore().PutManifest(&Manifest{Size: 1, ChunkSize: MaxChunkSize + 1, Chunks: []types.Hash{{}}})
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestStore_UploadAndCollect(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	data := testData(2500)
+	m, chunks := NewManifest(data, 1000)
+
+	hash, err := store.PutManifest(m)
+	require.NoError(t, err)
+
+	// the upload is resumed with the missing chunks
+	_, err = store.PutChunk(chunks[1])
+	require.NoError(t, err)
+
+	missing, err := store.Missing(hash)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 2}, missing)
+
+	_, err = store.Read(hash)
+	assert.ErrorIs(t, err, ErrIncompleteBlob)
+
+	for _, index := range missing {
+		_, err := store.PutChunk(chunks[index])
+		require.NoError(t, err)
+	}
+
+	read, err := store.Read(hash)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	chunk, err := store.ReadChunk(hash, 2)
+	require.NoError(t, err)
+	assert.Equal(t, chunks[2], chunk)
+
+	// the chunks not referenced by a blob are collected
+	orphan, err := store.PutChunk([]byte("orphan"))
+	require.NoError(t, err)
+
+	blobs, collected, err := store.Collect(uint64(time.Now().Add(time.Minute).UnixMilli()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, blobs)
+	assert.Equal(t, 4, collected)
+
+	_, ok, err := store.GetChunk(orphan)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = store.Missing(hash)
+	assert.ErrorIs(t, err, ErrUnknownBlob)
+}
+
+func TestStore_CollectKeepsReferencedChunks(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	hash := putBlob(t, store, testData(2500), 1000)
+
+	blobs, chunks, err := store.Collect(uint64(time.Now().Add(-time.Minute).UnixMilli()))
+	require.NoError(t, err)
+	assert.Zero(t, blobs)
+	assert.Zero(t, chunks)
+
+	missing, err := store.Missing(hash)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func newTestHost(t *testing.T) host.Host {
+	t.Helper()
+
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		h.Close()
+	})
+
+	return h
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	server, client := newTestHost(t), newTestHost(t)
+	serverStore, clientStore := NewMemoryStore(), NewMemoryStore()
+
+	Serve(hclog.NewNullLogger(), server, serverStore)
+
+	client.Peerstore().AddAddrs(server.ID(), server.Addrs(), time.Minute)
+
+	data := testData(5000)
+	hash := putBlob(t, serverStore, data, 1024)
+
+	// the chunk fetched already is not fetched again
+	m, chunks := NewManifest(data, 1024)
+	_, err := clientStore.PutManifest(m)
+	require.NoError(t, err)
+	_, err = clientStore.PutChunk(chunks[0])
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, Fetch(ctx, client, server.ID(), hash, clientStore))
+
+	read, err := clientStore.Read(hash)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, read))
+
+	// the unknown blob is not fetched
+	err = Fetch(ctx, client, server.ID(), types.StringToHash("0x1"), clientStore)
+	assert.ErrorIs(t, err, errNotFound)
+
+	// the corrupted chunk is rejected
+	corrupted := putBlob(t, serverStore, testData(100), 1024)
+	corruptedManifest, _, err := serverStore.GetManifest(corrupted)
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, testData(100)...)
+	tampered[0]++
+	require.NoError(t, serverStore.db.Put(kvstore.HashKey(chunkPrefix, corruptedManifest.Chunks[0]), kvstore.Stamp(tampered)))
+
+	err = Fetch(ctx, client, server.ID(), corrupted, clientStore)
+	assert.ErrorIs(t, err, ErrChunkMismatch)
+}
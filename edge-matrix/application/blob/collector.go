@@ -0,0 +1,36 @@
+package blob
+
+import (
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetention is the period the blobs are kept for since last stored
+	DefaultRetention = 24 * time.Hour
+
+	// collectInterval is the interval of the garbage collections
+	collectInterval = 10 * time.Minute
+)
+
+// Collector periodically deletes the blobs and the chunks of the store not stored again within the retention period
+type Collector struct {
+	*kvstore.Retention
+}
+
+// NewCollector creates the garbage collector of the store
+func NewCollector(logger hclog.Logger, store *Store, retention time.Duration) *Collector {
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+
+	collect := func(before uint64) (int, error) {
+		blobs, chunks, err := store.Collect(before)
+
+		return blobs + chunks, err
+	}
+
+	return &Collector{kvstore.NewRetention(logger.Named("blob_collector"), retention, collectInterval, collect)}
+}
@@ -0,0 +1,163 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// HeaderBlobs lists the blobs referenced by the edge call, fetched by the edge node from the node routing the call
+const HeaderBlobs = "Emc-Blobs"
+
+const (
+	// DefaultChunkSize is the chunk size of the blobs uploaded by the clients
+	DefaultChunkSize = 256 * 1024
+
+	// MaxChunkSize is the maximum size of a chunk
+	MaxChunkSize = 1024 * 1024
+
+	// MaxChunks is the maximum number of chunks of a blob
+	MaxChunks = 4096
+
+	// MaxCallBlobs is the maximum number of blobs referenced by an edge call
+	MaxCallBlobs = 16
+)
+
+var (
+	ErrUnknownBlob     = errors.New("blob not found")
+	ErrUnknownChunk    = errors.New("blob chunk not found")
+	ErrIncompleteBlob  = errors.New("blob chunks missing")
+	ErrInvalidManifest = errors.New("invalid blob manifest")
+	ErrChunkTooLarge   = fmt.Errorf("blob chunk larger than %d bytes", MaxChunkSize)
+	ErrChunkMismatch   = errors.New("blob chunk does not match its hash")
+	ErrTooManyBlobs    = fmt.Errorf("edge call references more than %d blobs", MaxCallBlobs)
+)
+
+var manifestArenaPool fastrlp.ArenaPool
+
+// ChunkHash returns the content address of the chunk
+func ChunkHash(data []byte) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, data))
+}
+
+// Manifest lists the chunks of a blob, the blob is addressed by the hash of its manifest
+type Manifest struct {
+	Size      uint64
+	ChunkSize uint64
+	Chunks    []types.Hash
+}
+
+// NewManifest splits the data into chunks of the given size, and returns its manifest with the chunks
+func NewManifest(data []byte, chunkSize uint64) (*Manifest, [][]byte) {
+	m := &Manifest{Size: uint64(len(data)), ChunkSize: chunkSize}
+	chunks := make([][]byte, 0, len(data)/int(chunkSize)+1)
+
+	for start := uint64(0); start < m.Size; start += chunkSize {
+		end := start + chunkSize
+		if end > m.Size {
+			end = m.Size
+		}
+
+		chunks = append(chunks, data[start:end])
+		m.Chunks = append(m.Chunks, ChunkHash(data[start:end]))
+	}
+
+	return m, chunks
+}
+
+// Hash returns the content address of the blob
+func (m *Manifest) Hash() types.Hash {
+	a := manifestArenaPool.Get()
+	defer manifestArenaPool.Put(a)
+
+	return types.BytesToHash(keccak.Keccak256Rlp(nil, m.MarshalRLPWith(a)))
+}
+
+// Validate checks the chunks of the given size cover the size of the blob
+func (m *Manifest) Validate() error {
+	if m.ChunkSize == 0 || m.ChunkSize > MaxChunkSize || len(m.Chunks) > MaxChunks {
+		return ErrInvalidManifest
+	}
+
+	if uint64(len(m.Chunks)) != (m.Size+m.ChunkSize-1)/m.ChunkSize {
+		return fmt.Errorf("%w: %d chunks of %d bytes for %d bytes", ErrInvalidManifest, len(m.Chunks), m.ChunkSize, m.Size)
+	}
+
+	return nil
+}
+
+// ChunkLength returns the expected length of the chunk of the given index
+func (m *Manifest) ChunkLength(index int) uint64 {
+	if index == len(m.Chunks)-1 {
+		return m.Size - uint64(index)*m.ChunkSize
+	}
+
+	return m.ChunkSize
+}
+
+func (m *Manifest) MarshalRLP() []byte {
+	return m.MarshalRLPTo(nil)
+}
+
+func (m *Manifest) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Manifest to RLP with a specific fastrlp.Arena
+func (m *Manifest) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(m.Size))
+	vv.Set(arena.NewUint(m.ChunkSize))
+
+	chunks := arena.NewArray()
+	for _, chunk := range m.Chunks {
+		chunks.Set(arena.NewBytes(chunk.Bytes()))
+	}
+
+	vv.Set(chunks)
+
+	return vv
+}
+
+func (m *Manifest) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Manifest in RLP format
+func (m *Manifest) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 3 {
+		return fmt.Errorf("incorrect number of elements to decode blob manifest, expected 3 but found %d", len(elems))
+	}
+
+	if m.Size, err = elems[0].GetUint64(); err != nil {
+		return err
+	}
+
+	if m.ChunkSize, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	chunks, err := elems[2].GetElems()
+	if err != nil {
+		return err
+	}
+
+	m.Chunks = make([]types.Hash, len(chunks))
+
+	for i, chunk := range chunks {
+		if err = chunk.GetHash(m.Chunks[i][:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
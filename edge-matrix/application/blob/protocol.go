@@ -0,0 +1,172 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// ProtoBlob is the libp2p protocol serving the manifests and the chunks of the blobs
+const ProtoBlob = "/em-blob"
+
+// kinds of the requests of the blob protocol
+const (
+	requestManifest byte = iota
+	requestChunk
+)
+
+// statuses of the responses of the blob protocol
+const (
+	statusOK byte = iota
+	statusNotFound
+)
+
+// maxResponseSize bounds the responses, a manifest of MaxChunks chunks is smaller than a chunk
+const maxResponseSize = 1 + MaxChunkSize
+
+var errNotFound = errors.New("not found by the peer")
+
+// Serve serves the blobs of the store to the peers of the host
+func Serve(logger hclog.Logger, h host.Host, store *Store) {
+	h.SetStreamHandler(protocol.ID(ProtoBlob), func(stream network.Stream) {
+		defer stream.Close()
+
+		req := make([]byte, 1+types.HashLength)
+		if _, err := io.ReadFull(stream, req); err != nil {
+			logger.Debug("invalid blob request", "peer", stream.Conn().RemotePeer(), "err", err)
+
+			return
+		}
+
+		hash := types.BytesToHash(req[1:])
+
+		var (
+			data []byte
+			ok   bool
+			err  error
+		)
+
+		switch req[0] {
+		case requestManifest:
+			var m *Manifest
+			if m, ok, err = store.GetManifest(hash); ok {
+				data = m.MarshalRLP()
+			}
+		case requestChunk:
+			data, ok, err = store.GetChunk(hash)
+		default:
+			logger.Debug("unknown blob request", "peer", stream.Conn().RemotePeer(), "kind", req[0])
+
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed to read blob", "hash", hash, "err", err)
+
+			return
+		}
+
+		if !ok {
+			_, _ = stream.Write([]byte{statusNotFound})
+
+			return
+		}
+
+		_, _ = stream.Write(append([]byte{statusOK}, data...))
+	})
+}
+
+// request sends the request of the given kind to the peer, and returns the response
+func request(ctx context.Context, h host.Host, peerID peer.ID, kind byte, hash types.Hash) ([]byte, error) {
+	// the peers behind relays are reached through the limited relayed connections
+	stream, err := h.NewStream(network.WithUseTransient(ctx, ProtoBlob), peerID, protocol.ID(ProtoBlob))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if _, err := stream.Write(append([]byte{kind}, hash.Bytes()...)); err != nil {
+		return nil, err
+	}
+
+	if err := stream.CloseWrite(); err != nil {
+		return nil, err
+	}
+
+	resp, err := io.ReadAll(io.LimitReader(stream, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp) == 0 || resp[0] != statusOK {
+		return nil, errNotFound
+	}
+
+	return resp[1:], nil
+}
+
+// Fetch fetches the blob from the peer into the store, verifying the manifest and the chunks against their hashes.
+// The chunks stored already are not fetched again, so an interrupted fetch resumes where it stopped
+func Fetch(ctx context.Context, h host.Host, peerID peer.ID, hash types.Hash, store *Store) error {
+	m, ok, err := store.GetManifest(hash)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		data, err := request(ctx, h, peerID, requestManifest, hash)
+		if err != nil {
+			return fmt.Errorf("%w: blob %s", err, hash)
+		}
+
+		m = &Manifest{}
+		if err := m.UnmarshalRLP(data); err != nil {
+			return err
+		}
+
+		if m.Hash() != hash {
+			return fmt.Errorf("%w: hash mismatch", ErrInvalidManifest)
+		}
+	}
+
+	// storing the manifest again delays its collection
+	if _, err := store.PutManifest(m); err != nil {
+		return err
+	}
+
+	missing, err := store.Missing(hash)
+	if err != nil {
+		return err
+	}
+
+	for _, index := range missing {
+		chunk := m.Chunks[index]
+
+		data, err := request(ctx, h, peerID, requestChunk, chunk)
+		if err != nil {
+			return fmt.Errorf("%w: chunk %d of blob %s", err, index, hash)
+		}
+
+		if uint64(len(data)) != m.ChunkLength(int(index)) || ChunkHash(data) != chunk {
+			return fmt.Errorf("%w: chunk %d of blob %s", ErrChunkMismatch, index, hash)
+		}
+
+		if _, err := store.PutChunk(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
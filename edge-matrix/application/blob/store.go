@@ -0,0 +1,228 @@
+package blob
+
+import (
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// key prefixes of the store, the values are prefixed by their store time
+var (
+	// manifest by blob hash
+	manifestPrefix = []byte("m")
+	// chunk by chunk hash
+	chunkPrefix = []byte("c")
+)
+
+// Store keeps the blobs uploaded to the node or fetched by the node, the chunks are shared by the blobs
+type Store struct {
+	db *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// get returns the value of the key without its store time, the values not stored again within the retention are collected
+func (s *Store) get(key []byte) ([]byte, bool, error) {
+	value, ok, err := s.db.Get(key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	data, _ := kvstore.Unstamp(value)
+
+	return data, true, nil
+}
+
+// PutChunk stores the chunk and returns its hash
+func (s *Store) PutChunk(data []byte) (types.Hash, error) {
+	if len(data) > MaxChunkSize {
+		return types.ZeroHash, ErrChunkTooLarge
+	}
+
+	hash := ChunkHash(data)
+
+	return hash, s.db.Put(kvstore.HashKey(chunkPrefix, hash), kvstore.Stamp(data))
+}
+
+// GetChunk returns the chunk of the given hash
+func (s *Store) GetChunk(hash types.Hash) ([]byte, bool, error) {
+	return s.get(kvstore.HashKey(chunkPrefix, hash))
+}
+
+// PutManifest stores the manifest of the blob and returns its hash, the chunks may be stored later
+func (s *Store) PutManifest(m *Manifest) (types.Hash, error) {
+	if err := m.Validate(); err != nil {
+		return types.ZeroHash, err
+	}
+
+	hash := m.Hash()
+
+	return hash, s.db.Put(kvstore.HashKey(manifestPrefix, hash), kvstore.Stamp(m.MarshalRLP()))
+}
+
+// GetManifest returns the manifest of the blob of the given hash
+func (s *Store) GetManifest(hash types.Hash) (*Manifest, bool, error) {
+	data, ok, err := s.get(kvstore.HashKey(manifestPrefix, hash))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	m := &Manifest{}
+	if err := m.UnmarshalRLP(data); err != nil {
+		return nil, false, err
+	}
+
+	return m, true, nil
+}
+
+// Missing returns the indexes of the chunks of the blob not stored yet
+func (s *Store) Missing(hash types.Hash) ([]uint64, error) {
+	m, ok, err := s.GetManifest(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrUnknownBlob
+	}
+
+	missing := make([]uint64, 0)
+
+	for i, chunk := range m.Chunks {
+		ok, err := s.db.Has(kvstore.HashKey(chunkPrefix, chunk))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			missing = append(missing, uint64(i))
+		}
+	}
+
+	return missing, nil
+}
+
+// ReadChunk returns the chunk of the given index of the blob
+func (s *Store) ReadChunk(hash types.Hash, index uint64) ([]byte, error) {
+	m, ok, err := s.GetManifest(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrUnknownBlob
+	}
+
+	if index >= uint64(len(m.Chunks)) {
+		return nil, ErrUnknownChunk
+	}
+
+	data, ok, err := s.GetChunk(m.Chunks[index])
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrIncompleteBlob
+	}
+
+	return data, nil
+}
+
+// Read returns the content of the complete blob
+func (s *Store) Read(hash types.Hash) ([]byte, error) {
+	m, ok, err := s.GetManifest(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrUnknownBlob
+	}
+
+	data := make([]byte, 0, m.Size)
+
+	for _, chunk := range m.Chunks {
+		chunkData, ok, err := s.GetChunk(chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, ErrIncompleteBlob
+		}
+
+		data = append(data, chunkData...)
+	}
+
+	return data, nil
+}
+
+// Collect deletes the blobs stored before the given time in milliseconds,
+// and the chunks stored before it which are not referenced by the remaining blobs.
+// It returns the number of the deleted blobs and chunks
+func (s *Store) Collect(before uint64) (int, int, error) {
+	batch := new(leveldb.Batch)
+	live := make(map[types.Hash]struct{})
+	blobs, chunks := 0, 0
+
+	err := s.db.Iterate(util.BytesPrefix(manifestPrefix), func(key, value []byte) error {
+		data, storedAt := kvstore.Unstamp(value)
+		if storedAt < before {
+			batch.Delete(key)
+			blobs++
+
+			return nil
+		}
+
+		m := &Manifest{}
+		if err := m.UnmarshalRLP(data); err != nil {
+			return err
+		}
+
+		for _, chunk := range m.Chunks {
+			live[chunk] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s.db.Iterate(util.BytesPrefix(chunkPrefix), func(key, value []byte) error {
+		if _, ok := live[types.BytesToHash(key[len(chunkPrefix):])]; ok {
+			return nil
+		}
+
+		if _, storedAt := kvstore.Unstamp(value); storedAt < before {
+			batch.Delete(key)
+			chunks++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return blobs, chunks, s.db.Write(batch)
+}
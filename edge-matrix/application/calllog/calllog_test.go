@@ -0,0 +1,109 @@
+package calllog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntry(hash string, caller types.Address, startTime uint64) *Entry {
+	return &Entry{
+		Hash:      types.StringToHash(hash),
+		Caller:    caller,
+		PeerID:    "peer",
+		Request:   []byte("request"),
+		Response:  []byte("response"),
+		StartTime: startTime,
+		Duration:  10,
+	}
+}
+
+func TestEntry_RLP(t *testing.T) {
+	t.Parallel()
+
+	entry := testEntry("0x1", types.StringToAddress("0x1"), 1000)
+	entry.Error = "failed"
+
+	decoded := &Entry{}
+	require.NoError(t, decoded.UnmarshalRLP(entry.MarshalRLP()))
+	assert.Equal(t, entry, decoded)
+}
+
+func TestStore_Query(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	a, b := types.StringToAddress("0xa"), types.StringToAddress("0xb")
+
+	for _, entry := range []*Entry{
+		testEntry("0x1", a, 1000),
+		testEntry("0x2", b, 2000),
+		testEntry("0x3", a, 3000),
+		testEntry("0x4", a, 4000),
+	} {
+		require.NoError(t, store.Put(entry))
+	}
+
+	entry, ok, err := store.Get(types.StringToHash("0x2"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, b, entry.Caller)
+
+	entries, total, err := store.Query(&Query{Caller: &a, FromTime: 2000})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, types.StringToHash("0x3"), entries[0].Hash)
+
+	entries, total, err = store.Query(&Query{ToTime: 3000, Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, types.StringToHash("0x2"), entries[0].Hash)
+
+	// the call stored again is moved to its new start time
+	require.NoError(t, store.Put(testEntry("0x1", a, 5000)))
+
+	entries, total, err = store.Query(&Query{Caller: &a})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), total)
+	assert.Equal(t, types.StringToHash("0x1"), entries[2].Hash)
+
+	_, _, err = store.Query(&Query{FromTime: 2, ToTime: 1})
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+
+	_, _, err = store.Query(&Query{Limit: MaxQueryLimit + 1})
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+}
+
+func TestPruner_Prune(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	caller := types.StringToAddress("0xa")
+	now := uint64(time.Now().UnixMilli())
+
+	require.NoError(t, store.Put(testEntry("0x1", caller, now-uint64(2*time.Hour.Milliseconds()))))
+	require.NoError(t, store.Put(testEntry("0x2", caller, now)))
+
+	pruned, err := NewPruner(hclog.NewNullLogger(), store, time.Hour).Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	_, ok, err := store.Get(types.StringToHash("0x1"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	entries, total, err := store.Query(&Query{Caller: &caller})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), total)
+	assert.Equal(t, types.StringToHash("0x2"), entries[0].Hash)
+}
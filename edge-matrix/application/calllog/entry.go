@@ -0,0 +1,101 @@
+package calllog
+
+import (
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// Entry is an edge call sent by the node on behalf of its caller, with the signed response of the edge node
+type Entry struct {
+	// Hash is the hash of the edge call telegram
+	Hash   types.Hash
+	Caller types.Address
+	PeerID string
+
+	// Request is the edge call input of the telegram
+	Request []byte
+
+	// Response is the signed edge response RLP, empty if the call failed
+	Response []byte
+
+	// StartTime is the time the call was sent in unix milliseconds, and Duration its round trip in milliseconds
+	StartTime uint64
+	Duration  uint64
+
+	// Error is the reason the call failed
+	Error string
+}
+
+func (e *Entry) MarshalRLP() []byte {
+	return e.MarshalRLPTo(nil)
+}
+
+func (e *Entry) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(e.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Entry to RLP with a specific fastrlp.Arena
+func (e *Entry) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(e.Hash.Bytes()))
+	vv.Set(arena.NewBytes(e.Caller.Bytes()))
+	vv.Set(arena.NewString(e.PeerID))
+	vv.Set(arena.NewCopyBytes(e.Request))
+	vv.Set(arena.NewCopyBytes(e.Response))
+	vv.Set(arena.NewUint(e.StartTime))
+	vv.Set(arena.NewUint(e.Duration))
+	vv.Set(arena.NewString(e.Error))
+
+	return vv
+}
+
+func (e *Entry) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(e.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals an Entry in RLP format
+func (e *Entry) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 8 {
+		return fmt.Errorf("incorrect number of elements to decode edge call entry, expected 8 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(e.Hash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetAddr(e.Caller[:]); err != nil {
+		return err
+	}
+
+	if e.PeerID, err = elems[2].GetString(); err != nil {
+		return err
+	}
+
+	if e.Request, err = elems[3].GetBytes(nil); err != nil {
+		return err
+	}
+
+	if e.Response, err = elems[4].GetBytes(nil); err != nil {
+		return err
+	}
+
+	if e.StartTime, err = elems[5].GetUint64(); err != nil {
+		return err
+	}
+
+	if e.Duration, err = elems[6].GetUint64(); err != nil {
+		return err
+	}
+
+	e.Error, err = elems[7].GetString()
+
+	return err
+}
@@ -0,0 +1,35 @@
+package calllog
+
+import (
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetention is the period the edge calls are kept for
+	DefaultRetention = 30 * 24 * time.Hour
+
+	// pruneInterval is the interval of the deletions of the expired edge calls
+	pruneInterval = time.Hour
+)
+
+// Pruner periodically deletes the edge calls of the store older than the retention period
+type Pruner struct {
+	*kvstore.Retention
+}
+
+// NewPruner creates the pruner of the edge calls of the store
+func NewPruner(logger hclog.Logger, store *Store, retention time.Duration) *Pruner {
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+
+	return &Pruner{kvstore.NewRetention(logger.Named("edge_call_pruner"), retention, pruneInterval, store.Prune)}
+}
+
+// Prune deletes the edge calls started before the retention period, and returns the number of the deleted calls
+func (p *Pruner) Prune() (int, error) {
+	return p.Expire()
+}
@@ -0,0 +1,176 @@
+package calllog
+
+import (
+	"errors"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	DefaultQueryLimit = kvstore.DefaultQueryLimit
+	MaxQueryLimit     = kvstore.MaxQueryLimit
+)
+
+// key prefixes of the store
+var (
+	// entry by telegram hash
+	entryPrefix = []byte("e")
+	// telegram hashes by start time
+	timePrefix = []byte("t")
+	// telegram hashes by caller and start time
+	callerPrefix = []byte("c")
+)
+
+var (
+	ErrUnknownEntry  = errors.New("edge call not found")
+	ErrInvalidOrder  = kvstore.ErrInvalidOrder
+	ErrLimitTooLarge = kvstore.ErrLimitTooLarge
+)
+
+// Query filters the stored edge calls, the empty fields match any call
+type Query struct {
+	Caller *types.Address `json:"caller"`
+
+	// FromTime and ToTime bound the start times of the calls in unix milliseconds, inclusive
+	FromTime uint64 `json:"fromTime"`
+	ToTime   uint64 `json:"toTime"`
+
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+// Store keeps the edge calls sent by the node, indexed by telegram hash and by caller
+type Store struct {
+	db *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func callerIndexPrefix(caller types.Address) []byte {
+	return append(append([]byte{}, callerPrefix...), caller.Bytes()...)
+}
+
+// Put stores the edge call, replacing the call of the same telegram hash
+func (s *Store) Put(entry *Entry) error {
+	batch := new(leveldb.Batch)
+
+	if prev, ok, err := s.Get(entry.Hash); err != nil {
+		return err
+	} else if ok {
+		deleteEntry(batch, prev)
+	}
+
+	batch.Put(kvstore.HashKey(entryPrefix, entry.Hash), entry.MarshalRLP())
+	batch.Put(kvstore.TimeKey(timePrefix, entry.StartTime, entry.Hash), []byte{})
+	batch.Put(kvstore.TimeKey(callerIndexPrefix(entry.Caller), entry.StartTime, entry.Hash), []byte{})
+
+	return s.db.Write(batch)
+}
+
+func deleteEntry(batch *leveldb.Batch, entry *Entry) {
+	batch.Delete(kvstore.HashKey(entryPrefix, entry.Hash))
+	batch.Delete(kvstore.TimeKey(timePrefix, entry.StartTime, entry.Hash))
+	batch.Delete(kvstore.TimeKey(callerIndexPrefix(entry.Caller), entry.StartTime, entry.Hash))
+}
+
+// Get returns the edge call of the given telegram hash
+func (s *Store) Get(hash types.Hash) (*Entry, bool, error) {
+	entry := &Entry{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(entryPrefix, hash), entry)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return entry, true, nil
+}
+
+// Query returns the page of the edge calls matching the query in the order of their start times,
+// and the number of all the matching calls
+func (s *Store) Query(q *Query) ([]*Entry, uint64, error) {
+	if q == nil {
+		q = &Query{}
+	}
+
+	page, err := kvstore.NewPage(q.FromTime, q.ToTime, q.Offset, q.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	prefix := timePrefix
+	if q.Caller != nil {
+		prefix = callerIndexPrefix(*q.Caller)
+	}
+
+	entries := make([]*Entry, 0)
+
+	err = s.db.Iterate(kvstore.TimeRange(prefix, q.FromTime, q.ToTime), func(key, _ []byte) error {
+		if !page.Add() {
+			return nil
+		}
+
+		entry, ok, err := s.Get(kvstore.TimeKeyHash(prefix, key))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			entries = append(entries, entry)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, page.Total, nil
+}
+
+// Prune deletes the edge calls started before the given time in milliseconds, and returns the number of the deleted calls
+func (s *Store) Prune(before uint64) (int, error) {
+	batch := new(leveldb.Batch)
+	pruned := 0
+
+	err := s.db.Iterate(kvstore.Before(timePrefix, before), func(key, _ []byte) error {
+		entry, ok, err := s.Get(kvstore.TimeKeyHash(timePrefix, key))
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			batch.Delete(key)
+
+			return nil
+		}
+
+		deleteEntry(batch, entry)
+		pruned++
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pruned, s.db.Write(batch)
+}
@@ -0,0 +1,356 @@
+package capability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/application/proto"
+)
+
+// SchemaVersion is the version of the capability schema reported by this node,
+// the capabilities of the later versions are accepted with the fields known to this version
+const SchemaVersion = 1
+
+// Names of the accelerator runtimes
+const (
+	RuntimeCuda   = "cuda"
+	RuntimeRocm   = "rocm"
+	RuntimeOpenCL = "opencl"
+)
+
+// limits of the gossiped capabilities
+const (
+	maxGpus      = 64
+	maxCpuFlags  = 512
+	maxRuntimes  = 16
+	maxStringLen = 256
+)
+
+var (
+	ErrMissingVersion     = errors.New("missing capability schema version")
+	ErrFreeExceedsTotal   = errors.New("free capacity exceeds the total")
+	ErrThreadsBelowCores  = errors.New("fewer threads than cores")
+	ErrTooManyGpus        = fmt.Errorf("more than %d GPUs", maxGpus)
+	ErrTooManyCpuFlags    = fmt.Errorf("more than %d CPU flags", maxCpuFlags)
+	ErrTooManyRuntimes    = fmt.Errorf("more than %d runtimes", maxRuntimes)
+	ErrStringTooLong      = fmt.Errorf("value longer than %d bytes", maxStringLen)
+	ErrMissingGpuModel    = errors.New("missing GPU model")
+	ErrMissingRuntimeName = errors.New("missing runtime name")
+)
+
+// Capabilities is the hardware of an edge node, reported by its app.
+// The capabilities are replaced on update, never modified, as they are shared by the peers and the events
+type Capabilities struct {
+	Version  uint32     `json:"version"`
+	Cpu      *Cpu       `json:"cpu,omitempty"`
+	Gpus     []*Gpu     `json:"gpus"`
+	Memory   *Memory    `json:"memory,omitempty"`
+	Disk     *Disk      `json:"disk,omitempty"`
+	Runtimes []*Runtime `json:"runtimes"`
+}
+
+type Cpu struct {
+	Vendor string `json:"vendor"`
+	Model  string `json:"model"`
+
+	// Cores are the physical cores and Threads the logical processors
+	Cores   uint32   `json:"cores"`
+	Threads uint32   `json:"threads"`
+	Mhz     float64  `json:"mhz"`
+	Flags   []string `json:"flags"`
+}
+
+type Gpu struct {
+	Vendor string `json:"vendor"`
+	Model  string `json:"model"`
+
+	// Vram is the video memory in bytes, zero if unknown
+	Vram   uint64 `json:"vram"`
+	Driver string `json:"driver"`
+}
+
+// Memory is the memory of the node in bytes
+type Memory struct {
+	Total uint64 `json:"total"`
+	Free  uint64 `json:"free"`
+}
+
+// Disk is the disk of the node data in bytes
+type Disk struct {
+	Total uint64 `json:"total"`
+	Free  uint64 `json:"free"`
+}
+
+// Runtime is an accelerator runtime, such as cuda or rocm
+type Runtime struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Validate checks the capabilities are consistent and within the gossip limits
+func (c *Capabilities) Validate() error {
+	if c.Version == 0 {
+		return ErrMissingVersion
+	}
+
+	if c.Cpu != nil {
+		if err := c.Cpu.validate(); err != nil {
+			return fmt.Errorf("invalid cpu: %w", err)
+		}
+	}
+
+	if len(c.Gpus) > maxGpus {
+		return ErrTooManyGpus
+	}
+
+	for i, gpu := range c.Gpus {
+		if err := gpu.validate(); err != nil {
+			return fmt.Errorf("invalid gpu %d: %w", i, err)
+		}
+	}
+
+	if c.Memory != nil && c.Memory.Free > c.Memory.Total {
+		return fmt.Errorf("invalid memory: %w", ErrFreeExceedsTotal)
+	}
+
+	if c.Disk != nil && c.Disk.Free > c.Disk.Total {
+		return fmt.Errorf("invalid disk: %w", ErrFreeExceedsTotal)
+	}
+
+	if len(c.Runtimes) > maxRuntimes {
+		return ErrTooManyRuntimes
+	}
+
+	for i, runtime := range c.Runtimes {
+		if runtime.Name == "" {
+			return fmt.Errorf("invalid runtime %d: %w", i, ErrMissingRuntimeName)
+		}
+
+		if err := checkStrings(runtime.Name, runtime.Version); err != nil {
+			return fmt.Errorf("invalid runtime %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cpu) validate() error {
+	if c.Threads != 0 && c.Threads < c.Cores {
+		return ErrThreadsBelowCores
+	}
+
+	if len(c.Flags) > maxCpuFlags {
+		return ErrTooManyCpuFlags
+	}
+
+	return checkStrings(append([]string{c.Vendor, c.Model}, c.Flags...)...)
+}
+
+func (g *Gpu) validate() error {
+	if g.Model == "" {
+		return ErrMissingGpuModel
+	}
+
+	return checkStrings(g.Vendor, g.Model, g.Driver)
+}
+
+func checkStrings(values ...string) error {
+	for _, value := range values {
+		if len(value) > maxStringLen {
+			return ErrStringTooLong
+		}
+	}
+
+	return nil
+}
+
+// TotalVram returns the video memory of all the GPUs
+func (c *Capabilities) TotalVram() uint64 {
+	total := uint64(0)
+	for _, gpu := range c.Gpus {
+		total += gpu.Vram
+	}
+
+	return total
+}
+
+// MaxVram returns the largest video memory of a GPU, which bounds the size of the models the node can load
+func (c *Capabilities) MaxVram() uint64 {
+	max := uint64(0)
+
+	for _, gpu := range c.Gpus {
+		if gpu.Vram > max {
+			max = gpu.Vram
+		}
+	}
+
+	return max
+}
+
+// Runtime returns the runtime of the given name, nil if the node does not provide it
+func (c *Capabilities) Runtime(name string) *Runtime {
+	for _, runtime := range c.Runtimes {
+		if runtime.Name == name {
+			return runtime
+		}
+	}
+
+	return nil
+}
+
+// ToProto converts the capabilities to their protobuf message, nil if not set
+func ToProto(c *Capabilities) *proto.Capabilities {
+	if c == nil {
+		return nil
+	}
+
+	msg := &proto.Capabilities{
+		Version: c.Version,
+	}
+
+	if c.Cpu != nil {
+		msg.Cpu = &proto.CpuCapability{
+			Vendor:  c.Cpu.Vendor,
+			Model:   c.Cpu.Model,
+			Cores:   c.Cpu.Cores,
+			Threads: c.Cpu.Threads,
+			Mhz:     c.Cpu.Mhz,
+			Flags:   c.Cpu.Flags,
+		}
+	}
+
+	for _, gpu := range c.Gpus {
+		msg.Gpus = append(msg.Gpus, &proto.GpuCapability{
+			Vendor: gpu.Vendor,
+			Model:  gpu.Model,
+			Vram:   gpu.Vram,
+			Driver: gpu.Driver,
+		})
+	}
+
+	if c.Memory != nil {
+		msg.Memory = &proto.MemoryCapability{Total: c.Memory.Total, Free: c.Memory.Free}
+	}
+
+	if c.Disk != nil {
+		msg.Disk = &proto.DiskCapability{Total: c.Disk.Total, Free: c.Disk.Free}
+	}
+
+	for _, runtime := range c.Runtimes {
+		msg.Runtimes = append(msg.Runtimes, &proto.RuntimeCapability{
+			Name:    runtime.Name,
+			Version: runtime.Version,
+		})
+	}
+
+	return msg
+}
+
+// FromProto converts and validates the received capabilities,
+// nil without error if the peer reports none, as the nodes prior to the schema
+func FromProto(msg *proto.Capabilities) (*Capabilities, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	c := &Capabilities{
+		Version:  msg.Version,
+		Gpus:     make([]*Gpu, 0, len(msg.Gpus)),
+		Runtimes: make([]*Runtime, 0, len(msg.Runtimes)),
+	}
+
+	if msg.Cpu != nil {
+		c.Cpu = &Cpu{
+			Vendor:  msg.Cpu.Vendor,
+			Model:   msg.Cpu.Model,
+			Cores:   msg.Cpu.Cores,
+			Threads: msg.Cpu.Threads,
+			Mhz:     msg.Cpu.Mhz,
+			Flags:   msg.Cpu.Flags,
+		}
+	}
+
+	for _, gpu := range msg.Gpus {
+		c.Gpus = append(c.Gpus, &Gpu{
+			Vendor: gpu.Vendor,
+			Model:  gpu.Model,
+			Vram:   gpu.Vram,
+			Driver: gpu.Driver,
+		})
+	}
+
+	if msg.Memory != nil {
+		c.Memory = &Memory{Total: msg.Memory.Total, Free: msg.Memory.Free}
+	}
+
+	if msg.Disk != nil {
+		c.Disk = &Disk{Total: msg.Disk.Total, Free: msg.Disk.Free}
+	}
+
+	for _, runtime := range msg.Runtimes {
+		c.Runtimes = append(c.Runtimes, &Runtime{
+			Name:    runtime.Name,
+			Version: runtime.Version,
+		})
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// legacyCpuInfo is the CPU info string reported by the nodes prior to the schema
+type legacyCpuInfo struct {
+	Cpus      int
+	VendorId  string
+	Family    string
+	Model     string
+	Cores     int32
+	ModelName string
+	Mhz       float64
+}
+
+// legacyGpuInfo is the GPU info string reported by the nodes prior to the schema
+type legacyGpuInfo struct {
+	Gpus         int      `json:"gpus"`
+	GraphicsCard []string `json:"graphics_card"`
+}
+
+// LegacyInfo returns the CPU, GPU and memory info strings in the formats reported by the nodes prior to the schema,
+// which are still published along the capabilities
+func (c *Capabilities) LegacyInfo() (cpuInfo, gpuInfo, memInfo string) {
+	if c.Cpu != nil {
+		if data, err := json.Marshal(&legacyCpuInfo{
+			Cpus:      int(c.Cpu.Threads),
+			VendorId:  c.Cpu.Vendor,
+			Cores:     int32(c.Cpu.Cores),
+			ModelName: c.Cpu.Model,
+			Mhz:       c.Cpu.Mhz,
+		}); err == nil {
+			cpuInfo = string(data)
+		}
+	}
+
+	gpus := &legacyGpuInfo{
+		Gpus:         len(c.Gpus),
+		GraphicsCard: make([]string, len(c.Gpus)),
+	}
+
+	for i, gpu := range c.Gpus {
+		gpus.GraphicsCard[i] = fmt.Sprintf("%s %s", gpu.Vendor, gpu.Model)
+	}
+
+	if data, err := json.Marshal(gpus); err == nil {
+		gpuInfo = string(data)
+	}
+
+	if c.Memory != nil && c.Memory.Total != 0 {
+		usedPercent := float64(c.Memory.Total-c.Memory.Free) / float64(c.Memory.Total) * 100
+		memInfo = fmt.Sprintf(`{"total": %v, "free":%v, "used_percent":%f}`, c.Memory.Total, c.Memory.Free, usedPercent)
+	}
+
+	return cpuInfo, gpuInfo, memInfo
+}
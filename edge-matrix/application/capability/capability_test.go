@@ -0,0 +1,155 @@
+package capability
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCapabilities() *Capabilities {
+	return &Capabilities{
+		Version: SchemaVersion,
+		Cpu: &Cpu{
+			Vendor:  "GenuineIntel",
+			Model:   "Intel(R) Xeon(R) Gold 6348",
+			Cores:   28,
+			Threads: 56,
+			Mhz:     2600,
+			Flags:   []string{"avx2", "avx512f"},
+		},
+		Gpus: []*Gpu{
+			{Vendor: "NVIDIA Corporation", Model: "NVIDIA A100-SXM4-80GB", Vram: 80 << 30, Driver: "535.104.05"},
+			{Vendor: "NVIDIA Corporation", Model: "NVIDIA GeForce RTX 4090", Vram: 24 << 30, Driver: "535.104.05"},
+		},
+		Memory:   &Memory{Total: 256 << 30, Free: 192 << 30},
+		Disk:     &Disk{Total: 2 << 40, Free: 1 << 40},
+		Runtimes: []*Runtime{{Name: RuntimeCuda, Version: "12.2"}},
+	}
+}
+
+func TestCapabilities_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, testCapabilities().Validate())
+
+	cases := []struct {
+		name   string
+		modify func(c *Capabilities)
+		err    error
+	}{
+		{"missing version", func(c *Capabilities) { c.Version = 0 }, ErrMissingVersion},
+		{"threads below cores", func(c *Capabilities) { c.Cpu.Threads = 8 }, ErrThreadsBelowCores},
+		{"too many flags", func(c *Capabilities) { c.Cpu.Flags = make([]string, maxCpuFlags+1) }, ErrTooManyCpuFlags},
+		{"long model", func(c *Capabilities) { c.Cpu.Model = strings.Repeat("x", maxStringLen+1) }, ErrStringTooLong},
+		{"missing gpu model", func(c *Capabilities) { c.Gpus[1].Model = "" }, ErrMissingGpuModel},
+		{"too many gpus", func(c *Capabilities) { c.Gpus = make([]*Gpu, maxGpus+1) }, ErrTooManyGpus},
+		{"free memory", func(c *Capabilities) { c.Memory.Free = c.Memory.Total + 1 }, ErrFreeExceedsTotal},
+		{"free disk", func(c *Capabilities) { c.Disk.Free = c.Disk.Total + 1 }, ErrFreeExceedsTotal},
+		{"missing runtime name", func(c *Capabilities) { c.Runtimes[0].Name = "" }, ErrMissingRuntimeName},
+		{"too many runtimes", func(c *Capabilities) { c.Runtimes = make([]*Runtime, maxRuntimes+1) }, ErrTooManyRuntimes},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			caps := testCapabilities()
+			c.modify(caps)
+
+			assert.ErrorIs(t, caps.Validate(), c.err)
+		})
+	}
+}
+
+func TestProtoConversion(t *testing.T) {
+	t.Parallel()
+
+	caps := testCapabilities()
+
+	converted, err := FromProto(ToProto(caps))
+	assert.NoError(t, err)
+	assert.Equal(t, caps, converted)
+
+	// the peers prior to the schema report no capabilities
+	converted, err = FromProto(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, converted)
+	assert.Nil(t, ToProto(nil))
+
+	// the later versions are accepted with the known fields
+	msg := ToProto(caps)
+	msg.Version = SchemaVersion + 1
+
+	converted, err = FromProto(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(SchemaVersion+1), converted.Version)
+
+	// the received capabilities are validated
+	_, err = FromProto(&proto.Capabilities{Version: SchemaVersion, Memory: &proto.MemoryCapability{Total: 1, Free: 2}})
+	assert.ErrorIs(t, err, ErrFreeExceedsTotal)
+}
+
+func TestCapabilities_Queries(t *testing.T) {
+	t.Parallel()
+
+	caps := testCapabilities()
+
+	assert.Equal(t, uint64(80<<30), caps.MaxVram())
+	assert.Equal(t, uint64(104<<30), caps.TotalVram())
+	assert.Equal(t, "12.2", caps.Runtime(RuntimeCuda).Version)
+	assert.Nil(t, caps.Runtime(RuntimeRocm))
+}
+
+func TestCapabilities_LegacyInfo(t *testing.T) {
+	t.Parallel()
+
+	cpuInfo, gpuInfo, memInfo := testCapabilities().LegacyInfo()
+
+	cpu := legacyCpuInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(cpuInfo), &cpu))
+	assert.Equal(t, int32(28), cpu.Cores)
+	assert.Equal(t, "Intel(R) Xeon(R) Gold 6348", cpu.ModelName)
+
+	gpu := legacyGpuInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(gpuInfo), &gpu))
+	assert.Equal(t, 2, gpu.Gpus)
+	assert.Equal(t, "NVIDIA Corporation NVIDIA GeForce RTX 4090", gpu.GraphicsCard[1])
+
+	var mem struct {
+		Total       uint64  `json:"total"`
+		Free        uint64  `json:"free"`
+		UsedPercent float64 `json:"used_percent"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(memInfo), &mem))
+	assert.Equal(t, uint64(192<<30), mem.Free)
+	assert.Equal(t, 25.0, mem.UsedPercent)
+}
+
+func TestFakeProvider(t *testing.T) {
+	t.Parallel()
+
+	caps := testCapabilities()
+
+	provided, err := NewFakeProvider(caps).Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, caps, provided)
+
+	failure := errors.New("no hardware")
+
+	_, err = (&FakeProvider{Err: failure}).Capabilities()
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestNormalizePciAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, normalizePciAddress("0000:01:00.0"), normalizePciAddress("00000000:01:00.0"))
+	assert.NotEqual(t, normalizePciAddress("0000:01:00.0"), normalizePciAddress("0000:02:00.0"))
+}
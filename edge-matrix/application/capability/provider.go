@@ -0,0 +1,275 @@
+package capability
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaypipes/ghw"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	// nvidiaSmiTimeout is the timeout of the nvidia-smi query of the NVIDIA GPUs
+	nvidiaSmiTimeout = 5 * time.Second
+
+	nvidiaDriverVersionFile = "/proc/driver/nvidia/version"
+	rocmVersionFile         = "/opt/rocm/.info/version"
+	openCLVendorsDir        = "/etc/OpenCL/vendors"
+	pciDevicesDir           = "/sys/bus/pci/devices"
+)
+
+// Provider reports the capabilities of the node
+type Provider interface {
+	Capabilities() (*Capabilities, error)
+}
+
+// HostProvider reports the hardware of the host, the parts which cannot be probed are left empty
+type HostProvider struct {
+	// DiskPath is a path on the disk of the node data
+	DiskPath string
+}
+
+// NewHostProvider creates the provider of the host hardware, with the disk holding the given path
+func NewHostProvider(diskPath string) *HostProvider {
+	if diskPath == "" {
+		diskPath = string(filepath.Separator)
+	}
+
+	return &HostProvider{DiskPath: diskPath}
+}
+
+// Capabilities probes the hardware of the host
+func (p *HostProvider) Capabilities() (*Capabilities, error) {
+	c := &Capabilities{
+		Version:  SchemaVersion,
+		Cpu:      hostCpu(),
+		Gpus:     hostGpus(),
+		Runtimes: hostRuntimes(),
+	}
+
+	if v, err := mem.VirtualMemory(); err == nil {
+		c.Memory = &Memory{Total: v.Total, Free: v.Available}
+	}
+
+	if usage, err := disk.Usage(p.DiskPath); err == nil {
+		c.Disk = &Disk{Total: usage.Total, Free: usage.Free}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func hostCpu() *Cpu {
+	infos, err := cpu.Info()
+	if err != nil || len(infos) == 0 {
+		return nil
+	}
+
+	c := &Cpu{
+		Vendor: infos[0].VendorID,
+		Model:  infos[0].ModelName,
+		Mhz:    infos[0].Mhz,
+		Flags:  infos[0].Flags,
+	}
+
+	if cores, err := cpu.Counts(false); err == nil {
+		c.Cores = uint32(cores)
+	}
+
+	if threads, err := cpu.Counts(true); err == nil {
+		c.Threads = uint32(threads)
+	}
+
+	if c.Threads < c.Cores {
+		c.Threads = c.Cores
+	}
+
+	if len(c.Flags) > maxCpuFlags {
+		c.Flags = c.Flags[:maxCpuFlags]
+	}
+
+	return c
+}
+
+func hostGpus() []*Gpu {
+	gpus := make([]*Gpu, 0)
+
+	// the probe warns on stderr on the hosts without graphics
+	info, err := ghw.GPU(ghw.WithDisableWarnings())
+	if err != nil {
+		return gpus
+	}
+
+	nvidia := nvidiaGpus()
+
+	for _, card := range info.GraphicsCards {
+		if card.DeviceInfo == nil || card.DeviceInfo.Product == nil || card.DeviceInfo.Product.Name == "" {
+			continue
+		}
+
+		gpu := &Gpu{
+			Model:  card.DeviceInfo.Product.Name,
+			Driver: card.DeviceInfo.Driver,
+		}
+
+		if card.DeviceInfo.Vendor != nil {
+			gpu.Vendor = card.DeviceInfo.Vendor.Name
+		}
+
+		if smi, ok := nvidia[normalizePciAddress(card.Address)]; ok {
+			// nvidia-smi reports the marketing name and the driver version
+			gpu.Model, gpu.Vram, gpu.Driver = smi.Model, smi.Vram, smi.Driver
+		} else if vram, ok := readUint(filepath.Join(pciDevicesDir, card.Address, "mem_info_vram_total")); ok {
+			// reported by the amdgpu driver
+			gpu.Vram = vram
+		}
+
+		gpus = append(gpus, gpu)
+
+		if len(gpus) == maxGpus {
+			break
+		}
+	}
+
+	return gpus
+}
+
+// nvidiaGpus queries the NVIDIA GPUs by PCI address, empty if nvidia-smi is not available
+func nvidiaGpus() map[string]*Gpu {
+	gpus := make(map[string]*Gpu)
+
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return gpus
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nvidiaSmiTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(
+		ctx,
+		"nvidia-smi",
+		"--query-gpu=pci.bus_id,name,memory.total,driver_version",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return gpus
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			continue
+		}
+
+		// memory.total is in MiB
+		mib, _ := strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 64)
+
+		gpus[normalizePciAddress(fields[0])] = &Gpu{
+			Vendor: "NVIDIA Corporation",
+			Model:  strings.TrimSpace(fields[1]),
+			Vram:   mib * 1024 * 1024,
+			Driver: strings.TrimSpace(fields[3]),
+		}
+	}
+
+	return gpus
+}
+
+// normalizePciAddress normalizes the PCI addresses of ghw (0000:01:00.0) and nvidia-smi (00000000:01:00.0)
+func normalizePciAddress(addr string) string {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+
+	if i := strings.IndexByte(addr, ':'); i >= 0 && strings.Count(addr, ":") == 2 {
+		domain := strings.TrimLeft(addr[:i], "0")
+
+		return domain + addr[i:]
+	}
+
+	return addr
+}
+
+func hostRuntimes() []*Runtime {
+	runtimes := make([]*Runtime, 0)
+
+	if version, ok := nvidiaDriverVersion(); ok {
+		runtimes = append(runtimes, &Runtime{Name: RuntimeCuda, Version: version})
+	}
+
+	if data, err := os.ReadFile(rocmVersionFile); err == nil {
+		runtimes = append(runtimes, &Runtime{Name: RuntimeRocm, Version: strings.TrimSpace(string(data))})
+	}
+
+	if entries, err := os.ReadDir(openCLVendorsDir); err == nil && len(entries) != 0 {
+		runtimes = append(runtimes, &Runtime{Name: RuntimeOpenCL})
+	}
+
+	return runtimes
+}
+
+// nvidiaDriverVersion returns the version of the loaded NVIDIA kernel module, supporting CUDA
+func nvidiaDriverVersion() (string, bool) {
+	file, err := os.Open(nvidiaDriverVersionFile)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return "", true
+	}
+
+	// NVRM version: NVIDIA UNIX x86_64 Kernel Module  535.104.05  Sat Aug 19 01:15:15 UTC 2023
+	fields := strings.Fields(scanner.Text())
+	for i, field := range fields {
+		if field == "Module" && i+1 < len(fields) {
+			return fields[i+1], true
+		}
+	}
+
+	return "", true
+}
+
+func readUint(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	value, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// FakeProvider reports fixed capabilities, so that the tests do not depend on the hardware of the host
+type FakeProvider struct {
+	Caps *Capabilities
+	Err  error
+}
+
+// NewFakeProvider creates the provider reporting the given capabilities
+func NewFakeProvider(caps *Capabilities) *FakeProvider {
+	return &FakeProvider{Caps: caps}
+}
+
+func (p *FakeProvider) Capabilities() (*Capabilities, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	return p.Caps, nil
+}
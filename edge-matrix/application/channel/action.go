@@ -0,0 +1,189 @@
+package channel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// ActionType is the type of the channel action telegram sent to the payment channel precompile
+type ActionType uint64
+
+const (
+	// ActionOpen opens the channel to the provider, depositing the telegram value
+	ActionOpen ActionType = iota + 1
+
+	// ActionTopUp adds the telegram value to the deposit of the channel, and extends its expiration
+	ActionTopUp
+
+	// ActionRedeem pays the provider the voucher amount not paid yet
+	ActionRedeem
+
+	// ActionClose closes the channel, refunding the sender the deposit not paid to the provider.
+	// The provider closes the channel at any time, redeeming the voucher if given,
+	// the sender only after the channel expires
+	ActionClose
+)
+
+// gas of the channel actions, charged as the storage opcodes and the ecrecover precompile
+const (
+	slotReadGas      = 800
+	slotSetGas       = 20000
+	slotResetGas     = 5000
+	voucherSignerGas = 3000
+)
+
+var (
+	ErrInvalidAction = errors.New("invalid payment channel action")
+	ErrActionGas     = errors.New("gas of the payment channel action is too low")
+)
+
+func (t ActionType) String() string {
+	switch t {
+	case ActionOpen:
+		return "open"
+	case ActionTopUp:
+		return "topUp"
+	case ActionRedeem:
+		return "redeem"
+	case ActionClose:
+		return "close"
+	default:
+		return fmt.Sprintf("ActionType(%d)", uint64(t))
+	}
+}
+
+// Action is the input of the telegrams to the payment channel precompile
+type Action struct {
+	Type ActionType
+
+	// Provider is the provider of the opened channel
+	Provider types.Address
+
+	// ChannelID is the channel of the top up, redeem and close actions
+	ChannelID types.Hash
+
+	// Expiration is the block number the opened or topped up channel expires at
+	Expiration uint64
+
+	// Voucher is the voucher redeemed by the provider
+	Voucher *Voucher
+}
+
+// Validate checks the fields required by the type of the action
+func (a *Action) Validate() error {
+	switch a.Type {
+	case ActionOpen:
+		if a.Provider == types.ZeroAddress || a.Expiration == 0 {
+			return ErrInvalidAction
+		}
+	case ActionTopUp:
+		if a.ChannelID == types.ZeroHash {
+			return ErrInvalidAction
+		}
+	case ActionRedeem:
+		if a.Voucher == nil || a.Voucher.ChannelID != a.ChannelID {
+			return ErrInvalidAction
+		}
+	case ActionClose:
+		if a.ChannelID == types.ZeroHash || (a.Voucher != nil && a.Voucher.ChannelID != a.ChannelID) {
+			return ErrInvalidAction
+		}
+	default:
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// Gas returns the gas charged by the precompile for the action,
+// the slots of the channel read and written and the recovery of the voucher signer
+func (a *Action) Gas() uint64 {
+	if a.Type == ActionOpen {
+		// the opened channel is looked up by its sender slot, and all its slots are set
+		return slotReadGas + uint64(NumSlots)*slotSetGas
+	}
+
+	gas := uint64(NumSlots) * (slotReadGas + slotResetGas)
+	if a.Voucher != nil {
+		gas += voucherSignerGas
+	}
+
+	return gas
+}
+
+func (a *Action) MarshalRLP() []byte {
+	return a.MarshalRLPTo(nil)
+}
+
+func (a *Action) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(a.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Action to RLP with a specific fastrlp.Arena
+func (a *Action) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(uint64(a.Type)))
+	vv.Set(arena.NewBytes(a.Provider.Bytes()))
+	vv.Set(arena.NewBytes(a.ChannelID.Bytes()))
+	vv.Set(arena.NewUint(a.Expiration))
+
+	if a.Voucher != nil {
+		vv.Set(a.Voucher.MarshalRLPWith(arena))
+	} else {
+		vv.Set(arena.NewNullArray())
+	}
+
+	return vv
+}
+
+func (a *Action) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(a.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals an Action in RLP format
+func (a *Action) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 5 {
+		return fmt.Errorf("incorrect number of elements to decode channel action, expected 5 but found %d", len(elems))
+	}
+
+	actionType, err := elems[0].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	a.Type = ActionType(actionType)
+
+	if err = elems[1].GetAddr(a.Provider[:]); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(a.ChannelID[:]); err != nil {
+		return err
+	}
+
+	if a.Expiration, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	a.Voucher = nil
+
+	if voucherElems, err := elems[4].GetElems(); err != nil {
+		return err
+	} else if len(voucherElems) > 0 {
+		a.Voucher = &Voucher{}
+		if err := a.Voucher.unmarshalRLPFrom(p, elems[4]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
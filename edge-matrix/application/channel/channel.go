@@ -0,0 +1,202 @@
+package channel
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrMissingVoucher      = errors.New("edge call is not paid by a voucher")
+	ErrUnknownChannel      = errors.New("payment channel is not opened")
+	ErrChannelClosed       = errors.New("payment channel is closed")
+	ErrChannelExpired      = errors.New("payment channel is expired")
+	ErrChannelNotExpired   = errors.New("payment channel is not expired")
+	ErrChannelExists       = errors.New("payment channel already exists")
+	ErrWrongProvider       = errors.New("payment channel is opened to another provider")
+	ErrInvalidVoucherSig   = errors.New("voucher is not signed by the channel sender")
+	ErrInsufficientDeposit = errors.New("voucher amount exceeds the channel deposit")
+	ErrStaleVoucher        = errors.New("voucher amount does not exceed the latest voucher")
+	ErrNothingToRedeem     = errors.New("no voucher to redeem")
+)
+
+// storage fields of a channel in the payment channel precompile
+const (
+	fieldSender byte = iota
+	fieldProvider
+	fieldDeposit
+	fieldPaid
+	fieldExpiration
+	fieldClosed
+	numFields
+)
+
+// NumSlots is the number of the storage slots keeping a channel in the payment channel precompile
+const NumSlots = int(numFields)
+
+var channelArenaPool fastrlp.ArenaPool
+
+// Channel is a unidirectional payment channel, locking the deposit of the sender
+// to pay the provider by the vouchers signed by the sender
+type Channel struct {
+	ID       types.Hash
+	Sender   types.Address
+	Provider types.Address
+	Deposit  *big.Int
+
+	// Paid is the cumulative amount redeemed by the provider
+	Paid *big.Int
+
+	// Expiration is the block number after which the sender can close the channel
+	Expiration uint64
+	Closed     bool
+}
+
+// ID returns the ID of the channel opened by the telegram of the sender with the given nonce
+func ID(sender types.Address, provider types.Address, nonce uint64) types.Hash {
+	a := channelArenaPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewBytes(sender.Bytes()))
+	v.Set(a.NewBytes(provider.Bytes()))
+	v.Set(a.NewUint(nonce))
+
+	hash := keccak.Keccak256Rlp(nil, v)
+
+	channelArenaPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// Unpaid returns the deposit not redeemed by the provider
+func (c *Channel) Unpaid() *big.Int {
+	return new(big.Int).Sub(c.Deposit, c.Paid)
+}
+
+func (c *Channel) Copy() *Channel {
+	cc := *c
+	cc.Deposit = new(big.Int).Set(c.Deposit)
+	cc.Paid = new(big.Int).Set(c.Paid)
+
+	return &cc
+}
+
+// Slot is a storage slot of the payment channel precompile
+type Slot struct {
+	Key   types.Hash
+	Value types.Hash
+}
+
+func slotKey(id types.Hash, field byte) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, append(id.Bytes(), field)))
+}
+
+// Slots returns the storage slots keeping the channel in the payment channel precompile
+func (c *Channel) Slots() []Slot {
+	closed := types.ZeroHash
+	if c.Closed {
+		closed = types.BytesToHash([]byte{1})
+	}
+
+	return []Slot{
+		{slotKey(c.ID, fieldSender), types.BytesToHash(c.Sender.Bytes())},
+		{slotKey(c.ID, fieldProvider), types.BytesToHash(c.Provider.Bytes())},
+		{slotKey(c.ID, fieldDeposit), types.BytesToHash(c.Deposit.Bytes())},
+		{slotKey(c.ID, fieldPaid), types.BytesToHash(c.Paid.Bytes())},
+		{slotKey(c.ID, fieldExpiration), types.BytesToHash(binary.BigEndian.AppendUint64(nil, c.Expiration))},
+		{slotKey(c.ID, fieldClosed), closed},
+	}
+}
+
+// ReadChannel reads the channel of the given ID from the storage of the payment channel precompile,
+// nil if the channel is not opened
+func ReadChannel(id types.Hash, getStorage func(key types.Hash) types.Hash) *Channel {
+	sender := getStorage(slotKey(id, fieldSender))
+	if sender == types.ZeroHash {
+		return nil
+	}
+
+	return &Channel{
+		ID:         id,
+		Sender:     types.BytesToAddress(sender.Bytes()),
+		Provider:   types.BytesToAddress(getStorage(slotKey(id, fieldProvider)).Bytes()),
+		Deposit:    new(big.Int).SetBytes(getStorage(slotKey(id, fieldDeposit)).Bytes()),
+		Paid:       new(big.Int).SetBytes(getStorage(slotKey(id, fieldPaid)).Bytes()),
+		Expiration: new(big.Int).SetBytes(getStorage(slotKey(id, fieldExpiration)).Bytes()).Uint64(),
+		Closed:     getStorage(slotKey(id, fieldClosed)) != types.ZeroHash,
+	}
+}
+
+func (c *Channel) MarshalRLP() []byte {
+	return c.MarshalRLPTo(nil)
+}
+
+func (c *Channel) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(c.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Channel to RLP with a specific fastrlp.Arena
+func (c *Channel) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(c.ID.Bytes()))
+	vv.Set(arena.NewBytes(c.Sender.Bytes()))
+	vv.Set(arena.NewBytes(c.Provider.Bytes()))
+	vv.Set(arena.NewBigInt(c.Deposit))
+	vv.Set(arena.NewBigInt(c.Paid))
+	vv.Set(arena.NewUint(c.Expiration))
+	vv.Set(arena.NewBool(c.Closed))
+
+	return vv
+}
+
+func (c *Channel) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(c.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Channel in RLP format
+func (c *Channel) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 7 {
+		return fmt.Errorf("incorrect number of elements to decode payment channel, expected 7 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(c.ID[:]); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetAddr(c.Sender[:]); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetAddr(c.Provider[:]); err != nil {
+		return err
+	}
+
+	c.Deposit = new(big.Int)
+	if err = elems[3].GetBigInt(c.Deposit); err != nil {
+		return err
+	}
+
+	c.Paid = new(big.Int)
+	if err = elems[4].GetBigInt(c.Paid); err != nil {
+		return err
+	}
+
+	if c.Expiration, err = elems[5].GetUint64(); err != nil {
+		return err
+	}
+
+	c.Closed, err = elems[6].GetBool()
+
+	return err
+}
@@ -0,0 +1,277 @@
+package channel
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChainID = 2
+
+var testProvider = types.StringToAddress("0x2")
+
+func testVoucher(t *testing.T, key *ecdsa.PrivateKey, id types.Hash, amount int64) *Voucher {
+	t.Helper()
+
+	v := &Voucher{ChannelID: id, Amount: big.NewInt(amount)}
+	assert.NoError(t, v.Sign(key, testChainID))
+
+	return v
+}
+
+func testChannel(t *testing.T) (*Channel, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&key.PublicKey)
+
+	return &Channel{
+		ID:         ID(sender, testProvider, 0),
+		Sender:     sender,
+		Provider:   testProvider,
+		Deposit:    big.NewInt(100),
+		Paid:       big.NewInt(0),
+		Expiration: 1000,
+	}, key
+}
+
+// mockReader serves the channels at the block number
+type mockReader struct {
+	channels map[types.Hash]*Channel
+	number   uint64
+	err      error
+}
+
+func newMockReader(channels ...*Channel) *mockReader {
+	r := &mockReader{channels: map[types.Hash]*Channel{}}
+	for _, ch := range channels {
+		r.channels[ch.ID] = ch
+	}
+
+	return r
+}
+
+func (r *mockReader) GetChannel(id types.Hash) (*Channel, uint64, error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+
+	ch, ok := r.channels[id]
+	if !ok {
+		return nil, r.number, nil
+	}
+
+	return ch.Copy(), r.number, nil
+}
+
+func TestVoucher_SignAndEncode(t *testing.T) {
+	t.Parallel()
+
+	ch, key := testChannel(t)
+	v := testVoucher(t, key, ch.ID, 42)
+
+	signer, err := v.Signer(testChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, ch.Sender, signer)
+
+	// the voucher of another chain recovers another signer
+	signer, err = v.Signer(testChainID + 1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, ch.Sender, signer)
+
+	decoded := &Voucher{}
+	assert.NoError(t, decoded.UnmarshalRLP(v.MarshalRLP()))
+	assert.Equal(t, v.MarshalRLP(), decoded.MarshalRLP())
+
+	raw, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	decoded = &Voucher{}
+	assert.NoError(t, json.Unmarshal(raw, decoded))
+	assert.Equal(t, v.MarshalRLP(), decoded.MarshalRLP())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"0xzz"}`), &Voucher{}))
+}
+
+func TestAction_RLP(t *testing.T) {
+	t.Parallel()
+
+	ch, key := testChannel(t)
+
+	actions := []*Action{
+		{Type: ActionOpen, Provider: testProvider, Expiration: 10},
+		{Type: ActionRedeem, ChannelID: ch.ID, Voucher: testVoucher(t, key, ch.ID, 5)},
+	}
+
+	for _, action := range actions {
+		assert.NoError(t, action.Validate())
+
+		decoded := &Action{}
+		assert.NoError(t, decoded.UnmarshalRLP(action.MarshalRLP()))
+		assert.Equal(t, action.MarshalRLP(), decoded.MarshalRLP())
+		assert.Equal(t, action.Voucher == nil, decoded.Voucher == nil)
+	}
+
+	assert.ErrorIs(t, (&Action{Type: ActionOpen}).Validate(), ErrInvalidAction)
+	assert.ErrorIs(t, (&Action{Type: ActionRedeem, ChannelID: ch.ID}).Validate(), ErrInvalidAction)
+	assert.ErrorIs(t, (&Action{Type: 9}).Validate(), ErrInvalidAction)
+}
+
+func TestReadChannel(t *testing.T) {
+	t.Parallel()
+
+	ch, _ := testChannel(t)
+	ch.Paid = big.NewInt(30)
+	ch.Closed = true
+
+	storage := map[types.Hash]types.Hash{}
+	for _, slot := range ch.Slots() {
+		storage[slot.Key] = slot.Value
+	}
+
+	get := func(key types.Hash) types.Hash {
+		return storage[key]
+	}
+
+	assert.Equal(t, ch.MarshalRLP(), ReadChannel(ch.ID, get).MarshalRLP())
+	assert.Nil(t, ReadChannel(types.StringToHash("0x1"), get))
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	ch, key := testChannel(t)
+	assert.NoError(t, store.PutChannel(ch))
+
+	stored, ok, err := store.GetChannel(ch.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ch.MarshalRLP(), stored.MarshalRLP())
+
+	assert.NoError(t, store.PutVoucher(testVoucher(t, key, ch.ID, 10)))
+	assert.ErrorIs(t, store.PutVoucher(testVoucher(t, key, ch.ID, 10)), ErrStaleVoucher)
+	assert.NoError(t, store.PutVoucher(testVoucher(t, key, ch.ID, 20)))
+
+	entries, err := store.Channels()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, big.NewInt(20), entries[0].Voucher.Amount)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	ch, key := testChannel(t)
+	other, _ := testChannel(t)
+	other.Provider = types.StringToAddress("0x3")
+
+	reader := newMockReader(ch, other)
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	verifier := NewVerifier(testProvider, testChainID, reader, store)
+
+	assert.NoError(t, verifier.Verify(testVoucher(t, key, ch.ID, 10)))
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, key, ch.ID, 10)), ErrStaleVoucher)
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, key, ch.ID, 101)), ErrInsufficientDeposit)
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, key, other.ID, 10)), ErrWrongProvider)
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, key, types.StringToHash("0x1"), 10)), ErrUnknownChannel)
+
+	otherKey, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, otherKey, ch.ID, 20)), ErrInvalidVoucherSig)
+
+	// the vouchers are refused within the expiry margin
+	reader.number = ch.Expiration - DefaultExpiryMargin
+	assert.ErrorIs(t, verifier.Verify(testVoucher(t, key, ch.ID, 20)), ErrChannelExpired)
+
+	// the verified channel is stored with its latest voucher
+	latest, ok, err := store.LatestVoucher(ch.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewInt(10), latest.Amount)
+
+	_, ok, err = store.GetChannel(ch.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+type mockSubmitter struct {
+	actions []*Action
+	err     error
+}
+
+func (m *mockSubmitter) SubmitAction(action *Action) (types.Hash, error) {
+	if m.err != nil {
+		return types.ZeroHash, m.err
+	}
+
+	m.actions = append(m.actions, action)
+
+	return types.BytesToHash([]byte{byte(len(m.actions))}), nil
+}
+
+func TestRedeemer(t *testing.T) {
+	t.Parallel()
+
+	ch, key := testChannel(t)
+	reader := newMockReader(ch)
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	submitter := &mockSubmitter{}
+	redeemer := NewRedeemer(hclog.NewNullLogger(), testProvider, store, reader, submitter, 0)
+
+	_, err := redeemer.Redeem(ch.ID)
+	assert.ErrorIs(t, err, ErrNothingToRedeem)
+
+	assert.NoError(t, NewVerifier(testProvider, testChainID, reader, store).Verify(testVoucher(t, key, ch.ID, 10)))
+
+	// the channel far from its expiration is not redeemed periodically
+	hashes, err := redeemer.RedeemExpiring()
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	hash, err := redeemer.Redeem(ch.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, types.ZeroHash, hash)
+	assert.Len(t, submitter.actions, 1)
+	assert.Equal(t, ActionRedeem, submitter.actions[0].Type)
+	assert.Equal(t, big.NewInt(10), submitter.actions[0].Voucher.Amount)
+
+	// the submitted voucher is not redeemed again when the channel expires
+	reader.number = ch.Expiration - 1
+
+	hashes, err = redeemer.RedeemExpiring()
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	reader.number = 0
+	assert.NoError(t, NewVerifier(testProvider, testChainID, reader, store).Verify(testVoucher(t, key, ch.ID, 20)))
+
+	reader.number = ch.Expiration - 1
+
+	hashes, err = redeemer.RedeemExpiring()
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 1)
+	assert.Len(t, submitter.actions, 2)
+
+	reader.err = errors.New("state unavailable")
+
+	_, err = redeemer.Redeem(ch.ID)
+	assert.ErrorIs(t, err, reader.err)
+}
@@ -0,0 +1,187 @@
+package channel
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultRedeemInterval is the interval of the checks for the expiring channels
+const DefaultRedeemInterval = time.Minute
+
+// Submitter submits the channel actions of the node on chain
+type Submitter interface {
+	// SubmitAction submits the telegram of the action and returns its hash
+	SubmitAction(action *Action) (types.Hash, error)
+}
+
+// Redeemer redeems the latest vouchers received by the provider, on request,
+// and periodically for the channels about to expire
+type Redeemer struct {
+	logger    hclog.Logger
+	provider  types.Address
+	store     *Store
+	reader    Reader
+	submitter Submitter
+	interval  time.Duration
+
+	// margin is the number of blocks before the expiration the channels are redeemed from
+	margin uint64
+
+	// submitted are the amounts of the vouchers submitted by the channel
+	submittedLock sync.Mutex
+	submitted     map[types.Hash]*big.Int
+
+	closeCh chan struct{}
+}
+
+// NewRedeemer creates the redeemer of the vouchers paying the provider kept in the store
+func NewRedeemer(
+	logger hclog.Logger,
+	provider types.Address,
+	store *Store,
+	reader Reader,
+	submitter Submitter,
+	interval time.Duration,
+) *Redeemer {
+	if interval == 0 {
+		interval = DefaultRedeemInterval
+	}
+
+	return &Redeemer{
+		logger:    logger.Named("channel_redeemer"),
+		provider:  provider,
+		store:     store,
+		reader:    reader,
+		submitter: submitter,
+		interval:  interval,
+		margin:    2 * DefaultExpiryMargin,
+		submitted: make(map[types.Hash]*big.Int),
+		closeCh:   make(chan struct{}),
+	}
+}
+
+// Start starts the periodic redemption of the expiring channels
+func (r *Redeemer) Start() {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := r.RedeemExpiring(); err != nil {
+					r.logger.Error("failed to redeem expiring channels", "err", err)
+				}
+			case <-r.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (r *Redeemer) Close() {
+	close(r.closeCh)
+}
+
+// Redeem submits the latest voucher of the channel, paying the provider the amount not paid yet
+func (r *Redeemer) Redeem(id types.Hash) (types.Hash, error) {
+	ch, _, err := r.reader.GetChannel(id)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if ch == nil {
+		return types.ZeroHash, ErrUnknownChannel
+	}
+
+	if err := r.store.PutChannel(ch); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return r.redeem(ch)
+}
+
+func (r *Redeemer) redeem(ch *Channel) (types.Hash, error) {
+	if ch.Provider != r.provider {
+		return types.ZeroHash, ErrWrongProvider
+	}
+
+	if ch.Closed {
+		return types.ZeroHash, ErrChannelClosed
+	}
+
+	voucher, ok, err := r.store.LatestVoucher(ch.ID)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if !ok || voucher.Amount.Cmp(ch.Paid) <= 0 {
+		return types.ZeroHash, ErrNothingToRedeem
+	}
+
+	hash, err := r.submitter.SubmitAction(&Action{Type: ActionRedeem, ChannelID: ch.ID, Voucher: voucher})
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	r.submittedLock.Lock()
+	r.submitted[ch.ID] = voucher.Amount
+	r.submittedLock.Unlock()
+
+	r.logger.Debug("voucher redeemed", "channel", ch.ID, "amount", voucher.Amount, "telegram", hash)
+
+	return hash, nil
+}
+
+// RedeemExpiring redeems the channels of the provider expiring within the redeem margin,
+// whose latest vouchers are not submitted yet, and returns the hashes of the redeem telegrams
+func (r *Redeemer) RedeemExpiring() ([]types.Hash, error) {
+	entries, err := r.store.Channels()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]types.Hash, 0)
+
+	for _, entry := range entries {
+		if entry.Voucher == nil || entry.Channel.Provider != r.provider || entry.Channel.Closed {
+			continue
+		}
+
+		r.submittedLock.Lock()
+		submitted, ok := r.submitted[entry.Channel.ID]
+		r.submittedLock.Unlock()
+
+		if ok && entry.Voucher.Amount.Cmp(submitted) <= 0 {
+			continue
+		}
+
+		ch, number, err := r.reader.GetChannel(entry.Channel.ID)
+		if err != nil {
+			return hashes, err
+		}
+
+		if ch == nil || number+r.margin < ch.Expiration {
+			continue
+		}
+
+		if err := r.store.PutChannel(ch); err != nil {
+			return hashes, err
+		}
+
+		hash, err := r.redeem(ch)
+		if err != nil {
+			r.logger.Warn("failed to redeem channel", "channel", ch.ID, "err", err)
+
+			continue
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
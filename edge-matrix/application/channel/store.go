@@ -0,0 +1,121 @@
+package channel
+
+import (
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// key prefixes of the store
+var (
+	// channel by ID
+	channelPrefix = []byte("c")
+	// latest voucher by channel ID
+	voucherPrefix = []byte("v")
+)
+
+// Entry is a channel known to the node with the latest voucher received by the node, nil if none
+type Entry struct {
+	Channel *Channel
+	Voucher *Voucher
+}
+
+// Store keeps the channels known to the node, as last seen on chain, and their latest vouchers
+type Store struct {
+	lock sync.Mutex
+	db   *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutChannel stores the channel
+func (s *Store) PutChannel(ch *Channel) error {
+	return s.db.Put(kvstore.HashKey(channelPrefix, ch.ID), ch.MarshalRLP())
+}
+
+// GetChannel returns the channel of the given ID
+func (s *Store) GetChannel(id types.Hash) (*Channel, bool, error) {
+	ch := &Channel{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(channelPrefix, id), ch)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return ch, true, nil
+}
+
+// PutVoucher stores the voucher as the latest of its channel,
+// unless it does not exceed the amount of the latest one
+func (s *Store) PutVoucher(v *Voucher) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	latest, ok, err := s.LatestVoucher(v.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	if ok && v.Amount.Cmp(latest.Amount) <= 0 {
+		return ErrStaleVoucher
+	}
+
+	return s.db.Put(kvstore.HashKey(voucherPrefix, v.ChannelID), v.MarshalRLP())
+}
+
+// LatestVoucher returns the latest voucher of the channel
+func (s *Store) LatestVoucher(id types.Hash) (*Voucher, bool, error) {
+	v := &Voucher{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(voucherPrefix, id), v)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return v, true, nil
+}
+
+// Channels returns the stored channels with their latest vouchers, in the order of their IDs
+func (s *Store) Channels() ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+
+	err := s.db.Iterate(util.BytesPrefix(channelPrefix), func(_, value []byte) error {
+		ch := &Channel{}
+		if err := ch.UnmarshalRLP(value); err != nil {
+			return err
+		}
+
+		v, _, err := s.LatestVoucher(ch.ID)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, &Entry{Channel: ch, Voucher: v})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,87 @@
+package channel
+
+import (
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// DefaultExpiryMargin is the number of blocks before the expiration of a channel its vouchers are refused from,
+// leaving the provider the time to redeem them before the sender can close the channel
+const DefaultExpiryMargin = 100
+
+// Reader reads the channels from the latest state of the chain
+type Reader interface {
+	// GetChannel returns the channel and the number of the block of the state read, nil if not opened
+	GetChannel(id types.Hash) (*Channel, uint64, error)
+}
+
+// Verifier checks the vouchers attached to the edge calls served by the provider
+type Verifier struct {
+	lock sync.Mutex
+
+	provider types.Address
+	chainID  uint64
+	reader   Reader
+	store    *Store
+
+	expiryMargin uint64
+}
+
+// NewVerifier creates the verifier of the vouchers paying the provider, keeping the latest ones in the store
+func NewVerifier(provider types.Address, chainID uint64, reader Reader, store *Store) *Verifier {
+	return &Verifier{
+		provider:     provider,
+		chainID:      chainID,
+		reader:       reader,
+		store:        store,
+		expiryMargin: DefaultExpiryMargin,
+	}
+}
+
+// Verify checks the voucher is signed by the sender of an open channel to the provider,
+// and pays more than the latest voucher within the channel deposit.
+// The verified voucher is stored as the latest of the channel
+func (v *Verifier) Verify(voucher *Voucher) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	ch, number, err := v.reader.GetChannel(voucher.ChannelID)
+	if err != nil {
+		return err
+	}
+
+	if ch == nil {
+		return ErrUnknownChannel
+	}
+
+	if ch.Provider != v.provider {
+		return ErrWrongProvider
+	}
+
+	if ch.Closed {
+		return ErrChannelClosed
+	}
+
+	if number+v.expiryMargin >= ch.Expiration {
+		return ErrChannelExpired
+	}
+
+	if signer, err := voucher.Signer(v.chainID); err != nil || signer != ch.Sender {
+		return ErrInvalidVoucherSig
+	}
+
+	if voucher.Amount.Cmp(ch.Deposit) > 0 {
+		return ErrInsufficientDeposit
+	}
+
+	if voucher.Amount.Cmp(ch.Paid) <= 0 {
+		return ErrStaleVoucher
+	}
+
+	if err := v.store.PutVoucher(voucher); err != nil {
+		return err
+	}
+
+	return v.store.PutChannel(ch)
+}
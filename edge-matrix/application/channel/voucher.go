@@ -0,0 +1,166 @@
+package channel
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// HeaderVoucher is the voucher attached to the edge call, base64 encoded RLP,
+// set by the node routing the call
+const HeaderVoucher = "Emc-Voucher"
+
+var errInvalidVoucherJSON = errors.New("voucher amount is not a hex number")
+
+var voucherArenaPool fastrlp.ArenaPool
+
+// Voucher promises the provider the cumulative amount of the channel deposit, signed by the channel sender.
+// Each voucher supersedes the previous ones, so the provider only redeems the latest
+type Voucher struct {
+	ChannelID types.Hash
+	Amount    *big.Int
+	Sig       []byte
+}
+
+// Hash returns the hash of the voucher signed by the sender,
+// bound to the chain and the payment channel precompile
+func (v *Voucher) Hash(chainID uint64) types.Hash {
+	a := voucherArenaPool.Get()
+
+	vv := a.NewArray()
+	vv.Set(a.NewUint(chainID))
+	vv.Set(a.NewBytes(contracts.EdgePaymentChannelPrecompile.Bytes()))
+	vv.Set(a.NewBytes(v.ChannelID.Bytes()))
+	vv.Set(a.NewBigInt(v.Amount))
+
+	hash := keccak.Keccak256Rlp(nil, vv)
+
+	voucherArenaPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// Sign signs the voucher by the key of the channel sender
+func (v *Voucher) Sign(key *ecdsa.PrivateKey, chainID uint64) error {
+	sig, err := crypto.Sign(key, v.Hash(chainID).Bytes())
+	if err != nil {
+		return err
+	}
+
+	v.Sig = sig
+
+	return nil
+}
+
+// Signer recovers the address signing the voucher
+func (v *Voucher) Signer(chainID uint64) (types.Address, error) {
+	pub, err := crypto.RecoverPubkey(v.Sig, v.Hash(chainID).Bytes())
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(pub), nil
+}
+
+func (v *Voucher) Copy() *Voucher {
+	return &Voucher{
+		ChannelID: v.ChannelID,
+		Amount:    new(big.Int).Set(v.Amount),
+		Sig:       append([]byte{}, v.Sig...),
+	}
+}
+
+type voucherJSON struct {
+	ChannelID types.Hash `json:"channelId"`
+	Amount    string     `json:"amount"`
+	Sig       string     `json:"signature"`
+}
+
+func (v *Voucher) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&voucherJSON{
+		ChannelID: v.ChannelID,
+		Amount:    hex.EncodeBig(v.Amount),
+		Sig:       hex.EncodeToHex(v.Sig),
+	})
+}
+
+func (v *Voucher) UnmarshalJSON(data []byte) error {
+	raw := &voucherJSON{}
+	if err := json.Unmarshal(data, raw); err != nil {
+		return err
+	}
+
+	amount, ok := new(big.Int).SetString(strings.TrimPrefix(raw.Amount, "0x"), 16)
+	if !ok || amount.Sign() < 0 {
+		return errInvalidVoucherJSON
+	}
+
+	sig, err := hex.DecodeHex(raw.Sig)
+	if err != nil {
+		return err
+	}
+
+	v.ChannelID = raw.ChannelID
+	v.Amount = amount
+	v.Sig = sig
+
+	return nil
+}
+
+func (v *Voucher) MarshalRLP() []byte {
+	return v.MarshalRLPTo(nil)
+}
+
+func (v *Voucher) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(v.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Voucher to RLP with a specific fastrlp.Arena
+func (v *Voucher) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(v.ChannelID.Bytes()))
+	vv.Set(arena.NewBigInt(v.Amount))
+	vv.Set(arena.NewCopyBytes(v.Sig))
+
+	return vv
+}
+
+func (v *Voucher) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(v.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Voucher in RLP format
+func (v *Voucher) unmarshalRLPFrom(_ *fastrlp.Parser, vv *fastrlp.Value) error {
+	elems, err := vv.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 3 {
+		return fmt.Errorf("incorrect number of elements to decode voucher, expected 3 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(v.ChannelID[:]); err != nil {
+		return err
+	}
+
+	v.Amount = new(big.Int)
+	if err = elems[1].GetBigInt(v.Amount); err != nil {
+		return err
+	}
+
+	v.Sig, err = elems[2].GetBytes(nil)
+
+	return err
+}
@@ -2,8 +2,14 @@ package application
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/application/metering"
 	"github.com/emc-protocol/edge-matrix/types"
 	p2phttp "github.com/libp2p/go-libp2p-http"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -11,18 +17,65 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type EdgeCall struct {
 	PeerId   string          `json:"peerId"`
 	Endpoint string          `json:"endpoint"`
 	Input    json.RawMessage `json:"input"`
+
+	// Voucher pays the provider for the call through the payment channel of the caller
+	Voucher *channel.Voucher `json:"voucher,omitempty"`
+
+	// Async runs the call as a job, the edge node responds with the job ID instead of the result
+	Async bool `json:"async,omitempty"`
+
+	// Redundancy is the number of the edge nodes running the model of the called one the call is dispatched to,
+	// the RLP of the verification result agreed by their majority, with the signed responses of all of them,
+	// is returned. The call is dispatched to the called edge node only if not set
+	Redundancy uint64 `json:"redundancy,omitempty"`
+
+	// Blobs are the blobs referenced by the input, fetched by the edge node from the node routing the call
+	Blobs []types.Hash `json:"blobs,omitempty"`
+
+	// App is the name of the app of the edge node the call is addressed to, the default app of the edge node if not set
+	App string `json:"app,omitempty"`
+}
+
+// error codes of the edge calls rejected before they are forwarded to the application
+const (
+	// CodePayment is the error code of the edge call rejected for its voucher
+	CodePayment = "payment"
+
+	// CodeBlob is the error code of the edge call whose blobs could not be fetched
+	CodeBlob = "blob"
+
+	// CodeApp is the error code of the edge call addressed to an app not hosted by the edge node
+	CodeApp = "app"
+)
+
+// CallError is the rejection of an edge call by the endpoint of the edge node
+type CallError struct {
+	Status int                     `json:"-"`
+	Errors appidl.ValidationErrors `json:"errors"`
+}
+
+func (e *CallError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("edge call failed with status %d", e.Status)
+	}
+
+	return fmt.Sprintf("invalid edge call: %s", e.Errors.Error())
 }
 
 func (e *EdgeCall) Copy() *EdgeCall {
 	tt := &EdgeCall{
-		PeerId:   e.PeerId,
-		Endpoint: e.Endpoint,
+		PeerId:     e.PeerId,
+		Endpoint:   e.Endpoint,
+		Async:      e.Async,
+		Redundancy: e.Redundancy,
+		App:        e.App,
 	}
 
 	if len(e.Input) > 0 {
@@ -30,6 +83,14 @@ func (e *EdgeCall) Copy() *EdgeCall {
 		copy(tt.Input[:], e.Input)
 	}
 
+	if e.Voucher != nil {
+		tt.Voucher = e.Voucher.Copy()
+	}
+
+	if len(e.Blobs) > 0 {
+		tt.Blobs = append([]types.Hash{}, e.Blobs...)
+	}
+
 	return tt
 }
 
@@ -67,11 +128,8 @@ func Call(clientHost host.Host, protoTag string, call *EdgeCall) ([]byte, error)
 		return nil, err
 	}
 	defer res.Body.Close()
-	all, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	return all, nil
+
+	return readCallResponse(res)
 
 }
 
@@ -107,10 +165,128 @@ func CallWithFrom(clientHost host.Host, protoTag string, call *EdgeCall, from ty
 		return nil, err
 	}
 	defer res.Body.Close()
+
+	return readCallResponse(res)
+
+}
+
+// CallWithUsage sends the edge call of the telegram on behalf of the caller,
+// and returns the response with the usage record signed by the provider,
+// the usage record is nil if the endpoint does not meter the calls
+func CallWithUsage(
+	clientHost host.Host,
+	protoTag string,
+	call *EdgeCall,
+	from types.Address,
+	requestHash types.Hash,
+) ([]byte, *metering.UsageRecord, error) {
+	tr := &http.Transport{}
+	tr.RegisterProtocol("libp2p", p2phttp.NewTransport(clientHost, p2phttp.ProtocolOption(protocol.ID(protoTag))))
+	client := &http.Client{Transport: tr}
+
+	if call.Input == nil {
+		return nil, nil, nil
+	}
+	raw, err := json.Marshal(call.Input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	URL, err := url.Parse(fmt.Sprintf("libp2p://%s%s", call.PeerId, call.Endpoint))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &http.Request{
+		URL:    URL,
+		Method: "POST",
+		Header: http.Header{
+			"Content-Type":             {"application/json"},
+			metering.HeaderFrom:        {from.String()},
+			metering.HeaderRequestHash: {requestHash.String()},
+			"Emc-Router":               {clientHost.ID().String()},
+		},
+		Body: io.NopCloser(bytes.NewBuffer(raw)),
+	}
+
+	if call.Voucher != nil {
+		req.Header.Set(channel.HeaderVoucher, base64.StdEncoding.EncodeToString(call.Voucher.MarshalRLP()))
+	}
+
+	if call.Async {
+		req.Header.Set(jobs.HeaderAsync, "true")
+	}
+
+	if call.App != "" {
+		req.Header.Set(HeaderApp, call.App)
+	}
+
+	for _, hash := range call.Blobs {
+		req.Header.Add(blob.HeaderBlobs, hash.String())
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	// the rejected calls are metered too
+	usage := readUsage(res)
+
+	resp, err := readCallResponse(res)
+
+	return resp, usage, err
+}
+
+// readUsage decodes the usage record attached to the edge call response,
+// nil if not attached or malformed, as the call itself is served regardless of its metering
+func readUsage(res *http.Response) *metering.UsageRecord {
+	raw, err := base64.StdEncoding.DecodeString(res.Header.Get(metering.HeaderUsage))
+	if err != nil || len(raw) == 0 {
+		return nil
+	}
+
+	usage := &metering.UsageRecord{}
+	if err := usage.UnmarshalRLP(raw); err != nil {
+		return nil
+	}
+
+	return usage
+}
+
+// Alive probes the /alive endpoint of the app peer, and returns an error if it does not respond in time
+func Alive(clientHost host.Host, protoTag string, peerId string, timeout time.Duration) error {
+	tr := &http.Transport{}
+	tr.RegisterProtocol("libp2p", p2phttp.NewTransport(clientHost, p2phttp.ProtocolOption(protocol.ID(protoTag))))
+	client := &http.Client{Transport: tr, Timeout: timeout}
+
+	res, err := client.Get(fmt.Sprintf("libp2p://%s/alive", peerId))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = readCallResponse(res)
+
+	return err
+}
+
+// readCallResponse returns the body of the edge call response, or the CallError if the call is rejected
+func readCallResponse(res *http.Response) ([]byte, error) {
 	all, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
-	return all, nil
 
+	if res.StatusCode != http.StatusOK {
+		callErr := &CallError{Status: res.StatusCode}
+
+		// only the validation failures are structured
+		_ = json.Unmarshal(all, callErr)
+
+		return nil, callErr
+	}
+
+	return all, nil
 }
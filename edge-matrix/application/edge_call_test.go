@@ -0,0 +1,104 @@
+package application
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCallResponse(t *testing.T) {
+	t.Parallel()
+
+	newResponse := func(status int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	t.Run("returns the body of the accepted call", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := readCallResponse(newResponse(http.StatusOK, "resp"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("resp"), body)
+	})
+
+	t.Run("returns the validation errors of the rejected call", func(t *testing.T) {
+		t.Parallel()
+
+		errs := appidl.ValidationErrors{{Field: "body.prompt", Code: appidl.CodeRequired, Message: "is required"}}
+
+		rec := httptest.NewRecorder()
+		writeCallError(rec, errs)
+
+		_, err := readCallResponse(rec.Result())
+
+		assert.Equal(t, &CallError{Status: http.StatusBadRequest, Errors: errs}, err)
+		assert.EqualError(t, err, "invalid edge call: body.prompt: is required")
+	})
+
+	t.Run("returns the status of the failed call", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := readCallResponse(newResponse(http.StatusInternalServerError, "failure"))
+
+		assert.EqualError(t, err, "edge call failed with status 500")
+	})
+}
+
+func TestMeterCall(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	store := metering.NewMemoryStore()
+	defer store.Close()
+
+	endpoint := &Endpoint{logger: hclog.NewNullLogger(), privateKey: key}
+	endpoint.SetUsageStore(store)
+
+	caller := types.StringToAddress("0xca11e5")
+	requestHash := types.StringToHash("0x1234")
+
+	req := httptest.NewRequest(http.MethodPost, "/api", nil)
+	req.Header.Set(metering.HeaderFrom, caller.String())
+	req.Header.Set(metering.HeaderRequestHash, requestHash.String())
+
+	rec := httptest.NewRecorder()
+	endpoint.meterCall(rec, req, "/v1/generate", time.Now(), 10, 20, http.StatusOK)
+
+	usage := readUsage(rec.Result())
+	require.NotNil(t, usage)
+	require.NoError(t, usage.Verify())
+
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), usage.Provider)
+	assert.Equal(t, caller, usage.Caller)
+	assert.Equal(t, requestHash, usage.RequestHash)
+	assert.Equal(t, "/v1/generate", usage.Path)
+	assert.Equal(t, uint64(10), usage.InputBytes)
+	assert.Equal(t, uint64(20), usage.OutputBytes)
+	assert.Equal(t, uint64(http.StatusOK), usage.Status)
+
+	// the provider keeps its own record of the call
+	entry, ok, err := store.Get(usage.Hash())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, entry.Record.IsCountersigned())
+
+	// the endpoints prior to the metering attach no record
+	assert.Nil(t, readUsage(httptest.NewRecorder().Result()))
+}
@@ -1,11 +1,18 @@
 package application
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	appAgent "github.com/emc-protocol/edge-matrix/application/proof/agent"
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/application/capability"
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/application/metering"
 	"github.com/emc-protocol/edge-matrix/application/proof/helper"
 	"github.com/emc-protocol/edge-matrix/crypto"
 	"github.com/emc-protocol/edge-matrix/helper/rpc"
@@ -22,7 +29,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 )
@@ -36,6 +42,11 @@ const (
 	txSlotSize = 32 * 1024 // 32kB
 )
 
+// blobFetchTimeout is the timeout of the fetch of the blobs referenced by an edge call
+const blobFetchTimeout = 5 * time.Minute
+
+var errBlobsNotSupported = errors.New("blobs not supported by the edge node")
+
 const (
 	DefaultAppStatusSyncDuration = 15 * time.Second
 )
@@ -43,15 +54,13 @@ const (
 type Endpoint struct {
 	logger hclog.Logger
 
-	// gauge for measuring app capacity
-	gauge slotGauge
 	sync.Mutex
 	nextNonce        uint64
 	nonceCacheEnable bool
 
-	name       string
-	appUrl     string
-	appOrigin  string
+	// apps hosted by the endpoint, the default app first
+	apps []*endpointApp
+
 	h          host.Host
 	tag        string
 	listener   net.Listener
@@ -61,6 +70,9 @@ type Endpoint struct {
 	address    types.Address
 	stream     *eventStream // Event subscriptions
 
+	// JSON of the proof binding the address signing the responses to the peer ID of the endpoint
+	providerProof []byte
+
 	application *Application
 	minerAgent  *miner.MinerHubAgent
 
@@ -72,6 +84,28 @@ type Endpoint struct {
 	latestBlockNum      uint64
 
 	isEdgeMode bool
+
+	// provider of the hardware capabilities reported by the application
+	capabilities capability.Provider
+
+	// store of the usage records of the served edge calls
+	usageStore *metering.Store
+
+	// verifier of the vouchers paying the served edge calls, nil if the calls are not paid
+	vouchers *channel.Verifier
+
+	// manager of the edge calls run as jobs, nil if the calls are run synchronously only
+	jobs *jobs.Manager
+
+	// store of the blobs referenced by the edge calls and returned by the application, nil if not supported
+	blobs *blob.Store
+}
+
+// AppHealth is the result of the last status check of the application
+type AppHealth struct {
+	// zero if the application is not checked yet
+	LastCheck time.Time
+	Err       error
 }
 
 // SubscribeEvents returns a application event subscription
@@ -92,6 +126,53 @@ func (e *Endpoint) Close() {
 	e.h.Close()
 }
 
+// SetCapabilityProvider sets the provider of the hardware capabilities reported by the application,
+// and refreshes them
+func (e *Endpoint) SetCapabilityProvider(p capability.Provider) {
+	e.capabilities = p
+	e.refreshCapabilities()
+}
+
+// refreshCapabilities updates the capabilities of the application and the info strings derived from them,
+// the previous capabilities are kept if the provider fails
+func (e *Endpoint) refreshCapabilities() {
+	caps, err := e.capabilities.Capabilities()
+	if err != nil {
+		e.logger.Warn("failed to get the capabilities", "err", err)
+
+		return
+	}
+
+	e.application.Capabilities = caps
+	e.application.CpuInfo, e.application.GpuInfo, e.application.MemInfo = caps.LegacyInfo()
+}
+
+// SetUsageStore sets the store of the usage records of the served edge calls
+func (e *Endpoint) SetUsageStore(store *metering.Store) {
+	e.usageStore = store
+}
+
+// SetVoucherVerifier sets the verifier of the vouchers attached to the edge calls,
+// the calls with invalid vouchers are rejected before they are forwarded to the application
+func (e *Endpoint) SetVoucherVerifier(v *channel.Verifier) {
+	e.vouchers = v
+}
+
+// SetJobManager enables the edge calls run as jobs, and fails the jobs interrupted by the restart of the node
+func (e *Endpoint) SetJobManager(m *jobs.Manager) error {
+	e.jobs = m
+
+	return m.FailInterrupted(e.h.ID().String())
+}
+
+// SetBlobStore enables the blobs referenced by the edge calls, fetched into the store before the calls are forwarded,
+// and serves the blobs of the store to the peers of the endpoint
+func (e *Endpoint) SetBlobStore(store *blob.Store) {
+	e.blobs = store
+
+	blob.Serve(e.logger, e.h, store)
+}
+
 // SetSigner sets the signer the endpint will use
 // to validate a edge call response's signature.
 func (e *Endpoint) SetSigner(s Signer) {
@@ -102,59 +183,67 @@ func (e *Endpoint) GetEndpointApplication() *Application {
 	return e.application
 }
 
-func (e *Endpoint) doAppNodeBind() error {
-	agent := appAgent.NewAppAgent(e.appUrl)
-	err := agent.BindAppNode(e.h.ID().String())
-	if err != nil {
-		return err
-	}
-	return nil
+// GetAppUrl returns the url of the default app served by the endpoint
+func (e *Endpoint) GetAppUrl() string {
+	return e.apps[0].appUrl
 }
 
-func (e *Endpoint) getAppOrigin() (error, string) {
-	agent := appAgent.NewAppAgent(e.appUrl)
-	err, appOrigin := agent.GetAppOrigin()
-	if err != nil {
-		return err, ""
-	}
-	return nil, appOrigin
+// Health returns the result of the last status check of the default app
+func (e *Endpoint) Health() AppHealth {
+	return e.apps[0].health()
 }
 
-func (e *Endpoint) getAppIdl() (error, string) {
-	agent := appAgent.NewAppAgent(e.appUrl)
-	err, appOrigin := agent.GetAppOrigin()
-	if err != nil {
-		return err, ""
+// HostedApps returns the statuses of the apps hosted by the endpoint, the default app first
+func (e *Endpoint) HostedApps() []*HostedApp {
+	apps := make([]*HostedApp, len(e.apps))
+	for i, app := range e.apps {
+		apps[i] = app.status()
 	}
-	return nil, appOrigin
+
+	return apps
 }
 
-func (e *Endpoint) validAppNode() (error, bool) {
-	agent := appAgent.NewAppAgent(e.appUrl)
-	err, nodeId := agent.GetAppNode()
-	if err != nil {
-		return err, false
+// app returns the hosted app of the given name, or the default app if the name is empty
+func (e *Endpoint) app(name string) (*endpointApp, error) {
+	if name == "" {
+		return e.apps[0], nil
 	}
-	if e.h.ID().String() == nodeId {
-		return nil, true
+
+	for _, app := range e.apps {
+		if app.name == name {
+			return app, nil
+		}
 	}
-	return nil, false
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownApp, name)
+}
+
+// refreshApplication updates the status of the node from the statuses of the hosted apps,
+// the fields describing a single app are the ones of the default app, for the nodes prior to the hosting of several apps
+func (e *Endpoint) refreshApplication() {
+	apps := e.HostedApps()
+
+	e.application.Apps = apps
+	e.application.AppOrigin = apps[0].AppOrigin
+	e.application.ModelHash = apps[0].ModelHash
+	e.application.GuageHeight = apps[0].GuageHeight
+	e.application.GuageMax = apps[0].GuageMax
 }
 
 func NewApplicationEndpoint(
 	logger hclog.Logger,
 	privateKey *ecdsa.PrivateKey,
 	srvHost host.Host,
-	name string,
-	appUrl string,
+	apps []*AppConfig,
 	blockchainStore blockchainStore,
 	minerAgent *miner.MinerHubAgent,
 	isEdgeMode bool) (*Endpoint, error) {
+	if err := ValidateAppConfigs(apps); err != nil {
+		return nil, err
+	}
+
 	endpoint := &Endpoint{
 		logger:              logger.Named("app_endpoint"),
-		name:                name,
-		appUrl:              appUrl,
-		appOrigin:           "",
 		h:                   srvHost,
 		tag:                 ProtoTagEcApp,
 		stream:              &eventStream{},
@@ -168,6 +257,9 @@ func NewApplicationEndpoint(
 	rand.Seed(time.Now().Unix())
 	endpoint.randomNum = rand.Intn(1000)
 	endpoint.httpClient = rpc.NewDefaultHttpClient()
+	for _, config := range apps {
+		endpoint.apps = append(endpoint.apps, newEndpointApp(endpoint.logger, config, endpoint.httpClient))
+	}
 	listener, err := gostream.Listen(srvHost, ProtoTagEcApp)
 	if err != nil {
 		return nil, err
@@ -181,25 +273,34 @@ func NewApplicationEndpoint(
 	}
 	endpoint.address = address
 	endpoint.privateKey = privateKey
+
+	proof, err := NewProviderProof(srvHost.ID().String(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.providerProof, err = json.Marshal(proof); err != nil {
+		return nil, err
+	}
+
 	// Push the initial event to the stream
 	endpoint.stream.push(&Event{})
 
 	// init application metric
 	mac, _ := helper.GetLocalMac()
 	endpoint.application = &Application{
-		Name:        name,
+		Name:        apps[0].Name,
 		PeerID:      srvHost.ID(),
 		StartupTime: uint64(time.Now().UnixMilli()),
 		Uptime:      0,
 		AppOrigin:   "",
 		GuageHeight: 0,
-		GuageMax:    200,
+		GuageMax:    defaultAppGuageMax,
 		Mac:         mac,
-		CpuInfo:     helper.GetCpuInfo(),
-		GpuInfo:     helper.GetGpuInfo(),
-		MemInfo:     helper.GetMemInfo(),
 		Version:     versioning.Version + " Build" + versioning.Build,
 	}
+	endpoint.refreshApplication()
+	endpoint.SetCapabilityProvider(capability.NewHostProvider(""))
 
 	// check app status
 	if isEdgeMode {
@@ -208,21 +309,14 @@ func NewApplicationEndpoint(
 			for {
 				<-ticker.C
 				event := &Event{}
-				// bind app node
-				err := endpoint.doAppNodeBind()
-				if err != nil {
-					endpoint.logger.Error("doAppNodeBind", "err", err.Error())
-				}
-
-				err, appOrigin := endpoint.getAppOrigin()
-				if err != nil {
-					endpoint.logger.Error("getAppOrigin", "err", err.Error())
+				// bind the app nodes and refresh the app statuses
+				for _, app := range endpoint.apps {
+					app.refreshStatus(srvHost.ID().String())
 				}
 
-				endpoint.application.AppOrigin = appOrigin
+				endpoint.refreshApplication()
 				endpoint.application.Uptime = uint64(time.Now().UnixMilli()) - endpoint.application.StartupTime
-				endpoint.application.MemInfo = helper.GetMemInfo()
-				endpoint.application.GpuInfo = helper.GetGpuInfo()
+				endpoint.refreshCapabilities()
 
 				event.AddNewApp(endpoint.application)
 				endpoint.stream.push(event)
@@ -234,7 +328,12 @@ func NewApplicationEndpoint(
 
 	go func() {
 		http.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
-			err, validApp := endpoint.validAppNode()
+			app, err := endpoint.app(r.Header.Get(HeaderApp))
+			if err != nil {
+				writeAppError(w, err)
+				return
+			}
+			err, validApp := app.validAppNode(srvHost.ID().String())
 			if err != nil {
 				endpoint.logger.Error("validAppNode", "err", err.Error())
 			}
@@ -243,6 +342,7 @@ func NewApplicationEndpoint(
 			}
 			defer r.Body.Close()
 
+			start := time.Now()
 			body, err := io.ReadAll(r.Body)
 			if err != nil {
 				http.Error(w, err.Error(), 400)
@@ -261,12 +361,38 @@ func NewApplicationEndpoint(
 			}
 			if err := json.Unmarshal(body, &obj); err != nil {
 				http.Error(w, err.Error(), 400)
+				return
+			}
+			if err := app.appSchema().Validate(obj.Method, obj.Path, obj.Body); err != nil {
+				endpoint.logger.Debug("/api =>invalid call", "err", err.Error())
+				endpoint.meterCall(w, r, obj.Path, start, len(body), 0, http.StatusBadRequest)
+				writeCallError(w, err)
+				return
+			}
+			if err := endpoint.verifyVoucher(r); err != nil {
+				endpoint.logger.Debug("/api =>invalid voucher", "err", err.Error())
+				endpoint.meterCall(w, r, obj.Path, start, len(body), 0, http.StatusPaymentRequired)
+				writePaymentError(w, err)
+				return
+			}
+			if err := endpoint.fetchBlobs(r); err != nil {
+				endpoint.logger.Debug("/api =>blob fetch failed", "err", err.Error())
+				endpoint.meterCall(w, r, obj.Path, start, len(body), 0, http.StatusBadGateway)
+				writeBlobError(w, err)
+				return
+			}
+			if r.Header.Get(jobs.HeaderAsync) != "" && endpoint.jobs != nil {
+				endpoint.runJob(w, r, app, obj.Method, obj.Path, obj.Body, start, len(body))
+				return
 			}
 			if obj.Method == "GET" {
-				resp, err := endpoint.httpClient.SendGetRequest(endpoint.appUrl + obj.Path)
+				resp, err := app.sendRequest(obj.Method, obj.Path, nil)
+				status := http.StatusOK
 				if err != nil {
 					resp = []byte("endpoint err: " + err.Error())
+					status = http.StatusBadGateway
 				}
+				endpoint.meterCall(w, r, obj.Path, start, len(body), len(resp), status)
 				encodeString := base64.StdEncoding.EncodeToString(resp)
 				edgeResp := &EdgeResponse{
 					RespString: encodeString,
@@ -286,11 +412,13 @@ func NewApplicationEndpoint(
 
 				w.Write(signedResp.MarshalRLP())
 			} else if obj.Method == "POST" {
-				resp, err := endpoint.httpClient.SendPostJsonRequest(
-					endpoint.appUrl+obj.Path, obj.Body)
+				resp, err := app.sendRequest(obj.Method, obj.Path, obj.Body)
+				status := http.StatusOK
 				if err != nil {
 					resp = []byte("endpoint err: " + err.Error())
+					status = http.StatusBadGateway
 				}
+				endpoint.meterCall(w, r, obj.Path, start, len(body), len(resp), status)
 				encodeString := base64.StdEncoding.EncodeToString(resp)
 				edgeResp := &EdgeResponse{
 					RespString: encodeString,
@@ -361,6 +489,10 @@ func NewApplicationEndpoint(
 				AveragePower float32 `json:"average_power"`
 				// gpu info
 				GpuInfo string `json:"gpu_info"`
+				// hardware capabilities
+				Capabilities *capability.Capabilities `json:"capabilities,omitempty"`
+				// hosted apps, the default app first
+				Apps []*HostedApp `json:"apps"`
 			}
 			infoObj.PeerID = endpoint.application.PeerID.String()
 			infoObj.Version = endpoint.application.Version
@@ -374,6 +506,8 @@ func NewApplicationEndpoint(
 			infoObj.Mac = endpoint.application.Mac
 			infoObj.ModelHash = endpoint.application.ModelHash
 			infoObj.AveragePower = endpoint.application.AveragePower
+			infoObj.Capabilities = endpoint.application.Capabilities
+			infoObj.Apps = endpoint.HostedApps()
 
 			info := make([]byte, 0)
 			info, err := json.Marshal(infoObj)
@@ -390,24 +524,36 @@ func NewApplicationEndpoint(
 			w.Write([]byte(resp))
 		})
 
+		http.HandleFunc("/provider", func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			w.Write(endpoint.providerProof)
+		})
+
 		http.HandleFunc("/idl", func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
-			err, appIdl := endpoint.getAppIdl()
+			app, err := endpoint.app(r.Header.Get(HeaderApp))
 			if err != nil {
-				// TODO Fetch idl json text through GET #{appUrl}/getAppIdl
-				endpoint.logger.Debug(fmt.Sprintf("/getAppIdl =>resp: %s", err.Error()))
-				idlData, err := os.ReadFile("idl.json")
-				if nil != err {
-					idlData = []byte("[]")
-				}
-				writeResponse(w, idlData, endpoint)
-			} else {
-				if len(appIdl) > 0 {
-					writeResponse(w, []byte(appIdl), endpoint)
-				} else {
-					writeResponse(w, []byte("[]"), endpoint)
-				}
+				writeAppError(w, err)
+				return
+			}
+			writeResponse(w, app.readAppIdl(), endpoint)
+		})
+
+		http.HandleFunc("/job", func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			var obj struct {
+				ID types.Hash `json:"id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+				http.Error(w, err.Error(), 400)
+				return
+			}
+			job, err := endpoint.getJob(obj.ID, types.StringToAddress(r.Header.Get(metering.HeaderFrom)))
+			if err != nil {
+				writeCallErr(w, &CallError{Status: http.StatusNotFound})
+				return
 			}
+			writeResponse(w, job.MarshalRLP(), endpoint)
 		})
 
 		server := &http.Server{}
@@ -418,22 +564,227 @@ func NewApplicationEndpoint(
 }
 
 func writeResponse(w http.ResponseWriter, info []byte, endpoint *Endpoint) {
-	resp := base64.StdEncoding.EncodeToString(info)
+	signedResp, err := endpoint.signResponse(info)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write(signedResp)
+}
+
+// signResponse returns the RLP of the edge response of the data signed by the endpoint
+func (e *Endpoint) signResponse(data []byte) ([]byte, error) {
 	edgeResp := &EdgeResponse{
-		RespString: resp,
+		RespString: base64.StdEncoding.EncodeToString(data),
 	}
-	endpoint.logger.Debug(fmt.Sprintf("/api =>resp size: %d", len(edgeResp.RespString)))
+	e.logger.Debug(fmt.Sprintf("/api =>resp size: %d", len(edgeResp.RespString)))
 
-	signedResp, err := endpoint.signer.SignEdgeResp(edgeResp, endpoint.privateKey)
+	signedResp, err := e.signer.SignEdgeResp(edgeResp, e.privateKey)
 	if err != nil {
-		w.Write([]byte(err.Error()))
+		return nil, err
 	}
-	provider, err := endpoint.signer.Provider(signedResp)
+	provider, err := e.signer.Provider(signedResp)
 	if err != nil {
-		return
+		return nil, err
 	}
 	signedResp.From = provider
-	signedResp.Hash = endpoint.signer.Hash(edgeResp)
+	signedResp.Hash = e.signer.Hash(edgeResp)
+
+	return signedResp.MarshalRLP(), nil
+}
+
+// runJob accepts the /api call as a job run in the background,
+// and responds with the ID of the job, which is the hash of the edge call telegram
+func (e *Endpoint) runJob(
+	w http.ResponseWriter,
+	r *http.Request,
+	app *endpointApp,
+	method string,
+	path string,
+	body []byte,
+	start time.Time,
+	inputBytes int,
+) {
+	id := types.StringToHash(r.Header.Get(metering.HeaderRequestHash))
+	if id == types.ZeroHash {
+		http.Error(w, "edge call job requires the request hash", 400)
+		return
+	}
+
+	job := &jobs.Job{
+		ID:     id,
+		PeerID: e.h.ID().String(),
+		Caller: types.StringToAddress(r.Header.Get(metering.HeaderFrom)),
+		Path:   path,
+	}
+
+	err := e.jobs.Run(job, func() ([]byte, error) {
+		resp, err := app.sendRequest(method, path, body)
+		if err != nil {
+			return nil, err
+		}
+
+		return e.signResponse(resp)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	accepted, err := json.Marshal(&jobs.Accepted{JobID: id})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the job is metered once accepted, as the caller node countersigns the usage with the response
+	e.meterCall(w, r, path, start, inputBytes, len(accepted), http.StatusAccepted)
+	writeResponse(w, accepted, e)
+}
+
+// getJob returns the job run by the endpoint for the caller
+func (e *Endpoint) getJob(id types.Hash, caller types.Address) (*jobs.Job, error) {
+	if e.jobs == nil {
+		return nil, jobs.ErrUnknownJob
+	}
+
+	job, ok, err := e.jobs.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || job.PeerID != e.h.ID().String() || job.Caller != caller {
+		return nil, jobs.ErrUnknownJob
+	}
+
+	return job, nil
+}
+
+// meterCall signs the usage record of the /api call, stores it and attaches it to the response headers,
+// so that the node routing the call countersigns it
+func (e *Endpoint) meterCall(w http.ResponseWriter, r *http.Request, path string, start time.Time, inputBytes int, outputBytes int, status int) {
+	record := &metering.UsageRecord{
+		RequestHash: types.StringToHash(r.Header.Get(metering.HeaderRequestHash)),
+		Caller:      types.StringToAddress(r.Header.Get(metering.HeaderFrom)),
+		Path:        path,
+		StartTime:   uint64(start.UnixMilli()),
+		Duration:    uint64(time.Since(start).Milliseconds()),
+		InputBytes:  uint64(inputBytes),
+		OutputBytes: uint64(outputBytes),
+		Status:      uint64(status),
+	}
+
+	if err := record.SignProvider(e.privateKey); err != nil {
+		e.logger.Error("failed to sign usage record", "err", err)
+
+		return
+	}
+
+	if e.usageStore != nil {
+		if err := e.usageStore.Put(record); err != nil {
+			e.logger.Error("failed to store usage record", "err", err)
+		}
+	}
+
+	w.Header().Set(metering.HeaderUsage, base64.StdEncoding.EncodeToString(record.MarshalRLP()))
+}
+
+// verifyVoucher verifies the voucher paying the /api call, which is required if the endpoint verifies the vouchers.
+// The vouchers are ignored otherwise
+func (e *Endpoint) verifyVoucher(r *http.Request) error {
+	if e.vouchers == nil {
+		return nil
+	}
+
+	header := r.Header.Get(channel.HeaderVoucher)
+	if header == "" {
+		return channel.ErrMissingVoucher
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return err
+	}
+
+	voucher := &channel.Voucher{}
+	if err := voucher.UnmarshalRLP(raw); err != nil {
+		return err
+	}
+
+	return e.vouchers.Verify(voucher)
+}
+
+// fetchBlobs fetches the blobs referenced by the /api call from the node routing it, through the connection of the call.
+// The blobs are fetched before the call is forwarded, so that the application reads them from the node
+func (e *Endpoint) fetchBlobs(r *http.Request) error {
+	hashes := r.Header.Values(blob.HeaderBlobs)
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	if e.blobs == nil {
+		return errBlobsNotSupported
+	}
+
+	if len(hashes) > blob.MaxCallBlobs {
+		return blob.ErrTooManyBlobs
+	}
+
+	router, err := peer.Decode(r.Header.Get("Emc-Router"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), blobFetchTimeout)
+	defer cancel()
+
+	for _, hash := range hashes {
+		if err := blob.Fetch(ctx, e.h, router, types.StringToHash(hash), e.blobs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCallError rejects the edge call violating the IDL of the application with the validation errors
+func writeCallError(w http.ResponseWriter, err error) {
+	callErr := &CallError{Status: http.StatusBadRequest}
+	if !errors.As(err, &callErr.Errors) {
+		callErr.Errors = appidl.ValidationErrors{{Field: "body", Code: appidl.CodeType, Message: err.Error()}}
+	}
+
+	writeCallErr(w, callErr)
+}
+
+// writePaymentError rejects the edge call with the missing or invalid voucher
+func writePaymentError(w http.ResponseWriter, err error) {
+	writeCallErr(w, &CallError{
+		Status: http.StatusPaymentRequired,
+		Errors: appidl.ValidationErrors{{Field: "voucher", Code: CodePayment, Message: err.Error()}},
+	})
+}
+
+// writeAppError rejects the edge call addressed to an app not hosted by the edge node
+func writeAppError(w http.ResponseWriter, err error) {
+	writeCallErr(w, &CallError{
+		Status: http.StatusNotFound,
+		Errors: appidl.ValidationErrors{{Field: "app", Code: CodeApp, Message: err.Error()}},
+	})
+}
+
+// writeBlobError rejects the edge call whose blobs could not be fetched
+func writeBlobError(w http.ResponseWriter, err error) {
+	writeCallErr(w, &CallError{
+		Status: http.StatusBadGateway,
+		Errors: appidl.ValidationErrors{{Field: "blobs", Code: CodeBlob, Message: err.Error()}},
+	})
+}
+
+func writeCallErr(w http.ResponseWriter, callErr *CallError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(callErr.Status)
 
-	w.Write(signedResp.MarshalRLP())
+	_ = json.NewEncoder(w).Encode(callErr)
 }
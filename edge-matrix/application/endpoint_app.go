@@ -0,0 +1,190 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	appAgent "github.com/emc-protocol/edge-matrix/application/proof/agent"
+	"github.com/emc-protocol/edge-matrix/helper/rpc"
+	"github.com/hashicorp/go-hclog"
+)
+
+// endpointApp is an app hosted by the endpoint, with its own url, status, IDL and gauge
+type endpointApp struct {
+	logger hclog.Logger
+
+	name       string
+	appUrl     string
+	agent      *appAgent.AppAgent
+	httpClient *rpc.FastHttpClient
+
+	// gauge for measuring app capacity
+	gauge slotGauge
+
+	statusLock sync.RWMutex
+	appOrigin  string
+	modelHash  string
+
+	// result of the last app status check
+	healthLock      sync.RWMutex
+	lastHealthCheck time.Time
+	healthErr       error
+
+	// parsed IDL of the application, validating the edge calls
+	schemaLock sync.RWMutex
+	schema     *appidl.Schema
+}
+
+func newEndpointApp(logger hclog.Logger, config *AppConfig, httpClient *rpc.FastHttpClient) *endpointApp {
+	return &endpointApp{
+		logger:     logger.With("app", config.Name),
+		name:       config.Name,
+		appUrl:     config.Url,
+		agent:      appAgent.NewAppAgent(config.Url),
+		httpClient: httpClient,
+		gauge:      slotGauge{max: defaultAppGuageMax},
+	}
+}
+
+// status returns the status of the app advertised with the status of the node
+func (a *endpointApp) status() *HostedApp {
+	a.statusLock.RLock()
+	defer a.statusLock.RUnlock()
+
+	return &HostedApp{
+		Name:        a.name,
+		AppOrigin:   a.appOrigin,
+		ModelHash:   a.modelHash,
+		GuageHeight: a.gauge.read(),
+		GuageMax:    a.gauge.max,
+	}
+}
+
+// refreshStatus binds the app to the node and reads its origin, model hash and IDL,
+// the health of the app is the result of the binding and of the origin
+func (a *endpointApp) refreshStatus(nodeID string) {
+	bindErr := a.agent.BindAppNode(nodeID)
+	if bindErr != nil {
+		a.logger.Error("doAppNodeBind", "err", bindErr.Error())
+	}
+
+	err, appOrigin := a.agent.GetAppOrigin()
+	if err != nil {
+		a.logger.Error("getAppOrigin", "err", err.Error())
+	}
+
+	if bindErr != nil {
+		a.setHealth(bindErr)
+	} else {
+		a.setHealth(err)
+	}
+
+	// the model hash is optional, as the apps prior to the hosting of several apps do not report it
+	modelErr, modelHash := a.agent.GetAppModelHash()
+	if modelErr != nil {
+		a.logger.Debug("getAppModelHash", "err", modelErr.Error())
+	}
+
+	a.statusLock.Lock()
+	a.appOrigin = appOrigin
+	if modelErr == nil {
+		a.modelHash = modelHash
+	}
+	a.statusLock.Unlock()
+
+	a.refreshSchema()
+}
+
+// health returns the result of the last status check of the app
+func (a *endpointApp) health() AppHealth {
+	a.healthLock.RLock()
+	defer a.healthLock.RUnlock()
+
+	return AppHealth{
+		LastCheck: a.lastHealthCheck,
+		Err:       a.healthErr,
+	}
+}
+
+func (a *endpointApp) setHealth(err error) {
+	a.healthLock.Lock()
+	defer a.healthLock.Unlock()
+
+	a.lastHealthCheck = time.Now()
+	a.healthErr = err
+}
+
+// readAppIdl returns the IDL published by the application, falling back to the idl.json file
+func (a *endpointApp) readAppIdl() []byte {
+	err, appIdl := a.agent.GetAppIdl()
+	if err != nil {
+		a.logger.Debug(fmt.Sprintf("/getAppIdl =>resp: %s", err.Error()))
+		idlData, err := os.ReadFile("idl.json")
+		if nil != err {
+			return []byte("[]")
+		}
+		return idlData
+	}
+	if len(appIdl) == 0 {
+		return []byte("[]")
+	}
+	return []byte(appIdl)
+}
+
+// refreshSchema parses the IDL of the application,
+// the edge calls are not validated if the IDL is invalid
+func (a *endpointApp) refreshSchema() *appidl.Schema {
+	schema, err := appidl.Parse(a.readAppIdl())
+	if err != nil {
+		a.logger.Warn("unable to parse the app IDL, edge calls are not validated", "err", err)
+
+		schema = &appidl.Schema{}
+	}
+
+	a.schemaLock.Lock()
+	a.schema = schema
+	a.schemaLock.Unlock()
+
+	return schema
+}
+
+// appSchema returns the parsed IDL of the application, which is loaded on first use
+func (a *endpointApp) appSchema() *appidl.Schema {
+	a.schemaLock.RLock()
+	schema := a.schema
+	a.schemaLock.RUnlock()
+
+	if schema == nil {
+		schema = a.refreshSchema()
+	}
+
+	return schema
+}
+
+// validAppNode returns true if the app is bound to the node of the given ID
+func (a *endpointApp) validAppNode(nodeID string) (error, bool) {
+	err, appNodeID := a.agent.GetAppNode()
+	if err != nil {
+		return err, false
+	}
+
+	return nil, appNodeID == nodeID
+}
+
+// sendRequest forwards the /api call to the application, the call occupying a slot of the app until it responds
+func (a *endpointApp) sendRequest(method string, path string, body []byte) ([]byte, error) {
+	a.gauge.increase(1)
+	defer a.gauge.decrease(1)
+
+	switch method {
+	case "GET":
+		return a.httpClient.SendGetRequest(a.appUrl + path)
+	case "POST":
+		return a.httpClient.SendPostJsonRequest(a.appUrl+path, body)
+	default:
+		return nil, fmt.Errorf("unsupported method %s", method)
+	}
+}
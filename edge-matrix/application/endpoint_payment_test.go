@@ -0,0 +1,100 @@
+package application
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChannelReader struct {
+	ch *channel.Channel
+}
+
+func (r *mockChannelReader) GetChannel(id types.Hash) (*channel.Channel, uint64, error) {
+	if r.ch == nil || r.ch.ID != id {
+		return nil, 0, nil
+	}
+
+	return r.ch.Copy(), 1, nil
+}
+
+func TestEndpoint_VerifyVoucher(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 2
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&key.PublicKey)
+	provider := types.StringToAddress("0x2")
+
+	ch := &channel.Channel{
+		ID:         channel.ID(sender, provider, 0),
+		Sender:     sender,
+		Provider:   provider,
+		Deposit:    big.NewInt(100),
+		Paid:       big.NewInt(10),
+		Expiration: 1000,
+	}
+
+	endpoint := &Endpoint{logger: hclog.NewNullLogger()}
+	endpoint.SetVoucherVerifier(channel.NewVerifier(provider, chainID, &mockChannelReader{ch: ch}, channel.NewMemoryStore()))
+
+	voucher := func(amount int64) string {
+		v := &channel.Voucher{ChannelID: ch.ID, Amount: big.NewInt(amount)}
+		require.NoError(t, v.Sign(key, chainID))
+
+		return base64.StdEncoding.EncodeToString(v.MarshalRLP())
+	}
+
+	cases := []struct {
+		name   string
+		header string
+		valid  bool
+	}{
+		{"no voucher", "", false},
+		{"invalid voucher", base64.StdEncoding.EncodeToString([]byte{0x1}), false},
+		{"stale voucher", voucher(5), false},
+		{"valid voucher", voucher(20), true},
+		{"replayed voucher", voucher(20), false},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api", nil)
+		if c.header != "" {
+			req.Header.Set(channel.HeaderVoucher, c.header)
+		}
+
+		err := endpoint.verifyVoucher(req)
+		if c.valid {
+			assert.NoError(t, err, c.name)
+
+			continue
+		}
+
+		require.Error(t, err, c.name)
+
+		rec := httptest.NewRecorder()
+		writePaymentError(rec, err)
+		assert.Equal(t, http.StatusPaymentRequired, rec.Code, c.name)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api", nil)
+	assert.ErrorIs(t, endpoint.verifyVoucher(req), channel.ErrMissingVoucher)
+
+	req.Header.Set(channel.HeaderVoucher, voucher(15))
+	assert.ErrorIs(t, endpoint.verifyVoucher(req), channel.ErrStaleVoucher)
+
+	// the calls are served for free by the endpoint which does not verify the vouchers
+	assert.NoError(t, (&Endpoint{}).verifyVoucher(httptest.NewRequest(http.MethodPost, "/api", nil)))
+}
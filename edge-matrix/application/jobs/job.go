@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// HeaderAsync marks the edge call run as a job, set by the node routing the call
+const HeaderAsync = "Emc-Async"
+
+var (
+	ErrUnknownJob   = errors.New("edge call job not found")
+	ErrJobFinished  = errors.New("edge call job is finished")
+	ErrJobExists    = errors.New("edge call job already exists")
+	ErrJobMismatch  = errors.New("edge call job does not match the request")
+	ErrInvalidState = errors.New("invalid edge call job status")
+)
+
+// Status is the state of the edge call job
+type Status uint64
+
+const (
+	// StatusPending is the job accepted by the edge node and not started yet
+	StatusPending Status = iota
+
+	// StatusRunning is the job forwarded to the application
+	StatusRunning
+
+	// StatusCompleted is the job answered by the application, with the signed result
+	StatusCompleted
+
+	// StatusFailed is the job the application failed to answer, or interrupted by the edge node
+	StatusFailed
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusRunning:
+		return "running"
+	case StatusCompleted:
+		return "completed"
+	case StatusFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("Status(%d)", uint64(s))
+	}
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	for status := StatusPending; status <= StatusFailed; status++ {
+		if status.String() == str {
+			*s = status
+
+			return nil
+		}
+	}
+
+	return ErrInvalidState
+}
+
+// Accepted is the response of the edge node accepting the edge call as a job
+type Accepted struct {
+	JobID types.Hash `json:"jobId"`
+}
+
+// Job is an edge call run asynchronously by the edge node
+type Job struct {
+	// ID is the hash of the edge call telegram
+	ID types.Hash
+
+	// PeerID is the edge node running the job
+	PeerID string
+	Caller types.Address
+	Path   string
+	Status Status
+
+	// CreatedAt and UpdatedAt are the unix times in milliseconds
+	CreatedAt uint64
+	UpdatedAt uint64
+
+	// Result is the signed edge response RLP of the completed job
+	Result []byte
+
+	// Error is the reason of the failed job
+	Error string
+}
+
+// IsFinished returns true if the job is completed or failed
+func (j *Job) IsFinished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed
+}
+
+func (j *Job) Copy() *Job {
+	jj := *j
+	jj.Result = append([]byte(nil), j.Result...)
+
+	return &jj
+}
+
+func (j *Job) MarshalRLP() []byte {
+	return j.MarshalRLPTo(nil)
+}
+
+func (j *Job) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(j.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Job to RLP with a specific fastrlp.Arena
+func (j *Job) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(j.ID.Bytes()))
+	vv.Set(arena.NewString(j.PeerID))
+	vv.Set(arena.NewBytes(j.Caller.Bytes()))
+	vv.Set(arena.NewString(j.Path))
+	vv.Set(arena.NewUint(uint64(j.Status)))
+	vv.Set(arena.NewUint(j.CreatedAt))
+	vv.Set(arena.NewUint(j.UpdatedAt))
+	vv.Set(arena.NewCopyBytes(j.Result))
+	vv.Set(arena.NewString(j.Error))
+
+	return vv
+}
+
+func (j *Job) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(j.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Job in RLP format
+func (j *Job) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 9 {
+		return fmt.Errorf("incorrect number of elements to decode edge call job, expected 9 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(j.ID[:]); err != nil {
+		return err
+	}
+
+	if j.PeerID, err = elems[1].GetString(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetAddr(j.Caller[:]); err != nil {
+		return err
+	}
+
+	if j.Path, err = elems[3].GetString(); err != nil {
+		return err
+	}
+
+	status, err := elems[4].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	if j.Status = Status(status); j.Status > StatusFailed {
+		return ErrInvalidState
+	}
+
+	if j.CreatedAt, err = elems[5].GetUint64(); err != nil {
+		return err
+	}
+
+	if j.UpdatedAt, err = elems[6].GetUint64(); err != nil {
+		return err
+	}
+
+	if j.Result, err = elems[7].GetBytes(nil); err != nil {
+		return err
+	}
+
+	j.Error, err = elems[8].GetString()
+
+	return err
+}
@@ -0,0 +1,237 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFetcher struct {
+	fetchFn func(job *Job) (*Job, error)
+}
+
+func (m *mockFetcher) FetchJob(job *Job) (*Job, error) {
+	return m.fetchFn(job)
+}
+
+func testJob(id string) *Job {
+	return &Job{
+		ID:     types.StringToHash(id),
+		PeerID: "peer",
+		Caller: types.StringToAddress("0xca11e5"),
+		Path:   "/v1/video",
+	}
+}
+
+func TestJob_RLP(t *testing.T) {
+	t.Parallel()
+
+	job := testJob("0x1")
+	job.Status = StatusCompleted
+	job.CreatedAt = 1000
+	job.UpdatedAt = 2000
+	job.Result = []byte{0x1, 0x2}
+
+	decoded := &Job{}
+	assert.NoError(t, decoded.UnmarshalRLP(job.MarshalRLP()))
+	assert.Equal(t, job, decoded)
+}
+
+func TestStore_PutAndPrune(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	job := testJob("0x1")
+	job.UpdatedAt = 1000
+	assert.NoError(t, store.Put(job))
+
+	// the tracked job does not override the stored one
+	tracked := job.Copy()
+	tracked.Path = "/other"
+	assert.NoError(t, store.PutIfAbsent(tracked))
+
+	stored, ok, err := store.Get(job.ID)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "/v1/video", stored.Path)
+
+	unfinished, err := store.Unfinished()
+	assert.NoError(t, err)
+	assert.Len(t, unfinished, 1)
+
+	finished := job.Copy()
+	finished.Status = StatusCompleted
+	assert.NoError(t, store.Put(finished))
+
+	unfinished, err = store.Unfinished()
+	assert.NoError(t, err)
+	assert.Len(t, unfinished, 0)
+
+	// the finished jobs are not updated anymore
+	assert.ErrorIs(t, store.Put(job), ErrJobFinished)
+
+	pruned, err := store.Prune(1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, pruned)
+
+	pruned, err = store.Prune(1001)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	_, ok, err = store.Get(job.ID)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(hclog.NewNullLogger(), NewMemoryStore(), 0)
+	sub := m.Subscribe()
+
+	defer sub.Close()
+
+	done := make(chan struct{})
+	assert.NoError(t, m.Run(testJob("0x1"), func() ([]byte, error) {
+		<-done
+
+		return []byte("result"), nil
+	}))
+	assert.NoError(t, m.Run(testJob("0x2"), func() ([]byte, error) {
+		return nil, errors.New("app failed")
+	}))
+
+	close(done)
+
+	// both jobs go through pending and running before finishing
+	for finished := 0; finished < 2; {
+		if job := sub.GetEvent(); job.IsFinished() {
+			finished++
+		}
+	}
+
+	job, ok, err := m.Get(types.StringToHash("0x1"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StatusCompleted, job.Status)
+	assert.Equal(t, []byte("result"), job.Result)
+
+	job, _, err = m.Get(types.StringToHash("0x2"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Equal(t, "app failed", job.Error)
+}
+
+func TestManager_RunExistingJob(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(hclog.NewNullLogger(), NewMemoryStore(), 0)
+	sub := m.Subscribe()
+
+	defer sub.Close()
+
+	runs := make(chan struct{}, 2)
+	done := make(chan struct{})
+	run := func() ([]byte, error) {
+		runs <- struct{}{}
+		<-done
+
+		return []byte("result"), nil
+	}
+
+	assert.NoError(t, m.Run(testJob("0x1"), run))
+
+	// the job of the same ID is not run again while pending or running
+	assert.ErrorIs(t, m.Run(testJob("0x1"), run), ErrJobExists)
+
+	close(done)
+
+	for !sub.GetEvent().IsFinished() {
+	}
+
+	// nor once finished
+	assert.ErrorIs(t, m.Run(testJob("0x1"), run), ErrJobExists)
+	assert.Len(t, runs, 1)
+
+	job, _, err := m.Get(types.StringToHash("0x1"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, job.Status)
+
+	// the routed jobs stored already are left unchanged
+	assert.NoError(t, m.Track(testJob("0x1")))
+}
+
+func TestManager_Poll(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(hclog.NewNullLogger(), NewMemoryStore(), time.Hour)
+	m.SetFetcher(&mockFetcher{
+		fetchFn: func(job *Job) (*Job, error) {
+			fetched := job.Copy()
+			fetched.Status = StatusCompleted
+			fetched.Result = []byte("result")
+
+			return fetched, nil
+		},
+	})
+
+	assert.NoError(t, m.Track(testJob("0x1")))
+
+	expired := testJob("0x2")
+	expired.CreatedAt = now() - uint64(2*time.Hour.Milliseconds())
+	assert.NoError(t, m.Track(expired))
+
+	assert.NoError(t, m.Poll())
+
+	job, _, err := m.Get(types.StringToHash("0x1"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, job.Status)
+	assert.Equal(t, []byte("result"), job.Result)
+
+	job, _, err = m.Get(types.StringToHash("0x2"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+
+	// the fetched job of another caller is rejected
+	m.SetFetcher(&mockFetcher{
+		fetchFn: func(job *Job) (*Job, error) {
+			fetched := job.Copy()
+			fetched.Caller = types.StringToAddress("0x1")
+
+			return fetched, nil
+		},
+	})
+
+	assert.NoError(t, m.Track(testJob("0x3")))
+
+	_, err = m.refresh(testJob("0x3"))
+	assert.ErrorIs(t, err, ErrJobMismatch)
+}
+
+func TestManager_FailInterrupted(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(hclog.NewNullLogger(), NewMemoryStore(), 0)
+
+	assert.NoError(t, m.Track(testJob("0x1")))
+
+	other := testJob("0x2")
+	other.PeerID = "other"
+	assert.NoError(t, m.Track(other))
+
+	assert.NoError(t, m.FailInterrupted("peer"))
+
+	job, _, err := m.Get(types.StringToHash("0x1"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+
+	job, _, err = m.Get(types.StringToHash("0x2"))
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPending, job.Status)
+}
@@ -0,0 +1,361 @@
+package jobs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultRetention is the period the finished jobs are kept for
+	DefaultRetention = 24 * time.Hour
+
+	// DefaultPollInterval is the interval of the status checks of the routed jobs
+	DefaultPollInterval = 5 * time.Second
+
+	// pruneInterval is the interval of the deletions of the expired jobs
+	pruneInterval = 10 * time.Minute
+)
+
+// Fetcher fetches the routed jobs from the edge nodes running them
+type Fetcher interface {
+	// FetchJob returns the job as stored by the edge node running it
+	FetchJob(job *Job) (*Job, error)
+}
+
+// RunFunc runs the job and returns its signed result
+type RunFunc func() ([]byte, error)
+
+// Manager runs the jobs of the edge calls served by the node, tracks the jobs routed by the node,
+// and notifies the subscribers of their status changes
+type Manager struct {
+	logger    hclog.Logger
+	store     *Store
+	retention *kvstore.Retention
+	interval  time.Duration
+
+	fetcher Fetcher
+
+	// jobs run by the node
+	runningLock sync.Mutex
+	running     map[types.Hash]struct{}
+
+	subsLock sync.Mutex
+	subs     map[*Subscription]struct{}
+
+	closeCh chan struct{}
+}
+
+// NewManager creates the manager of the jobs of the store, keeping the finished jobs for the retention period
+func NewManager(logger hclog.Logger, store *Store, retention time.Duration) *Manager {
+	if retention == 0 {
+		retention = DefaultRetention
+	}
+
+	logger = logger.Named("edge_call_jobs")
+
+	return &Manager{
+		logger:    logger,
+		store:     store,
+		retention: kvstore.NewRetention(logger, retention, pruneInterval, store.Prune),
+		interval:  DefaultPollInterval,
+		running:   make(map[types.Hash]struct{}),
+		subs:      make(map[*Subscription]struct{}),
+		closeCh:   make(chan struct{}),
+	}
+}
+
+// SetFetcher enables the tracking of the jobs routed by the node
+func (m *Manager) SetFetcher(f Fetcher) {
+	m.fetcher = f
+}
+
+// Start starts the periodic status checks of the routed jobs and the deletions of the expired jobs
+func (m *Manager) Start() {
+	m.retention.Start()
+
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := m.Poll(); err != nil {
+					m.logger.Error("failed to poll edge call jobs", "err", err)
+				}
+			case <-m.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (m *Manager) Close() {
+	m.retention.Close()
+	close(m.closeCh)
+}
+
+func now() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
+
+// Get returns the job of the given ID, refreshed from the edge node running it if routed and not finished
+func (m *Manager) Get(id types.Hash) (*Job, bool, error) {
+	job, ok, err := m.store.Get(id)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	if !job.IsFinished() && !m.isRunning(id) && m.fetcher != nil {
+		if refreshed, err := m.refresh(job); err != nil {
+			m.logger.Debug("failed to refresh edge call job", "id", id, "err", err)
+		} else {
+			job = refreshed
+		}
+	}
+
+	return job, true, nil
+}
+
+// Run stores the new job and runs it in the background, storing its result once finished.
+// The job is rejected if a job of the same ID is running or stored already
+func (m *Manager) Run(job *Job, run RunFunc) error {
+	job.Status = StatusPending
+	job.CreatedAt = now()
+	job.UpdatedAt = job.CreatedAt
+
+	m.runningLock.Lock()
+	if _, ok := m.running[job.ID]; ok {
+		m.runningLock.Unlock()
+
+		return ErrJobExists
+	}
+
+	m.running[job.ID] = struct{}{}
+	m.runningLock.Unlock()
+
+	if err := m.store.Create(job); err != nil {
+		m.finishRunning(job.ID)
+
+		return err
+	}
+
+	m.publish(job)
+
+	go func() {
+		defer m.finishRunning(job.ID)
+
+		job = job.Copy()
+		job.Status = StatusRunning
+		job.UpdatedAt = now()
+
+		if err := m.update(job); err != nil {
+			m.logger.Error("failed to update edge call job", "id", job.ID, "err", err)
+		}
+
+		result, err := run()
+
+		job = job.Copy()
+		job.UpdatedAt = now()
+
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = StatusCompleted
+			job.Result = result
+		}
+
+		if err := m.update(job); err != nil {
+			m.logger.Error("failed to update edge call job", "id", job.ID, "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// Track stores the job routed by the node, unless the node runs it
+func (m *Manager) Track(job *Job) error {
+	if job.CreatedAt == 0 {
+		job.CreatedAt = now()
+		job.UpdatedAt = job.CreatedAt
+	}
+
+	return m.store.PutIfAbsent(job)
+}
+
+// FailInterrupted fails the unfinished jobs of the edge node which are not running,
+// as the restart of the node interrupted them
+func (m *Manager) FailInterrupted(peerID string) error {
+	unfinished, err := m.store.Unfinished()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range unfinished {
+		if job.PeerID != peerID || m.isRunning(job.ID) {
+			continue
+		}
+
+		job.Status = StatusFailed
+		job.Error = "job interrupted"
+		job.UpdatedAt = now()
+
+		if err := m.update(job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Poll refreshes the unfinished jobs routed by the node from the edge nodes running them,
+// the jobs not finished within the retention period are failed
+func (m *Manager) Poll() error {
+	unfinished, err := m.store.Unfinished()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range unfinished {
+		if m.isRunning(job.ID) {
+			continue
+		}
+
+		if time.Since(time.UnixMilli(int64(job.CreatedAt))) > m.retention.Period() {
+			job.Status = StatusFailed
+			job.Error = "job expired"
+			job.UpdatedAt = now()
+
+			if err := m.update(job); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if m.fetcher == nil {
+			continue
+		}
+
+		if _, err := m.refresh(job); err != nil {
+			m.logger.Debug("failed to refresh edge call job", "id", job.ID, "err", err)
+		}
+	}
+
+	return nil
+}
+
+// Prune deletes the jobs finished before the retention period, and returns the number of the deleted jobs
+func (m *Manager) Prune() (int, error) {
+	return m.retention.Expire()
+}
+
+// refresh fetches the job from the edge node running it, and stores it if its status changed
+func (m *Manager) refresh(job *Job) (*Job, error) {
+	fetched, err := m.fetcher.FetchJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	if fetched.ID != job.ID || fetched.PeerID != job.PeerID || fetched.Caller != job.Caller {
+		return nil, ErrJobMismatch
+	}
+
+	if fetched.Status == job.Status {
+		return job, nil
+	}
+
+	if err := m.update(fetched); err != nil {
+		return nil, err
+	}
+
+	return fetched, nil
+}
+
+func (m *Manager) update(job *Job) error {
+	if err := m.store.Put(job); err != nil {
+		return err
+	}
+
+	m.publish(job)
+
+	return nil
+}
+
+func (m *Manager) isRunning(id types.Hash) bool {
+	m.runningLock.Lock()
+	defer m.runningLock.Unlock()
+
+	_, ok := m.running[id]
+
+	return ok
+}
+
+func (m *Manager) finishRunning(id types.Hash) {
+	m.runningLock.Lock()
+	defer m.runningLock.Unlock()
+
+	delete(m.running, id)
+}
+
+// Subscription receives the status changes of the jobs
+type Subscription struct {
+	m        *Manager
+	updateCh chan *Job
+	closeCh  chan struct{}
+	once     sync.Once
+}
+
+// Subscribe returns a subscription to the status changes of the jobs
+func (m *Manager) Subscribe() *Subscription {
+	sub := &Subscription{
+		m:        m,
+		updateCh: make(chan *Job, 16),
+		closeCh:  make(chan struct{}),
+	}
+
+	m.subsLock.Lock()
+	m.subs[sub] = struct{}{}
+	m.subsLock.Unlock()
+
+	return sub
+}
+
+// publish notifies the subscribers of the job, the slow subscribers miss the update
+func (m *Manager) publish(job *Job) {
+	m.subsLock.Lock()
+	defer m.subsLock.Unlock()
+
+	for sub := range m.subs {
+		select {
+		case sub.updateCh <- job.Copy():
+		default:
+		}
+	}
+}
+
+// GetEvent returns the next updated job (BLOCKING), nil once the subscription is closed
+func (s *Subscription) GetEvent() *Job {
+	select {
+	case job := <-s.updateCh:
+		return job
+	case <-s.closeCh:
+		return nil
+	}
+}
+
+// Close closes the subscription
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.m.subsLock.Lock()
+		delete(s.m.subs, s)
+		s.m.subsLock.Unlock()
+
+		close(s.closeCh)
+	})
+}
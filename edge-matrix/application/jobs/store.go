@@ -0,0 +1,164 @@
+package jobs
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// key prefixes of the store
+var (
+	// job by ID
+	jobPrefix = []byte("j")
+	// unfinished job IDs
+	unfinishedPrefix = []byte("u")
+	// finished job IDs by update time
+	finishedPrefix = []byte("f")
+)
+
+// Store keeps the edge call jobs run by the node and the jobs routed by the node to the edge nodes
+type Store struct {
+	lock sync.Mutex
+	db   *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put stores the job, the finished jobs are left unchanged
+func (s *Store) Put(job *Job) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.put(job, false)
+}
+
+// PutIfAbsent stores the job unless a job of the same ID is stored already
+func (s *Store) PutIfAbsent(job *Job) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.put(job, true); !errors.Is(err, ErrJobExists) {
+		return err
+	}
+
+	return nil
+}
+
+// Create stores the new job, failing if a job of the same ID is stored already
+func (s *Store) Create(job *Job) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.put(job, true)
+}
+
+func (s *Store) put(job *Job, absent bool) error {
+	existing, ok, err := s.get(job.ID)
+	if err != nil {
+		return err
+	}
+
+	if ok && absent {
+		return ErrJobExists
+	}
+
+	if ok && existing.IsFinished() {
+		return ErrJobFinished
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put(kvstore.HashKey(jobPrefix, job.ID), job.MarshalRLP())
+
+	if job.IsFinished() {
+		batch.Delete(kvstore.HashKey(unfinishedPrefix, job.ID))
+		batch.Put(kvstore.TimeKey(finishedPrefix, job.UpdatedAt, job.ID), []byte{})
+	} else {
+		batch.Put(kvstore.HashKey(unfinishedPrefix, job.ID), []byte{})
+	}
+
+	return s.db.Write(batch)
+}
+
+func (s *Store) get(id types.Hash) (*Job, bool, error) {
+	job := &Job{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(jobPrefix, id), job)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return job, true, nil
+}
+
+// Get returns the job of the given ID
+func (s *Store) Get(id types.Hash) (*Job, bool, error) {
+	return s.get(id)
+}
+
+// Unfinished returns the jobs which are not completed or failed yet, in the order of their IDs
+func (s *Store) Unfinished() ([]*Job, error) {
+	jobs := make([]*Job, 0)
+
+	err := s.db.Iterate(util.BytesPrefix(unfinishedPrefix), func(key, _ []byte) error {
+		job, ok, err := s.get(types.BytesToHash(key[len(unfinishedPrefix):]))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			jobs = append(jobs, job)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Prune deletes the jobs finished before the given unix time in milliseconds,
+// and returns the number of the deleted jobs
+func (s *Store) Prune(before uint64) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch := new(leveldb.Batch)
+	pruned := 0
+
+	err := s.db.Iterate(kvstore.Before(finishedPrefix, before), func(key, _ []byte) error {
+		batch.Delete(key)
+		batch.Delete(kvstore.HashKey(jobPrefix, kvstore.TimeKeyHash(finishedPrefix, key)))
+
+		pruned++
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return pruned, s.db.Write(batch)
+}
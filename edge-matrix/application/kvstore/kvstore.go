@@ -0,0 +1,148 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ErrStop stops the iteration of the values without failing it
+var ErrStop = errors.New("iteration stopped")
+
+// Unmarshaler is a value stored in its RLP encoding
+type Unmarshaler interface {
+	UnmarshalRLP(input []byte) error
+}
+
+// DB is the leveldb database of an application store, keeping each kind of values under its own key prefix
+type DB struct {
+	db *leveldb.DB
+}
+
+// OpenLevelDB opens the database in the given directory
+func OpenLevelDB(path string) (*DB, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{db: db}, nil
+}
+
+// OpenMemory creates the database kept in memory
+func OpenMemory() *DB {
+	// opening the memory storage does not fail
+	db, _ := leveldb.Open(storage.NewMemStorage(), nil)
+
+	return &DB{db: db}
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Get returns the value of the key
+func (d *DB) Get(key []byte) ([]byte, bool, error) {
+	data, err := d.db.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+// GetRLP decodes the value of the key into v
+func (d *DB) GetRLP(key []byte, v Unmarshaler) (bool, error) {
+	data, ok, err := d.Get(key)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := v.UnmarshalRLP(data); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (d *DB) Has(key []byte) (bool, error) {
+	return d.db.Has(key, nil)
+}
+
+func (d *DB) Put(key, value []byte) error {
+	return d.db.Put(key, value, nil)
+}
+
+// Write applies the batch atomically
+func (d *DB) Write(batch *leveldb.Batch) error {
+	return d.db.Write(batch, nil)
+}
+
+// Iterate calls fn with the keys and the values of the range in the order of their keys,
+// until fn fails or returns ErrStop. The key and the value are only valid during the call
+func (d *DB) Iterate(rng *util.Range, fn func(key, value []byte) error) error {
+	iter := d.db.NewIterator(rng, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); errors.Is(err, ErrStop) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+// HashKey returns the key of the hash under the prefix
+func HashKey(prefix []byte, hash types.Hash) []byte {
+	return append(append([]byte{}, prefix...), hash.Bytes()...)
+}
+
+// TimeKey returns the key of the hash under the prefix, ordered by the time
+func TimeKey(prefix []byte, time uint64, hash types.Hash) []byte {
+	key := make([]byte, 0, len(prefix)+8+types.HashLength)
+	key = append(key, prefix...)
+	key = binary.BigEndian.AppendUint64(key, time)
+
+	return append(key, hash.Bytes()...)
+}
+
+// TimeKeyHash returns the hash of the time key under the prefix
+func TimeKeyHash(prefix []byte, key []byte) types.Hash {
+	return types.BytesToHash(key[len(prefix)+8:])
+}
+
+// TimeRange returns the range of the time keys under the prefix between the times, inclusive,
+// the zero end time leaving the range unbounded
+func TimeRange(prefix []byte, from, to uint64) *util.Range {
+	rng := &util.Range{Start: TimeKey(prefix, from, types.ZeroHash), Limit: util.BytesPrefix(prefix).Limit}
+	if to != 0 {
+		rng.Limit = TimeKey(prefix, to+1, types.ZeroHash)
+	}
+
+	return rng
+}
+
+// Before returns the range of the time keys under the prefix before the time
+func Before(prefix []byte, time uint64) *util.Range {
+	return &util.Range{Start: TimeKey(prefix, 0, types.ZeroHash), Limit: TimeKey(prefix, time, types.ZeroHash)}
+}
+
+// Stamp prefixes the value with the current time in unix milliseconds, for the values expired by their store time
+func Stamp(data []byte) []byte {
+	return append(binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixMilli())), data...)
+}
+
+// Unstamp returns the value stamped by Stamp and its store time
+func Unstamp(value []byte) ([]byte, uint64) {
+	return value[8:], binary.BigEndian.Uint64(value[:8])
+}
@@ -0,0 +1,194 @@
+package kvstore
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var testPrefix = []byte("t")
+
+type testValue struct {
+	n uint64
+}
+
+func (v *testValue) UnmarshalRLP(input []byte) error {
+	if len(input) != 8 {
+		return errors.New("invalid value")
+	}
+
+	v.n = binary.BigEndian.Uint64(input)
+
+	return nil
+}
+
+// putTimes stores the keys of the times under the test prefix, the hash of each key being its time
+func putTimes(t *testing.T, db *DB, times ...uint64) {
+	t.Helper()
+
+	batch := new(leveldb.Batch)
+	for _, time := range times {
+		batch.Put(TimeKey(testPrefix, time, types.BytesToHash(binary.BigEndian.AppendUint64(nil, time))), []byte{})
+	}
+
+	require.NoError(t, db.Write(batch))
+}
+
+// countKeys returns the number of the keys iterated until fn stops the iteration
+func countKeys(t *testing.T, db *DB, fn func() error) int {
+	t.Helper()
+
+	count := 0
+
+	require.NoError(t, db.Iterate(nil, func(_, _ []byte) error {
+		count++
+
+		return fn()
+	}))
+
+	return count
+}
+
+func TestDB_GetRLP(t *testing.T) {
+	t.Parallel()
+
+	db := OpenMemory()
+	defer db.Close()
+
+	key := HashKey(testPrefix, types.StringToHash("0x1"))
+
+	v := &testValue{}
+	ok, err := db.GetRLP(key, v)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, db.Put(key, binary.BigEndian.AppendUint64(nil, 7)))
+
+	ok, err = db.GetRLP(key, v)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(7), v.n)
+
+	require.NoError(t, db.Put(key, []byte{1}))
+
+	_, err = db.GetRLP(key, v)
+	assert.Error(t, err)
+}
+
+func TestDB_IterateTimes(t *testing.T) {
+	t.Parallel()
+
+	db := OpenMemory()
+	defer db.Close()
+
+	putTimes(t, db, 3000, 1000, 2000)
+
+	collect := func(from, to uint64, before bool) []uint64 {
+		rng := TimeRange(testPrefix, from, to)
+		if before {
+			rng = Before(testPrefix, to)
+		}
+
+		found := make([]uint64, 0)
+
+		require.NoError(t, db.Iterate(rng, func(key, _ []byte) error {
+			found = append(found, binary.BigEndian.Uint64(key[len(testPrefix):]))
+			assert.Equal(t, found[len(found)-1], binary.BigEndian.Uint64(TimeKeyHash(testPrefix, key).Bytes()[24:]))
+
+			return nil
+		}))
+
+		return found
+	}
+
+	assert.Equal(t, []uint64{1000, 2000, 3000}, collect(0, 0, false))
+	assert.Equal(t, []uint64{2000, 3000}, collect(2000, 0, false))
+	assert.Equal(t, []uint64{1000, 2000}, collect(0, 2000, false))
+	assert.Equal(t, []uint64{1000}, collect(0, 2000, true))
+
+	// the iteration is stopped without failing
+	assert.Equal(t, 1, countKeys(t, db, func() error { return ErrStop }))
+}
+
+func TestStamp(t *testing.T) {
+	t.Parallel()
+
+	before := uint64(time.Now().UnixMilli())
+
+	data, storedAt := Unstamp(Stamp([]byte("value")))
+	assert.Equal(t, []byte("value"), data)
+	assert.GreaterOrEqual(t, storedAt, before)
+}
+
+func TestNewPage(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPage(3, 2, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+
+	_, err = NewPage(0, 0, 0, MaxQueryLimit+1)
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+
+	page, err := NewPage(3, 0, 1, 2)
+	require.NoError(t, err)
+
+	selected := make([]bool, 5)
+	for i := range selected {
+		selected[i] = page.Add()
+	}
+
+	assert.Equal(t, []bool{false, true, true, false, false}, selected)
+	assert.Equal(t, uint64(5), page.Total)
+
+	page, err = NewPage(0, 0, 0, 0)
+	require.NoError(t, err)
+
+	for i := 0; i < DefaultQueryLimit; i++ {
+		require.True(t, page.Add())
+	}
+
+	assert.False(t, page.Add())
+}
+
+func TestRetention_Expire(t *testing.T) {
+	t.Parallel()
+
+	db := OpenMemory()
+	defer db.Close()
+
+	now := uint64(time.Now().UnixMilli())
+	putTimes(t, db, now-uint64(2*time.Hour.Milliseconds()), now)
+
+	expire := func(before uint64) (int, error) {
+		batch := new(leveldb.Batch)
+		expired := 0
+
+		err := db.Iterate(Before(testPrefix, before), func(key, _ []byte) error {
+			batch.Delete(key)
+			expired++
+
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return expired, db.Write(batch)
+	}
+
+	r := NewRetention(hclog.NewNullLogger(), time.Hour, time.Minute, expire)
+	assert.Equal(t, time.Hour, r.Period())
+
+	expired, err := r.Expire()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	assert.Equal(t, 1, countKeys(t, db, func() error { return nil }))
+}
@@ -0,0 +1,55 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultQueryLimit = 100
+	MaxQueryLimit     = 1000
+)
+
+var (
+	ErrInvalidOrder  = errors.New("query times are out of order")
+	ErrLimitTooLarge = fmt.Errorf("limit is larger than %d", MaxQueryLimit)
+)
+
+// Page selects the page of the values matching a query, and counts all of them
+type Page struct {
+	offset uint64
+	limit  uint64
+	size   uint64
+
+	// Total is the number of the matching values
+	Total uint64
+}
+
+// NewPage checks the time range of a query in unix milliseconds, the zero end time leaving it unbounded,
+// and returns the page of the query, the zero limit selecting the default one
+func NewPage(fromTime, toTime, offset, limit uint64) (*Page, error) {
+	if toTime != 0 && fromTime > toTime {
+		return nil, ErrInvalidOrder
+	}
+
+	if limit == 0 {
+		limit = DefaultQueryLimit
+	} else if limit > MaxQueryLimit {
+		return nil, ErrLimitTooLarge
+	}
+
+	return &Page{offset: offset, limit: limit}, nil
+}
+
+// Add counts the next matching value, and returns whether it belongs to the page
+func (p *Page) Add() bool {
+	p.Total++
+
+	if p.Total <= p.offset || p.size == p.limit {
+		return false
+	}
+
+	p.size++
+
+	return true
+}
@@ -0,0 +1,66 @@
+package kvstore
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// ExpireFunc deletes the values kept since before the unix time in milliseconds, and returns the number of the deleted values
+type ExpireFunc func(before uint64) (int, error)
+
+// Retention periodically deletes the values of a store kept for longer than the retention period
+type Retention struct {
+	logger   hclog.Logger
+	period   time.Duration
+	interval time.Duration
+	expire   ExpireFunc
+
+	closeCh chan struct{}
+}
+
+// NewRetention creates the retention of the values deleted by expire, checked at every interval
+func NewRetention(logger hclog.Logger, period, interval time.Duration, expire ExpireFunc) *Retention {
+	return &Retention{
+		logger:   logger,
+		period:   period,
+		interval: interval,
+		expire:   expire,
+		closeCh:  make(chan struct{}),
+	}
+}
+
+// Start starts the periodic deletions
+func (r *Retention) Start() {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if expired, err := r.Expire(); err != nil {
+					r.logger.Error("failed to delete the expired values", "err", err)
+				} else if expired > 0 {
+					r.logger.Debug("deleted the expired values", "count", expired)
+				}
+			case <-r.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (r *Retention) Close() {
+	close(r.closeCh)
+}
+
+// Period returns the retention period
+func (r *Retention) Period() time.Duration {
+	return r.period
+}
+
+// Expire deletes the values kept for longer than the retention period, and returns the number of the deleted values
+func (r *Retention) Expire() (int, error) {
+	return r.expire(uint64(time.Now().Add(-r.period).UnixMilli()))
+}
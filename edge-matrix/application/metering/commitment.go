@@ -0,0 +1,145 @@
+package metering
+
+import (
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// Commitment aggregates the countersigned usage records of a provider,
+// committing to the records by the Merkle root of their hashes
+type Commitment struct {
+	Provider types.Address
+	Root     types.Hash
+	Count    uint64
+
+	// FromTime and ToTime are the earliest and the latest start times of the records
+	FromTime uint64
+	ToTime   uint64
+
+	TotalDuration    uint64
+	TotalInputBytes  uint64
+	TotalOutputBytes uint64
+}
+
+// NewCommitment aggregates the records of the provider, in the given order
+func NewCommitment(provider types.Address, records []*UsageRecord) *Commitment {
+	c := &Commitment{
+		Provider: provider,
+		Count:    uint64(len(records)),
+	}
+
+	hashes := make([]types.Hash, len(records))
+
+	for i, record := range records {
+		hashes[i] = record.Hash()
+
+		if i == 0 || record.StartTime < c.FromTime {
+			c.FromTime = record.StartTime
+		}
+
+		if record.StartTime > c.ToTime {
+			c.ToTime = record.StartTime
+		}
+
+		c.TotalDuration += record.Duration
+		c.TotalInputBytes += record.InputBytes
+		c.TotalOutputBytes += record.OutputBytes
+	}
+
+	c.Root = MerkleRoot(hashes)
+
+	return c
+}
+
+// MerkleRoot returns the root of the binary keccak tree of the hashes,
+// the last hash of an odd level is promoted to the next level unchanged
+func MerkleRoot(hashes []types.Hash) types.Hash {
+	if len(hashes) == 0 {
+		return types.ZeroHash
+	}
+
+	level := append([]types.Hash(nil), hashes...)
+
+	for len(level) > 1 {
+		next := make([]types.Hash, 0, (len(level)+1)/2)
+
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+
+				continue
+			}
+
+			next = append(next, types.BytesToHash(keccak.Keccak256(nil, append(level[i].Bytes(), level[i+1].Bytes()...))))
+		}
+
+		level = next
+	}
+
+	return level[0]
+}
+
+// Hash returns the keccak256 hash of the RLP encoded commitment
+func (c *Commitment) Hash() types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, c.MarshalRLP()))
+}
+
+func (c *Commitment) MarshalRLP() []byte {
+	return c.MarshalRLPTo(nil)
+}
+
+func (c *Commitment) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(c.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Commitment to RLP with a specific fastrlp.Arena
+func (c *Commitment) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(c.Provider.Bytes()))
+	vv.Set(arena.NewBytes(c.Root.Bytes()))
+	vv.Set(arena.NewUint(c.Count))
+	vv.Set(arena.NewUint(c.FromTime))
+	vv.Set(arena.NewUint(c.ToTime))
+	vv.Set(arena.NewUint(c.TotalDuration))
+	vv.Set(arena.NewUint(c.TotalInputBytes))
+	vv.Set(arena.NewUint(c.TotalOutputBytes))
+
+	return vv
+}
+
+func (c *Commitment) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(c.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Commitment in RLP format
+func (c *Commitment) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 8 {
+		return fmt.Errorf("incorrect number of elements to decode usage commitment, expected 8 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetAddr(c.Provider[:]); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetHash(c.Root[:]); err != nil {
+		return err
+	}
+
+	fields := []*uint64{&c.Count, &c.FromTime, &c.ToTime, &c.TotalDuration, &c.TotalInputBytes, &c.TotalOutputBytes}
+	for i, field := range fields {
+		if *field, err = elems[2+i].GetUint64(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package metering
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultCommitInterval is the interval of the usage commitments
+	DefaultCommitInterval = 10 * time.Minute
+
+	// maxCommitRecords is the number of the records committed at most per interval
+	maxCommitRecords = 10000
+)
+
+// Submitter submits the usage commitments on chain
+type Submitter interface {
+	SubmitCommitment(commitment *Commitment) error
+}
+
+// Committer periodically aggregates the countersigned usage records by provider,
+// and submits their commitments
+type Committer struct {
+	logger    hclog.Logger
+	store     *Store
+	submitter Submitter
+	interval  time.Duration
+
+	closeCh chan struct{}
+}
+
+// NewCommitter creates the committer of the pending records of the store
+func NewCommitter(logger hclog.Logger, store *Store, submitter Submitter, interval time.Duration) *Committer {
+	if interval == 0 {
+		interval = DefaultCommitInterval
+	}
+
+	return &Committer{
+		logger:    logger.Named("usage_committer"),
+		store:     store,
+		submitter: submitter,
+		interval:  interval,
+		closeCh:   make(chan struct{}),
+	}
+}
+
+// Start starts the periodic commitments
+func (c *Committer) Start() {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := c.Commit(); err != nil {
+					c.logger.Error("failed to commit usage records", "err", err)
+				}
+			case <-c.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (c *Committer) Close() {
+	close(c.closeCh)
+}
+
+// Commit submits the commitments of the pending records, one per provider.
+// The records of a failed submission are left pending for the next commit
+func (c *Committer) Commit() ([]*Commitment, error) {
+	records, err := c.store.Pending(maxCommitRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	byProvider := make(map[types.Address][]*UsageRecord)
+	providers := make([]types.Address, 0)
+
+	for _, record := range records {
+		if _, ok := byProvider[record.Provider]; !ok {
+			providers = append(providers, record.Provider)
+		}
+
+		byProvider[record.Provider] = append(byProvider[record.Provider], record)
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return bytes.Compare(providers[i].Bytes(), providers[j].Bytes()) < 0
+	})
+
+	commitments := make([]*Commitment, 0, len(providers))
+
+	for _, provider := range providers {
+		commitment := NewCommitment(provider, byProvider[provider])
+
+		if err := c.submitter.SubmitCommitment(commitment); err != nil {
+			return commitments, err
+		}
+
+		if err := c.store.PutCommitment(commitment, byProvider[provider]); err != nil {
+			return commitments, err
+		}
+
+		c.logger.Debug("usage committed", "provider", provider, "records", commitment.Count, "root", commitment.Root)
+
+		commitments = append(commitments, commitment)
+	}
+
+	return commitments, nil
+}
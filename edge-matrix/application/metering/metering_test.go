@@ -0,0 +1,290 @@
+package metering
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecord(t *testing.T, startTime uint64) *UsageRecord {
+	t.Helper()
+
+	providerKey, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	return testProviderRecord(t, providerKey, startTime)
+}
+
+func testProviderRecord(t *testing.T, providerKey *ecdsa.PrivateKey, startTime uint64) *UsageRecord {
+	t.Helper()
+
+	record := &UsageRecord{
+		RequestHash: types.StringToHash("0x1234"),
+		Caller:      types.StringToAddress("0xca11e5"),
+		Path:        "/api",
+		StartTime:   startTime,
+		Duration:    250,
+		InputBytes:  64,
+		OutputBytes: 1024,
+		Status:      200,
+	}
+	assert.NoError(t, record.SignProvider(providerKey))
+
+	return record
+}
+
+func countersign(t *testing.T, record *UsageRecord) *UsageRecord {
+	t.Helper()
+
+	callerKey, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	record = record.Copy()
+	assert.NoError(t, record.Countersign(callerKey))
+
+	return record
+}
+
+func TestUsageRecord_SignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	record := testRecord(t, 1000)
+	assert.NoError(t, record.Verify())
+	assert.False(t, record.IsCountersigned())
+
+	signed := countersign(t, record)
+	assert.NoError(t, signed.Verify())
+	assert.True(t, signed.IsCountersigned())
+
+	// the countersignature does not change the signed fields
+	assert.Equal(t, record.Hash(), signed.Hash())
+
+	tampered := signed.Copy()
+	tampered.OutputBytes++
+	assert.ErrorIs(t, tampered.Verify(), ErrInvalidProviderSig)
+
+	tampered = signed.Copy()
+	tampered.CallerNode = types.StringToAddress("0x1")
+	assert.ErrorIs(t, tampered.Verify(), ErrInvalidCallerSig)
+
+	assert.ErrorIs(t, (&UsageRecord{}).Verify(), ErrMissingProviderSig)
+}
+
+func TestUsageRecord_RLP(t *testing.T) {
+	t.Parallel()
+
+	for _, record := range []*UsageRecord{testRecord(t, 1000), countersign(t, testRecord(t, 2000))} {
+		decoded := &UsageRecord{}
+		assert.NoError(t, decoded.UnmarshalRLP(record.MarshalRLP()))
+		assert.Equal(t, record.Hash(), decoded.Hash())
+		assert.Equal(t, record.MarshalRLP(), decoded.MarshalRLP())
+		assert.NoError(t, decoded.Verify())
+	}
+
+	commitment := NewCommitment(types.StringToAddress("0x2"), []*UsageRecord{testRecord(t, 3000), testRecord(t, 1000)})
+	decoded := &Commitment{}
+	assert.NoError(t, decoded.UnmarshalRLP(commitment.MarshalRLP()))
+	assert.Equal(t, commitment, decoded)
+}
+
+func TestMerkleRoot(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := types.StringToHash("0xa"), types.StringToHash("0xb"), types.StringToHash("0xc")
+	ab := types.BytesToHash(keccak.Keccak256(nil, append(a.Bytes(), b.Bytes()...)))
+
+	assert.Equal(t, types.ZeroHash, MerkleRoot(nil))
+	assert.Equal(t, a, MerkleRoot([]types.Hash{a}))
+	assert.Equal(t, ab, MerkleRoot([]types.Hash{a, b}))
+	assert.Equal(t,
+		types.BytesToHash(keccak.Keccak256(nil, append(ab.Bytes(), c.Bytes()...))),
+		MerkleRoot([]types.Hash{a, b, c}),
+	)
+}
+
+func TestNewCommitment(t *testing.T) {
+	t.Parallel()
+
+	records := []*UsageRecord{testRecord(t, 3000), testRecord(t, 1000), testRecord(t, 2000)}
+	commitment := NewCommitment(types.StringToAddress("0x2"), records)
+
+	assert.Equal(t, uint64(3), commitment.Count)
+	assert.Equal(t, uint64(1000), commitment.FromTime)
+	assert.Equal(t, uint64(3000), commitment.ToTime)
+	assert.Equal(t, uint64(750), commitment.TotalDuration)
+	assert.Equal(t, uint64(192), commitment.TotalInputBytes)
+	assert.Equal(t, uint64(3072), commitment.TotalOutputBytes)
+	assert.Equal(t, MerkleRoot([]types.Hash{records[0].Hash(), records[1].Hash(), records[2].Hash()}), commitment.Root)
+}
+
+func TestStore_PutAndQuery(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	records := []*UsageRecord{testRecord(t, 3000), testRecord(t, 1000), testRecord(t, 2000)}
+	for _, record := range records {
+		assert.NoError(t, store.Put(record))
+	}
+
+	entry, ok, err := store.Get(records[0].Hash())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, records[0].MarshalRLP(), entry.Record.MarshalRLP())
+	assert.Equal(t, types.ZeroHash, entry.Commitment)
+
+	_, ok, err = store.Get(types.StringToHash("0x1"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	startTimes := func(entries []*Entry) []uint64 {
+		times := make([]uint64, len(entries))
+		for i, entry := range entries {
+			times[i] = entry.Record.StartTime
+		}
+
+		return times
+	}
+
+	provider := records[2].Provider
+
+	cases := []struct {
+		name  string
+		query *Query
+		times []uint64
+		total uint64
+	}{
+		{"all", nil, []uint64{1000, 2000, 3000}, 3},
+		{"from", &Query{FromTime: 2000}, []uint64{2000, 3000}, 2},
+		{"to", &Query{ToTime: 2000}, []uint64{1000, 2000}, 2},
+		{"provider", &Query{Provider: &provider}, []uint64{2000}, 1},
+		{"page", &Query{Offset: 1, Limit: 1}, []uint64{2000}, 3},
+	}
+
+	for _, c := range cases {
+		entries, total, err := store.Query(c.query)
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.times, startTimes(entries), c.name)
+		assert.Equal(t, c.total, total, c.name)
+	}
+
+	_, _, err = store.Query(&Query{FromTime: 3, ToTime: 2})
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+
+	_, _, err = store.Query(&Query{Limit: MaxQueryLimit + 1})
+	assert.ErrorIs(t, err, ErrLimitTooLarge)
+}
+
+func TestStore_Countersigned(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	record := testRecord(t, 1000)
+	signed := countersign(t, record)
+
+	// only the countersigned records are pending commitment
+	assert.NoError(t, store.Put(record))
+
+	pending, err := store.Pending(10)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	assert.NoError(t, store.Put(signed))
+
+	// the record signed only by the provider does not replace the countersigned one
+	assert.NoError(t, store.Put(record))
+
+	pending, err = store.Pending(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.True(t, pending[0].IsCountersigned())
+
+	commitment := NewCommitment(signed.Provider, pending)
+	assert.NoError(t, store.PutCommitment(commitment, pending))
+
+	pending, err = store.Pending(10)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	entry, _, err := store.Get(record.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, commitment.Hash(), entry.Commitment)
+
+	stored, ok, err := store.GetCommitment(commitment.Hash())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, commitment, stored)
+}
+
+type mockSubmitter struct {
+	commitments []*Commitment
+	err         error
+}
+
+func (m *mockSubmitter) SubmitCommitment(commitment *Commitment) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.commitments = append(m.commitments, commitment)
+
+	return nil
+}
+
+func TestCommitter_Commit(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	providerKey, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	// the first and the third records are served by the same provider
+	first := countersign(t, testProviderRecord(t, providerKey, 1000))
+	second := countersign(t, testRecord(t, 2000))
+	third := countersign(t, testProviderRecord(t, providerKey, 3000))
+
+	for _, record := range []*UsageRecord{first, second, third} {
+		assert.NoError(t, store.Put(record))
+	}
+
+	failure := errors.New("pool is full")
+	submitter := &mockSubmitter{err: failure}
+	committer := NewCommitter(hclog.NewNullLogger(), store, submitter, 0)
+
+	_, err = committer.Commit()
+	assert.ErrorIs(t, err, failure)
+
+	// the records are left pending on failure
+	pending, err := store.Pending(10)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 3)
+
+	submitter.err = nil
+
+	commitments, err := committer.Commit()
+	assert.NoError(t, err)
+	assert.Len(t, commitments, 2)
+	assert.Equal(t, submitter.commitments, commitments)
+
+	counts := map[types.Address]uint64{}
+	for _, commitment := range commitments {
+		counts[commitment.Provider] = commitment.Count
+	}
+
+	assert.Equal(t, map[types.Address]uint64{first.Provider: 2, second.Provider: 1}, counts)
+
+	commitments, err = committer.Commit()
+	assert.NoError(t, err)
+	assert.Empty(t, commitments)
+}
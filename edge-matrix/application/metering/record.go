@@ -0,0 +1,233 @@
+package metering
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// Headers of the metered edge calls
+const (
+	// HeaderFrom is the address of the caller, set by the node routing the call
+	HeaderFrom = "Emc-From"
+
+	// HeaderRequestHash is the hash of the edge call telegram, set by the node routing the call
+	HeaderRequestHash = "Emc-Request-Hash"
+
+	// HeaderUsage is the usage record signed by the provider, base64 encoded RLP
+	HeaderUsage = "Emc-Usage"
+)
+
+var (
+	ErrMissingProviderSig = errors.New("usage record is not signed by the provider")
+	ErrInvalidProviderSig = errors.New("usage record signature does not match the provider")
+	ErrInvalidCallerSig   = errors.New("usage record countersignature does not match the caller node")
+)
+
+var recordArenaPool fastrlp.ArenaPool
+
+// UsageRecord is the usage of a single edge call, signed by the provider serving the call
+// and countersigned by the node routing the call on behalf of the caller
+type UsageRecord struct {
+	// RequestHash is the hash of the edge call telegram
+	RequestHash types.Hash
+	Caller      types.Address
+	Provider    types.Address
+	Path        string
+
+	// StartTime is the unix time in milliseconds the provider received the call
+	StartTime uint64
+	// Duration is the time in milliseconds the provider served the call
+	Duration uint64
+
+	InputBytes  uint64
+	OutputBytes uint64
+
+	// Status is the HTTP status of the call
+	Status uint64
+
+	ProviderSig []byte
+
+	// CallerNode is the node which routed the call for the caller and countersigned the record
+	CallerNode types.Address
+	CallerSig  []byte
+}
+
+// Hash returns the hash of the record fields signed by both parties
+func (r *UsageRecord) Hash() types.Hash {
+	a := recordArenaPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewBytes(r.RequestHash.Bytes()))
+	v.Set(a.NewBytes(r.Caller.Bytes()))
+	v.Set(a.NewBytes(r.Provider.Bytes()))
+	v.Set(a.NewString(r.Path))
+	v.Set(a.NewUint(r.StartTime))
+	v.Set(a.NewUint(r.Duration))
+	v.Set(a.NewUint(r.InputBytes))
+	v.Set(a.NewUint(r.OutputBytes))
+	v.Set(a.NewUint(r.Status))
+
+	hash := keccak.Keccak256Rlp(nil, v)
+
+	recordArenaPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// IsCountersigned returns true if the caller node countersigned the record
+func (r *UsageRecord) IsCountersigned() bool {
+	return len(r.CallerSig) > 0
+}
+
+// SignProvider sets the provider to the address of the key and signs the record
+func (r *UsageRecord) SignProvider(key *ecdsa.PrivateKey) error {
+	r.Provider = crypto.PubKeyToAddress(&key.PublicKey)
+
+	sig, err := crypto.Sign(key, r.Hash().Bytes())
+	if err != nil {
+		return err
+	}
+
+	r.ProviderSig = sig
+
+	return nil
+}
+
+// Countersign sets the caller node to the address of the key and countersigns the record
+func (r *UsageRecord) Countersign(key *ecdsa.PrivateKey) error {
+	sig, err := crypto.Sign(key, r.Hash().Bytes())
+	if err != nil {
+		return err
+	}
+
+	r.CallerNode = crypto.PubKeyToAddress(&key.PublicKey)
+	r.CallerSig = sig
+
+	return nil
+}
+
+// Verify checks the provider signature, and the countersignature if present
+func (r *UsageRecord) Verify() error {
+	if len(r.ProviderSig) == 0 {
+		return ErrMissingProviderSig
+	}
+
+	hash := r.Hash().Bytes()
+
+	if signer, err := recoverAddress(hash, r.ProviderSig); err != nil || signer != r.Provider {
+		return ErrInvalidProviderSig
+	}
+
+	if r.IsCountersigned() {
+		if signer, err := recoverAddress(hash, r.CallerSig); err != nil || signer != r.CallerNode {
+			return ErrInvalidCallerSig
+		}
+	}
+
+	return nil
+}
+
+func recoverAddress(hash []byte, sig []byte) (types.Address, error) {
+	pub, err := crypto.RecoverPubkey(sig, hash)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(pub), nil
+}
+
+func (r *UsageRecord) Copy() *UsageRecord {
+	tt := new(UsageRecord)
+	*tt = *r
+
+	tt.ProviderSig = append([]byte(nil), r.ProviderSig...)
+	tt.CallerSig = append([]byte(nil), r.CallerSig...)
+
+	return tt
+}
+
+func (r *UsageRecord) MarshalRLP() []byte {
+	return r.MarshalRLPTo(nil)
+}
+
+func (r *UsageRecord) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(r.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the UsageRecord to RLP with a specific fastrlp.Arena
+func (r *UsageRecord) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(r.RequestHash.Bytes()))
+	vv.Set(arena.NewBytes(r.Caller.Bytes()))
+	vv.Set(arena.NewBytes(r.Provider.Bytes()))
+	vv.Set(arena.NewString(r.Path))
+	vv.Set(arena.NewUint(r.StartTime))
+	vv.Set(arena.NewUint(r.Duration))
+	vv.Set(arena.NewUint(r.InputBytes))
+	vv.Set(arena.NewUint(r.OutputBytes))
+	vv.Set(arena.NewUint(r.Status))
+	vv.Set(arena.NewCopyBytes(r.ProviderSig))
+	vv.Set(arena.NewBytes(r.CallerNode.Bytes()))
+	vv.Set(arena.NewCopyBytes(r.CallerSig))
+
+	return vv
+}
+
+func (r *UsageRecord) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(r.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a UsageRecord in RLP format
+func (r *UsageRecord) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 12 {
+		return fmt.Errorf("incorrect number of elements to decode usage record, expected 12 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(r.RequestHash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetAddr(r.Caller[:]); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetAddr(r.Provider[:]); err != nil {
+		return err
+	}
+
+	if r.Path, err = elems[3].GetString(); err != nil {
+		return err
+	}
+
+	for i, field := range []*uint64{&r.StartTime, &r.Duration, &r.InputBytes, &r.OutputBytes, &r.Status} {
+		if *field, err = elems[4+i].GetUint64(); err != nil {
+			return err
+		}
+	}
+
+	if r.ProviderSig, err = elems[9].GetBytes(r.ProviderSig[:0]); err != nil {
+		return err
+	}
+
+	if err = elems[10].GetAddr(r.CallerNode[:]); err != nil {
+		return err
+	}
+
+	if r.CallerSig, err = elems[11].GetBytes(r.CallerSig[:0]); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package metering
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	DefaultQueryLimit = kvstore.DefaultQueryLimit
+	MaxQueryLimit     = kvstore.MaxQueryLimit
+)
+
+// key prefixes of the store
+var (
+	// record by start time and hash
+	recordPrefix = []byte("t")
+	// start time by record hash
+	hashPrefix = []byte("h")
+	// countersigned records pending commitment, by start time and hash
+	pendingPrefix = []byte("p")
+	// commitment hash by record hash
+	committedPrefix = []byte("c")
+	// commitment by hash
+	commitmentPrefix = []byte("m")
+)
+
+var (
+	ErrInvalidOrder  = kvstore.ErrInvalidOrder
+	ErrLimitTooLarge = kvstore.ErrLimitTooLarge
+)
+
+// Entry is a stored usage record with the hash of its commitment, zero if not committed
+type Entry struct {
+	Record     *UsageRecord
+	Commitment types.Hash
+}
+
+// Query filters the stored usage records, the empty fields match any record
+type Query struct {
+	Caller   *types.Address `json:"caller"`
+	Provider *types.Address `json:"provider"`
+
+	// FromTime and ToTime bound the start times of the records in unix milliseconds, inclusive
+	FromTime uint64 `json:"fromTime"`
+	ToTime   uint64 `json:"toTime"`
+
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+func (q *Query) matches(r *UsageRecord) bool {
+	if q.Caller != nil && *q.Caller != r.Caller {
+		return false
+	}
+
+	if q.Provider != nil && *q.Provider != r.Provider {
+		return false
+	}
+
+	return true
+}
+
+// Store keeps the usage records of the edge calls served and routed by the node
+type Store struct {
+	lock sync.Mutex
+	db   *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put stores the record. The countersigned record replaces the one signed only by the provider,
+// the committed records are left unchanged
+func (s *Store) Put(record *UsageRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	hash := record.Hash()
+
+	if _, committed, err := s.getCommitment(hash); err != nil || committed {
+		return err
+	}
+
+	if existing, ok, err := s.get(hash); err != nil {
+		return err
+	} else if ok && existing.IsCountersigned() && !record.IsCountersigned() {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	batch.Put(kvstore.TimeKey(recordPrefix, record.StartTime, hash), record.MarshalRLP())
+	batch.Put(kvstore.HashKey(hashPrefix, hash), binary.BigEndian.AppendUint64(nil, record.StartTime))
+
+	if record.IsCountersigned() {
+		batch.Put(kvstore.TimeKey(pendingPrefix, record.StartTime, hash), []byte{})
+	}
+
+	return s.db.Write(batch)
+}
+
+func (s *Store) get(hash types.Hash) (*UsageRecord, bool, error) {
+	startTime, ok, err := s.db.Get(kvstore.HashKey(hashPrefix, hash))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	record := &UsageRecord{}
+	ok, err = s.db.GetRLP(kvstore.TimeKey(recordPrefix, binary.BigEndian.Uint64(startTime), hash), record)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return record, true, nil
+}
+
+func (s *Store) getCommitment(hash types.Hash) (types.Hash, bool, error) {
+	data, ok, err := s.db.Get(kvstore.HashKey(committedPrefix, hash))
+	if err != nil || !ok {
+		return types.ZeroHash, false, err
+	}
+
+	return types.BytesToHash(data), true, nil
+}
+
+// Get returns the record of the given hash with its commitment
+func (s *Store) Get(hash types.Hash) (*Entry, bool, error) {
+	record, ok, err := s.get(hash)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	commitment, _, err := s.getCommitment(hash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &Entry{Record: record, Commitment: commitment}, true, nil
+}
+
+// Query returns the page of the records matching the query in the order of their start times,
+// and the number of all the matching records
+func (s *Store) Query(q *Query) ([]*Entry, uint64, error) {
+	if q == nil {
+		q = &Query{}
+	}
+
+	page, err := kvstore.NewPage(q.FromTime, q.ToTime, q.Offset, q.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*Entry, 0)
+
+	err = s.db.Iterate(kvstore.TimeRange(recordPrefix, q.FromTime, q.ToTime), func(_, value []byte) error {
+		record := &UsageRecord{}
+		if err := record.UnmarshalRLP(value); err != nil {
+			return err
+		}
+
+		if !q.matches(record) || !page.Add() {
+			return nil
+		}
+
+		commitment, _, err := s.getCommitment(record.Hash())
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, &Entry{Record: record, Commitment: commitment})
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, page.Total, nil
+}
+
+// Pending returns up to the limit of the countersigned records not committed yet, oldest first
+func (s *Store) Pending(limit int) ([]*UsageRecord, error) {
+	records := make([]*UsageRecord, 0)
+
+	err := s.db.Iterate(util.BytesPrefix(pendingPrefix), func(key, _ []byte) error {
+		if len(records) == limit {
+			return kvstore.ErrStop
+		}
+
+		record, ok, err := s.get(kvstore.TimeKeyHash(pendingPrefix, key))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			records = append(records, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// PutCommitment stores the commitment and marks its records as committed
+func (s *Store) PutCommitment(commitment *Commitment, records []*UsageRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	commitmentHash := commitment.Hash()
+
+	batch := new(leveldb.Batch)
+	batch.Put(kvstore.HashKey(commitmentPrefix, commitmentHash), commitment.MarshalRLP())
+
+	for _, record := range records {
+		hash := record.Hash()
+
+		batch.Delete(kvstore.TimeKey(pendingPrefix, record.StartTime, hash))
+		batch.Put(kvstore.HashKey(committedPrefix, hash), commitmentHash.Bytes())
+	}
+
+	return s.db.Write(batch)
+}
+
+// GetCommitment returns the commitment of the given hash
+func (s *Store) GetCommitment(hash types.Hash) (*Commitment, bool, error) {
+	commitment := &Commitment{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(commitmentPrefix, hash), commitment)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return commitment, true, nil
+}
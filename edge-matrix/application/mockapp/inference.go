@@ -0,0 +1,275 @@
+package mockapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultErrorStatus is the status of the failed inference if not set
+	defaultErrorStatus = http.StatusInternalServerError
+
+	// streamDone is the last event of a streamed inference
+	streamDone = "[DONE]"
+
+	payloadFiller = "lorem ipsum dolor sit amet "
+)
+
+// InferenceConfig is the configuration of a fake inference endpoint of the mock app
+type InferenceConfig struct {
+	// Path is the path of the endpoint
+	Path string
+
+	// Latency is the delay before the first byte of the response
+	Latency time.Duration
+
+	// ErrorRate is the probability in [0, 1] of failing the call with ErrorStatus
+	ErrorRate   float64
+	ErrorStatus int
+
+	// PayloadSize is the size in bytes of the generated output
+	PayloadSize int
+
+	// Stream streams the output as server-sent events in Chunks chunks sent every ChunkInterval,
+	// a call is also streamed if its body sets "stream" to true
+	Stream        bool
+	Chunks        int
+	ChunkInterval time.Duration
+}
+
+// DefaultInference returns the inference endpoints served by the mock app if not set,
+// a text completion and an image generation endpoint
+func DefaultInference() []*InferenceConfig {
+	return []*InferenceConfig{
+		{
+			Path:          "/v1/completions",
+			Latency:       50 * time.Millisecond,
+			PayloadSize:   256,
+			Chunks:        8,
+			ChunkInterval: 20 * time.Millisecond,
+		},
+		{
+			Path:        "/sdapi/v1/txt2img",
+			Latency:     200 * time.Millisecond,
+			PayloadSize: 64 * 1024,
+		},
+	}
+}
+
+// inferenceResponse is the response of a non-streamed inference
+type inferenceResponse struct {
+	ID     string `json:"id"`
+	Origin string `json:"origin"`
+	Path   string `json:"path"`
+	Output string `json:"output"`
+}
+
+// inferenceChunk is an event of a streamed inference
+type inferenceChunk struct {
+	ID     string `json:"id"`
+	Index  int    `json:"index"`
+	Output string `json:"output"`
+}
+
+// errorResponse is the response of a failed inference
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// idl is the IDL generated from the endpoints of the mock app
+type idl struct {
+	Service string       `json:"service"`
+	Methods []*idlMethod `json:"methods"`
+}
+
+type idlMethod struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+// buildIdl describes the inference endpoints and the echo of the mock app
+func buildIdl(origin string, inference []*InferenceConfig) string {
+	desc := &idl{
+		Service: origin,
+	}
+
+	for _, config := range inference {
+		desc.Methods = append(desc.Methods, &idlMethod{
+			Name:   strings.Trim(config.Path, "/"),
+			Path:   config.Path,
+			Method: http.MethodPost,
+		})
+	}
+
+	desc.Methods = append(desc.Methods, &idlMethod{
+		Name:   "echo",
+		Path:   "/echo",
+		Method: http.MethodPost,
+	})
+
+	data, err := json.Marshal(desc)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// inferenceHandler serves a fake inference endpoint
+type inferenceHandler struct {
+	origin string
+	config *InferenceConfig
+
+	calls uint64
+}
+
+func newInferenceHandler(origin string, config *InferenceConfig) *inferenceHandler {
+	if config.ErrorStatus == 0 {
+		config.ErrorStatus = defaultErrorStatus
+	}
+
+	if config.Chunks <= 0 {
+		config.Chunks = 1
+	}
+
+	return &inferenceHandler{
+		origin: origin,
+		config: config,
+	}
+}
+
+func (h *inferenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	id := fmt.Sprintf("%s-%d", h.origin, atomic.AddUint64(&h.calls, 1))
+
+	if !sleepContext(r, h.config.Latency) {
+		return
+	}
+
+	//nolint:gosec
+	if h.config.ErrorRate > 0 && rand.Float64() < h.config.ErrorRate {
+		writeError(w, h.config.ErrorStatus, fmt.Sprintf("mock inference %s failed", id))
+
+		return
+	}
+
+	output := generatePayload(h.config.PayloadSize)
+
+	if !h.config.Stream && !streamRequested(body) {
+		writeJSON(w, &inferenceResponse{
+			ID:     id,
+			Origin: h.origin,
+			Path:   r.URL.Path,
+			Output: output,
+		})
+
+		return
+	}
+
+	h.stream(w, r, id, output)
+}
+
+// stream writes the output as server-sent events, one per chunk
+func (h *inferenceHandler) stream(w http.ResponseWriter, r *http.Request, id, output string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	chunkSize := (len(output) + h.config.Chunks - 1) / h.config.Chunks
+
+	for i := 0; i < h.config.Chunks; i++ {
+		if i > 0 && !sleepContext(r, h.config.ChunkInterval) {
+			return
+		}
+
+		start, end := i*chunkSize, (i+1)*chunkSize
+		if start > len(output) {
+			start = len(output)
+		}
+
+		if end > len(output) {
+			end = len(output)
+		}
+
+		data, err := json.Marshal(&inferenceChunk{
+			ID:     id,
+			Index:  i,
+			Output: output[start:end],
+		})
+		if err != nil {
+			return
+		}
+
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	fmt.Fprintf(w, "data: %s\n\n", streamDone)
+	flusher.Flush()
+}
+
+// streamRequested returns true if the body of the call is a JSON object setting "stream" to true
+func streamRequested(body []byte) bool {
+	var req struct {
+		Stream bool `json:"stream"`
+	}
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+
+	return req.Stream
+}
+
+// sleepContext waits for the given duration, and returns false if the call is canceled before
+func sleepContext(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// generatePayload returns a text of the given size
+func generatePayload(size int) string {
+	if size <= 0 {
+		return ""
+	}
+
+	return strings.Repeat(payloadFiller, size/len(payloadFiller)+1)[:size]
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: msg})
+}
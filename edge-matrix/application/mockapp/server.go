@@ -0,0 +1,198 @@
+package mockapp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultOrigin is the app origin reported by the mock app if not set
+	DefaultOrigin = "mock"
+
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Config is the configuration of the mock app
+type Config struct {
+	// Addr is the listening address of the mock app
+	Addr string
+
+	// NodeID is the node bound to the mock app on start,
+	// which is replaced by the node calling bindNode
+	NodeID string
+
+	// Origin and Idl are the app origin and the IDL reported to the edge node,
+	// the IDL is generated from the served endpoints if not set
+	Origin string
+	Idl    string
+
+	// ModelHash is the hash of the model reported to the edge node
+	ModelHash string
+
+	// Inference are the fake inference endpoints served by the mock app,
+	// DefaultInference is served if not set
+	Inference []*InferenceConfig
+}
+
+// Server is the mock app served behind the app_url of an edge node,
+// implementing the hub api called by the application endpoint, serving the fake inference endpoints
+// and echoing any other request
+type Server struct {
+	logger hclog.Logger
+	config *Config
+
+	listener net.Listener
+	server   *http.Server
+
+	nodeIDLock sync.RWMutex
+	nodeID     string
+}
+
+// dataResponse is the response of the hub api
+type dataResponse struct {
+	Data string `json:"data"`
+}
+
+// echoResponse is the response echoing the request
+type echoResponse struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body"`
+}
+
+// NewServer creates the mock app, which is started by Start
+func NewServer(logger hclog.Logger, config *Config) *Server {
+	if config.Origin == "" {
+		config.Origin = DefaultOrigin
+	}
+
+	if config.Inference == nil {
+		config.Inference = DefaultInference()
+	}
+
+	if config.Idl == "" {
+		config.Idl = buildIdl(config.Origin, config.Inference)
+	}
+
+	s := &Server{
+		logger: logger.Named("mockapp"),
+		config: config,
+		nodeID: config.NodeID,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hubapi/v1/bindNode", s.handleBindNode)
+	mux.HandleFunc("/hubapi/v1/getNode", s.handleData(s.NodeID))
+	mux.HandleFunc("/hubapi/v1/getOrigin", s.handleData(func() string { return s.config.Origin }))
+	mux.HandleFunc("/hubapi/v1/getIdl", s.handleData(func() string { return s.config.Idl }))
+	mux.HandleFunc("/hubapi/v1/getModelHash", s.handleData(func() string { return s.config.ModelHash }))
+	mux.HandleFunc("/", s.handleEcho)
+
+	for _, inference := range config.Inference {
+		mux.Handle(inference.Path, newInferenceHandler(config.Origin, inference))
+	}
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	return s
+}
+
+// Start starts listening on the configured address
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return err
+	}
+
+	s.listener = listener
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("mock app stopped", "err", err)
+		}
+	}()
+
+	s.logger.Info("mock app running", "url", s.URL())
+
+	return nil
+}
+
+// URL returns the url of the started mock app, used as the app_url of the edge node
+func (s *Server) URL() string {
+	if s.listener == nil {
+		return ""
+	}
+
+	return "http://" + s.listener.Addr().String()
+}
+
+// NodeID returns the node bound to the mock app
+func (s *Server) NodeID() string {
+	s.nodeIDLock.RLock()
+	defer s.nodeIDLock.RUnlock()
+
+	return s.nodeID
+}
+
+// Close stops the mock app
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+func (s *Server) handleBindNode(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		NodeID string `json:"nodeId"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	s.nodeIDLock.Lock()
+	s.nodeID = req.NodeID
+	s.nodeIDLock.Unlock()
+
+	s.logger.Debug("node bound", "node", req.NodeID)
+	writeJSON(w, &dataResponse{Data: req.NodeID})
+}
+
+func (s *Server) handleData(data func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &dataResponse{Data: data()})
+	}
+}
+
+func (s *Server) handleEcho(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	writeJSON(w, &echoResponse{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Body:   string(body),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
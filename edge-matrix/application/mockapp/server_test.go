@@ -0,0 +1,181 @@
+package mockapp
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/proof/agent"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HubAPI(t *testing.T) {
+	s := NewServer(hclog.NewNullLogger(), &Config{
+		Addr:      "127.0.0.1:0",
+		NodeID:    "node-1",
+		ModelHash: "model-1",
+	})
+
+	require.NoError(t, s.Start())
+
+	defer s.Close()
+
+	appAgent := agent.NewAppAgent(s.URL())
+
+	err, nodeID := appAgent.GetAppNode()
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", nodeID)
+
+	require.NoError(t, appAgent.BindAppNode("node-2"))
+
+	err, nodeID = appAgent.GetAppNode()
+	require.NoError(t, err)
+	assert.Equal(t, "node-2", nodeID)
+
+	err, origin := appAgent.GetAppOrigin()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultOrigin, origin)
+
+	err, modelHash := appAgent.GetAppModelHash()
+	require.NoError(t, err)
+	assert.Equal(t, "model-1", modelHash)
+
+	err, idl := appAgent.GetAppIdl()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"service": "mock",
+		"methods": [
+			{"name": "v1/completions", "path": "/v1/completions", "method": "POST"},
+			{"name": "sdapi/v1/txt2img", "path": "/sdapi/v1/txt2img", "method": "POST"},
+			{"name": "echo", "path": "/echo", "method": "POST"}
+		]
+	}`, idl)
+}
+
+func TestServer_Inference(t *testing.T) {
+	s := NewServer(hclog.NewNullLogger(), &Config{
+		Addr: "127.0.0.1:0",
+		Inference: []*InferenceConfig{
+			{
+				Path:        "/infer",
+				Latency:     50 * time.Millisecond,
+				PayloadSize: 100,
+			},
+			{
+				Path:          "/stream",
+				PayloadSize:   10,
+				Stream:        true,
+				Chunks:        3,
+				ChunkInterval: 10 * time.Millisecond,
+			},
+			{
+				Path:        "/fail",
+				ErrorRate:   1,
+				ErrorStatus: http.StatusServiceUnavailable,
+			},
+		},
+	})
+
+	require.NoError(t, s.Start())
+
+	defer s.Close()
+
+	post := func(t *testing.T, path, body string) *http.Response {
+		t.Helper()
+
+		resp, err := http.Post(s.URL()+path, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			resp.Body.Close()
+		})
+
+		return resp
+	}
+
+	readStream := func(t *testing.T, resp *http.Response) []string {
+		t.Helper()
+
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		events := []string{}
+		scanner := bufio.NewScanner(resp.Body)
+
+		for scanner.Scan() {
+			if data := strings.TrimPrefix(scanner.Text(), "data: "); data != scanner.Text() {
+				events = append(events, data)
+			}
+		}
+
+		require.NoError(t, scanner.Err())
+
+		return events
+	}
+
+	t.Run("latency and payload size", func(t *testing.T) {
+		start := time.Now()
+		resp := post(t, "/infer", `{}`)
+
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var res inferenceResponse
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		assert.Equal(t, "mock-1", res.ID)
+		assert.Equal(t, "/infer", res.Path)
+		assert.Len(t, res.Output, 100)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		events := readStream(t, post(t, "/stream", `{}`))
+
+		require.Len(t, events, 4)
+		assert.Equal(t, streamDone, events[3])
+
+		output := ""
+
+		for i, event := range events[:3] {
+			var chunk inferenceChunk
+
+			require.NoError(t, json.Unmarshal([]byte(event), &chunk))
+			assert.Equal(t, i, chunk.Index)
+
+			output += chunk.Output
+		}
+
+		assert.Equal(t, generatePayload(10), output)
+	})
+
+	t.Run("streaming requested by the body", func(t *testing.T) {
+		events := readStream(t, post(t, "/infer", `{"stream": true}`))
+
+		require.Len(t, events, 2)
+		assert.Equal(t, streamDone, events[1])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		resp := post(t, "/fail", `{}`)
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		var res errorResponse
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		assert.NotEmpty(t, res.Error)
+	})
+
+	t.Run("echo", func(t *testing.T) {
+		resp := post(t, "/other", `{"a":1}`)
+
+		var res echoResponse
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		assert.Equal(t, echoResponse{Method: http.MethodPost, Path: "/other", Body: `{"a":1}`}, res)
+	})
+}
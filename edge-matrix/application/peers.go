@@ -1,9 +1,11 @@
 package application
 
 import (
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"math/big"
 	"sync"
+	"time"
 )
 
 type AppPeer struct {
@@ -43,6 +45,15 @@ type AppPeer struct {
 	GpuInfo string
 	// version
 	Version string
+	// hardware capabilities, nil if not reported
+	Capabilities *capability.Capabilities
+	// apps hosted by the node, the default app first, empty for the nodes hosting a single app
+	Apps []*HostedApp
+
+	// last time a status or a liveness proof of the app was received
+	LastSeen time.Time
+	// duration after LastSeen the peer expires, DefaultAppPeerTTL if not set
+	TTL time.Duration
 }
 
 func (p *AppPeer) IsBetter(t *AppPeer) bool {
@@ -53,8 +64,47 @@ func (p *AppPeer) IsBetter(t *AppPeer) bool {
 	return p.Distance.Cmp(t.Distance) < 0
 }
 
+// HostedApps returns the apps hosted by the node,
+// which is the single app described by the status of the node if it does not report its apps
+func (p *AppPeer) HostedApps() []*HostedApp {
+	if len(p.Apps) > 0 {
+		return p.Apps
+	}
+
+	return []*HostedApp{{
+		Name:        p.Name,
+		AppOrigin:   p.AppOrigin,
+		ModelHash:   p.ModelHash,
+		GuageHeight: p.Guage_height,
+		GuageMax:    p.Guage_max,
+	}}
+}
+
+// App returns the app of the given name hosted by the node, or its default app if the name is empty
+func (p *AppPeer) App(name string) *HostedApp {
+	return FindHostedApp(p.HostedApps(), name)
+}
+
+// IsStale returns true if no status of the app was received for the given duration
+func (p *AppPeer) IsStale(now time.Time, staleDuration time.Duration) bool {
+	return now.Sub(p.LastSeen) > staleDuration
+}
+
+// IsExpired returns true if the app was last seen more than its TTL ago
+func (p *AppPeer) IsExpired(now time.Time) bool {
+	ttl := p.TTL
+	if ttl == 0 {
+		ttl = DefaultAppPeerTTL
+	}
+
+	return now.Sub(p.LastSeen) > ttl
+}
+
 type PeerMap struct {
 	sync.Map
+
+	// writeLock serializes the updates, so that an expired peer is not evicted after being refreshed
+	writeLock sync.Mutex
 }
 
 func NewPeerMap(peers []*AppPeer) *PeerMap {
@@ -65,15 +115,81 @@ func NewPeerMap(peers []*AppPeer) *PeerMap {
 	return peerMap
 }
 
+// Put stores the peers, last seen now if not set
 func (m *PeerMap) Put(peers ...*AppPeer) {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
 	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+
+		if peer.LastSeen.IsZero() {
+			peer.LastSeen = time.Now()
+		}
+
 		m.Store(peer.ID, peer)
 	}
 }
 
 // Remove removes a peer from heap if it exists
 func (m *PeerMap) Remove(peerID peer.ID) {
-	m.Delete(peerID.String())
+	m.RemoveByID(peerID.String())
+}
+
+// RemoveByID removes the peer of the given ID and returns it, if it exists
+func (m *PeerMap) RemoveByID(id string) *AppPeer {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
+	value, ok := m.LoadAndDelete(id)
+	if !ok {
+		return nil
+	}
+
+	peer, _ := value.(*AppPeer)
+
+	return peer
+}
+
+// Touch marks the peer of the given ID as last seen at the given time,
+// the peer is copied as the stored peers are shared with the readers
+func (m *PeerMap) Touch(id string, seen time.Time) bool {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
+	peer := m.Get(id)
+	if peer == nil {
+		return false
+	}
+
+	touched := *peer
+	touched.LastSeen = seen
+
+	m.Store(id, &touched)
+
+	return true
+}
+
+// EvictExpired removes the peers expired at the given time and returns them
+func (m *PeerMap) EvictExpired(now time.Time) []*AppPeer {
+	m.writeLock.Lock()
+	defer m.writeLock.Unlock()
+
+	expired := make([]*AppPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		if peer, ok := value.(*AppPeer); ok && peer.IsExpired(now) {
+			m.Delete(key)
+
+			expired = append(expired, peer)
+		}
+
+		return true
+	})
+
+	return expired
 }
 
 func (m *PeerMap) Get(id string) *AppPeer {
@@ -84,6 +200,21 @@ func (m *PeerMap) Get(id string) *AppPeer {
 	return nil
 }
 
+// Peers returns all the peers in the map
+func (m *PeerMap) Peers() []*AppPeer {
+	peers := make([]*AppPeer, 0)
+
+	m.Range(func(key, value interface{}) bool {
+		if peer, ok := value.(*AppPeer); ok {
+			peers = append(peers, peer)
+		}
+
+		return true
+	})
+
+	return peers
+}
+
 // BestPeer returns the top of heap
 func (m *PeerMap) BestPeer(skipMap map[string]bool) *AppPeer {
 	var bestPeer *AppPeer
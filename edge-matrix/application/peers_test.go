@@ -0,0 +1,96 @@
+package application
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppPeer_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	peer := &AppPeer{ID: "a", LastSeen: now.Add(-DefaultAppPeerTTL + time.Second)}
+	assert.False(t, peer.IsExpired(now))
+	assert.True(t, peer.IsExpired(now.Add(2*time.Second)))
+
+	peer = &AppPeer{ID: "b", LastSeen: now.Add(-2 * time.Minute), TTL: time.Minute}
+	assert.True(t, peer.IsExpired(now))
+
+	assert.True(t, peer.IsStale(now, time.Minute))
+	assert.False(t, peer.IsStale(now, 3*time.Minute))
+}
+
+func TestPeerMap_EvictExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	m := NewPeerMap([]*AppPeer{
+		{ID: "fresh", LastSeen: now},
+		{ID: "expired", LastSeen: now.Add(-DefaultAppPeerTTL - time.Second)},
+		{ID: "unseen"},
+	})
+
+	// the peers put without last seen time are seen at put
+	assert.False(t, m.Get("unseen").LastSeen.IsZero())
+
+	expired := m.EvictExpired(now)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, "expired", expired[0].ID)
+
+	assert.Nil(t, m.Get("expired"))
+	assert.NotNil(t, m.Get("fresh"))
+	assert.Len(t, m.Peers(), 2)
+}
+
+func TestPeerMap_Touch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	old := &AppPeer{ID: "a", LastSeen: now.Add(-DefaultAppPeerTTL - time.Second), Distance: big.NewInt(1)}
+
+	m := NewPeerMap([]*AppPeer{old})
+
+	assert.True(t, m.Touch("a", now))
+	assert.False(t, m.Touch("b", now))
+
+	// the stored peer is replaced, not updated in place
+	assert.Equal(t, now, m.Get("a").LastSeen)
+	assert.NotEqual(t, now, old.LastSeen)
+
+	assert.Empty(t, m.EvictExpired(now))
+}
+
+type mockOfflineClient struct {
+	SyncAppPeerClient
+
+	offline []*AppPeer
+}
+
+func (m *mockOfflineClient) NotifyAppOffline(appPeer *AppPeer) {
+	m.offline = append(m.offline, appPeer)
+}
+
+func TestSyncer_MarkOffline(t *testing.T) {
+	t.Parallel()
+
+	client := &mockOfflineClient{}
+	s := &syncer{
+		logger:            hclog.NewNullLogger(),
+		peerMap:           NewPeerMap([]*AppPeer{{ID: "a"}}),
+		syncAppPeerClient: client,
+	}
+
+	s.MarkOffline("b")
+	assert.Empty(t, client.offline)
+
+	s.MarkOffline("a")
+	assert.Nil(t, s.GetAppPeer("a"))
+	assert.Len(t, client.offline, 1)
+	assert.Equal(t, "a", client.offline[0].ID)
+}
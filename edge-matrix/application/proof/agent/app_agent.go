@@ -95,3 +95,22 @@ func (p *AppAgent) GetAppIdl() (err error, appOrigin string) {
 	appOrigin = response.Data
 	return
 }
+
+func (p *AppAgent) GetAppModelHash() (err error, modelHash string) {
+	err = nil
+	modelHash = ""
+	apiUrl := p.appPath + "/hubapi/v1/getModelHash"
+	jsonBytes, err := p.httpClient.SendGetRequest(apiUrl)
+	if err != nil {
+		err = errors.New("GetAppModelHash error:" + err.Error())
+		return
+	}
+	response := &GetDataResponse{}
+	err = json.Unmarshal(jsonBytes, response)
+	if err != nil {
+		err = errors.New("GetAppModelHashResponse json.Unmarshal error")
+		return
+	}
+	modelHash = response.Data
+	return
+}
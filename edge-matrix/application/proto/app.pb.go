@@ -0,0 +1,188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.19.4
+// source: application/proto/app.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HostedApp is an app hosted by an edge node, addressed by its name in the edge calls
+type HostedApp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// app name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// app origin name
+	AppOrigin string `protobuf:"bytes,2,opt,name=app_origin,json=appOrigin,proto3" json:"app_origin,omitempty"`
+	// ai model hash string
+	ModelHash string `protobuf:"bytes,3,opt,name=model_hash,json=modelHash,proto3" json:"model_hash,omitempty"`
+	// amount of slots currently occupying the app
+	GuageHeight uint64 `protobuf:"varint,4,opt,name=guage_height,json=guageHeight,proto3" json:"guage_height,omitempty"`
+	// max limit
+	GuageMax uint64 `protobuf:"varint,5,opt,name=guage_max,json=guageMax,proto3" json:"guage_max,omitempty"`
+}
+
+func (x *HostedApp) Reset() {
+	*x = HostedApp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_app_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostedApp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostedApp) ProtoMessage() {}
+
+func (x *HostedApp) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_app_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostedApp.ProtoReflect.Descriptor instead.
+func (*HostedApp) Descriptor() ([]byte, []int) {
+	return file_application_proto_app_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HostedApp) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HostedApp) GetAppOrigin() string {
+	if x != nil {
+		return x.AppOrigin
+	}
+	return ""
+}
+
+func (x *HostedApp) GetModelHash() string {
+	if x != nil {
+		return x.ModelHash
+	}
+	return ""
+}
+
+func (x *HostedApp) GetGuageHeight() uint64 {
+	if x != nil {
+		return x.GuageHeight
+	}
+	return 0
+}
+
+func (x *HostedApp) GetGuageMax() uint64 {
+	if x != nil {
+		return x.GuageMax
+	}
+	return 0
+}
+
+var File_application_proto_app_proto protoreflect.FileDescriptor
+
+var file_application_proto_app_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76,
+	0x31, 0x22, 0x9d, 0x01, 0x0a, 0x09, 0x48, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x41, 0x70, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x67, 0x75, 0x61, 0x67, 0x65, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x61,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x67, 0x75, 0x61, 0x67, 0x65, 0x4d, 0x61,
+	0x78, 0x42, 0x14, 0x5a, 0x12, 0x2f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_application_proto_app_proto_rawDescOnce sync.Once
+	file_application_proto_app_proto_rawDescData = file_application_proto_app_proto_rawDesc
+)
+
+func file_application_proto_app_proto_rawDescGZIP() []byte {
+	file_application_proto_app_proto_rawDescOnce.Do(func() {
+		file_application_proto_app_proto_rawDescData = protoimpl.X.CompressGZIP(file_application_proto_app_proto_rawDescData)
+	})
+	return file_application_proto_app_proto_rawDescData
+}
+
+var file_application_proto_app_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_application_proto_app_proto_goTypes = []interface{}{
+	(*HostedApp)(nil), // 0: v1.HostedApp
+}
+var file_application_proto_app_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_application_proto_app_proto_init() }
+func file_application_proto_app_proto_init() {
+	if File_application_proto_app_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_application_proto_app_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostedApp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_application_proto_app_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_application_proto_app_proto_goTypes,
+		DependencyIndexes: file_application_proto_app_proto_depIdxs,
+		MessageInfos:      file_application_proto_app_proto_msgTypes,
+	}.Build()
+	File_application_proto_app_proto = out.File
+	file_application_proto_app_proto_rawDesc = nil
+	file_application_proto_app_proto_goTypes = nil
+	file_application_proto_app_proto_depIdxs = nil
+}
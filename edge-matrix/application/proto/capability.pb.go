@@ -0,0 +1,628 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.19.4
+// source: application/proto/capability.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Capabilities is the hardware of an edge node, reported by its app
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// version of the capability schema
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// processor
+	Cpu *CpuCapability `protobuf:"bytes,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// graphics cards
+	Gpus []*GpuCapability `protobuf:"bytes,3,rep,name=gpus,proto3" json:"gpus,omitempty"`
+	// memory in bytes
+	Memory *MemoryCapability `protobuf:"bytes,4,opt,name=memory,proto3" json:"memory,omitempty"`
+	// disk of the node data in bytes
+	Disk *DiskCapability `protobuf:"bytes,5,opt,name=disk,proto3" json:"disk,omitempty"`
+	// accelerator runtimes, such as cuda or rocm
+	Runtimes []*RuntimeCapability `protobuf:"bytes,6,rep,name=runtimes,proto3" json:"runtimes,omitempty"`
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Capabilities) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Capabilities) GetCpu() *CpuCapability {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *Capabilities) GetGpus() []*GpuCapability {
+	if x != nil {
+		return x.Gpus
+	}
+	return nil
+}
+
+func (x *Capabilities) GetMemory() *MemoryCapability {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *Capabilities) GetDisk() *DiskCapability {
+	if x != nil {
+		return x.Disk
+	}
+	return nil
+}
+
+func (x *Capabilities) GetRuntimes() []*RuntimeCapability {
+	if x != nil {
+		return x.Runtimes
+	}
+	return nil
+}
+
+type CpuCapability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vendor string `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Model  string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// physical cores
+	Cores uint32 `protobuf:"varint,3,opt,name=cores,proto3" json:"cores,omitempty"`
+	// logical processors
+	Threads uint32   `protobuf:"varint,4,opt,name=threads,proto3" json:"threads,omitempty"`
+	Mhz     float64  `protobuf:"fixed64,5,opt,name=mhz,proto3" json:"mhz,omitempty"`
+	Flags   []string `protobuf:"bytes,6,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *CpuCapability) Reset() {
+	*x = CpuCapability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CpuCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CpuCapability) ProtoMessage() {}
+
+func (x *CpuCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CpuCapability.ProtoReflect.Descriptor instead.
+func (*CpuCapability) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CpuCapability) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *CpuCapability) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CpuCapability) GetCores() uint32 {
+	if x != nil {
+		return x.Cores
+	}
+	return 0
+}
+
+func (x *CpuCapability) GetThreads() uint32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *CpuCapability) GetMhz() float64 {
+	if x != nil {
+		return x.Mhz
+	}
+	return 0
+}
+
+func (x *CpuCapability) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type GpuCapability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vendor string `protobuf:"bytes,1,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Model  string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// video memory in bytes, zero if unknown
+	Vram   uint64 `protobuf:"varint,3,opt,name=vram,proto3" json:"vram,omitempty"`
+	Driver string `protobuf:"bytes,4,opt,name=driver,proto3" json:"driver,omitempty"`
+}
+
+func (x *GpuCapability) Reset() {
+	*x = GpuCapability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GpuCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GpuCapability) ProtoMessage() {}
+
+func (x *GpuCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GpuCapability.ProtoReflect.Descriptor instead.
+func (*GpuCapability) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GpuCapability) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *GpuCapability) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GpuCapability) GetVram() uint64 {
+	if x != nil {
+		return x.Vram
+	}
+	return 0
+}
+
+func (x *GpuCapability) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+type MemoryCapability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total uint64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Free  uint64 `protobuf:"varint,2,opt,name=free,proto3" json:"free,omitempty"`
+}
+
+func (x *MemoryCapability) Reset() {
+	*x = MemoryCapability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryCapability) ProtoMessage() {}
+
+func (x *MemoryCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryCapability.ProtoReflect.Descriptor instead.
+func (*MemoryCapability) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MemoryCapability) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *MemoryCapability) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+type DiskCapability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total uint64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Free  uint64 `protobuf:"varint,2,opt,name=free,proto3" json:"free,omitempty"`
+}
+
+func (x *DiskCapability) Reset() {
+	*x = DiskCapability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiskCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskCapability) ProtoMessage() {}
+
+func (x *DiskCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskCapability.ProtoReflect.Descriptor instead.
+func (*DiskCapability) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DiskCapability) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DiskCapability) GetFree() uint64 {
+	if x != nil {
+		return x.Free
+	}
+	return 0
+}
+
+type RuntimeCapability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *RuntimeCapability) Reset() {
+	*x = RuntimeCapability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_application_proto_capability_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuntimeCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeCapability) ProtoMessage() {}
+
+func (x *RuntimeCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_application_proto_capability_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeCapability.ProtoReflect.Descriptor instead.
+func (*RuntimeCapability) Descriptor() ([]byte, []int) {
+	return file_application_proto_capability_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RuntimeCapability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RuntimeCapability) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+var File_application_proto_capability_proto protoreflect.FileDescriptor
+
+var file_application_proto_capability_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0xfd, 0x01, 0x0a, 0x0c, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x70, 0x75, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x25, 0x0a, 0x04, 0x67, 0x70, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x70, 0x75, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x04, 0x67, 0x70, 0x75, 0x73, 0x12,
+	0x2c, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x0a,
+	0x04, 0x64, 0x69, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52,
+	0x04, 0x64, 0x69, 0x73, 0x6b, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x08,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x0d, 0x43, 0x70, 0x75,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x72, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x68, 0x7a, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x68, 0x7a, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x22, 0x69, 0x0a, 0x0d, 0x47, 0x70, 0x75, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x76, 0x72, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x76,
+	0x72, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x22, 0x3c, 0x0a, 0x10, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x65, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x65, 0x65, 0x22, 0x3a, 0x0a, 0x0e, 0x44, 0x69, 0x73,
+	0x6b, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x66, 0x72, 0x65, 0x65, 0x22, 0x41, 0x0a, 0x11, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x14, 0x5a, 0x12, 0x2f, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_application_proto_capability_proto_rawDescOnce sync.Once
+	file_application_proto_capability_proto_rawDescData = file_application_proto_capability_proto_rawDesc
+)
+
+func file_application_proto_capability_proto_rawDescGZIP() []byte {
+	file_application_proto_capability_proto_rawDescOnce.Do(func() {
+		file_application_proto_capability_proto_rawDescData = protoimpl.X.CompressGZIP(file_application_proto_capability_proto_rawDescData)
+	})
+	return file_application_proto_capability_proto_rawDescData
+}
+
+var file_application_proto_capability_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_application_proto_capability_proto_goTypes = []interface{}{
+	(*Capabilities)(nil),      // 0: v1.Capabilities
+	(*CpuCapability)(nil),     // 1: v1.CpuCapability
+	(*GpuCapability)(nil),     // 2: v1.GpuCapability
+	(*MemoryCapability)(nil),  // 3: v1.MemoryCapability
+	(*DiskCapability)(nil),    // 4: v1.DiskCapability
+	(*RuntimeCapability)(nil), // 5: v1.RuntimeCapability
+}
+var file_application_proto_capability_proto_depIdxs = []int32{
+	1, // 0: v1.Capabilities.cpu:type_name -> v1.CpuCapability
+	2, // 1: v1.Capabilities.gpus:type_name -> v1.GpuCapability
+	3, // 2: v1.Capabilities.memory:type_name -> v1.MemoryCapability
+	4, // 3: v1.Capabilities.disk:type_name -> v1.DiskCapability
+	5, // 4: v1.Capabilities.runtimes:type_name -> v1.RuntimeCapability
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_application_proto_capability_proto_init() }
+func file_application_proto_capability_proto_init() {
+	if File_application_proto_capability_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_application_proto_capability_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_capability_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CpuCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_capability_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GpuCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_capability_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemoryCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_capability_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiskCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_application_proto_capability_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeCapability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_application_proto_capability_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_application_proto_capability_proto_goTypes,
+		DependencyIndexes: file_application_proto_capability_proto_depIdxs,
+		MessageInfos:      file_application_proto_capability_proto_msgTypes,
+	}.Build()
+	File_application_proto_capability_proto = out.File
+	file_application_proto_capability_proto_rawDesc = nil
+	file_application_proto_capability_proto_goTypes = nil
+	file_application_proto_capability_proto_depIdxs = nil
+}
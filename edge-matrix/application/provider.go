@@ -0,0 +1,96 @@
+package application
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	p2phttp "github.com/libp2p/go-libp2p-http"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// providerProofPrefix separates the provider proofs from the other data signed by the provider key
+const providerProofPrefix = "edge-matrix provider"
+
+var ErrInvalidProviderProof = errors.New("provider proof not signed for the edge node")
+
+// ProviderProof binds the provider signing the edge responses of an edge node to its peer ID,
+// as the responses are signed by the validator key of the edge node and not by its network key
+type ProviderProof struct {
+	PeerID    string        `json:"peerId"`
+	Provider  types.Address `json:"provider"`
+	Signature []byte        `json:"signature"`
+}
+
+// providerProofHash returns the hash signed by the provider of the edge node of the peer ID
+func providerProofHash(peerID string) []byte {
+	return crypto.Keccak256([]byte(providerProofPrefix), []byte(peerID))
+}
+
+// NewProviderProof returns the proof of the provider key signing the edge responses of the edge node of the peer ID
+func NewProviderProof(peerID string, key *ecdsa.PrivateKey) (*ProviderProof, error) {
+	sig, err := crypto.Sign(key, providerProofHash(peerID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProviderProof{
+		PeerID:    peerID,
+		Provider:  crypto.PubKeyToAddress(&key.PublicKey),
+		Signature: sig,
+	}, nil
+}
+
+// Verify checks the proof is signed by its provider for the edge node of the peer ID
+func (p *ProviderProof) Verify(peerID string) error {
+	if p.PeerID != peerID {
+		return fmt.Errorf("%w: proof of %s", ErrInvalidProviderProof, p.PeerID)
+	}
+
+	pub, err := crypto.SigToPub(providerProofHash(peerID), p.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProviderProof, err)
+	}
+
+	if crypto.PubKeyToAddress(pub) != p.Provider {
+		return ErrInvalidProviderProof
+	}
+
+	return nil
+}
+
+// ProviderOf fetches the provider proof of the app peer through the stream authenticating its peer ID,
+// and returns the provider signing its edge responses
+func ProviderOf(clientHost host.Host, protoTag string, peerId string, timeout time.Duration) (types.Address, error) {
+	tr := &http.Transport{}
+	tr.RegisterProtocol("libp2p", p2phttp.NewTransport(clientHost, p2phttp.ProtocolOption(protocol.ID(protoTag))))
+	client := &http.Client{Transport: tr, Timeout: timeout}
+
+	res, err := client.Get(fmt.Sprintf("libp2p://%s/provider", peerId))
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+	defer res.Body.Close()
+
+	body, err := readCallResponse(res)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	proof := &ProviderProof{}
+	if err := json.Unmarshal(body, proof); err != nil {
+		return types.ZeroAddress, fmt.Errorf("%w: %v", ErrInvalidProviderProof, err)
+	}
+
+	if err := proof.Verify(peerId); err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return proof.Provider, nil
+}
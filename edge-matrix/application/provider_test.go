@@ -0,0 +1,91 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	gostream "github.com/libp2p/go-libp2p-gostream"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderProof_Verify(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	proof, err := NewProviderProof("peer0", key)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), proof.Provider)
+	assert.NoError(t, proof.Verify("peer0"))
+
+	// the proof of another edge node is not replayable
+	assert.ErrorIs(t, proof.Verify("peer1"), ErrInvalidProviderProof)
+
+	claimed := *proof
+	claimed.Provider = types.StringToAddress("0x1")
+	assert.ErrorIs(t, claimed.Verify("peer0"), ErrInvalidProviderProof)
+
+	relabeled := *proof
+	relabeled.PeerID = "peer1"
+	assert.ErrorIs(t, relabeled.Verify("peer1"), ErrInvalidProviderProof)
+}
+
+func TestProviderOf(t *testing.T) {
+	t.Parallel()
+
+	listen, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/0")
+	require.NoError(t, err)
+
+	srvHost := newHost(t, listen)
+	defer srvHost.Close()
+
+	clientHost := newHost(t, listen)
+	defer clientHost.Close()
+
+	clientHost.Peerstore().AddAddrs(srvHost.ID(), srvHost.Addrs(), peerstore.PermanentAddrTTL)
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherProof, err := NewProviderProof("other", key)
+	require.NoError(t, err)
+
+	proof, err := NewProviderProof(srvHost.ID().String(), key)
+	require.NoError(t, err)
+
+	var served atomic.Value
+	served.Store(proof)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/provider", func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := json.Marshal(served.Load())
+		w.Write(raw)
+	})
+
+	listener, err := gostream.Listen(srvHost, ProtoTagEcApp)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: mux}
+	defer server.Close()
+
+	go server.Serve(listener)
+
+	provider, err := ProviderOf(clientHost, ProtoTagEcApp, srvHost.ID().String(), 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), provider)
+
+	// the edge node serves the proof of another edge node
+	served.Store(otherProof)
+
+	_, err = ProviderOf(clientHost, ProtoTagEcApp, srvHost.ID().String(), 5*time.Second)
+	assert.ErrorIs(t, err, ErrInvalidProviderProof)
+}
@@ -84,9 +84,10 @@ func (s *subscription) Close() {
 type EventType int
 
 const (
-	EventHead  EventType = iota // New head event
-	EventReorg                  // Chain reorganization event
-	EventFork                   // Chain fork event
+	EventHead       EventType = iota // New head event
+	EventReorg                       // Chain reorganization event
+	EventFork                        // Chain fork event
+	EventAppOffline                  // Application peer offline event
 )
 
 // Event is the application event that gets passed to the listeners
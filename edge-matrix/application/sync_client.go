@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	"github.com/emc-protocol/edge-matrix/application/proto"
 	"github.com/emc-protocol/edge-matrix/miner"
 	"github.com/emc-protocol/edge-matrix/network"
@@ -53,6 +54,36 @@ func (b *syncAppPeerClient) SubscribeAppEvents() Subscription {
 	return b.stream.subscribe()
 }
 
+// NotifyAppOffline emits the offline event of the AppPeer to the subscribers
+func (m *syncAppPeerClient) NotifyAppOffline(appPeer *AppPeer) {
+	peerId, err := peer.Decode(appPeer.ID)
+	if err != nil {
+		return
+	}
+
+	event := &Event{Type: EventAppOffline}
+	event.AddNewApp(&Application{
+		Name:         appPeer.Name,
+		PeerID:       peerId,
+		StartupTime:  appPeer.Starup_time,
+		Uptime:       appPeer.Uptime,
+		GuageHeight:  appPeer.Guage_height,
+		GuageMax:     appPeer.Guage_max,
+		AppOrigin:    appPeer.AppOrigin,
+		Mac:          appPeer.Mac,
+		CpuInfo:      appPeer.CpuInfo,
+		MemInfo:      appPeer.MemInfo,
+		GpuInfo:      appPeer.GpuInfo,
+		ModelHash:    appPeer.ModelHash,
+		AveragePower: appPeer.AveragePower,
+		Version:      appPeer.Version,
+		Capabilities: appPeer.Capabilities,
+		Offline:      true,
+	})
+
+	m.stream.push(event)
+}
+
 // Start processes for SyncAppPeerClient
 func (m *syncAppPeerClient) Start(topicSubFlag bool) error {
 	// Mark client active.
@@ -118,6 +149,16 @@ func (m *syncAppPeerClient) GetPeerStatus(peerID peer.ID) (*AppPeer, error) {
 		return nil, err
 	}
 
+	caps, err := capability.FromProto(status.Capabilities)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capabilities: %w", err)
+	}
+
+	apps, err := HostedAppsFromProto(status.Apps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apps: %w", err)
+	}
+
 	return &AppPeer{
 		ID:           peerID.String(),
 		Starup_time:  status.StartupTime,
@@ -125,6 +166,8 @@ func (m *syncAppPeerClient) GetPeerStatus(peerID peer.ID) (*AppPeer, error) {
 		Guage_height: status.GuageHeight,
 		Guage_max:    status.GuageMax,
 		Distance:     m.network.GetPeerDistance(peerID),
+		Capabilities: caps,
+		Apps:         apps,
 	}, nil
 }
 
@@ -225,6 +268,20 @@ func (m *syncAppPeerClient) handleGossipAppStatusUpdate(obj interface{}, from pe
 		return
 	}
 
+	caps, err := capability.FromProto(status.Capabilities)
+	if err != nil {
+		m.logger.Warn("invalid gossiped app capabilities, skip", "ID", status.NodeId, "err", err)
+
+		return
+	}
+
+	apps, err := HostedAppsFromProto(status.Apps)
+	if err != nil {
+		m.logger.Warn("invalid gossiped hosted apps, skip", "ID", status.NodeId, "err", err)
+
+		return
+	}
+
 	ip_addr := ""
 	if status.Addr != "" {
 		ip_addr, _ = m.getMaskedIp(status.Addr)
@@ -247,6 +304,8 @@ func (m *syncAppPeerClient) handleGossipAppStatusUpdate(obj interface{}, from pe
 		ModelHash:    status.ModelHash,
 		AveragePower: status.AveragePower,
 		Version:      status.Version,
+		Capabilities: caps,
+		Apps:         apps,
 	}
 	event.AddNewApp(app)
 	m.stream.push(event) // push to jsonRpc
@@ -272,6 +331,8 @@ func (m *syncAppPeerClient) handleGossipAppStatusUpdate(obj interface{}, from pe
 		ModelHash:    status.ModelHash,
 		AveragePower: status.AveragePower,
 		Version:      status.Version,
+		Capabilities: caps,
+		Apps:         apps,
 	}
 }
 
@@ -444,6 +505,8 @@ type SyncAppPeerClient interface {
 	PublishApplicationStatus(status *proto.AppStatus)
 	// SubscribeAppEvents returns a application event subscription
 	SubscribeAppEvents() Subscription
+	// NotifyAppOffline emits the offline event of the AppPeer to the subscribers
+	NotifyAppOffline(appPeer *AppPeer)
 }
 
 func NewSyncAppPeerClient(
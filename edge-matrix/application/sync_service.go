@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	"github.com/emc-protocol/edge-matrix/application/proto"
 	"github.com/emc-protocol/edge-matrix/miner"
 	"github.com/emc-protocol/edge-matrix/network"
@@ -112,11 +113,13 @@ func (s *syncAppService) GetStatus(
 ) (*proto.AppStatus, error) {
 	application := s.applicationStore.GetEndpointApplication()
 	return &proto.AppStatus{
-		Name:        application.Name,
-		StartupTime: application.StartupTime,
-		Uptime:      application.Uptime,
-		GuageMax:    application.GuageMax,
-		GuageHeight: application.GuageHeight,
+		Name:         application.Name,
+		StartupTime:  application.StartupTime,
+		Uptime:       application.Uptime,
+		GuageMax:     application.GuageMax,
+		GuageHeight:  application.GuageHeight,
+		Capabilities: capability.ToProto(application.Capabilities),
+		Apps:         HostedAppsToProto(application.Apps),
 	}, nil
 }
 
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	appProto "github.com/emc-protocol/edge-matrix/application/proto"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/emc-protocol/edge-matrix/validators"
@@ -17,6 +18,14 @@ const (
 
 const (
 	DefaultAppStatusPublishDuration = 15 * 60 * time.Second
+
+	// DefaultAppPeerStaleDuration is the duration without status after which an app peer is probed before being called
+	DefaultAppPeerStaleDuration = DefaultAppStatusPublishDuration + time.Minute
+
+	// DefaultAppPeerTTL is the duration without status after which an app peer is evicted
+	DefaultAppPeerTTL = 3 * DefaultAppStatusPublishDuration
+
+	appPeerEvictionInterval = time.Minute
 )
 
 type blockchainStore interface {
@@ -57,6 +66,8 @@ type syncer struct {
 	applicationStore ApplicationStore
 
 	peersBlockNumMap map[peer.ID]uint64
+
+	closeCh chan struct{}
 }
 
 type ValidatorStore interface {
@@ -74,6 +85,12 @@ type Syncer interface {
 	Close() error
 	// GetAppPeer get AppPeer by PeerID
 	GetAppPeer(id string) *AppPeer
+	// GetAppPeers returns all the known AppPeers
+	GetAppPeers() []*AppPeer
+	// MarkAlive refreshes the last seen time of the AppPeer, after a liveness proof
+	MarkAlive(id string)
+	// MarkOffline evicts the AppPeer and notifies the node subscribers it is offline
+	MarkOffline(id string)
 }
 
 func NewSyncer(
@@ -94,6 +111,7 @@ func NewSyncer(
 		blockchainStore:    blockchainStore,
 		applicationStore:   applicationStore,
 		peersBlockNumMap:   make(map[peer.ID]uint64),
+		closeCh:            make(chan struct{}),
 	}
 }
 
@@ -105,6 +123,7 @@ func (s *syncer) initializePeerMap() {
 
 // Close terminates goroutine processes
 func (s *syncer) Close() error {
+	close(s.closeCh)
 	close(s.newStatusCh)
 
 	if err := s.syncAppPeerService.Close(); err != nil {
@@ -124,6 +143,7 @@ func (s *syncer) Start(topicSubFlag bool) error {
 	s.syncAppPeerService.Start()
 
 	go s.startPeerStatusUpdateProcess()
+	go s.startPeerEvictionProcess()
 	//go s.startPeerConnectionEventProcess()
 	go func() {
 		s.doPublishAppStatus()
@@ -149,6 +169,8 @@ func (s *syncer) doPublishAppStatus() {
 		NodeId:       s.applicationStore.GetEndpointApplication().PeerID.String(),
 		Uptime:       s.applicationStore.GetEndpointApplication().Uptime,
 		StartupTime:  s.applicationStore.GetEndpointApplication().StartupTime,
+		GuageHeight:  s.applicationStore.GetEndpointApplication().GuageHeight,
+		GuageMax:     s.applicationStore.GetEndpointApplication().GuageMax,
 		Relay:        "",
 		Addr:         addr,
 		AppOrigin:    s.applicationStore.GetEndpointApplication().AppOrigin,
@@ -159,6 +181,8 @@ func (s *syncer) doPublishAppStatus() {
 		ModelHash:    s.applicationStore.GetEndpointApplication().ModelHash,
 		AveragePower: s.applicationStore.GetEndpointApplication().AveragePower,
 		Version:      s.applicationStore.GetEndpointApplication().Version,
+		Capabilities: capability.ToProto(s.applicationStore.GetEndpointApplication().Capabilities),
+		Apps:         HostedAppsToProto(s.applicationStore.GetEndpointApplication().Apps),
 	})
 
 	s.logger.Debug("AppPeerStatus published ", "NodeID", s.applicationStore.GetEndpointApplication().PeerID.String(), "Addr", addr, "Mac", s.applicationStore.GetEndpointApplication().Mac)
@@ -186,11 +210,52 @@ func (s *syncer) GetAppPeer(id string) *AppPeer {
 	return s.peerMap.Get(id)
 }
 
+// GetAppPeers returns all the AppPeers in the peer map
+func (s *syncer) GetAppPeers() []*AppPeer {
+	return s.peerMap.Peers()
+}
+
+// MarkAlive refreshes the last seen time of the AppPeer
+func (s *syncer) MarkAlive(id string) {
+	s.peerMap.Touch(id, time.Now())
+}
+
+// MarkOffline evicts the AppPeer and notifies it is offline
+func (s *syncer) MarkOffline(id string) {
+	if appPeer := s.peerMap.RemoveByID(id); appPeer != nil {
+		s.notifyOffline(appPeer)
+	}
+}
+
 // removeFromPeerMap removes the peer from peer map
 func (s *syncer) removeFromPeerMap(peerID peer.ID) {
 	s.peerMap.Remove(peerID)
 }
 
+// startPeerEvictionProcess periodically evicts the expired peers,
+// which stop publishing their status without disconnecting, such as the peers gossiped by the relays
+func (s *syncer) startPeerEvictionProcess() {
+	ticker := time.NewTicker(appPeerEvictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case now := <-ticker.C:
+			for _, appPeer := range s.peerMap.EvictExpired(now) {
+				s.notifyOffline(appPeer)
+			}
+		}
+	}
+}
+
+func (s *syncer) notifyOffline(appPeer *AppPeer) {
+	s.logger.Debug("AppPeer offline", "NodeID", appPeer.ID, "LastSeen", appPeer.LastSeen)
+
+	s.syncAppPeerClient.NotifyAppOffline(appPeer)
+}
+
 // notifyNewStatusEvent emits signal to newStatusCh
 func (s *syncer) notifyNewStatusEvent() {
 	select {
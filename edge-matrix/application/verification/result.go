@@ -0,0 +1,231 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/helper/keccak"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// MaxRedundancy is the maximum number of the edge nodes an edge call is dispatched to
+const MaxRedundancy = 7
+
+var (
+	ErrInvalidRedundancy = fmt.Errorf("edge call redundancy must be between 2 and %d", MaxRedundancy)
+	ErrNoMajority        = errors.New("no majority of the edge nodes agree on the edge call response")
+	ErrInvalidResponse   = errors.New("edge response not signed by the provider of the edge node")
+	ErrUnknownResult     = errors.New("edge call verification not found")
+)
+
+// ResultHash returns the hash of the content of the edge response, regardless of its signature
+func ResultHash(resp *application.EdgeResponse) types.Hash {
+	return types.BytesToHash(keccak.Keccak256(nil, []byte(resp.RespString)))
+}
+
+// Attestation is the response of one of the edge nodes the edge call is dispatched to
+type Attestation struct {
+	PeerID string
+
+	// Provider is the address the edge node proves to sign its responses with, over the stream authenticating it
+	Provider types.Address
+
+	// Response is the signed response of the edge node, nil if it failed to respond
+	Response *application.EdgeResponse
+
+	// Error is the reason the edge node failed to respond
+	Error string
+}
+
+// ResultHash returns the hash of the content of the response, zero if the edge node failed to respond
+func (a *Attestation) ResultHash() types.Hash {
+	if a.Response == nil {
+		return types.ZeroHash
+	}
+
+	return ResultHash(a.Response)
+}
+
+// Result is the outcome of the edge call dispatched to several edge nodes running the same model
+type Result struct {
+	RequestHash types.Hash
+	Caller      types.Address
+
+	// ResultHash is the hash of the response of the majority of the edge nodes
+	ResultHash types.Hash
+	CreatedAt  uint64
+
+	// Attestations are the responses of all the edge nodes, the first one is the edge node called
+	Attestations []*Attestation
+}
+
+// Tally returns the result of the edge call agreed by the strict majority of the edge nodes it is dispatched to,
+// the edge nodes failing to respond count against the majority
+func Tally(requestHash types.Hash, caller types.Address, attestations []*Attestation) (*Result, error) {
+	counts := make(map[types.Hash]int)
+
+	for _, a := range attestations {
+		if a.Response != nil {
+			counts[a.ResultHash()]++
+		}
+	}
+
+	for hash, count := range counts {
+		if 2*count > len(attestations) {
+			return &Result{
+				RequestHash:  requestHash,
+				Caller:       caller,
+				ResultHash:   hash,
+				Attestations: attestations,
+			}, nil
+		}
+	}
+
+	return nil, ErrNoMajority
+}
+
+// Response returns the response of the majority, of the first edge node agreeing with it
+func (r *Result) Response() *application.EdgeResponse {
+	for _, a := range r.Attestations {
+		if a.Response != nil && a.ResultHash() == r.ResultHash {
+			return a.Response
+		}
+	}
+
+	return nil
+}
+
+// Dissenters returns the edge nodes responding another result than the majority.
+// The edge nodes failing to respond are not dissenters
+func (r *Result) Dissenters() []*Attestation {
+	dissenters := make([]*Attestation, 0)
+
+	for _, a := range r.Attestations {
+		if a.Response != nil && a.ResultHash() != r.ResultHash {
+			dissenters = append(dissenters, a)
+		}
+	}
+
+	return dissenters
+}
+
+func (r *Result) MarshalRLP() []byte {
+	return r.MarshalRLPTo(nil)
+}
+
+func (r *Result) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(r.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith marshals the Result to RLP with a specific fastrlp.Arena
+func (r *Result) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(r.RequestHash.Bytes()))
+	vv.Set(arena.NewBytes(r.Caller.Bytes()))
+	vv.Set(arena.NewBytes(r.ResultHash.Bytes()))
+	vv.Set(arena.NewUint(r.CreatedAt))
+
+	attestations := arena.NewArray()
+
+	for _, a := range r.Attestations {
+		av := arena.NewArray()
+		av.Set(arena.NewString(a.PeerID))
+		av.Set(arena.NewBytes(a.Provider.Bytes()))
+
+		if a.Response != nil {
+			av.Set(arena.NewCopyBytes(a.Response.MarshalRLP()))
+		} else {
+			av.Set(arena.NewNull())
+		}
+
+		av.Set(arena.NewString(a.Error))
+		attestations.Set(av)
+	}
+
+	vv.Set(attestations)
+
+	return vv
+}
+
+func (r *Result) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(r.unmarshalRLPFrom, input)
+}
+
+// unmarshalRLPFrom unmarshals a Result in RLP format
+func (r *Result) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 5 {
+		return fmt.Errorf("incorrect number of elements to decode edge call verification, expected 5 but found %d", len(elems))
+	}
+
+	if err = elems[0].GetHash(r.RequestHash[:]); err != nil {
+		return err
+	}
+
+	if err = elems[1].GetAddr(r.Caller[:]); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(r.ResultHash[:]); err != nil {
+		return err
+	}
+
+	if r.CreatedAt, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+
+	attestations, err := elems[4].GetElems()
+	if err != nil {
+		return err
+	}
+
+	r.Attestations = make([]*Attestation, len(attestations))
+
+	for i, av := range attestations {
+		fields, err := av.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) != 4 {
+			return fmt.Errorf("incorrect number of elements to decode edge call attestation, expected 4 but found %d", len(fields))
+		}
+
+		a := &Attestation{}
+
+		if a.PeerID, err = fields[0].GetString(); err != nil {
+			return err
+		}
+
+		if err = fields[1].GetAddr(a.Provider[:]); err != nil {
+			return err
+		}
+
+		raw, err := fields[2].GetBytes(nil)
+		if err != nil {
+			return err
+		}
+
+		if len(raw) > 0 {
+			a.Response = &application.EdgeResponse{}
+			if err := a.Response.UnmarshalRLP(raw); err != nil {
+				return err
+			}
+		}
+
+		if a.Error, err = fields[3].GetString(); err != nil {
+			return err
+		}
+
+		r.Attestations[i] = a
+	}
+
+	return nil
+}
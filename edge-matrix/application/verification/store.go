@@ -0,0 +1,95 @@
+package verification
+
+import (
+	"github.com/emc-protocol/edge-matrix/application/kvstore"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// key prefixes of the store
+var (
+	// result by request hash
+	resultPrefix = []byte("r")
+	// request hashes of the results by dissenting peer ID
+	dissentPrefix = []byte("d")
+)
+
+// Store keeps the results of the edge calls verified by the node, and the edge nodes disagreeing with them
+type Store struct {
+	db *kvstore.DB
+}
+
+// NewLevelDBStore opens the store in the given directory
+func NewLevelDBStore(path string) (*Store, error) {
+	db, err := kvstore.OpenLevelDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// NewMemoryStore creates the store kept in memory
+func NewMemoryStore() *Store {
+	return &Store{db: kvstore.OpenMemory()}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// peerKey returns the prefix of the dissents of the peer, peer IDs are base58 encoded so never contain the separator
+func peerKey(peerID string) []byte {
+	key := append(append([]byte{}, dissentPrefix...), peerID...)
+
+	return append(key, '/')
+}
+
+// Put stores the result and indexes its dissenters
+func (s *Store) Put(result *Result) error {
+	batch := new(leveldb.Batch)
+	batch.Put(kvstore.HashKey(resultPrefix, result.RequestHash), result.MarshalRLP())
+
+	for _, a := range result.Dissenters() {
+		batch.Put(kvstore.HashKey(peerKey(a.PeerID), result.RequestHash), []byte{})
+	}
+
+	return s.db.Write(batch)
+}
+
+// Get returns the result of the edge call of the given request hash
+func (s *Store) Get(hash types.Hash) (*Result, bool, error) {
+	result := &Result{}
+
+	ok, err := s.db.GetRLP(kvstore.HashKey(resultPrefix, hash), result)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
+// Disagreements returns the results the peer disagreed with, in the order of their request hashes
+func (s *Store) Disagreements(peerID string) ([]*Result, error) {
+	prefix := peerKey(peerID)
+	results := make([]*Result, 0)
+
+	err := s.db.Iterate(util.BytesPrefix(prefix), func(key, _ []byte) error {
+		result, ok, err := s.Get(types.BytesToHash(key[len(prefix):]))
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			results = append(results, result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
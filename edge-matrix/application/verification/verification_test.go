@@ -0,0 +1,153 @@
+package verification
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSigner = application.NewEIP155Signer(chain.AllForksEnabled.At(0), 100)
+
+func testAttestation(t *testing.T, peerID string, resp string) *Attestation {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	signed, err := testSigner.SignEdgeResp(&application.EdgeResponse{
+		RespString: base64.StdEncoding.EncodeToString([]byte(resp)),
+	}, key)
+	assert.NoError(t, err)
+
+	signed.From = crypto.PubKeyToAddress(&key.PublicKey)
+
+	return &Attestation{PeerID: peerID, Provider: signed.From, Response: signed}
+}
+
+func TestTally(t *testing.T) {
+	t.Parallel()
+
+	requestHash := types.StringToHash("0x1")
+
+	cases := []struct {
+		name       string
+		responses  []string
+		majority   string
+		dissenters []string
+	}{
+		{"unanimous", []string{"a", "a", "a"}, "a", []string{}},
+		{"dissenter", []string{"b", "a", "a"}, "a", []string{"peer0"}},
+		{"failure against majority", []string{"a", "", "b"}, "", nil},
+		{"failure within majority", []string{"a", "", "a"}, "a", []string{}},
+		{"tie", []string{"a", "a", "b", "b"}, "", nil},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			attestations := make([]*Attestation, len(c.responses))
+
+			for i, resp := range c.responses {
+				peerID := "peer" + string(rune('0'+i))
+				if resp == "" {
+					attestations[i] = &Attestation{PeerID: peerID, Error: "offline"}
+				} else {
+					attestations[i] = testAttestation(t, peerID, resp)
+				}
+			}
+
+			result, err := Tally(requestHash, types.ZeroAddress, attestations)
+			if c.majority == "" {
+				assert.ErrorIs(t, err, ErrNoMajority)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(c.majority)), result.Response().RespString)
+
+			dissenters := make([]string, 0)
+			for _, a := range result.Dissenters() {
+				dissenters = append(dissenters, a.PeerID)
+			}
+
+			assert.Equal(t, c.dissenters, dissenters)
+		})
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	verifier := NewVerifier(hclog.NewNullLogger(), testSigner, store)
+
+	// the forged response is discarded, leaving no majority
+	forged := testAttestation(t, "forged", "b")
+	forged.Response.From = types.StringToAddress("0x1")
+
+	_, err := verifier.Verify(types.StringToHash("0x1"), types.ZeroAddress, []*Attestation{
+		testAttestation(t, "peer0", "a"),
+		forged,
+		testAttestation(t, "peer1", "b"),
+	})
+	assert.ErrorIs(t, err, ErrNoMajority)
+	assert.Nil(t, forged.Response)
+	assert.Equal(t, ErrInvalidResponse.Error(), forged.Error)
+
+	// the responses relayed from another edge node, or of an edge node not proving its provider, are discarded
+	relayed := testAttestation(t, "relayed", "b")
+	relayed.Provider = types.StringToAddress("0x2")
+
+	unproven := testAttestation(t, "unproven", "b")
+	unproven.Provider = types.ZeroAddress
+
+	_, err = verifier.Verify(types.StringToHash("0x1"), types.ZeroAddress, []*Attestation{
+		testAttestation(t, "peer0", "a"),
+		relayed,
+		unproven,
+	})
+	assert.ErrorIs(t, err, ErrNoMajority)
+	assert.Nil(t, relayed.Response)
+	assert.Nil(t, unproven.Response)
+
+	caller := types.StringToAddress("0xca11e5")
+	requestHash := types.StringToHash("0x2")
+
+	result, err := verifier.Verify(requestHash, caller, []*Attestation{
+		testAttestation(t, "peer0", "a"),
+		testAttestation(t, "peer1", "b"),
+		testAttestation(t, "peer2", "a"),
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, result.CreatedAt)
+
+	stored, ok, err := store.Get(requestHash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, caller, stored.Caller)
+	assert.Equal(t, result.ResultHash, stored.ResultHash)
+	assert.Len(t, stored.Attestations, 3)
+	assert.Equal(t, result.Attestations[1].Response.From, stored.Attestations[1].Response.From)
+	assert.Equal(t, result.Attestations[1].Provider, stored.Attestations[1].Provider)
+
+	disagreements, err := store.Disagreements("peer1")
+	assert.NoError(t, err)
+	assert.Len(t, disagreements, 1)
+	assert.Equal(t, requestHash, disagreements[0].RequestHash)
+
+	disagreements, err = store.Disagreements("peer0")
+	assert.NoError(t, err)
+	assert.Len(t, disagreements, 0)
+}
@@ -0,0 +1,60 @@
+package verification
+
+import (
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Verifier checks the responses of the edge nodes an edge call is dispatched to agree,
+// and records the edge nodes disagreeing with the majority, for their penalization
+type Verifier struct {
+	logger hclog.Logger
+	signer application.Signer
+	store  *Store
+}
+
+// NewVerifier creates the verifier of the edge responses signed with the signer, keeping the results in the store
+func NewVerifier(logger hclog.Logger, signer application.Signer, store *Store) *Verifier {
+	return &Verifier{
+		logger: logger.Named("edge_call_verifier"),
+		signer: signer,
+		store:  store,
+	}
+}
+
+// Verify checks the signatures of the responses and returns the result agreed by the majority of the edge nodes.
+// The responses not signed by the provider proven by their edge node are discarded,
+// and the result is stored with its dissenters
+func (v *Verifier) Verify(requestHash types.Hash, caller types.Address, attestations []*Attestation) (*Result, error) {
+	for _, a := range attestations {
+		if a.Response == nil {
+			continue
+		}
+
+		provider, err := v.signer.Provider(a.Response)
+		if err != nil || a.Provider == types.ZeroAddress || provider != a.Provider || provider != a.Response.From {
+			a.Response = nil
+			a.Error = ErrInvalidResponse.Error()
+		}
+	}
+
+	result, err := Tally(requestHash, caller, attestations)
+	if err != nil {
+		return nil, err
+	}
+
+	result.CreatedAt = uint64(time.Now().UnixMilli())
+
+	if err := v.store.Put(result); err != nil {
+		return nil, err
+	}
+
+	for _, a := range result.Dissenters() {
+		v.logger.Warn("edge node disagreed with the majority", "RequestHash", requestHash, "PeerId", a.PeerID, "Provider", a.Response.From)
+	}
+
+	return result, nil
+}
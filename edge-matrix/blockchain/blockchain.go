@@ -32,6 +32,7 @@ var (
 	ErrInvalidParentHash    = errors.New("parent block hash is invalid")
 	ErrParentHashMismatch   = errors.New("invalid parent block hash")
 	ErrInvalidBlockSequence = errors.New("invalid block sequence")
+	ErrInvalidBaseFee       = errors.New("invalid block base fee")
 	ErrInvalidSha3Uncles    = errors.New("invalid block sha3 uncles root")
 	ErrInvalidTxRoot        = errors.New("invalid block transactions root")
 	ErrInvalidReceiptsSize  = errors.New("invalid number of receipts")
@@ -380,6 +381,12 @@ func (b *Blockchain) CalculateGasLimit(number uint64) (uint64, error) {
 	return b.calculateGasLimit(parent.GasLimit), nil
 }
 
+// CalculateBaseFee returns the base fee of the next block after parent,
+// which is zero when the dynamic fees are not active
+func (b *Blockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	return b.Config().CalculateBaseFee(parent)
+}
+
 // calculateGasLimit calculates gas limit in reference to the block gas target
 func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	// The gas limit cannot move more than 1/1024 * parentGasLimit
@@ -698,6 +705,19 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock,
 	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
+// VerifyFinalizedHeader verifies that the header is sealed (committed) and in line
+// with the locally saved parent header. Unlike VerifyFinalizedBlock, it doesn't
+// require the block body, so the transactions of the block are not executed
+func (b *Blockchain) VerifyFinalizedHeader(header *types.Header) error {
+	// Make sure the consensus layer verifies this block header
+	if err := b.consensus.VerifyHeader(header); err != nil {
+		return fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	// Make sure the header is in line with the parent header
+	return b.verifyBlockParent(&types.Block{Header: header})
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
@@ -768,6 +788,11 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 		return fmt.Errorf("invalid gas limit, %w", gasLimitErr)
 	}
 
+	// Make sure the base fee follows the parent block
+	if expected := b.CalculateBaseFee(parent); childBlock.Header.BaseFee != expected {
+		return fmt.Errorf("%w: expected %d but found %d", ErrInvalidBaseFee, expected, childBlock.Header.BaseFee)
+	}
+
 	return nil
 }
 
@@ -937,6 +962,38 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 	return nil
 }
 
+// WriteHeader writes a single header without the block body and receipts
+// to the local blockchain. It is used by the checkpoint sync, which only keeps
+// the headers of the blocks below the checkpoint.
+// It doesn't do any kind of verification, only commits the header to the DB
+func (b *Blockchain) WriteHeader(header *types.Header, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if header.Number <= b.Header().Number {
+		b.logger.Info("header already inserted", "header", header.Number, "source", source)
+
+		return nil
+	}
+
+	// Write the header to the chain
+	evnt := &Event{Source: source}
+	if err := b.writeHeaderImpl(evnt, header); err != nil {
+		return err
+	}
+
+	// update snapshot
+	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+		return err
+	}
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Debug("new header", "number", header.Number, "hash", header.Hash)
+
+	return nil
+}
+
 // WriteBlock writes a single block to the local blockchain.
 // It doesn't do any kind of verification, only commits the block to the DB
 func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
@@ -1050,7 +1107,7 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 
 	gasPrices := make([]*big.Int, len(block.Telegrams))
 	for i, transaction := range block.Telegrams {
-		gasPrices[i] = transaction.GasPrice
+		gasPrices[i] = transaction.EffectiveGasPrice(block.Header.BaseFee)
 	}
 
 	b.updateGasPriceAvg(gasPrices)
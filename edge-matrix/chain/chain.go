@@ -43,6 +43,7 @@ type Genesis struct {
 	Mixhash    types.Hash                        `json:"mixHash"`
 	Coinbase   types.Address                     `json:"coinbase"`
 	Alloc      map[types.Address]*GenesisAccount `json:"alloc,omitempty"`
+	BaseFee    uint64                            `json:"baseFeePerGas,omitempty"`
 
 	// Override
 	StateRoot types.Hash
@@ -76,6 +77,7 @@ func (g *Genesis) GenesisHeader() *types.Header {
 		//Sha3Uncles:   types.EmptyUncleHash,
 		ReceiptsRoot: types.EmptyRootHash,
 		TeleRoot:     types.EmptyRootHash,
+		BaseFee:      g.BaseFee,
 	}
 
 	// Set default values if none are passed in
@@ -112,6 +114,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		Number     *string                     `json:"number,omitempty"`
 		GasUsed    *string                     `json:"gasUsed,omitempty"`
 		ParentHash types.Hash                  `json:"parentHash"`
+		BaseFee    *string                     `json:"baseFeePerGas,omitempty"`
 	}
 
 	var enc Genesis
@@ -139,6 +142,10 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 	enc.GasUsed = types.EncodeUint64(g.GasUsed)
 	enc.ParentHash = g.ParentHash
 
+	if g.BaseFee != 0 {
+		enc.BaseFee = types.EncodeUint64(g.BaseFee)
+	}
+
 	return json.Marshal(&enc)
 }
 
@@ -156,6 +163,7 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		Number     *string                    `json:"number"`
 		GasUsed    *string                    `json:"gasUsed"`
 		ParentHash *types.Hash                `json:"parentHash"`
+		BaseFee    *string                    `json:"baseFeePerGas"`
 	}
 
 	var dec Genesis
@@ -231,6 +239,11 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		g.ParentHash = *dec.ParentHash
 	}
 
+	g.BaseFee, subErr = types.ParseUint64orHex(dec.BaseFee)
+	if subErr != nil {
+		parseError("basefee", subErr)
+	}
+
 	return err
 }
 
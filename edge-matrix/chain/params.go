@@ -13,6 +13,98 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+	BaseFee        *BaseFeeParams         `json:"baseFee,omitempty"`
+}
+
+// BaseFeeParams are the EIP-1559 dynamic fee params of the chain,
+// the base fee is active once both these params are set and the London fork is reached
+type BaseFeeParams struct {
+	// InitialBaseFee is the base fee of the first block with the dynamic fees
+	InitialBaseFee uint64 `json:"initialBaseFee"`
+
+	// ChangeDenom bounds the change of the base fee between the blocks
+	ChangeDenom uint64 `json:"changeDenom"`
+
+	// ElasticityMultiplier bounds the block gas limit relative to the gas target
+	ElasticityMultiplier uint64 `json:"elasticityMultiplier"`
+
+	// Recipient receives the base fees, which are burnt when it is not set
+	Recipient *types.Address `json:"recipient,omitempty"`
+}
+
+const (
+	DefaultInitialBaseFee       uint64 = 1000000000
+	DefaultBaseFeeChangeDenom   uint64 = 8
+	DefaultElasticityMultiplier uint64 = 2
+)
+
+// IsBaseFeeActive returns true if the base fee applies to the given block
+func (p *Params) IsBaseFeeActive(block uint64) bool {
+	return p.BaseFee != nil && p.Forks != nil && p.Forks.IsLondon(block)
+}
+
+// CalculateBaseFee returns the base fee of the block following the given parent,
+// which moves towards the gas target by at most 1/ChangeDenom per block
+func (p *Params) CalculateBaseFee(parent *types.Header) uint64 {
+	if !p.IsBaseFeeActive(parent.Number + 1) {
+		return 0
+	}
+
+	// the first block with the dynamic fees starts at the initial base fee
+	if !p.IsBaseFeeActive(parent.Number) || parent.BaseFee == 0 {
+		return p.BaseFee.InitialBaseFee
+	}
+
+	parentGasTarget := parent.GasLimit / p.BaseFee.GetElasticityMultiplier()
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		return parent.BaseFee
+	}
+
+	var gasDelta uint64
+	if parent.GasUsed > parentGasTarget {
+		gasDelta = parent.GasUsed - parentGasTarget
+	} else {
+		gasDelta = parentGasTarget - parent.GasUsed
+	}
+
+	// delta = parentBaseFee * gasDelta / parentGasTarget / changeDenom
+	delta := new(big.Int).SetUint64(parent.BaseFee)
+	delta.Mul(delta, new(big.Int).SetUint64(gasDelta))
+	delta.Div(delta, new(big.Int).SetUint64(parentGasTarget))
+	delta.Div(delta, new(big.Int).SetUint64(p.BaseFee.GetChangeDenom()))
+
+	if parent.GasUsed > parentGasTarget {
+		// the base fee increases by at least 1 when the block is above the target
+		if delta.Sign() == 0 {
+			delta.SetUint64(1)
+		}
+
+		return parent.BaseFee + delta.Uint64()
+	}
+
+	if delta.Uint64() > parent.BaseFee {
+		return 0
+	}
+
+	return parent.BaseFee - delta.Uint64()
+}
+
+// GetChangeDenom returns the base fee change denominator, or its default value
+func (b *BaseFeeParams) GetChangeDenom() uint64 {
+	if b.ChangeDenom == 0 {
+		return DefaultBaseFeeChangeDenom
+	}
+
+	return b.ChangeDenom
+}
+
+// GetElasticityMultiplier returns the elasticity multiplier, or its default value
+func (b *BaseFeeParams) GetElasticityMultiplier() uint64 {
+	if b.ElasticityMultiplier == 0 {
+		return DefaultElasticityMultiplier
+	}
+
+	return b.ElasticityMultiplier
 }
 
 func (p *Params) GetEngine() string {
@@ -37,6 +129,8 @@ type Forks struct {
 	Petersburg     *Fork `json:"petersburg,omitempty"`
 	Istanbul       *Fork `json:"istanbul,omitempty"`
 	London         *Fork `json:"london,omitempty"`
+	Shanghai       *Fork `json:"shanghai,omitempty"`
+	Cancun         *Fork `json:"cancun,omitempty"`
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
@@ -70,6 +164,14 @@ func (f *Forks) IsLondon(block uint64) bool {
 	return f.active(f.London, block)
 }
 
+func (f *Forks) IsShanghai(block uint64) bool {
+	return f.active(f.Shanghai, block)
+}
+
+func (f *Forks) IsCancun(block uint64) bool {
+	return f.active(f.Cancun, block)
+}
+
 func (f *Forks) IsEIP150(block uint64) bool {
 	return f.active(f.EIP150, block)
 }
@@ -90,6 +192,8 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Petersburg:     f.active(f.Petersburg, block),
 		Istanbul:       f.active(f.Istanbul, block),
 		London:         f.active(f.London, block),
+		Shanghai:       f.active(f.Shanghai, block),
+		Cancun:         f.active(f.Cancun, block),
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
@@ -119,6 +223,8 @@ type ForksInTime struct {
 	Petersburg,
 	Istanbul,
 	London,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
 	EIP155 bool
@@ -134,4 +240,6 @@ var AllForksEnabled = &Forks{
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
 	London:         NewFork(0),
+	Shanghai:       NewFork(0),
+	Cancun:         NewFork(0),
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"reflect"
 	"testing"
+
+	"github.com/emc-protocol/edge-matrix/types"
 )
 
 func TestParamsForks(t *testing.T) {
@@ -54,3 +56,62 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestParamsCalculateBaseFee(t *testing.T) {
+	params := &Params{
+		Forks: &Forks{
+			London: NewFork(5),
+		},
+		BaseFee: &BaseFeeParams{
+			InitialBaseFee: 1000,
+		},
+	}
+
+	cases := []struct {
+		name    string
+		parent  *types.Header
+		baseFee uint64
+	}{
+		{
+			name:    "before london",
+			parent:  &types.Header{Number: 3, GasLimit: 100},
+			baseFee: 0,
+		},
+		{
+			name:    "london activation",
+			parent:  &types.Header{Number: 4, GasLimit: 100},
+			baseFee: 1000,
+		},
+		{
+			name:    "gas used at target",
+			parent:  &types.Header{Number: 5, GasLimit: 100, GasUsed: 50, BaseFee: 1000},
+			baseFee: 1000,
+		},
+		{
+			name:    "full block",
+			parent:  &types.Header{Number: 5, GasLimit: 100, GasUsed: 100, BaseFee: 1000},
+			baseFee: 1125,
+		},
+		{
+			name:    "empty block",
+			parent:  &types.Header{Number: 5, GasLimit: 100, GasUsed: 0, BaseFee: 1000},
+			baseFee: 875,
+		},
+		{
+			name:    "minimal increase",
+			parent:  &types.Header{Number: 5, GasLimit: 100, GasUsed: 51, BaseFee: 8},
+			baseFee: 9,
+		},
+	}
+
+	for _, c := range cases {
+		if baseFee := params.CalculateBaseFee(c.parent); baseFee != c.baseFee {
+			t.Fatalf("%s: expected base fee %d but found %d", c.name, c.baseFee, baseFee)
+		}
+	}
+
+	// no base fee without the params
+	if baseFee := (&Params{Forks: AllForksEnabled}).CalculateBaseFee(&types.Header{Number: 1}); baseFee != 0 {
+		t.Fatalf("expected no base fee but found %d", baseFee)
+	}
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/emc-protocol/edge-matrix/command/app/genclient"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	appCmd := &cobra.Command{
+		Use:   "app",
+		Short: "Top level command for working with the apps served by edge nodes. Only accepts subcommands.",
+	}
+
+	registerSubcommands(appCmd)
+
+	return appCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// app gen-client
+		genclient.GetCommand(),
+	)
+}
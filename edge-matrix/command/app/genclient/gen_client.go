@@ -0,0 +1,93 @@
+package genclient
+
+import (
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/server"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	genClientCmd := &cobra.Command{
+		Use: "gen-client",
+		Short: "Generates a typed Go client for the methods of an app, " +
+			"from its IDL file or from the IDL published by the edge node serving it",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genClientCmd)
+
+	return genClientCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.idlPath,
+		idlFlag,
+		"",
+		"the path to the IDL file of the app, in JSON or Candid",
+	)
+
+	cmd.Flags().StringVar(
+		&params.peerID,
+		peerFlag,
+		"",
+		"the node ID of the edge node serving the app, whose IDL is fetched through the JSON-RPC",
+	)
+
+	cmd.Flags().StringVar(
+		&params.appName,
+		appFlag,
+		"",
+		"the name of the app hosted by the edge node, its default app if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.jsonRPCAddr,
+		command.JSONRPCFlag,
+		fmt.Sprintf("http://%s:%d", helper.LocalHostBinding, server.DefaultJSONRPCPort),
+		"the JSON-RPC address of the node fetching the IDL from the edge node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.pkg,
+		packageFlag,
+		"client",
+		"the package of the generated client",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		"client.go",
+		"the path of the generated Go file",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(idlFlag, peerFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.loadSchema(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.generateClient(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
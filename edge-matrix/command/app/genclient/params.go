@@ -0,0 +1,99 @@
+package genclient
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/emc-protocol/edge-matrix/helper/rpc"
+)
+
+const (
+	idlFlag     = "idl"
+	peerFlag    = "peer"
+	appFlag     = "app"
+	packageFlag = "package"
+	outputFlag  = "output"
+)
+
+var (
+	params = &genClientParams{}
+)
+
+var (
+	errNoIdlSource = errors.New("either the IDL file or the peer serving the app is required")
+)
+
+type genClientParams struct {
+	idlPath     string
+	peerID      string
+	appName     string
+	jsonRPCAddr string
+
+	pkg        string
+	outputPath string
+
+	schema *appidl.Schema
+}
+
+func (p *genClientParams) validateFlags() error {
+	if p.idlPath == "" && p.peerID == "" {
+		return errNoIdlSource
+	}
+
+	if !token.IsIdentifier(p.pkg) {
+		return fmt.Errorf("invalid package name %q", p.pkg)
+	}
+
+	return nil
+}
+
+// loadSchema parses the IDL file, or the IDL of the app served by the peer fetched through the JSON-RPC
+func (p *genClientParams) loadSchema() error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if p.idlPath != "" {
+		data, err = os.ReadFile(p.idlPath)
+	} else {
+		data, err = rpc.NewJsonRpcClient(p.jsonRPCAddr).GetAppIdl(p.peerID, p.appName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to load the IDL: %w", err)
+	}
+
+	if p.schema, err = appidl.Parse(data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *genClientParams) generateClient() error {
+	src, err := appidl.GenerateClient(p.schema, p.pkg)
+	if err != nil {
+		return err
+	}
+
+	return common.SaveFileSafe(p.outputPath, src, 0660)
+}
+
+func (p *genClientParams) getResult() *GenClientResult {
+	methods := make([]string, len(p.schema.Methods))
+	for i, method := range p.schema.Methods {
+		methods[i] = fmt.Sprintf("%s %s", method.Method, method.Path)
+	}
+
+	return &GenClientResult{
+		Output:  p.outputPath,
+		Package: p.pkg,
+		Format:  p.schema.Format,
+		Methods: methods,
+	}
+}
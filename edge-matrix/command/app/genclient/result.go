@@ -0,0 +1,34 @@
+package genclient
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/command/helper"
+)
+
+type GenClientResult struct {
+	Output  string   `json:"output"`
+	Package string   `json:"package"`
+	Format  string   `json:"format"`
+	Methods []string `json:"methods"`
+}
+
+func (r *GenClientResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[APP CLIENT GENERATED]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Output|%s", r.Output),
+		fmt.Sprintf("Package|%s", r.Package),
+		fmt.Sprintf("IDL format|%s", r.Format),
+		fmt.Sprintf("Methods|%d", len(r.Methods)),
+	}))
+	buffer.WriteString("\n")
+
+	for _, method := range r.Methods {
+		buffer.WriteString(fmt.Sprintf("  %s\n", method))
+	}
+
+	return buffer.String()
+}
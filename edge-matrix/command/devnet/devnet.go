@@ -0,0 +1,128 @@
+package devnet
+
+import (
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	devnetCmd := &cobra.Command{
+		Use: "devnet",
+		Short: "Runs a local devnet of validators, relay nodes and edge nodes with mock apps, " +
+			"each node as a child process on loopback",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(devnetCmd)
+
+	return devnetCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dir,
+		dirFlag,
+		"./devnet",
+		"the directory for the keys, genesis, configs and logs of the devnet, "+
+			"the existing devnet in the directory is restarted",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.numValidators,
+		validatorsFlag,
+		4,
+		"the number of validators",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.numRelays,
+		relaysFlag,
+		1,
+		"the number of relay nodes",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.numEdges,
+		edgesFlag,
+		1,
+		"the number of edge nodes, each one serving a mock app",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chainID,
+		chainIDFlag,
+		command.DefaultChainID,
+		"the ID of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blockTime,
+		blockTimeFlag,
+		1,
+		"the block time of the validators in seconds",
+	)
+
+	cmd.Flags().StringVar(
+		&params.validatorType,
+		command.IBFTValidatorTypeFlag,
+		string(validators.BLSValidatorType),
+		"the type of validators in IBFT",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initSecrets(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.startApps(); err != nil {
+		params.closeApps()
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer params.closeApps()
+
+	if err := params.generateGenesis(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	exitCh, err := params.startNodes()
+	if err != nil {
+		params.stopNodes()
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer params.stopNodes()
+
+	outputter.WriteCommandResult(params.getResult())
+
+	select {
+	case err := <-exitCh:
+		outputter.SetError(fmt.Errorf("devnet stopped: %w", err))
+	case <-common.GetTerminationSignalCh():
+	}
+}
@@ -0,0 +1,103 @@
+package devnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/stretchr/testify/require"
+)
+
+// blockNumber returns the latest block number reported by the JSON-RPC of the node
+func blockNumber(port int) (uint64, error) {
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"edge_blockNumber","params":[]}`)
+
+	res, err := http.Post(fmt.Sprintf("http://%s:%d", devnetHost, port), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	resp := &struct {
+		Result string `json:"result"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return 0, err
+	}
+
+	return hex.DecodeUint64(resp.Result)
+}
+
+// TestDevnet_Boot generates the genesis and the node configs of a devnet from its network profile,
+// and boots the validators from them until they seal blocks together
+func TestDevnet_Boot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("boots the devnet nodes")
+	}
+
+	dir := t.TempDir()
+
+	// the nodes are run by the binary of the node, as the devnet command does
+	executable := filepath.Join(dir, "edge-matrix")
+
+	output, err := exec.Command("go", "build", "-o", executable, "github.com/emc-protocol/edge-matrix").CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	p := &devnetParams{
+		dir:           dir,
+		numValidators: 2,
+		chainID:       command.DefaultChainID,
+		blockTime:     1,
+		validatorType: string(validators.BLSValidatorType),
+		executable:    executable,
+	}
+
+	require.NoError(t, p.validateFlags())
+
+	p.initNodes()
+
+	require.NoError(t, p.initSecrets())
+	require.NoError(t, p.generateGenesis())
+
+	for _, node := range p.nodes {
+		require.FileExists(t, node.configPath)
+	}
+
+	exitCh, err := p.startNodes()
+	require.NoError(t, err)
+
+	defer p.stopNodes()
+
+	// the blocks are sealed by both validators, so each one reaching the height proves they boot the same network
+	const height = 3
+
+	timeout := time.After(2 * time.Minute)
+	ticker := time.NewTicker(time.Second)
+
+	defer ticker.Stop()
+
+	for _, node := range p.nodes {
+		for {
+			number, err := blockNumber(node.profile.JSONRPCPort)
+			if err == nil && number >= height {
+				break
+			}
+
+			select {
+			case err := <-exitCh:
+				t.Fatal(err)
+			case <-timeout:
+				t.Fatalf("%s did not reach block %d, see %s", node.profile.Name, height, node.logPath)
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package devnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/mockapp"
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/command/genesis"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/secrets"
+	secretsHelper "github.com/emc-protocol/edge-matrix/secrets/helper"
+	"github.com/emc-protocol/edge-matrix/server"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	dirFlag        = "dir"
+	validatorsFlag = "validators"
+	relaysFlag     = "relays"
+	edgesFlag      = "edges"
+	chainIDFlag    = "chain-id"
+	blockTimeFlag  = "block-time"
+)
+
+const (
+	// devnetHost is the loopback host all the nodes of the devnet listen on
+	devnetHost = "127.0.0.1"
+
+	// mockAppPortOffset is the offset of the mock app port from the ports of its edge node
+	mockAppPortOffset = 5
+
+	profileFileName = "profile.json"
+
+	// stopTimeout is the time the nodes are given to shut down gracefully
+	stopTimeout = 10 * time.Second
+)
+
+var (
+	params = &devnetParams{}
+)
+
+var (
+	errNoValidators      = errors.New("devnet requires at least one validator")
+	errEdgesWithoutRelay = errors.New("edge nodes of devnet require at least one relay node")
+)
+
+type nodeKind string
+
+const (
+	validatorNode nodeKind = "validator"
+	relayNode     nodeKind = "relay"
+	edgeNode      nodeKind = "edge"
+)
+
+// devnetNode is a node of the devnet run as a child process
+type devnetNode struct {
+	kind    nodeKind
+	profile *genesis.ProfileNode
+
+	configPath string
+	logPath    string
+
+	app  *mockapp.Server
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+type devnetParams struct {
+	dir string
+
+	numValidators uint64
+	numRelays     uint64
+	numEdges      uint64
+
+	chainID       uint64
+	blockTime     uint64
+	validatorType string
+
+	executable string
+	nodes      []*devnetNode
+}
+
+func (p *devnetParams) validateFlags() error {
+	if p.numValidators == 0 {
+		return errNoValidators
+	}
+
+	if p.numEdges != 0 && p.numRelays == 0 {
+		return errEdgesWithoutRelay
+	}
+
+	if _, err := validators.ParseValidatorType(p.validatorType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *devnetParams) initRawParams() error {
+	var err error
+
+	// the nodes are run by the same binary as child processes
+	if p.executable, err = os.Executable(); err != nil {
+		return err
+	}
+
+	if p.dir, err = filepath.Abs(p.dir); err != nil {
+		return err
+	}
+
+	if err := common.CreateDirSafe(p.dir, 0750); err != nil {
+		return err
+	}
+
+	p.initNodes()
+
+	return nil
+}
+
+// initNodes declares the validators, the relay nodes and the edge nodes in this order,
+// each one listening on the ports of its index in the profile
+func (p *devnetParams) initNodes() {
+	addNodes := func(kind nodeKind, num uint64) {
+		for i := uint64(1); i <= num; i++ {
+			offset := len(p.nodes) * genesis.ProfilePortStride
+			name := fmt.Sprintf("%s-%d", kind, i)
+
+			p.nodes = append(p.nodes, &devnetNode{
+				kind: kind,
+				profile: &genesis.ProfileNode{
+					Name:            name,
+					DataDir:         filepath.Join(p.dir, name),
+					GRPCPort:        server.DefaultGRPCPort + offset,
+					JSONRPCPort:     server.DefaultJSONRPCPort + offset,
+					Libp2pPort:      network.DefaultLibp2pPort + offset,
+					EdgeLibp2pPort:  network.DefaultEdgeLibp2pPort + offset,
+					RelayLibp2pPort: network.DefaultRelayLibp2pPort + offset,
+				},
+				configPath: filepath.Join(p.dir, name+".json"),
+				logPath:    filepath.Join(p.dir, name+".log"),
+			})
+		}
+	}
+
+	addNodes(validatorNode, p.numValidators)
+	addNodes(relayNode, p.numRelays)
+	addNodes(edgeNode, p.numEdges)
+}
+
+// initSecrets generates the keys of the nodes missing them, and loads their node ids
+// and validator keys into the profile
+func (p *devnetParams) initSecrets() error {
+	for _, node := range p.nodes {
+		secretsManager, err := secretsHelper.SetupLocalSecretsManager(node.profile.DataDir)
+		if err != nil {
+			return fmt.Errorf("failed to set up secrets of %s: %w", node.profile.Name, err)
+		}
+
+		if !secretsManager.HasSecret(secrets.ValidatorKey) {
+			if _, err := secretsHelper.InitECDSAValidatorKey(secretsManager); err != nil {
+				return err
+			}
+		}
+
+		if !secretsManager.HasSecret(secrets.ValidatorBLSKey) {
+			if _, err := secretsHelper.InitBLSValidatorKey(secretsManager); err != nil {
+				return err
+			}
+		}
+
+		if !secretsManager.HasSecret(secrets.NetworkKey) {
+			if _, err := secretsHelper.InitNetworkingPrivateKey(secretsManager); err != nil {
+				return err
+			}
+		}
+
+		if node.profile.NodeID, err = secretsHelper.LoadNodeID(secretsManager); err != nil {
+			return err
+		}
+
+		if node.kind != validatorNode {
+			continue
+		}
+
+		address, err := secretsHelper.LoadValidatorAddress(secretsManager)
+		if err != nil {
+			return err
+		}
+
+		node.profile.Validator = address.String()
+		node.profile.Bootnode = true
+
+		if validators.ValidatorType(p.validatorType) == validators.BLSValidatorType {
+			blsPubKey, err := secretsHelper.LoadBLSPublicKey(secretsManager)
+			if err != nil {
+				return err
+			}
+
+			node.profile.Validator += ":" + blsPubKey
+		}
+	}
+
+	return nil
+}
+
+// startApps starts the mock app behind each edge node
+func (p *devnetParams) startApps() error {
+	for _, node := range p.nodes {
+		if node.kind != edgeNode {
+			continue
+		}
+
+		node.app = mockapp.NewServer(hclog.NewNullLogger(), &mockapp.Config{
+			Addr:   fmt.Sprintf("%s:%d", devnetHost, node.profile.GRPCPort+mockAppPortOffset),
+			NodeID: node.profile.NodeID,
+		})
+
+		if err := node.app.Start(); err != nil {
+			return fmt.Errorf("failed to start mock app of %s: %w", node.profile.Name, err)
+		}
+
+		node.profile.RunningMode = string(server.RunningModeEdge)
+		node.profile.RelayOn = true
+		node.profile.AppName = mockapp.DefaultOrigin
+		node.profile.AppURL = node.app.URL()
+	}
+
+	return nil
+}
+
+// closeApps stops the started mock apps
+func (p *devnetParams) closeApps() {
+	for _, node := range p.nodes {
+		if node.app != nil {
+			_ = node.app.Close()
+		}
+	}
+}
+
+// generateGenesis writes the profile of the devnet, and generates the genesis and the node configs from it,
+// the existing genesis is reused to restart the devnet
+func (p *devnetParams) generateGenesis() error {
+	genesisPath := filepath.Join(p.dir, command.DefaultGenesisFileName)
+	if _, err := os.Stat(genesisPath); err == nil {
+		return nil
+	}
+
+	profile := &genesis.NetworkProfile{
+		Name:          "devnet",
+		ChainID:       p.chainID,
+		Host:          devnetHost,
+		ValidatorType: p.validatorType,
+		BlockTime:     p.blockTime,
+	}
+
+	for _, node := range p.nodes {
+		profile.Nodes = append(profile.Nodes, node.profile)
+
+		if node.kind == relayNode {
+			profile.RelayBootnodes = append(
+				profile.RelayBootnodes,
+				fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", devnetHost, node.profile.RelayLibp2pPort, node.profile.NodeID),
+			)
+		}
+	}
+
+	data, err := json.MarshalIndent(profile, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	profilePath := filepath.Join(p.dir, profileFileName)
+	if err := common.SaveFileSafe(profilePath, data, 0660); err != nil {
+		return err
+	}
+
+	//nolint:gosec
+	output, err := exec.Command(
+		p.executable,
+		"genesis",
+		"--profile", profilePath,
+		"--dir", genesisPath,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to generate genesis: %w\n%s", err, output)
+	}
+
+	return nil
+}
+
+// startNodes starts each node as a child process logging to its own file,
+// and returns the channel notified when any of them exits
+func (p *devnetParams) startNodes() (<-chan error, error) {
+	exitCh := make(chan error, len(p.nodes))
+
+	for _, node := range p.nodes {
+		logFile, err := os.OpenFile(node.logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0660)
+		if err != nil {
+			return nil, err
+		}
+
+		//nolint:gosec
+		node.cmd = exec.Command(p.executable, "server", "--config", node.configPath)
+		node.cmd.Stdout = logFile
+		node.cmd.Stderr = logFile
+		node.done = make(chan struct{})
+
+		if err := node.cmd.Start(); err != nil {
+			logFile.Close()
+
+			return nil, fmt.Errorf("failed to start %s: %w", node.profile.Name, err)
+		}
+
+		go func(node *devnetNode) {
+			err := node.cmd.Wait()
+			logFile.Close()
+			close(node.done)
+
+			exitCh <- fmt.Errorf("%s exited: %v, see %s", node.profile.Name, err, node.logPath)
+		}(node)
+	}
+
+	return exitCh, nil
+}
+
+// stopNodes interrupts the running nodes, and kills the ones still running after the timeout
+func (p *devnetParams) stopNodes() {
+	for _, node := range p.nodes {
+		if node.done != nil {
+			_ = node.cmd.Process.Signal(syscall.SIGINT)
+		}
+	}
+
+	timeout := time.After(stopTimeout)
+
+	for _, node := range p.nodes {
+		if node.done == nil {
+			continue
+		}
+
+		select {
+		case <-node.done:
+		case <-timeout:
+			_ = node.cmd.Process.Kill()
+		}
+	}
+}
+
+func (p *devnetParams) getResult() command.CommandResult {
+	result := &DevnetResult{
+		Dir: p.dir,
+	}
+
+	for _, node := range p.nodes {
+		res := &DevnetNodeResult{
+			Name:   node.profile.Name,
+			Kind:   string(node.kind),
+			NodeID: node.profile.NodeID,
+			Log:    node.logPath,
+		}
+
+		// the edge nodes serve the app instead of the JSON-RPC
+		if node.app != nil {
+			res.AppURL = node.app.URL()
+		} else {
+			res.JSONRPC = fmt.Sprintf("http://%s:%d", devnetHost, node.profile.JSONRPCPort)
+			res.WS = fmt.Sprintf("ws://%s:%d/edge_ws", devnetHost, node.profile.JSONRPCPort)
+		}
+
+		result.Nodes = append(result.Nodes, res)
+	}
+
+	return result
+}
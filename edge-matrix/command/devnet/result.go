@@ -0,0 +1,59 @@
+package devnet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/command/helper"
+)
+
+type DevnetNodeResult struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	NodeID  string `json:"node_id"`
+	JSONRPC string `json:"jsonrpc,omitempty"`
+	WS      string `json:"ws,omitempty"`
+	AppURL  string `json:"app_url,omitempty"`
+	Log     string `json:"log"`
+}
+
+type DevnetResult struct {
+	Dir   string              `json:"dir"`
+	Nodes []*DevnetNodeResult `json:"nodes"`
+}
+
+func (r *DevnetResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DEVNET RUNNING]\n")
+	buffer.WriteString(fmt.Sprintf("Data written to %s\n", r.Dir))
+
+	for _, node := range r.Nodes {
+		buffer.WriteString(fmt.Sprintf("\n[%s]\n", node.Name))
+
+		vals := []string{
+			fmt.Sprintf("Kind|%s", node.Kind),
+			fmt.Sprintf("Node ID|%s", node.NodeID),
+		}
+
+		if node.JSONRPC != "" {
+			vals = append(vals,
+				fmt.Sprintf("JSON-RPC|%s", node.JSONRPC),
+				fmt.Sprintf("WebSocket|%s", node.WS),
+			)
+		}
+
+		if node.AppURL != "" {
+			vals = append(vals, fmt.Sprintf("App URL|%s", node.AppURL))
+		}
+
+		vals = append(vals, fmt.Sprintf("Log|%s", node.Log))
+
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n")
+	}
+
+	buffer.WriteString("\nPress Ctrl+C to stop the devnet\n")
+
+	return buffer.String()
+}
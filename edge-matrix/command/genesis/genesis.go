@@ -72,6 +72,28 @@ func setFlags(cmd *cobra.Command) {
 		"the maximum amount of gas used by all transactions in a block",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.baseFee,
+		baseFeeFlag,
+		0,
+		"the initial EIP-1559 base fee per gas, the dynamic fees are disabled if zero",
+	)
+
+	cmd.Flags().StringVar(
+		&params.baseFeeRecipientRaw,
+		baseFeeRecipientFlag,
+		"",
+		"the address receiving the base fees, which are burnt if not set",
+	)
+
+	cmd.Flags().StringVar(
+		&params.profilePath,
+		profileFlag,
+		"",
+		"the network profile (json or yaml) declaring the validators, initial stakes, bootnodes and ports "+
+			"of the nodes, whose config files are written next to the genesis",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.bootnodes,
 		command.BootnodeFlag,
@@ -3,6 +3,7 @@ package genesis
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/emc-protocol/edge-matrix/chain"
@@ -19,16 +20,19 @@ import (
 )
 
 const (
-	dirFlag           = "dir"
-	nameFlag          = "name"
-	premineFlag       = "premine"
-	chainIDFlag       = "chain-id"
-	epochSizeFlag     = "epoch-size"
-	epochRewardFlag   = "epoch-reward"
-	blockGasLimitFlag = "block-gas-limit"
-	posFlag           = "pos"
-	minValidatorCount = "min-validator-count"
-	maxValidatorCount = "max-validator-count"
+	dirFlag              = "dir"
+	nameFlag             = "name"
+	premineFlag          = "premine"
+	chainIDFlag          = "chain-id"
+	epochSizeFlag        = "epoch-size"
+	epochRewardFlag      = "epoch-reward"
+	blockGasLimitFlag    = "block-gas-limit"
+	baseFeeFlag          = "base-fee"
+	baseFeeRecipientFlag = "base-fee-recipient"
+	profileFlag          = "profile"
+	posFlag              = "pos"
+	minValidatorCount    = "min-validator-count"
+	maxValidatorCount    = "max-validator-count"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -44,6 +48,8 @@ var (
 	errValidatorsNotSpecified = errors.New("validator information not specified")
 	errUnsupportedConsensus   = errors.New("specified consensusRaw not supported")
 	errInvalidEpochSize       = errors.New("epoch size must be greater than 1")
+
+	errBaseFeeRecipientWithoutBaseFee = errors.New("base fee recipient requires the base fee")
 )
 
 type genesisParams struct {
@@ -63,6 +69,19 @@ type genesisParams struct {
 	blockGasLimit uint64
 	isPos         bool
 
+	baseFee             uint64
+	baseFeeRecipientRaw string
+	baseFeeRecipient    *types.Address
+
+	// network profile
+	profilePath       string
+	profile           *NetworkProfile
+	baseBootnodes     []string
+	relaynodes        []string
+	stakedBalances    map[types.Address]*big.Int
+	reservedAddresses []types.Address
+	nodeConfigPaths   []string
+
 	minNumValidators uint64
 	maxNumValidators uint64
 
@@ -87,6 +106,13 @@ type genesisParams struct {
 }
 
 func (p *genesisParams) validateFlags() error {
+	// The network profile overrides the flags it declares
+	if p.profilePath != "" {
+		if err := p.initProfileParams(); err != nil {
+			return err
+		}
+	}
+
 	// Check if the consensusRaw is supported
 	if !server.ConsensusSupported(p.consensusRaw) {
 		return errUnsupportedConsensus
@@ -117,6 +143,19 @@ func (p *genesisParams) validateFlags() error {
 		return err
 	}
 
+	if p.baseFeeRecipientRaw != "" {
+		if p.baseFee == 0 {
+			return errBaseFeeRecipientWithoutBaseFee
+		}
+
+		recipient := types.Address{}
+		if err := recipient.UnmarshalText([]byte(p.baseFeeRecipientRaw)); err != nil {
+			return fmt.Errorf("invalid base fee recipient: %w", err)
+		}
+
+		p.baseFeeRecipient = &recipient
+	}
+
 	return nil
 }
 
@@ -133,7 +172,8 @@ func (p *genesisParams) areValidatorsSetByPrefix() bool {
 }
 
 func (p *genesisParams) getRequiredFlags() []string {
-	if p.isIBFTConsensus() {
+	// the bootnodes are declared by the network profile
+	if p.isIBFTConsensus() && p.profile == nil {
 		return []string{
 			command.BootnodeFlag,
 		}
@@ -297,7 +337,15 @@ func (p *genesisParams) generateGenesis() error {
 		return err
 	}
 
-	return nil
+	if p.profile == nil {
+		return nil
+	}
+
+	if err := p.writeNodeConfigs(); err != nil {
+		return err
+	}
+
+	return p.verifyNetwork()
 }
 
 func (p *genesisParams) initGenesisConfig() error {
@@ -315,7 +363,19 @@ func (p *genesisParams) initGenesisConfig() error {
 			Forks:   chain.AllForksEnabled,
 			Engine:  p.consensusEngineConfig,
 		},
-		Bootnodes: p.bootnodes,
+		Bootnodes:     p.bootnodes,
+		BaseBootnodes: p.baseBootnodes,
+		Relaynodes:    p.relaynodes,
+	}
+
+	// the dynamic fees start at the initial base fee from the first block
+	if p.baseFee != 0 {
+		chainConfig.Params.BaseFee = &chain.BaseFeeParams{
+			InitialBaseFee:       p.baseFee,
+			ChangeDenom:          chain.DefaultBaseFeeChangeDenom,
+			ElasticityMultiplier: chain.DefaultElasticityMultiplier,
+			Recipient:            p.baseFeeRecipient,
+		}
 	}
 
 	// Predeploy staking smart contract if needed
@@ -339,6 +399,12 @@ func (p *genesisParams) initGenesisConfig() error {
 		}
 	}
 
+	for _, addr := range p.reservedAddresses {
+		if _, ok := chainConfig.Genesis.Alloc[addr]; ok {
+			return fmt.Errorf("reserved precompile %s can't be allocated", addr)
+		}
+	}
+
 	p.genesisConfig = chainConfig
 
 	return nil
@@ -356,6 +422,7 @@ func (p *genesisParams) predeployStakingSC() (*chain.GenesisAccount, error) {
 		stakingHelper.PredeployParams{
 			MinValidatorCount: p.minNumValidators,
 			MaxValidatorCount: p.maxNumValidators,
+			StakedBalances:    p.stakedBalances,
 		})
 	if predeployErr != nil {
 		return nil, predeployErr
@@ -365,7 +432,13 @@ func (p *genesisParams) predeployStakingSC() (*chain.GenesisAccount, error) {
 }
 
 func (p *genesisParams) getResult() command.CommandResult {
+	message := fmt.Sprintf("Genesis written to %s\n", p.genesisPath)
+
+	for _, path := range p.nodeConfigPaths {
+		message += fmt.Sprintf("Node config written to %s\n", path)
+	}
+
 	return &GenesisResult{
-		Message: fmt.Sprintf("Genesis written to %s\n", p.genesisPath),
+		Message: message,
 	}
 }
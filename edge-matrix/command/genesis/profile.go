@@ -0,0 +1,491 @@
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/command/server/config"
+	"github.com/emc-protocol/edge-matrix/consensus/ibft/signer"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/contracts/staking"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/emc-protocol/edge-matrix/network"
+	networkCommon "github.com/emc-protocol/edge-matrix/network/common"
+	"github.com/emc-protocol/edge-matrix/server"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultProfileHost is the host the nodes of the profile listen on if not set
+	defaultProfileHost = "127.0.0.1"
+
+	// ProfilePortStride is the offset between the default ports of the consecutive nodes
+	ProfilePortStride = 100
+)
+
+var (
+	errProfileNoNodes          = errors.New("network profile has no nodes")
+	errProfileNoValidators     = errors.New("network profile has no validator nodes")
+	errProfileNoBootnodes      = errors.New("network profile has no bootnode")
+	errProfileNoRelayBootnodes = errors.New("edge nodes of network profile require relay bootnodes")
+	errProfileStakeWithoutPoS  = errors.New("initial stake of network profile requires PoS")
+)
+
+// builtinPrecompiles are the addresses of the precompiled contracts,
+// which are always reserved and can't be allocated in the network profile
+var builtinPrecompiles = []types.Address{
+	types.StringToAddress("1"),
+	types.StringToAddress("2"),
+	types.StringToAddress("3"),
+	types.StringToAddress("4"),
+	types.StringToAddress("5"),
+	types.StringToAddress("6"),
+	types.StringToAddress("7"),
+	types.StringToAddress("8"),
+	types.StringToAddress("9"),
+	contracts.NativeTransferPrecompile,
+	contracts.ConsolePrecompile,
+	contracts.EdgeSubscribeRegisterPrecompile,
+	contracts.EdgeCallPrecompile,
+	contracts.EdgeMeteringPrecompile,
+	contracts.EdgePaymentChannelPrecompile,
+	contracts.EdgeRtcSubjectPrecompile,
+}
+
+// NetworkProfile declares an edge network, from which the genesis
+// and the config files of its nodes are generated
+type NetworkProfile struct {
+	Name          string `json:"name" yaml:"name"`
+	ChainID       uint64 `json:"chain_id" yaml:"chain_id"`
+	Host          string `json:"host" yaml:"host"`
+	ValidatorType string `json:"validator_type" yaml:"validator_type"`
+	PoS           bool   `json:"pos" yaml:"pos"`
+	EpochSize     uint64 `json:"epoch_size" yaml:"epoch_size"`
+	BlockGasLimit uint64 `json:"block_gas_limit" yaml:"block_gas_limit"`
+	BlockTime     uint64 `json:"block_time_s" yaml:"block_time_s"`
+	BaseFee       uint64 `json:"base_fee" yaml:"base_fee"`
+
+	Premine        []string `json:"premine" yaml:"premine"`
+	RelayBootnodes []string `json:"relay_bootnodes" yaml:"relay_bootnodes"`
+
+	// ReservedPrecompiles are the addresses kept free of allocations
+	// in addition to the built-in precompiles
+	ReservedPrecompiles []string `json:"reserved_precompiles" yaml:"reserved_precompiles"`
+
+	Nodes []*ProfileNode `json:"nodes" yaml:"nodes"`
+}
+
+// ProfileNode declares a node of the network profile
+type ProfileNode struct {
+	Name        string `json:"name" yaml:"name"`
+	NodeID      string `json:"node_id" yaml:"node_id"`
+	Validator   string `json:"validator" yaml:"validator"`
+	Stake       string `json:"stake" yaml:"stake"`
+	Bootnode    bool   `json:"bootnode" yaml:"bootnode"`
+	RunningMode string `json:"running_mode" yaml:"running_mode"`
+	RelayOn     bool   `json:"relay_on" yaml:"relay_on"`
+	DataDir     string `json:"data_dir" yaml:"data_dir"`
+	AppName     string `json:"app_name" yaml:"app_name"`
+	AppURL      string `json:"app_url" yaml:"app_url"`
+
+	GRPCPort        int `json:"grpc_port" yaml:"grpc_port"`
+	JSONRPCPort     int `json:"jsonrpc_port" yaml:"jsonrpc_port"`
+	Libp2pPort      int `json:"libp2p_port" yaml:"libp2p_port"`
+	EdgeLibp2pPort  int `json:"edge_libp2p_port" yaml:"edge_libp2p_port"`
+	RelayLibp2pPort int `json:"relay_libp2p_port" yaml:"relay_libp2p_port"`
+
+	validator validators.Validator
+}
+
+// readNetworkProfile reads the network profile from the json or yaml file
+func readNetworkProfile(path string) (*NetworkProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmarshalFunc func([]byte, interface{}) error
+
+	switch {
+	case strings.HasSuffix(path, ".json"):
+		unmarshalFunc = json.Unmarshal
+	case strings.HasSuffix(path, ".yaml"), strings.HasSuffix(path, ".yml"):
+		unmarshalFunc = yaml.Unmarshal
+	default:
+		return nil, fmt.Errorf("suffix of %s is neither json, yaml nor yml", path)
+	}
+
+	profile := &NetworkProfile{}
+	if err := unmarshalFunc(data, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// setDefaults fills the unset host, names, data directories and ports of the nodes
+func (np *NetworkProfile) setDefaults(baseDir string) {
+	if np.Host == "" {
+		np.Host = defaultProfileHost
+	}
+
+	for i, node := range np.Nodes {
+		offset := i * ProfilePortStride
+
+		if node.Name == "" {
+			node.Name = fmt.Sprintf("node-%d", i+1)
+		}
+
+		if node.DataDir == "" {
+			node.DataDir = filepath.Join(baseDir, node.Name)
+		}
+
+		if node.RunningMode == "" {
+			node.RunningMode = config.DefaultRunningMode
+		}
+
+		setDefaultPort(&node.GRPCPort, server.DefaultGRPCPort+offset)
+		setDefaultPort(&node.JSONRPCPort, server.DefaultJSONRPCPort+offset)
+		setDefaultPort(&node.Libp2pPort, network.DefaultLibp2pPort+offset)
+		setDefaultPort(&node.EdgeLibp2pPort, network.DefaultEdgeLibp2pPort+offset)
+		setDefaultPort(&node.RelayLibp2pPort, network.DefaultRelayLibp2pPort+offset)
+	}
+}
+
+func setDefaultPort(port *int, defaultPort int) {
+	if *port == 0 {
+		*port = defaultPort
+	}
+}
+
+// validate checks the nodes of the network profile can run side by side on the host
+func (np *NetworkProfile) validate(validatorType validators.ValidatorType) error {
+	if len(np.Nodes) == 0 {
+		return errProfileNoNodes
+	}
+
+	var (
+		names    = map[string]bool{}
+		nodeIDs  = map[string]bool{}
+		dataDirs = map[string]bool{}
+		ports    = map[int]string{}
+
+		hasValidator, hasBootnode, hasEdgeNode bool
+	)
+
+	for _, node := range np.Nodes {
+		if names[node.Name] {
+			return fmt.Errorf("duplicate node name %s", node.Name)
+		}
+
+		names[node.Name] = true
+
+		if dataDirs[node.DataDir] {
+			return fmt.Errorf("duplicate data directory %s of node %s", node.DataDir, node.Name)
+		}
+
+		dataDirs[node.DataDir] = true
+
+		for _, port := range []int{
+			node.GRPCPort,
+			node.JSONRPCPort,
+			node.Libp2pPort,
+			node.EdgeLibp2pPort,
+			node.RelayLibp2pPort,
+		} {
+			if owner, ok := ports[port]; ok {
+				return fmt.Errorf("port %d of node %s is already used by node %s", port, node.Name, owner)
+			}
+
+			ports[port] = node.Name
+		}
+
+		if node.NodeID != "" {
+			if _, err := peer.Decode(node.NodeID); err != nil {
+				return fmt.Errorf("invalid node id of node %s: %w", node.Name, err)
+			}
+
+			if nodeIDs[node.NodeID] {
+				return fmt.Errorf("duplicate node id %s", node.NodeID)
+			}
+
+			nodeIDs[node.NodeID] = true
+		}
+
+		switch server.RunningModeType(node.RunningMode) {
+		case server.RunningModeFull:
+		case server.RunningModeEdge:
+			hasEdgeNode = true
+		case server.RunningModeLight:
+		default:
+			return fmt.Errorf("invalid running mode %s of node %s", node.RunningMode, node.Name)
+		}
+
+		if node.Bootnode {
+			if node.NodeID == "" {
+				return fmt.Errorf("bootnode %s requires the node id", node.Name)
+			}
+
+			if node.RunningMode != config.DefaultRunningMode {
+				return fmt.Errorf("bootnode %s must run in %s mode", node.Name, config.DefaultRunningMode)
+			}
+
+			hasBootnode = true
+		}
+
+		if node.Stake != "" && !np.PoS {
+			return errProfileStakeWithoutPoS
+		}
+
+		if node.Validator == "" {
+			if node.Stake != "" {
+				return fmt.Errorf("stake of node %s requires the validator", node.Name)
+			}
+
+			continue
+		}
+
+		if node.RunningMode != config.DefaultRunningMode {
+			return fmt.Errorf("validator %s must run in %s mode", node.Name, config.DefaultRunningMode)
+		}
+
+		validator, err := validators.ParseValidator(validatorType, node.Validator)
+		if err != nil {
+			return fmt.Errorf("invalid validator of node %s: %w", node.Name, err)
+		}
+
+		node.validator = validator
+		hasValidator = true
+	}
+
+	if !hasValidator {
+		return errProfileNoValidators
+	}
+
+	if !hasBootnode {
+		return errProfileNoBootnodes
+	}
+
+	if hasEdgeNode && len(np.RelayBootnodes) == 0 {
+		return errProfileNoRelayBootnodes
+	}
+
+	for _, relaynode := range np.RelayBootnodes {
+		if _, err := networkCommon.StringToAddrInfo(relaynode); err != nil {
+			return fmt.Errorf("invalid relay bootnode %s: %w", relaynode, err)
+		}
+	}
+
+	return nil
+}
+
+// reservedAddresses returns the built-in precompiles and the reserved precompiles of the profile
+func (np *NetworkProfile) reservedAddresses() ([]types.Address, error) {
+	reserved := append([]types.Address{}, builtinPrecompiles...)
+
+	for _, raw := range np.ReservedPrecompiles {
+		addr := types.Address{}
+		if err := addr.UnmarshalText([]byte(raw)); err != nil {
+			return nil, fmt.Errorf("invalid reserved precompile %s: %w", raw, err)
+		}
+
+		reserved = append(reserved, addr)
+	}
+
+	return reserved, nil
+}
+
+// bootnodeAddr returns the multiaddr of the node listening on the given port
+func (np *NetworkProfile) bootnodeAddr(node *ProfileNode, port int) string {
+	return fmt.Sprintf("/ip4/%s/tcp/%d/p2p/%s", np.Host, port, node.NodeID)
+}
+
+// initProfileParams loads the network profile and overrides the genesis params with it
+func (p *genesisParams) initProfileParams() error {
+	profile, err := readNetworkProfile(p.profilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read network profile: %w", err)
+	}
+
+	if profile.Name != "" {
+		p.name = profile.Name
+	}
+
+	if profile.ChainID != 0 {
+		p.chainID = profile.ChainID
+	}
+
+	if profile.ValidatorType != "" {
+		p.rawIBFTValidatorType = profile.ValidatorType
+	}
+
+	if profile.EpochSize != 0 {
+		p.epochSize = profile.EpochSize
+	}
+
+	if profile.BlockGasLimit != 0 {
+		p.blockGasLimit = profile.BlockGasLimit
+	}
+
+	if profile.BaseFee != 0 {
+		p.baseFee = profile.BaseFee
+	}
+
+	validatorType, err := validators.ParseValidatorType(p.rawIBFTValidatorType)
+	if err != nil {
+		return err
+	}
+
+	profile.setDefaults(filepath.Dir(p.genesisPath))
+
+	if err := profile.validate(validatorType); err != nil {
+		return err
+	}
+
+	if p.reservedAddresses, err = profile.reservedAddresses(); err != nil {
+		return err
+	}
+
+	p.consensusRaw = string(server.IBFTConsensus)
+	p.isPos = profile.PoS
+	p.premine = append(p.premine, profile.Premine...)
+	p.relaynodes = profile.RelayBootnodes
+	p.stakedBalances = map[types.Address]*big.Int{}
+
+	for _, node := range profile.Nodes {
+		if node.Bootnode {
+			p.bootnodes = append(p.bootnodes, profile.bootnodeAddr(node, node.EdgeLibp2pPort))
+			p.baseBootnodes = append(p.baseBootnodes, profile.bootnodeAddr(node, node.Libp2pPort))
+		}
+
+		if node.validator == nil {
+			continue
+		}
+
+		p.ibftValidatorsRaw = append(p.ibftValidatorsRaw, node.Validator)
+
+		if node.Stake != "" {
+			stake, err := types.ParseUint256orHex(&node.Stake)
+			if err != nil {
+				return fmt.Errorf("invalid stake of node %s: %w", node.Name, err)
+			}
+
+			p.stakedBalances[node.validator.Addr()] = stake
+		}
+	}
+
+	p.profile = profile
+
+	return nil
+}
+
+// writeNodeConfigs writes the server config file of each node of the profile
+// next to the genesis file
+func (p *genesisParams) writeNodeConfigs() error {
+	baseDir := filepath.Dir(p.genesisPath)
+
+	for _, node := range p.profile.Nodes {
+		nodeConfig := config.DefaultConfig()
+
+		nodeConfig.GenesisPath = p.genesisPath
+		nodeConfig.DataDir = node.DataDir
+		nodeConfig.GRPCAddr = fmt.Sprintf("%s:%d", p.profile.Host, node.GRPCPort)
+		nodeConfig.JSONRPCAddr = fmt.Sprintf("%s:%d", p.profile.Host, node.JSONRPCPort)
+		nodeConfig.Network.Libp2pAddr = fmt.Sprintf("%s:%d", p.profile.Host, node.Libp2pPort)
+		nodeConfig.Network.EdgeLibp2pAddr = fmt.Sprintf("%s:%d", p.profile.Host, node.EdgeLibp2pPort)
+		nodeConfig.Network.RelayLibp2pAddr = fmt.Sprintf("%s:%d", p.profile.Host, node.RelayLibp2pPort)
+		nodeConfig.ShouldSeal = node.validator != nil
+		nodeConfig.RunningMode = node.RunningMode
+		nodeConfig.RelayOn = node.RelayOn
+		nodeConfig.AppName = node.AppName
+		nodeConfig.AppUrl = node.AppURL
+
+		if p.profile.BlockTime != 0 {
+			nodeConfig.BlockTime = p.profile.BlockTime
+		}
+
+		data, err := json.MarshalIndent(nodeConfig, "", "    ")
+		if err != nil {
+			return fmt.Errorf("failed to generate config of node %s: %w", node.Name, err)
+		}
+
+		path := filepath.Join(baseDir, node.Name+".json")
+		if err := common.SaveFileSafe(path, data, 0660); err != nil {
+			return fmt.Errorf("failed to write config of node %s: %w", node.Name, err)
+		}
+
+		p.nodeConfigPaths = append(p.nodeConfigPaths, path)
+	}
+
+	return nil
+}
+
+// verifyNetwork reads back the written genesis and node configs, and checks they declare
+// the bootnodes, the validators and the staking contract of the profile.
+// The nodes booting from them are covered by the devnet tests
+func (p *genesisParams) verifyNetwork() error {
+	genesis, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to import genesis: %w", err)
+	}
+
+	// both the base and the edge networks refuse to start without bootnodes
+	if len(genesis.Bootnodes) == 0 || len(genesis.BaseBootnodes) == 0 {
+		return errProfileNoBootnodes
+	}
+
+	header := genesis.Genesis.GenesisHeader()
+	if len(header.ExtraData) < signer.IstanbulExtraVanity {
+		return fmt.Errorf("genesis extra data is too short")
+	}
+
+	extra := &signer.IstanbulExtra{
+		Validators:   validators.NewValidatorSetFromType(p.ibftValidatorType),
+		ProposerSeal: []byte{},
+	}
+
+	switch p.ibftValidatorType {
+	case validators.ECDSAValidatorType:
+		extra.CommittedSeals = new(signer.SerializedSeal)
+	case validators.BLSValidatorType:
+		extra.CommittedSeals = new(signer.AggregatedSeal)
+	}
+
+	if err := extra.UnmarshalRLP(header.ExtraData[signer.IstanbulExtraVanity:]); err != nil {
+		return fmt.Errorf("failed to decode genesis validators: %w", err)
+	}
+
+	for _, node := range p.profile.Nodes {
+		if node.validator != nil && !extra.Validators.Includes(node.validator.Addr()) {
+			return fmt.Errorf("validator of node %s is missing in genesis", node.Name)
+		}
+	}
+
+	if p.isPos {
+		if _, ok := genesis.Genesis.Alloc[staking.AddrStakingContract]; !ok {
+			return fmt.Errorf("staking contract is missing in genesis")
+		}
+	}
+
+	for _, path := range p.nodeConfigPaths {
+		nodeConfig, err := config.ReadConfigFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read node config %s: %w", path, err)
+		}
+
+		if nodeConfig.GenesisPath != p.genesisPath {
+			return fmt.Errorf("node config %s refers to genesis %s", path, nodeConfig.GenesisPath)
+		}
+	}
+
+	return nil
+}
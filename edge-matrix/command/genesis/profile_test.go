@@ -0,0 +1,306 @@
+package genesis
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/command/server/config"
+	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/server"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNodeID1 = "16Uiu2HAm4rZMZ1YkB9AyfbeqKnJrwcHiu2i4ZnXdRMQdF9QYDMNS"
+	testNodeID2 = "16Uiu2HAkuSMXmMbHyoNmc21aFg63BSfCwFNZ1Mra4ubB7qQJ8bap"
+
+	testValidator1 = "0x73932b85979DBb4880BDa35734C088D043c56cbd"
+	testValidator2 = "0x9B5b8741eB8A2a0D074aF8fC8939737466893D92"
+)
+
+// newTestProfile returns a profile of two validators, the first one being the bootnode, and an edge node
+func newTestProfile() *NetworkProfile {
+	profile := &NetworkProfile{
+		PoS:            true,
+		RelayBootnodes: []string{"/ip4/127.0.0.1/tcp/50005/p2p/" + testNodeID2},
+		Nodes: []*ProfileNode{
+			{NodeID: testNodeID1, Validator: testValidator1, Bootnode: true, Stake: "0x100"},
+			{NodeID: testNodeID2, Validator: testValidator2},
+			{RunningMode: string(server.RunningModeEdge)},
+		},
+	}
+
+	profile.setDefaults("/data")
+
+	return profile
+}
+
+func TestReadNetworkProfile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	cases := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{"profile.json", `{"name":"testnet","chain_id":7,"nodes":[{"name":"v1","stake":"0x10"}]}`, true},
+		{"profile.yaml", "name: testnet\nchain_id: 7\nnodes:\n  - name: v1\n    stake: \"0x10\"\n", true},
+		{"profile.yml", "name: testnet\nchain_id: 7\nnodes:\n  - name: v1\n    stake: \"0x10\"\n", true},
+		{"profile.toml", `name = "testnet"`, false},
+		{"broken.json", `{"name":`, false},
+		{"broken.yaml", "nodes: [", false},
+	}
+
+	for _, c := range cases {
+		path := filepath.Join(dir, c.name)
+		require.NoError(t, os.WriteFile(path, []byte(c.content), 0600))
+
+		profile, err := readNetworkProfile(path)
+		if !c.valid {
+			assert.Error(t, err, c.name)
+
+			continue
+		}
+
+		require.NoError(t, err, c.name)
+		assert.Equal(t, "testnet", profile.Name, c.name)
+		assert.Equal(t, uint64(7), profile.ChainID, c.name)
+		require.Len(t, profile.Nodes, 1, c.name)
+		assert.Equal(t, "0x10", profile.Nodes[0].Stake, c.name)
+	}
+
+	_, err := readNetworkProfile(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNetworkProfile_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	profile := &NetworkProfile{
+		Nodes: []*ProfileNode{
+			{},
+			{Name: "edge", DataDir: "/edge", RunningMode: string(server.RunningModeEdge), JSONRPCPort: 9000},
+		},
+	}
+
+	profile.setDefaults("/data")
+
+	assert.Equal(t, defaultProfileHost, profile.Host)
+
+	first, second := profile.Nodes[0], profile.Nodes[1]
+
+	assert.Equal(t, &ProfileNode{
+		Name:            "node-1",
+		DataDir:         filepath.Join("/data", "node-1"),
+		RunningMode:     config.DefaultRunningMode,
+		GRPCPort:        server.DefaultGRPCPort,
+		JSONRPCPort:     server.DefaultJSONRPCPort,
+		Libp2pPort:      network.DefaultLibp2pPort,
+		EdgeLibp2pPort:  network.DefaultEdgeLibp2pPort,
+		RelayLibp2pPort: network.DefaultRelayLibp2pPort,
+	}, first)
+
+	// the set values are kept, the unset ports are offset by the index of the node
+	assert.Equal(t, &ProfileNode{
+		Name:            "edge",
+		DataDir:         "/edge",
+		RunningMode:     string(server.RunningModeEdge),
+		GRPCPort:        server.DefaultGRPCPort + ProfilePortStride,
+		JSONRPCPort:     9000,
+		Libp2pPort:      network.DefaultLibp2pPort + ProfilePortStride,
+		EdgeLibp2pPort:  network.DefaultEdgeLibp2pPort + ProfilePortStride,
+		RelayLibp2pPort: network.DefaultRelayLibp2pPort + ProfilePortStride,
+	}, second)
+
+	profile.Host = "10.0.0.1"
+	profile.setDefaults("/data")
+	assert.Equal(t, "10.0.0.1", profile.Host)
+}
+
+func TestNetworkProfile_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		modify func(np *NetworkProfile)
+		err    string
+	}{
+		{"valid", func(np *NetworkProfile) {}, ""},
+		{"no nodes", func(np *NetworkProfile) { np.Nodes = nil }, errProfileNoNodes.Error()},
+		{"duplicate name", func(np *NetworkProfile) { np.Nodes[1].Name = np.Nodes[0].Name }, "duplicate node name"},
+		{
+			"duplicate data dir",
+			func(np *NetworkProfile) { np.Nodes[1].DataDir = np.Nodes[0].DataDir },
+			"duplicate data directory",
+		},
+		{
+			"shared port",
+			func(np *NetworkProfile) { np.Nodes[2].RelayLibp2pPort = np.Nodes[0].GRPCPort },
+			"is already used by node node-1",
+		},
+		{"invalid node id", func(np *NetworkProfile) { np.Nodes[1].NodeID = "node" }, "invalid node id of node node-2"},
+		{"duplicate node id", func(np *NetworkProfile) { np.Nodes[1].NodeID = testNodeID1 }, "duplicate node id"},
+		{"invalid running mode", func(np *NetworkProfile) { np.Nodes[2].RunningMode = "archive" }, "invalid running mode"},
+		{"bootnode without node id", func(np *NetworkProfile) { np.Nodes[0].NodeID = "" }, "requires the node id"},
+		{
+			"edge bootnode",
+			func(np *NetworkProfile) {
+				np.Nodes[1].Bootnode, np.Nodes[1].RunningMode = true, string(server.RunningModeEdge)
+			},
+			"bootnode node-2 must run in",
+		},
+		{"stake without PoS", func(np *NetworkProfile) { np.PoS = false }, errProfileStakeWithoutPoS.Error()},
+		{"stake without validator", func(np *NetworkProfile) { np.Nodes[2].Stake = "0x1" }, "requires the validator"},
+		{
+			"edge validator",
+			func(np *NetworkProfile) { np.Nodes[1].RunningMode = string(server.RunningModeEdge) },
+			"validator node-2 must run in",
+		},
+		{
+			"no validators",
+			func(np *NetworkProfile) { np.Nodes[0].Validator, np.Nodes[0].Stake, np.Nodes[1].Validator = "", "", "" },
+			errProfileNoValidators.Error(),
+		},
+		{"no bootnode", func(np *NetworkProfile) { np.Nodes[0].Bootnode = false }, errProfileNoBootnodes.Error()},
+		{
+			"edge nodes without relays",
+			func(np *NetworkProfile) { np.RelayBootnodes = nil },
+			errProfileNoRelayBootnodes.Error(),
+		},
+		{
+			"invalid relay bootnode",
+			func(np *NetworkProfile) { np.RelayBootnodes = []string{"relay"} },
+			"invalid relay bootnode",
+		},
+	}
+
+	for _, c := range cases {
+		profile := newTestProfile()
+		c.modify(profile)
+
+		err := profile.validate(validators.ECDSAValidatorType)
+		if c.err == "" {
+			require.NoError(t, err, c.name)
+			assert.Equal(t, types.StringToAddress(testValidator1), profile.Nodes[0].validator.Addr(), c.name)
+			assert.Nil(t, profile.Nodes[2].validator, c.name)
+
+			continue
+		}
+
+		assert.ErrorContains(t, err, c.err, c.name)
+	}
+
+	// the validators of the profile are parsed with the validator type of the genesis
+	assert.ErrorContains(t, newTestProfile().validate(validators.BLSValidatorType), "invalid validator of node node-1")
+}
+
+func TestInitProfileParams(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	profilePath := filepath.Join(dir, "profile.yaml")
+
+	require.NoError(t, os.WriteFile(profilePath, []byte(`
+name: testnet
+chain_id: 7
+pos: true
+premine:
+  - 0x1
+relay_bootnodes:
+  - /ip4/127.0.0.1/tcp/50005/p2p/`+testNodeID2+`
+nodes:
+  - node_id: `+testNodeID1+`
+    validator: "`+testValidator1+`"
+    stake: "0x100"
+    bootnode: true
+  - validator: "`+testValidator2+`"
+  - node_id: `+testNodeID2+`
+    running_mode: edge
+`), 0600))
+
+	p := &genesisParams{
+		profilePath:          profilePath,
+		genesisPath:          filepath.Join(dir, "genesis.json"),
+		chainID:              100,
+		rawIBFTValidatorType: string(validators.ECDSAValidatorType),
+	}
+
+	require.NoError(t, p.initProfileParams())
+
+	assert.Equal(t, "testnet", p.name)
+	assert.Equal(t, uint64(7), p.chainID)
+	assert.True(t, p.isPos)
+	assert.Equal(t, []string{"0x1"}, p.premine)
+	assert.Equal(t, []string{testValidator1, testValidator2}, p.ibftValidatorsRaw)
+	bootnode := func(port int) []string {
+		return []string{fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", port, testNodeID1)}
+	}
+
+	assert.Equal(t, bootnode(network.DefaultEdgeLibp2pPort), p.bootnodes)
+	assert.Equal(t, bootnode(network.DefaultLibp2pPort), p.baseBootnodes)
+
+	// only the validators declaring their stake override the default stake
+	assert.Equal(t, map[types.Address]*big.Int{
+		types.StringToAddress(testValidator1): big.NewInt(0x100),
+	}, p.stakedBalances)
+
+	assert.Equal(t, filepath.Join(dir, "node-2"), p.profile.Nodes[1].DataDir)
+}
+
+func TestWriteNodeConfigs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	profile := newTestProfile()
+	profile.BlockTime = 5
+	profile.Nodes[2].AppName = "llama"
+	profile.Nodes[2].AppURL = "http://127.0.0.1:9528"
+
+	require.NoError(t, profile.validate(validators.ECDSAValidatorType))
+
+	p := &genesisParams{
+		genesisPath: filepath.Join(dir, "genesis.json"),
+		profile:     profile,
+	}
+
+	require.NoError(t, p.writeNodeConfigs())
+	require.Len(t, p.nodeConfigPaths, 3)
+
+	cases := []struct {
+		path        string
+		node        *ProfileNode
+		shouldSeal  bool
+		runningMode string
+	}{
+		{filepath.Join(dir, "node-1.json"), profile.Nodes[0], true, config.DefaultRunningMode},
+		{filepath.Join(dir, "node-2.json"), profile.Nodes[1], true, config.DefaultRunningMode},
+		{filepath.Join(dir, "node-3.json"), profile.Nodes[2], false, string(server.RunningModeEdge)},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.path, p.nodeConfigPaths[i])
+
+		nodeConfig, err := config.ReadConfigFile(c.path)
+		require.NoError(t, err, c.path)
+
+		assert.Equal(t, p.genesisPath, nodeConfig.GenesisPath, c.path)
+		assert.Equal(t, c.node.DataDir, nodeConfig.DataDir, c.path)
+		assert.Equal(t, c.shouldSeal, nodeConfig.ShouldSeal, c.path)
+		assert.Equal(t, c.runningMode, nodeConfig.RunningMode, c.path)
+		assert.Equal(t, uint64(5), nodeConfig.BlockTime, c.path)
+		assert.Equal(t, "127.0.0.1:"+strconv.Itoa(c.node.JSONRPCPort), nodeConfig.JSONRPCAddr, c.path)
+		assert.Equal(t, "127.0.0.1:"+strconv.Itoa(c.node.Libp2pPort), nodeConfig.Network.Libp2pAddr, c.path)
+		assert.Equal(t, "127.0.0.1:"+strconv.Itoa(c.node.EdgeLibp2pPort), nodeConfig.Network.EdgeLibp2pAddr, c.path)
+		assert.Equal(t, c.node.AppURL, nodeConfig.AppUrl, c.path)
+	}
+}
@@ -0,0 +1,132 @@
+package mockapp
+
+import (
+	"net/http"
+	"time"
+
+	mockappServer "github.com/emc-protocol/edge-matrix/application/mockapp"
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	mockappCmd := &cobra.Command{
+		Use: "mockapp",
+		Short: "Runs a mock app to serve behind the app_url of an edge node, " +
+			"implementing the hub api and fake inference endpoints",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(mockappCmd)
+
+	return mockappCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.addr,
+		addrFlag,
+		"127.0.0.1:9527",
+		"the listening address of the mock app",
+	)
+
+	cmd.Flags().StringVar(
+		&params.nodeID,
+		nodeIDFlag,
+		"",
+		"the node bound to the mock app on start, replaced by the edge node binding it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.origin,
+		originFlag,
+		mockappServer.DefaultOrigin,
+		"the app origin reported to the edge node",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.paths,
+		pathFlag,
+		defaultPaths(),
+		"the paths of the fake inference endpoints",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.latency,
+		latencyFlag,
+		100*time.Millisecond,
+		"the delay before the first byte of an inference response",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.errorRate,
+		errorRateFlag,
+		0,
+		"the probability in [0, 1] of failing an inference",
+	)
+
+	cmd.Flags().IntVar(
+		&params.errorStatus,
+		errorStatusFlag,
+		http.StatusInternalServerError,
+		"the HTTP status of a failed inference",
+	)
+
+	cmd.Flags().IntVar(
+		&params.payloadSize,
+		payloadSizeFlag,
+		1024,
+		"the size in bytes of the generated inference output",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.stream,
+		streamFlag,
+		false,
+		"stream every inference as server-sent events, "+
+			"otherwise only the ones whose body sets \"stream\" to true are streamed",
+	)
+
+	cmd.Flags().IntVar(
+		&params.chunks,
+		chunksFlag,
+		8,
+		"the number of chunks of a streamed inference",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.chunkInterval,
+		chunkIntervalFlag,
+		20*time.Millisecond,
+		"the interval between the chunks of a streamed inference",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	params.initServer(hclog.New(&hclog.LoggerOptions{
+		Name:  "edge-matrix",
+		Level: hclog.Info,
+	}))
+
+	if err := params.server.Start(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	defer params.server.Close()
+
+	outputter.WriteCommandResult(params.getResult())
+
+	<-common.GetTerminationSignalCh()
+}
@@ -0,0 +1,106 @@
+package mockapp
+
+import (
+	"errors"
+	"time"
+
+	mockappServer "github.com/emc-protocol/edge-matrix/application/mockapp"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	addrFlag          = "addr"
+	nodeIDFlag        = "node-id"
+	originFlag        = "origin"
+	pathFlag          = "path"
+	latencyFlag       = "latency"
+	errorRateFlag     = "error-rate"
+	errorStatusFlag   = "error-status"
+	payloadSizeFlag   = "payload-size"
+	streamFlag        = "stream"
+	chunksFlag        = "chunks"
+	chunkIntervalFlag = "chunk-interval"
+)
+
+var (
+	params = &mockappParams{}
+)
+
+var (
+	errInvalidErrorRate = errors.New("error rate must be in [0, 1]")
+	errNoPaths          = errors.New("at least one inference path is required")
+)
+
+type mockappParams struct {
+	addr   string
+	nodeID string
+	origin string
+	paths  []string
+
+	latency       time.Duration
+	errorRate     float64
+	errorStatus   int
+	payloadSize   int
+	stream        bool
+	chunks        int
+	chunkInterval time.Duration
+
+	server *mockappServer.Server
+}
+
+func (p *mockappParams) validateFlags() error {
+	if p.errorRate < 0 || p.errorRate > 1 {
+		return errInvalidErrorRate
+	}
+
+	if len(p.paths) == 0 {
+		return errNoPaths
+	}
+
+	return nil
+}
+
+// defaultPaths returns the paths of the default inference endpoints of the mock app
+func defaultPaths() []string {
+	defaults := mockappServer.DefaultInference()
+	paths := make([]string, len(defaults))
+
+	for i, inference := range defaults {
+		paths[i] = inference.Path
+	}
+
+	return paths
+}
+
+// initServer creates the mock app serving each path with the configured behavior
+func (p *mockappParams) initServer(logger hclog.Logger) {
+	config := &mockappServer.Config{
+		Addr:   p.addr,
+		NodeID: p.nodeID,
+		Origin: p.origin,
+	}
+
+	for _, path := range p.paths {
+		config.Inference = append(config.Inference, &mockappServer.InferenceConfig{
+			Path:          path,
+			Latency:       p.latency,
+			ErrorRate:     p.errorRate,
+			ErrorStatus:   p.errorStatus,
+			PayloadSize:   p.payloadSize,
+			Stream:        p.stream,
+			Chunks:        p.chunks,
+			ChunkInterval: p.chunkInterval,
+		})
+	}
+
+	p.server = mockappServer.NewServer(logger, config)
+}
+
+func (p *mockappParams) getResult() *MockAppResult {
+	return &MockAppResult{
+		URL:    p.server.URL(),
+		NodeID: p.server.NodeID(),
+		Origin: p.origin,
+		Paths:  p.paths,
+	}
+}
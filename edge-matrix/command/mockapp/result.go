@@ -0,0 +1,31 @@
+package mockapp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/emc-protocol/edge-matrix/command/helper"
+)
+
+type MockAppResult struct {
+	URL    string   `json:"url"`
+	NodeID string   `json:"node_id"`
+	Origin string   `json:"origin"`
+	Paths  []string `json:"paths"`
+}
+
+func (r *MockAppResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[MOCK APP RUNNING]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("URL|%s", r.URL),
+		fmt.Sprintf("Node ID|%s", r.NodeID),
+		fmt.Sprintf("Origin|%s", r.Origin),
+		fmt.Sprintf("Inference paths|%s", strings.Join(r.Paths, ", ")),
+	}))
+	buffer.WriteString("\n\nPress Ctrl+C to stop the mock app\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/helper/common"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	monitorCmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Starts logging block add / remove events on the blockchain",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(monitorCmd)
+
+	return monitorCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := runSubscribeEventsCommand(ctx, helper.GetGRPCAddress(cmd), outputter)
+
+	select {
+	case err, ok := <-errCh:
+		if ok && err != nil {
+			outputter.SetError(fmt.Errorf("error while subscribing to events: %w", err))
+		}
+	case <-common.GetTerminationSignalCh():
+	}
+}
+
+// runSubscribeEventsCommand writes every blockchain event from the stream
+// until the stream is closed, and returns the channel of the stream error
+func runSubscribeEventsCommand(
+	ctx context.Context,
+	grpcAddress string,
+	outputter command.OutputFormatter,
+) <-chan error {
+	errCh := make(chan error, 1)
+
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		errCh <- err
+
+		return errCh
+	}
+
+	stream, err := client.Subscribe(ctx, &empty.Empty{})
+	if err != nil {
+		errCh <- err
+
+		return errCh
+	}
+
+	go func() {
+		defer close(errCh)
+
+		for {
+			evnt, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				errCh <- err
+
+				return
+			}
+
+			outputter.WriteCommandResult(NewBlockEventResult(evnt))
+		}
+	}()
+
+	return errCh
+}
@@ -0,0 +1,75 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/server/proto"
+)
+
+const (
+	eventAdded   = "added"
+	eventRemoved = "removed"
+)
+
+type BlockchainEvent struct {
+	Type   string `json:"type"`
+	Number int64  `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+type BlockchainEventResult struct {
+	Type   string            `json:"type"`
+	Source string            `json:"source"`
+	Events []BlockchainEvent `json:"events"`
+}
+
+func NewBlockEventResult(e *proto.BlockchainEvent) *BlockchainEventResult {
+	res := &BlockchainEventResult{
+		Type:   e.Type.String(),
+		Source: e.Source,
+		Events: make([]BlockchainEvent, 0, len(e.Removed)+len(e.Added)),
+	}
+
+	for _, removed := range e.Removed {
+		res.Events = append(res.Events, BlockchainEvent{
+			Type:   eventRemoved,
+			Number: removed.Number,
+			Hash:   removed.Hash,
+		})
+	}
+
+	for _, added := range e.Added {
+		res.Events = append(res.Events, BlockchainEvent{
+			Type:   eventAdded,
+			Number: added.Number,
+			Hash:   added.Hash,
+		})
+	}
+
+	return res
+}
+
+func (r *BlockchainEventResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("\n[BLOCK EVENT: %s]\n", r.Type))
+
+	rows := []string{
+		fmt.Sprintf("Source|%s", r.Source),
+	}
+
+	for _, event := range r.Events {
+		rows = append(rows,
+			fmt.Sprintf("Event Type|%s", event.Type),
+			fmt.Sprintf("Block Number|%d", event.Number),
+			fmt.Sprintf("Block Hash|%s", event.Hash),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
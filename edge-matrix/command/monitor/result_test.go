@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/server/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBlockEventResult(t *testing.T) {
+	t.Parallel()
+
+	res := NewBlockEventResult(&proto.BlockchainEvent{
+		Type:    proto.BlockchainEvent_REORG,
+		Source:  "syncer",
+		Added:   []*proto.BlockchainEvent_Header{{Number: 12, Hash: "0x0d"}, {Number: 13, Hash: "0x0e"}},
+		Removed: []*proto.BlockchainEvent_Header{{Number: 12, Hash: "0x0c"}},
+	})
+
+	// the removed blocks are listed before the added ones
+	assert.Equal(t, &BlockchainEventResult{
+		Type:   "REORG",
+		Source: "syncer",
+		Events: []BlockchainEvent{
+			{Type: eventRemoved, Number: 12, Hash: "0x0c"},
+			{Type: eventAdded, Number: 12, Hash: "0x0d"},
+			{Type: eventAdded, Number: 13, Hash: "0x0e"},
+		},
+	}, res)
+}
+
+func TestBlockchainEventResult_GetOutput(t *testing.T) {
+	t.Parallel()
+
+	res := NewBlockEventResult(&proto.BlockchainEvent{
+		Type:   proto.BlockchainEvent_HEAD,
+		Source: "consensus",
+		Added:  []*proto.BlockchainEvent_Header{{Number: 11, Hash: "0x0b"}},
+	})
+
+	assert.Equal(t, `
+[BLOCK EVENT: HEAD]
+Source       = consensus
+Event Type   = added
+Block Number = 11
+Block Hash   = 0x0b
+`, res.GetOutput())
+}
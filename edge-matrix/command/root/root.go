@@ -2,13 +2,18 @@ package root
 
 import (
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/command/app"
+	"github.com/emc-protocol/edge-matrix/command/devnet"
 	"github.com/emc-protocol/edge-matrix/command/genesis"
 	"github.com/emc-protocol/edge-matrix/command/helper"
 	"github.com/emc-protocol/edge-matrix/command/ibft"
 	"github.com/emc-protocol/edge-matrix/command/miner"
+	"github.com/emc-protocol/edge-matrix/command/mockapp"
+	"github.com/emc-protocol/edge-matrix/command/monitor"
 	"github.com/emc-protocol/edge-matrix/command/peers"
 	"github.com/emc-protocol/edge-matrix/command/secrets"
 	"github.com/emc-protocol/edge-matrix/command/server"
+	"github.com/emc-protocol/edge-matrix/command/status"
 	"github.com/emc-protocol/edge-matrix/command/version"
 	"os"
 
@@ -36,8 +41,13 @@ func NewRootCommand() *RootCommand {
 func (rc *RootCommand) registerSubCommands() {
 	rc.baseCmd.AddCommand(
 		version.GetCommand(),
+		status.GetCommand(),
+		monitor.GetCommand(),
 		secrets.GetCommand(),
 		genesis.GetCommand(),
+		devnet.GetCommand(),
+		app.GetCommand(),
+		mockapp.GetCommand(),
 		server.GetCommand(),
 		peers.GetCommand(),
 		ibft.GetCommand(),
@@ -6,39 +6,52 @@ import (
 	"os"
 	"strings"
 
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
 	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/telepool"
 	"github.com/hashicorp/hcl"
 	"gopkg.in/yaml.v3"
 )
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network                  *Network   `json:"network" yaml:"network"`
-	ShouldSeal               bool       `json:"seal" yaml:"seal"`
-	TelePool                 *TelePool  `json:"tele_pool" yaml:"tele_pool"`
-	LogLevel                 string     `json:"log_level" yaml:"log_level"`
-	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
-	BlockTime                uint64     `json:"block_time_s" yaml:"block_time_s"`
-	Headers                  *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath              string     `json:"log_to" yaml:"log_to"`
-	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
-	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
-	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
+	GenesisPath                string       `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath          string       `json:"secrets_config" yaml:"secrets_config"`
+	DataDir                    string       `json:"data_dir" yaml:"data_dir"`
+	BlockGasTarget             string       `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr                   string       `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr                string       `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry                  *Telemetry   `json:"telemetry" yaml:"telemetry"`
+	Network                    *Network     `json:"network" yaml:"network"`
+	ShouldSeal                 bool         `json:"seal" yaml:"seal"`
+	TelePool                   *TelePool    `json:"tele_pool" yaml:"tele_pool"`
+	LogLevel                   string       `json:"log_level" yaml:"log_level"`
+	RestoreFile                string       `json:"restore_file" yaml:"restore_file"`
+	BlockTime                  uint64       `json:"block_time_s" yaml:"block_time_s"`
+	Headers                    *Headers     `json:"headers" yaml:"headers"`
+	LogFilePath                string       `json:"log_to" yaml:"log_to"`
+	JSONRPCBatchRequestLimit   uint64       `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
+	JSONRPCBlockRangeLimit     uint64       `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCWSSubscriptionLimit uint64       `json:"json_rpc_ws_subscription_limit" yaml:"json_rpc_ws_subscription_limit"`
+	JSONRPCAuth                *JSONRPCAuth `json:"json_rpc_auth,omitempty" yaml:"json_rpc_auth,omitempty"`
+	IPCPath                    string       `json:"ipc_path" yaml:"ipc_path"`
+	IPCDisable                 bool         `json:"ipc_disable" yaml:"ipc_disable"`
+	JSONLogFormat              bool         `json:"json_log_format" yaml:"json_log_format"`
 
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
-
-	RelayOn        bool   `json:"relay_on,omitempty" yaml:"relay_on,omitempty"`
-	RelayDiscovery bool   `json:"relay_discovery,omitempty" yaml:"relay_discovery,omitempty"`
-	RunningMode    string `json:"running_mode,omitempty" yaml:"running_mode,omitempty"`
-	AppUrl         string `json:"app_url,omitempty" yaml:"app_url,omitempty"`
-	AppName        string `json:"app_name,omitempty" yaml:"app_name,omitempty"`
+	SyncMode              string `json:"sync_mode,omitempty" yaml:"sync_mode,omitempty"`
+
+	RelayOn        bool     `json:"relay_on,omitempty" yaml:"relay_on,omitempty"`
+	RelayDiscovery bool     `json:"relay_discovery,omitempty" yaml:"relay_discovery,omitempty"`
+	RunningMode    string   `json:"running_mode,omitempty" yaml:"running_mode,omitempty"`
+	AppUrl         string   `json:"app_url,omitempty" yaml:"app_url,omitempty"`
+	AppName        string   `json:"app_name,omitempty" yaml:"app_name,omitempty"`
+	Apps           []string `json:"apps,omitempty" yaml:"apps,omitempty"`
+	ChannelRPC     string   `json:"channel_rpc,omitempty" yaml:"channel_rpc,omitempty"`
+	PaidCalls      bool     `json:"paid_calls,omitempty" yaml:"paid_calls,omitempty"`
+	JobRetention   uint64   `json:"job_retention_s,omitempty" yaml:"job_retention_s,omitempty"`
+	CallRetention  uint64   `json:"call_retention_s,omitempty" yaml:"call_retention_s,omitempty"`
 	//AppOrigin string `json:"app_origin,omitempty" yaml:"app_origin,omitempty"`
 	EmcHost string `json:"emc_host,omitempty" yaml:"emc_host,omitempty"`
 }
@@ -48,6 +61,30 @@ type Telemetry struct {
 	PrometheusAddr string `json:"prometheus_addr" yaml:"prometheus_addr"`
 }
 
+// JSONRPCAuth defines the authentication and the rate limits of the JSON-RPC server
+type JSONRPCAuth struct {
+	Required      bool                         `json:"required" yaml:"required"`
+	JWTSecretFile string                       `json:"jwt_secret_file" yaml:"jwt_secret_file"`
+	APIKeys       []*JSONRPCAPIKey             `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+	IPRateLimits  map[string]*JSONRPCRateLimit `json:"ip_rate_limits,omitempty" yaml:"ip_rate_limits,omitempty"`
+	KeyRateLimits map[string]*JSONRPCRateLimit `json:"key_rate_limits,omitempty" yaml:"key_rate_limits,omitempty"`
+}
+
+// JSONRPCAPIKey defines an API key accepted by the JSON-RPC server
+type JSONRPCAPIKey struct {
+	Name       string                       `json:"name" yaml:"name"`
+	Key        string                       `json:"key" yaml:"key"`
+	Methods    []string                     `json:"methods,omitempty" yaml:"methods,omitempty"`
+	RateLimits map[string]*JSONRPCRateLimit `json:"rate_limits,omitempty" yaml:"rate_limits,omitempty"`
+}
+
+// JSONRPCRateLimit defines the token bucket of a rate limit,
+// refilled with rate tokens per second up to burst tokens
+type JSONRPCRateLimit struct {
+	Rate  float64 `json:"rate" yaml:"rate"`
+	Burst uint64  `json:"burst" yaml:"burst"`
+}
+
 // Network defines the network configuration params
 type Network struct {
 	NoDiscover       bool   `json:"no_discover" yaml:"no_discover"`
@@ -63,9 +100,18 @@ type Network struct {
 
 // TelePool defines the TelePool configuration params
 type TelePool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64         `json:"price_limit" yaml:"price_limit"`
+	PriceBump          uint64         `json:"price_bump" yaml:"price_bump"`
+	MaxSlots           uint64         `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64         `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	Lanes              *TelePoolLanes `json:"lanes,omitempty" yaml:"lanes,omitempty"`
+}
+
+// TelePoolLanes defines the slot budgets of the TelePool lanes, zero for no separate budget
+type TelePoolLanes struct {
+	EdgeCallSlots   uint64 `json:"edge_call_slots" yaml:"edge_call_slots"`
+	RtcSubjectSlots uint64 `json:"rtc_subject_slots" yaml:"rtc_subject_slots"`
+	TransferSlots   uint64 `json:"transfer_slots" yaml:"transfer_slots"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -88,11 +134,28 @@ const (
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
 
+	// DefaultIPCPath is the path of the IPC endpoint relative to the data directory
+	DefaultIPCPath = "edge.ipc"
+
+	// DefaultJSONRPCWSSubscriptionLimit maximum number of subscriptions allowed for a websocket connection
+	DefaultJSONRPCWSSubscriptionLimit uint64 = 32
+
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
 
 	DefaultRunningMode string = "full"
+
+	// LightRunningMode follows the verified headers only,
+	// and fetches the telegram receipts with Merkle proofs from the full peers
+	LightRunningMode string = "light"
+
+	// BulkSyncMode syncs the chain by executing all the blocks from genesis
+	BulkSyncMode string = "bulk"
+
+	// CheckpointSyncMode syncs the state snapshot at the latest checkpoint from peers,
+	// and executes only the blocks after the checkpoint
+	CheckpointSyncMode string = "checkpoint"
 )
 
 // DefaultConfig returns the default server configuration
@@ -124,8 +187,10 @@ func DefaultConfig() *Config {
 		ShouldSeal: true,
 		TelePool: &TelePool{
 			PriceLimit:         0,
+			PriceBump:          telepool.DefaultPriceBump,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			Lanes:              &TelePoolLanes{},
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -133,13 +198,19 @@ func DefaultConfig() *Config {
 		Headers: &Headers{
 			AccessControlAllowOrigins: []string{"*"},
 		},
-		LogFilePath:              "",
-		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
-		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
-		RelayOn:                  false,
-		RelayDiscovery:           false,
-		NumBlockConfirmations:    DefaultNumBlockConfirmations,
-		RunningMode:              DefaultRunningMode,
+		LogFilePath:                "",
+		JSONRPCBatchRequestLimit:   DefaultJSONRPCBatchRequestLimit,
+		JSONRPCBlockRangeLimit:     DefaultJSONRPCBlockRangeLimit,
+		JSONRPCWSSubscriptionLimit: DefaultJSONRPCWSSubscriptionLimit,
+		JSONRPCAuth:                &JSONRPCAuth{},
+		IPCPath:                    DefaultIPCPath,
+		RelayOn:                    false,
+		RelayDiscovery:             false,
+		NumBlockConfirmations:      DefaultNumBlockConfirmations,
+		RunningMode:                DefaultRunningMode,
+		JobRetention:               uint64(jobs.DefaultRetention.Seconds()),
+		CallRetention:              uint64(calllog.DefaultRetention.Seconds()),
+		SyncMode:                   BulkSyncMode,
 	}
 }
 
@@ -3,15 +3,23 @@ package server
 import (
 	"errors"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application"
 	"github.com/emc-protocol/edge-matrix/chain"
 	"math"
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/emc-protocol/edge-matrix/command/server/config"
 
 	"github.com/emc-protocol/edge-matrix/network/common"
 
 	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/helper/ipc"
+	"github.com/emc-protocol/edge-matrix/jsonrpc"
 	"github.com/emc-protocol/edge-matrix/network"
 	"github.com/emc-protocol/edge-matrix/secrets"
 	"github.com/emc-protocol/edge-matrix/types"
@@ -21,6 +29,8 @@ var (
 	errInvalidBlockTime       = errors.New("invalid block time specified")
 	errDataDirectoryUndefined = errors.New("data directory not defined")
 	errMinerCanisterUndefined = errors.New("miner canister not defined")
+	errInvalidSyncMode        = errors.New("invalid sync mode specified")
+	errInvalidAPIKey          = errors.New("invalid json-rpc API key")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -54,8 +64,23 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initSyncMode(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCAuth(); err != nil {
+		return err
+	}
+
+	if err := p.initApps(); err != nil {
+		return err
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
+	if err := p.initIPCPath(); err != nil {
+		return err
+	}
 
 	return p.initAddresses()
 }
@@ -68,6 +93,94 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initSyncMode() error {
+	switch p.rawConfig.SyncMode {
+	case "":
+		p.rawConfig.SyncMode = config.BulkSyncMode
+	case config.BulkSyncMode, config.CheckpointSyncMode:
+	default:
+		return errInvalidSyncMode
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCAuth() error {
+	rawAuth := p.rawConfig.JSONRPCAuth
+	if rawAuth == nil {
+		return nil
+	}
+
+	auth := &jsonrpc.AuthConfig{
+		Required:      rawAuth.Required,
+		APIKeys:       make([]*jsonrpc.APIKey, 0, len(rawAuth.APIKeys)),
+		IPRateLimits:  toRateLimits(rawAuth.IPRateLimits),
+		KeyRateLimits: toRateLimits(rawAuth.KeyRateLimits),
+	}
+
+	if rawAuth.JWTSecretFile != "" {
+		data, err := os.ReadFile(rawAuth.JWTSecretFile)
+		if err != nil {
+			return fmt.Errorf("unable to read jwt secret, %w", err)
+		}
+
+		if auth.JWTSecret, err = hex.DecodeHex(strings.TrimSpace(string(data))); err != nil {
+			return fmt.Errorf("unable to decode jwt secret, %w", err)
+		}
+	}
+
+	for _, key := range rawAuth.APIKeys {
+		if key == nil || key.Name == "" || key.Key == "" {
+			return errInvalidAPIKey
+		}
+
+		auth.APIKeys = append(auth.APIKeys, &jsonrpc.APIKey{
+			Name:       key.Name,
+			Key:        key.Key,
+			Methods:    key.Methods,
+			RateLimits: toRateLimits(key.RateLimits),
+		})
+	}
+
+	p.jsonRPCAuth = auth
+
+	return nil
+}
+
+func toRateLimits(rawLimits map[string]*config.JSONRPCRateLimit) map[string]jsonrpc.RateLimit {
+	if rawLimits == nil {
+		return nil
+	}
+
+	limits := make(map[string]jsonrpc.RateLimit, len(rawLimits))
+	for rule, limit := range rawLimits {
+		if limit != nil {
+			limits[rule] = jsonrpc.RateLimit{Rate: limit.Rate, Burst: limit.Burst}
+		}
+	}
+
+	return limits
+}
+
+// initApps parses the apps hosted by the edge node besides the default one
+func (p *serverParams) initApps() error {
+	p.apps = make([]*application.AppConfig, 0, len(p.rawConfig.Apps))
+
+	for _, raw := range p.rawConfig.Apps {
+		app, err := application.ParseAppConfig(raw)
+		if err != nil {
+			return err
+		}
+
+		p.apps = append(p.apps, app)
+	}
+
+	return application.ValidateAppConfigs(append(
+		[]*application.AppConfig{{Name: p.rawConfig.AppName, Url: p.rawConfig.AppUrl}},
+		p.apps...,
+	))
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -76,6 +189,31 @@ func (p *serverParams) initDataDirLocation() error {
 	return nil
 }
 
+func (p *serverParams) initIPCPath() error {
+	if p.rawConfig.IPCDisable || p.rawConfig.IPCPath == "" {
+		return nil
+	}
+
+	path := p.rawConfig.IPCPath
+
+	if runtime.GOOS == "windows" {
+		// the IPC endpoint is a named pipe on windows
+		if !strings.HasPrefix(path, `\\.\pipe\`) {
+			path = `\\.\pipe\` + filepath.Base(path)
+		}
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(p.rawConfig.DataDir, path)
+	}
+
+	if err := ipc.ValidatePath(path); err != nil {
+		return fmt.Errorf("%w, set a shorter --%s or disable it with --%s", err, ipcPathFlag, ipcDisableFlag)
+	}
+
+	p.ipcPath = path
+
+	return nil
+}
+
 func (p *serverParams) initLogFileLocation() {
 	if p.isLogFileLocationSet() {
 		p.logFileLocation = p.rawConfig.LogFilePath
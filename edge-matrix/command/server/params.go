@@ -2,52 +2,71 @@ package server
 
 import (
 	"errors"
+	"github.com/emc-protocol/edge-matrix/application"
 	"github.com/emc-protocol/edge-matrix/chain"
 	"net"
+	"time"
 
 	"github.com/emc-protocol/edge-matrix/command/server/config"
+	"github.com/emc-protocol/edge-matrix/jsonrpc"
 	"github.com/emc-protocol/edge-matrix/network"
 	"github.com/emc-protocol/edge-matrix/secrets"
 	"github.com/emc-protocol/edge-matrix/server"
+	"github.com/emc-protocol/edge-matrix/telepool"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
 
 const (
-	configFlag                   = "config"
-	genesisPathFlag              = "chain"
-	dataDirFlag                  = "data-dir"
-	libp2pAddressFlag            = "base-libp2p"
-	edgeLibp2pAddressFlag        = "libp2p"
-	relayLibp2pAddressFlag       = "relay-libp2p"
-	prometheusAddressFlag        = "prometheus"
-	natFlag                      = "nat"
-	dnsFlag                      = "dns"
-	sealFlag                     = "seal"
-	maxPeersFlag                 = "max-peers"
-	maxInboundPeersFlag          = "max-inbound-peers"
-	maxOutboundPeersFlag         = "max-outbound-peers"
-	priceLimitFlag               = "price-limit"
-	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
-	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
-	maxSlotsFlag                 = "max-slots"
-	maxEnqueuedFlag              = "max-enqueued"
-	blockGasTargetFlag           = "block-gas-target"
-	secretsConfigFlag            = "secrets-config"
-	restoreFlag                  = "restore"
-	blockTimeFlag                = "block-time"
-	devIntervalFlag              = "dev-interval"
-	devFlag                      = "dev"
-	corsOriginFlag               = "access-control-allow-origins"
-	logFileLocationFlag          = "log-to"
+	configFlag                     = "config"
+	genesisPathFlag                = "chain"
+	dataDirFlag                    = "data-dir"
+	libp2pAddressFlag              = "base-libp2p"
+	edgeLibp2pAddressFlag          = "libp2p"
+	relayLibp2pAddressFlag         = "relay-libp2p"
+	prometheusAddressFlag          = "prometheus"
+	natFlag                        = "nat"
+	dnsFlag                        = "dns"
+	sealFlag                       = "seal"
+	maxPeersFlag                   = "max-peers"
+	maxInboundPeersFlag            = "max-inbound-peers"
+	maxOutboundPeersFlag           = "max-outbound-peers"
+	priceLimitFlag                 = "price-limit"
+	jsonRPCBatchRequestLimitFlag   = "json-rpc-batch-request-limit"
+	jsonRPCBlockRangeLimitFlag     = "json-rpc-block-range-limit"
+	jsonRPCWSSubscriptionLimitFlag = "json-rpc-ws-subscription-limit"
+	jsonRPCAuthRequiredFlag        = "json-rpc-auth-required"
+	jsonRPCJWTSecretFlag           = "json-rpc-jwt-secret"
+	ipcPathFlag                    = "ipc-path"
+	ipcDisableFlag                 = "ipc-disable"
+	maxSlotsFlag                   = "max-slots"
+	maxEnqueuedFlag                = "max-enqueued"
+	priceBumpFlag                  = "price-bump"
+	maxSlotsEdgeCallFlag           = "max-slots-edge-call"
+	maxSlotsRtcSubjectFlag         = "max-slots-rtc-subject"
+	maxSlotsTransferFlag           = "max-slots-transfer"
+	blockGasTargetFlag             = "block-gas-target"
+	secretsConfigFlag              = "secrets-config"
+	restoreFlag                    = "restore"
+	blockTimeFlag                  = "block-time"
+	devIntervalFlag                = "dev-interval"
+	devFlag                        = "dev"
+	corsOriginFlag                 = "access-control-allow-origins"
+	logFileLocationFlag            = "log-to"
 
 	numBlockConfirmationsFlag = "num-block-confirmations"
+	syncModeFlag              = "sync-mode"
 
 	relayOnFlag        = "relay-on"
 	relayDiscoveryFlag = "relay-discovery"
 	runningModeFlag    = "running-mode"
 	appNameFlag        = "app-name"
 	appUrlFlag         = "app-url"
+	appFlag            = "app"
+	channelRPCFlag     = "channel-rpc"
+	paidCallsFlag      = "paid-calls"
+	jobRetentionFlag   = "job-retention"
+	callRetentionFlag  = "call-retention"
 	//appOriginFlag = "app-origin"
 	icHostFlag = "ic-host"
 )
@@ -67,7 +86,10 @@ var (
 		rawConfig: &config.Config{
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
-			TelePool:  &config.TelePool{},
+			TelePool: &config.TelePool{
+				Lanes: &config.TelePoolLanes{},
+			},
+			JSONRPCAuth: &config.JSONRPCAuth{},
 		},
 	}
 )
@@ -94,6 +116,11 @@ type serverParams struct {
 	isDevMode      bool
 
 	corsAllowedOrigins []string
+	jsonRPCAuth        *jsonrpc.AuthConfig
+	ipcPath            string
+
+	// apps hosted by the edge node besides the default app
+	apps []*application.AppConfig
 
 	ibftBaseTimeoutLegacy uint64
 
@@ -144,6 +171,19 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+func (p *serverParams) getTelePoolLanes() telepool.LaneConfig {
+	lanes := p.rawConfig.TelePool.Lanes
+	if lanes == nil {
+		return telepool.LaneConfig{}
+	}
+
+	return telepool.LaneConfig{
+		EdgeCallSlots:   lanes.EdgeCallSlots,
+		RtcSubjectSlots: lanes.RtcSubjectSlots,
+		TransferSlots:   lanes.TransferSlots,
+	}
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -164,6 +204,9 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			WSSubscriptionLimit:      p.rawConfig.JSONRPCWSSubscriptionLimit,
+			Auth:                     p.jsonRPCAuth,
+			IPCPath:                  p.ipcPath,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -192,12 +235,14 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
 		},
-		RelayAddr: p.relayLibp2pAddress,
-		DataDir:   p.rawConfig.DataDir,
-		Seal:      p.rawConfig.ShouldSeal,
-		//PriceLimit:         p.rawConfig.TelePool.PriceLimit,
+		RelayAddr:          p.relayLibp2pAddress,
+		DataDir:            p.rawConfig.DataDir,
+		Seal:               p.rawConfig.ShouldSeal,
+		PriceLimit:         p.rawConfig.TelePool.PriceLimit,
+		PriceBump:          p.rawConfig.TelePool.PriceBump,
 		MaxSlots:           p.rawConfig.TelePool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TelePool.MaxAccountEnqueued,
+		TelePoolLanes:      p.getTelePoolLanes(),
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		BlockTime:          p.rawConfig.BlockTime,
@@ -208,10 +253,17 @@ func (p *serverParams) generateConfig() *server.Config {
 		RelayOn:               p.rawConfig.RelayOn,
 		RelayDiscovery:        p.rawConfig.RelayDiscovery,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		SyncMode:              p.rawConfig.SyncMode,
 
 		RunningMode: p.rawConfig.RunningMode,
 		AppName:     p.rawConfig.AppName,
 		AppUrl:      p.rawConfig.AppUrl,
+		Apps:        p.apps,
+		ChannelRPC:  p.rawConfig.ChannelRPC,
+		PaidCalls:   p.rawConfig.PaidCalls,
+
+		JobRetention:  time.Duration(p.rawConfig.JobRetention) * time.Second,
+		CallRetention: time.Duration(p.rawConfig.CallRetention) * time.Second,
 
 		EmcHost: p.rawConfig.EmcHost,
 	}
@@ -170,7 +170,7 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RunningMode,
 		runningModeFlag,
 		defaultConfig.RunningMode,
-		"the mode for running",
+		"the mode for running: full, edge or light",
 	)
 
 	cmd.Flags().BoolVar(
@@ -201,6 +201,41 @@ func setFlags(cmd *cobra.Command) {
 		"the url for application",
 	)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Apps,
+		appFlag,
+		[]string{},
+		"an app hosted by the edge node besides the default one, as name=url, addressed by its name in the edge calls",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ChannelRPC,
+		channelRPCFlag,
+		"",
+		"the JSON-RPC address of the full node the edge node verifies the payment channels with",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.PaidCalls,
+		paidCallsFlag,
+		false,
+		"require a voucher of a payment channel to the node paying each edge call it serves",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JobRetention,
+		jobRetentionFlag,
+		defaultConfig.JobRetention,
+		"the period in seconds the finished edge call jobs are kept for",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.CallRetention,
+		callRetentionFlag,
+		defaultConfig.CallRetention,
+		"the period in seconds the edge calls sent by the node are kept in its audit log for",
+	)
+
 	//cmd.Flags().StringVar(
 	//	&params.rawConfig.AppOrigin,
 	//	appOriginFlag,
@@ -215,6 +250,20 @@ func setFlags(cmd *cobra.Command) {
 	//	"the url used for IC api calling",
 	//)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TelePool.PriceLimit,
+		priceLimitFlag,
+		defaultConfig.TelePool.PriceLimit,
+		"minimum gas price limit to enforce for acceptance into the pool",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TelePool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TelePool.PriceBump,
+		"minimum gas price bump in percent to replace the telegram of the same nonce",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TelePool.MaxSlots,
 		maxSlotsFlag,
@@ -229,6 +278,27 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TelePool.Lanes.EdgeCallSlots,
+		maxSlotsEdgeCallFlag,
+		defaultConfig.TelePool.Lanes.EdgeCallSlots,
+		"maximum slots in the pool for edge call telegrams (0 for no separate budget)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TelePool.Lanes.RtcSubjectSlots,
+		maxSlotsRtcSubjectFlag,
+		defaultConfig.TelePool.Lanes.RtcSubjectSlots,
+		"maximum slots in the pool for rtc subject telegrams (0 for no separate budget)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TelePool.Lanes.TransferSlots,
+		maxSlotsTransferFlag,
+		defaultConfig.TelePool.Lanes.TransferSlots,
+		"maximum slots in the pool for the other telegrams (0 for no separate budget)",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -258,6 +328,44 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCWSSubscriptionLimit,
+		jsonRPCWSSubscriptionLimitFlag,
+		defaultConfig.JSONRPCWSSubscriptionLimit,
+		"max number of subscriptions to be held by a json-rpc websocket connection, value of 0 disables it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.IPCPath,
+		ipcPathFlag,
+		defaultConfig.IPCPath,
+		"the path of the json-rpc IPC endpoint serving the admin, debug and personal namespaces, "+
+			"relative to the data directory if it's not absolute",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.IPCDisable,
+		ipcDisableFlag,
+		defaultConfig.IPCDisable,
+		"disable the json-rpc IPC endpoint",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCAuth.Required,
+		jsonRPCAuthRequiredFlag,
+		defaultConfig.JSONRPCAuth.Required,
+		"reject the json-rpc requests without an API key or a token, "+
+			"the API keys and the rate limits are set in the config file",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAuth.JWTSecretFile,
+		jsonRPCJWTSecretFlag,
+		defaultConfig.JSONRPCAuth.JWTSecretFile,
+		"the path to the hex encoded secret of the HS256 tokens accepted by json-rpc, "+
+			"tokens are not accepted if it's not set",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -272,6 +380,18 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.SyncMode,
+		syncModeFlag,
+		defaultConfig.SyncMode,
+		fmt.Sprintf(
+			"the mode for syncing the chain, %q executes all blocks from genesis, "+
+				"%q downloads the state at the latest checkpoint from peers",
+			config.BulkSyncMode,
+			config.CheckpointSyncMode,
+		),
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -0,0 +1,161 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/server/proto"
+)
+
+type StatusResult struct {
+	ChainID            int64  `json:"chain_id"`
+	Genesis            string `json:"genesis"`
+	CurrentBlockNumber int64  `json:"current_block_number"`
+	CurrentBlockHash   string `json:"current_block_hash"`
+	LibP2PAddress      string `json:"libp2p_address"`
+	RunningMode        string `json:"running_mode"`
+
+	Peers       PeerCount    `json:"peers"`
+	Sync        *SyncStatus  `json:"sync,omitempty"`
+	AppEndpoint *AppEndpoint `json:"app_endpoint,omitempty"`
+}
+
+type PeerCount struct {
+	Base  int64 `json:"base"`
+	Edge  int64 `json:"edge"`
+	Relay int64 `json:"relay"`
+}
+
+type SyncStatus struct {
+	Type          string `json:"type"`
+	StartingBlock uint64 `json:"starting_block"`
+	CurrentBlock  uint64 `json:"current_block"`
+	HighestBlock  uint64 `json:"highest_block"`
+	PulledStates  uint64 `json:"pulled_states,omitempty"`
+}
+
+type AppEndpoint struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Origin    string `json:"origin"`
+	Healthy   bool   `json:"healthy"`
+	LastCheck uint64 `json:"last_check"`
+	Error     string `json:"error,omitempty"`
+	Uptime    uint64 `json:"uptime"`
+}
+
+func newStatusResult(status *proto.ServerStatus) *StatusResult {
+	res := &StatusResult{
+		ChainID:       status.Network,
+		Genesis:       status.Genesis,
+		LibP2PAddress: status.P2PAddr,
+		RunningMode:   status.RunningMode,
+	}
+
+	if status.Current != nil {
+		res.CurrentBlockNumber = status.Current.Number
+		res.CurrentBlockHash = status.Current.Hash
+	}
+
+	if status.Peers != nil {
+		res.Peers = PeerCount{
+			Base:  status.Peers.Base,
+			Edge:  status.Peers.Edge,
+			Relay: status.Peers.Relay,
+		}
+	}
+
+	if p := status.SyncProgression; p != nil {
+		res.Sync = &SyncStatus{
+			Type:          p.Type,
+			StartingBlock: p.StartingBlock,
+			CurrentBlock:  p.CurrentBlock,
+			HighestBlock:  p.HighestBlock,
+			PulledStates:  p.PulledStates,
+		}
+	}
+
+	if app := status.AppEndpoint; app != nil {
+		res.AppEndpoint = &AppEndpoint{
+			Name:      app.Name,
+			URL:       app.Url,
+			Origin:    app.Origin,
+			Healthy:   app.Healthy,
+			LastCheck: app.LastCheck,
+			Error:     app.Error,
+			Uptime:    app.Uptime,
+		}
+	}
+
+	return res
+}
+
+func (r *StatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CLIENT STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Network (Chain ID)|%d", r.ChainID),
+		fmt.Sprintf("Genesis|%s", r.Genesis),
+		fmt.Sprintf("Current Block Number (base 10)|%d", r.CurrentBlockNumber),
+		fmt.Sprintf("Current Block Hash|%s", r.CurrentBlockHash),
+		fmt.Sprintf("Libp2p Address|%s", r.LibP2PAddress),
+		fmt.Sprintf("Running Mode|%s", r.RunningMode),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[PEERS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Base|%d", r.Peers.Base),
+		fmt.Sprintf("Edge|%d", r.Peers.Edge),
+		fmt.Sprintf("Relay|%d", r.Peers.Relay),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[SYNC]\n")
+
+	if r.Sync == nil {
+		buffer.WriteString("Not syncing\n")
+	} else {
+		rows := []string{
+			fmt.Sprintf("Type|%s", r.Sync.Type),
+			fmt.Sprintf("Starting Block|%d", r.Sync.StartingBlock),
+			fmt.Sprintf("Current Block|%d", r.Sync.CurrentBlock),
+			fmt.Sprintf("Highest Block|%d", r.Sync.HighestBlock),
+		}
+
+		if r.Sync.PulledStates > 0 {
+			rows = append(rows, fmt.Sprintf("Pulled States|%d", r.Sync.PulledStates))
+		}
+
+		buffer.WriteString(helper.FormatKV(rows))
+		buffer.WriteString("\n")
+	}
+
+	if r.AppEndpoint != nil {
+		buffer.WriteString("\n[APP ENDPOINT]\n")
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Name|%s", r.AppEndpoint.Name),
+			fmt.Sprintf("URL|%s", r.AppEndpoint.URL),
+			fmt.Sprintf("Origin|%s", r.AppEndpoint.Origin),
+			fmt.Sprintf("Health|%s", r.AppEndpoint.health()),
+			fmt.Sprintf("Uptime|%s", time.Duration(r.AppEndpoint.Uptime)*time.Millisecond),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+func (a *AppEndpoint) health() string {
+	switch {
+	case a.LastCheck == 0:
+		return "not checked"
+	case a.Healthy:
+		return fmt.Sprintf("healthy (checked at %s)", time.UnixMilli(int64(a.LastCheck)).Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("unhealthy (checked at %s): %s", time.UnixMilli(int64(a.LastCheck)).Format(time.RFC3339), a.Error)
+	}
+}
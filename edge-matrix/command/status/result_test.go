@@ -0,0 +1,130 @@
+package status
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/server/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStatus() *proto.ServerStatus {
+	return &proto.ServerStatus{
+		Network:     100,
+		Genesis:     "0x01",
+		Current:     &proto.ServerStatus_Block{Number: 10, Hash: "0x0a"},
+		P2PAddr:     "/ip4/127.0.0.1/tcp/50001/p2p/16Uiu2HAm4rZMZ1YkB9AyfbeqKnJrwcHiu2i4ZnXdRMQdF9QYDMNS",
+		RunningMode: "edge",
+		Peers:       &proto.ServerStatus_PeerCount{Base: 3, Edge: 5, Relay: 1},
+	}
+}
+
+// outputRows returns the rows of the result output without the alignment of their columns
+func outputRows(res *StatusResult) []string {
+	lines := strings.Split(res.GetOutput(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+
+	return lines
+}
+
+func TestNewStatusResult(t *testing.T) {
+	t.Parallel()
+
+	status := newTestStatus()
+
+	res := newStatusResult(status)
+	assert.Equal(t, int64(100), res.ChainID)
+	assert.Equal(t, int64(10), res.CurrentBlockNumber)
+	assert.Equal(t, "0x0a", res.CurrentBlockHash)
+	assert.Equal(t, PeerCount{Base: 3, Edge: 5, Relay: 1}, res.Peers)
+	assert.Nil(t, res.Sync)
+	assert.Nil(t, res.AppEndpoint)
+
+	// the sync and the app endpoint are left out of the JSON output when absent
+	raw, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "sync")
+	assert.NotContains(t, string(raw), "app_endpoint")
+
+	status.SyncProgression = &proto.ServerStatus_SyncProgression{
+		Type:          "bulk-sync",
+		StartingBlock: 1,
+		CurrentBlock:  10,
+		HighestBlock:  20,
+	}
+	status.AppEndpoint = &proto.ServerStatus_AppEndpoint{
+		Name:      "sd",
+		Url:       "http://127.0.0.1:7860",
+		Origin:    "stable-diffusion",
+		Healthy:   false,
+		LastCheck: 1000,
+		Error:     "connection refused",
+		Uptime:    2000,
+	}
+
+	res = newStatusResult(status)
+	assert.Equal(t, &SyncStatus{Type: "bulk-sync", StartingBlock: 1, CurrentBlock: 10, HighestBlock: 20}, res.Sync)
+	assert.Equal(t, &AppEndpoint{
+		Name:      "sd",
+		URL:       "http://127.0.0.1:7860",
+		Origin:    "stable-diffusion",
+		LastCheck: 1000,
+		Error:     "connection refused",
+		Uptime:    2000,
+	}, res.AppEndpoint)
+}
+
+func TestStatusResult_GetOutput(t *testing.T) {
+	t.Parallel()
+
+	status := newTestStatus()
+
+	assert.Equal(t, `
+[CLIENT STATUS]
+Network (Chain ID)             = 100
+Genesis                        = 0x01
+Current Block Number (base 10) = 10
+Current Block Hash             = 0x0a
+Libp2p Address                 = /ip4/127.0.0.1/tcp/50001/p2p/16Uiu2HAm4rZMZ1YkB9AyfbeqKnJrwcHiu2i4ZnXdRMQdF9QYDMNS
+Running Mode                   = edge
+
+[PEERS]
+Base  = 3
+Edge  = 5
+Relay = 1
+
+[SYNC]
+Not syncing
+`, newStatusResult(status).GetOutput())
+
+	checkedAt := time.Now().Add(-time.Minute).UnixMilli()
+	checked := time.UnixMilli(checkedAt).Format(time.RFC3339)
+
+	status.SyncProgression = &proto.ServerStatus_SyncProgression{Type: "bulk-sync", StartingBlock: 1, CurrentBlock: 10, HighestBlock: 20}
+	status.AppEndpoint = &proto.ServerStatus_AppEndpoint{
+		Name:      "sd",
+		Healthy:   true,
+		LastCheck: uint64(checkedAt),
+		Uptime:    uint64(90 * time.Second.Milliseconds()),
+	}
+
+	output := outputRows(newStatusResult(status))
+	assert.Contains(t, output, "Type = bulk-sync")
+	assert.Contains(t, output, "Highest Block = 20")
+	assert.NotContains(t, newStatusResult(status).GetOutput(), "Pulled States")
+	assert.Contains(t, output, "[APP ENDPOINT]")
+	assert.Contains(t, output, "Health = healthy (checked at "+checked+")")
+	assert.Contains(t, output, "Uptime = 1m30s")
+
+	status.AppEndpoint.Healthy = false
+	status.AppEndpoint.Error = "connection refused"
+	assert.Contains(t, outputRows(newStatusResult(status)), "Health = unhealthy (checked at "+checked+"): connection refused")
+
+	status.AppEndpoint.LastCheck = 0
+	assert.Contains(t, outputRows(newStatusResult(status)), "Health = not checked")
+}
@@ -0,0 +1,46 @@
+package status
+
+import (
+	"context"
+
+	"github.com/emc-protocol/edge-matrix/command"
+	"github.com/emc-protocol/edge-matrix/command/helper"
+	"github.com/emc-protocol/edge-matrix/server/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Returns the status of the Edge Matrix client",
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(statusCmd)
+
+	return statusCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statusResponse, err := getSystemStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newStatusResult(statusResponse))
+}
+
+func getSystemStatus(grpcAddress string) (*proto.ServerStatus, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetStatus(context.Background(), &empty.Empty{})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/emc-protocol/edge-matrix/network"
 	"github.com/emc-protocol/edge-matrix/secrets"
 	"github.com/emc-protocol/edge-matrix/state"
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc"
@@ -52,6 +53,9 @@ type Consensus interface {
 
 	// Get singer address
 	GetSignerAddress() types.Address
+
+	// GetTelegramProof fetches the telegram and its receipt verified by the Merkle proofs from peers
+	GetTelegramProof(hash types.Hash) (*types.TelegramProof, error)
 }
 
 // Config is the configuration for the consensus
@@ -76,12 +80,21 @@ type Params struct {
 	Network        *network.Server
 	Blockchain     *blockchain.Blockchain
 	Executor       *state.Executor
+	StateStorage   itrie.Storage
 	Grpc           *grpc.Server
 	Logger         hclog.Logger
 	SecretsManager secrets.SecretsManager
 	BlockTime      uint64
 
 	NumBlockConfirmations uint64
+
+	// CheckpointSync enables syncing the state snapshot at the latest checkpoint
+	// instead of executing all the blocks from genesis
+	CheckpointSync bool
+
+	// LightClient makes the consensus follow the verified headers only,
+	// without executing blocks and taking part in the consensus
+	LightClient bool
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -209,6 +209,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = i.blockchain.CalculateBaseFee(parent)
 
 	if err := i.currentHooks.ModifyHeader(header, i.currentSigner.Address()); err != nil {
 		return nil, err
@@ -238,6 +239,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 		writeCtx,
 		gasLimit,
 		header.Number,
+		header.BaseFee,
 		transition,
 	)
 
@@ -319,7 +321,8 @@ type transitionInterface interface {
 func (i *backendIBFT) writeTransactions(
 	writeCtx context.Context,
 	gasLimit,
-	blockNumber uint64,
+	blockNumber,
+	baseFee uint64,
 	transition transitionInterface,
 ) (executed []*types.Telegram) {
 	executed = make([]*types.Telegram, 0)
@@ -344,7 +347,7 @@ func (i *backendIBFT) writeTransactions(
 		)
 	}()
 
-	i.telepool.Prepare()
+	i.telepool.Prepare(baseFee)
 
 write:
 	for {
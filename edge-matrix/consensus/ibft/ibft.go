@@ -43,7 +43,7 @@ var (
 )
 
 type txPoolInterface interface {
-	Prepare()
+	Prepare(baseFee uint64)
 	Length() uint64
 	Peek() *types.Telegram
 	Pop(tx *types.Telegram)
@@ -89,6 +89,8 @@ type backendIBFT struct {
 	epochSize          uint64
 	quorumSizeBlockNum uint64
 	blockTime          time.Duration // Minimum block generation time in seconds
+	checkpointSync     bool          // Flag to sync the checkpoint state before blocks
+	lightClient        bool          // Flag to sync headers only
 
 	// Channels
 	closeCh chan struct{} // Channel for closing
@@ -149,6 +151,7 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Logger,
 			params.Network,
 			params.Blockchain,
+			params.StateStorage,
 			time.Duration(params.BlockTime)*3*time.Second,
 		),
 		secretsManager: params.SecretsManager,
@@ -160,6 +163,8 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		epochSize:          epochSize,
 		quorumSizeBlockNum: quorumSizeBlockNum,
 		blockTime:          time.Duration(params.BlockTime) * time.Second,
+		checkpointSync:     params.CheckpointSync,
+		lightClient:        params.LightClient,
 
 		// Channels
 		closeCh: make(chan struct{}),
@@ -178,9 +183,11 @@ func (i *backendIBFT) Initialize() error {
 		proto.RegisterIbftOperatorServer(i.Grpc, i.operator)
 	}
 
-	// start the transport protocol
-	if err := i.setupTransport(); err != nil {
-		return err
+	// start the transport protocol, the light client doesn't take part in the consensus
+	if !i.lightClient {
+		if err := i.setupTransport(); err != nil {
+			return err
+		}
 	}
 
 	// initialize fork manager
@@ -236,15 +243,67 @@ func (i *backendIBFT) Start() error {
 		return err
 	}
 
-	// Start syncing blocks from other peers
-	go i.startSyncing()
+	if i.lightClient {
+		// Follow the headers from other peers
+		go i.startHeaderSyncing()
+
+		return nil
+	}
 
-	// Start the actual consensus protocol
-	go i.startConsensus()
+	go func() {
+		// Sync the state at the latest checkpoint before the blocks after it
+		if i.checkpointSync {
+			i.startCheckpointSyncing()
+		}
+
+		// Start syncing blocks from other peers
+		go i.startSyncing()
+
+		// Start the actual consensus protocol
+		i.startConsensus()
+	}()
 
 	return nil
 }
 
+// startCheckpointSyncing runs the syncer to receive the state snapshot and
+// headers up to the latest checkpoint from advanced peers
+func (i *backendIBFT) startCheckpointSyncing() {
+	if err := i.syncer.CheckpointSync(); err != nil {
+		i.logger.Error("checkpoint sync failed", "err", err)
+
+		return
+	}
+
+	if err := i.updateCurrentModules(i.blockchain.Header().Number + 1); err != nil {
+		i.logger.Error("failed to update sub modules", "height", i.blockchain.Header().Number+1, "err", err)
+	}
+}
+
+// startHeaderSyncing runs the syncer in the background to receive headers from advanced peers.
+// The validator set changes are tracked by the snapshot of the written headers
+func (i *backendIBFT) startHeaderSyncing() {
+	callUpdateModules := func(header *types.Header) bool {
+		if err := i.updateCurrentModules(header.Number + 1); err != nil {
+			i.logger.Error("failed to update sub modules", "height", header.Number+1, "err", err)
+		}
+
+		return false
+	}
+
+	if err := i.syncer.HeaderSync(
+		callUpdateModules,
+	); err != nil {
+		i.logger.Error("watch header sync failed", "err", err)
+	}
+}
+
+// GetTelegramProof fetches the telegram and its receipt from peers,
+// verified by the Merkle proofs against the local header
+func (i *backendIBFT) GetTelegramProof(hash types.Hash) (*types.TelegramProof, error) {
+	return i.syncer.GetTelegramProof(hash)
+}
+
 // GetSyncProgression gets the latest sync progression, if any
 func (i *backendIBFT) GetSyncProgression() *progress.Progression {
 	return i.syncer.GetSyncProgression()
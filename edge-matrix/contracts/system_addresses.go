@@ -29,6 +29,10 @@ var (
 	EdgeSubscribeRegisterPrecompile = types.StringToAddress("0x3000")
 	// EdgeCallPrecompile is and address of edge call precompile
 	EdgeCallPrecompile = types.StringToAddress("0x3001")
+	// EdgeMeteringPrecompile is and address of edge usage commitment precompile
+	EdgeMeteringPrecompile = types.StringToAddress("0x3002")
+	// EdgePaymentChannelPrecompile is and address of edge payment channel precompile
+	EdgePaymentChannelPrecompile = types.StringToAddress("0x3003")
 	// EdgeRtcSubjectPrecompile is and address of edge subject precompile
 	EdgeRtcSubjectPrecompile = types.StringToAddress("0x3101")
 )
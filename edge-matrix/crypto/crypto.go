@@ -34,6 +34,7 @@ var (
 	one                    = big.NewInt(1)
 	iv                     = []byte{30, 36, 57, 24, 25, 35, 24, 74, 87, 35, 18, 98, 66, 32, 14, 05}
 	ErrInvalidBLSSignature = errors.New("invalid BLS Signature")
+	ErrInvalidChainID      = errors.New("invalid chain ID")
 )
 
 type KeyType string
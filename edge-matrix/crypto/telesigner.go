@@ -78,6 +78,44 @@ func calcTeleHash(tele *types.Telegram, chainID uint64) types.Hash {
 	return types.BytesToHash(hash)
 }
 
+// calcEnvelopeHash calculates the signing hash of the EIP-2718 typed telegram,
+// which is the keccak256 hash of its type followed by the RLP value of its fields
+func calcEnvelopeHash(tele *types.Telegram, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tele.Nonce))
+
+	if tele.Type == types.DynamicFeeTx {
+		v.Set(a.NewBigInt(tele.GetGasTipCap()))
+		v.Set(a.NewBigInt(tele.GetGasFeeCap()))
+	} else {
+		v.Set(a.NewBigInt(tele.GasPrice))
+	}
+
+	v.Set(a.NewUint(tele.Gas))
+
+	if tele.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tele.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tele.Value))
+	v.Set(a.NewCopyBytes(tele.Input))
+	v.Set(tele.AccessList.MarshalRLPWith(a))
+
+	hasher := keccak.DefaultKeccakPool.Get()
+	_, _ = hasher.Write([]byte{byte(tele.Type)})
+	hash := hasher.WriteRlp(nil, v)
+
+	keccak.DefaultKeccakPool.Put(hasher)
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
 // Hash is a wrapper function for the calcTeleHash, with chainID 0
 func (f *FrontierSigner) Hash(tx *types.Telegram) types.Hash {
 	return calcTeleHash(tx, 0)
@@ -154,11 +192,19 @@ type EIP155Signer struct {
 
 // Hash is a wrapper function that calls calcTeleHash with the EIP155Signer's chainID
 func (e *EIP155Signer) Hash(tx *types.Telegram) types.Hash {
+	if tx.Type.IsEnvelope() {
+		return calcEnvelopeHash(tx, e.chainID)
+	}
+
 	return calcTeleHash(tx, e.chainID)
 }
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tele *types.Telegram) (types.Address, error) {
+	if tele.Type.IsEnvelope() {
+		return e.envelopeSender(tele)
+	}
+
 	protected := true
 
 	// Check if v value conforms to an earlier standard (before EIP155)
@@ -196,6 +242,33 @@ func (e *EIP155Signer) Sender(tele *types.Telegram) (types.Address, error) {
 	return types.BytesToAddress(buf), nil
 }
 
+// envelopeSender returns the sender of the EIP-2718 typed telegram,
+// whose V value is the plain signature parity
+func (e *EIP155Signer) envelopeSender(tele *types.Telegram) (types.Address, error) {
+	if tele.ChainID == nil || tele.ChainID.Cmp(new(big.Int).SetUint64(e.chainID)) != 0 {
+		return types.Address{}, ErrInvalidChainID
+	}
+
+	bigV := big.NewInt(0)
+	if tele.V != nil {
+		bigV.SetBytes(tele.V.Bytes())
+	}
+
+	sig, err := encodeSignature(tele.R, tele.S, bigV, e.isHomestead)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(e.Hash(tele).Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
 // Provider returns the telegram provider
 func (e *EIP155Signer) Provider(tele *types.Telegram) (types.Address, error) {
 
@@ -233,6 +306,10 @@ func (e *EIP155Signer) SignTele(
 ) (*types.Telegram, error) {
 	tx = tx.Copy()
 
+	if tx.Type.IsEnvelope() && tx.ChainID == nil {
+		tx.ChainID = new(big.Int).SetUint64(e.chainID)
+	}
+
 	h := e.Hash(tx)
 
 	sig, err := Sign(privateKey, h[:])
@@ -242,7 +319,12 @@ func (e *EIP155Signer) SignTele(
 
 	tx.R = new(big.Int).SetBytes(sig[:32])
 	tx.S = new(big.Int).SetBytes(sig[32:64])
-	tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+
+	if tx.Type.IsEnvelope() {
+		tx.V = new(big.Int).SetUint64(uint64(sig[64]))
+	} else {
+		tx.V = new(big.Int).SetBytes(e.CalculateV(sig[64]))
+	}
 
 	return tx, nil
 }
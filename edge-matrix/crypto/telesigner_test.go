@@ -585,3 +585,51 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestEIP155Signer_Envelope(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+
+	key, keyGenError := GenerateECDSAKey()
+	if keyGenError != nil {
+		t.Fatalf("Unable to generate key")
+	}
+
+	for _, teleType := range []types.TeleType{types.AccessListTx, types.DynamicFeeTx} {
+		txn := &types.Telegram{
+			Type:      teleType,
+			To:        &toAddress,
+			Value:     big.NewInt(1),
+			GasPrice:  big.NewInt(10),
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10),
+			AccessList: types.TxAccessList{
+				{Address: toAddress, StorageKeys: []types.Hash{types.StringToHash("1")}},
+			},
+		}
+
+		signer := NewEIP155Signer(chain.AllForksEnabled.At(0), 100)
+
+		signedTx, signErr := signer.SignTele(txn, key)
+		if signErr != nil {
+			t.Fatalf("Unable to sign telegram")
+		}
+
+		// the envelope V value is the signature parity
+		assert.True(t, signedTx.V.Uint64() <= 1)
+		assert.Equal(t, uint64(100), signedTx.ChainID.Uint64())
+
+		// the sender is recovered after the round trip of the envelope
+		decodedTx := new(types.Telegram)
+		assert.NoError(t, decodedTx.UnmarshalRLP(signedTx.MarshalRLP()))
+
+		recoveredSender, recoverErr := signer.Sender(decodedTx)
+		assert.NoError(t, recoverErr)
+		assert.Equal(t, PubKeyToAddress(&key.PublicKey), recoveredSender)
+
+		// the envelope of another chain is rejected
+		_, recoverErr = NewEIP155Signer(chain.AllForksEnabled.At(0), 1).Sender(decodedTx)
+		assert.ErrorIs(t, recoverErr, ErrInvalidChainID)
+	}
+}
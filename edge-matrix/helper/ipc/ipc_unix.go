@@ -4,9 +4,11 @@
 package ipc
 
 import (
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/emc-protocol/edge-matrix/helper/common"
@@ -22,13 +24,38 @@ func DialTimeout(path string, timeout time.Duration) (net.Conn, error) {
 	return net.DialTimeout("unix", path, timeout)
 }
 
+// MaxPathLength is the maximum length of a unix socket path, the size of sun_path less its NUL terminator
+func MaxPathLength() int {
+	if runtime.GOOS == "linux" {
+		return 107
+	}
+
+	// darwin and the BSDs
+	return 103
+}
+
+// ValidatePath checks the IPC path fits in a unix socket address
+func ValidatePath(path string) error {
+	if len(path) > MaxPathLength() {
+		return fmt.Errorf("ipc path %s is %d bytes long, longer than the %d bytes of a unix socket path",
+			path, len(path), MaxPathLength())
+	}
+
+	return nil
+}
+
 // Listen listens an IPC path
 func Listen(path string) (net.Listener, error) {
+	if err := ValidatePath(path); err != nil {
+		return nil, err
+	}
+
 	if err := common.CreateDirSafe(filepath.Dir(path), 0751); err != nil {
 		return nil, err
 	}
 
-	if removeErr := os.Remove(path); removeErr != nil {
+	// remove the socket left by the previous run
+	if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
 		return nil, removeErr
 	}
 
@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+package ipc
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListen_PathTooLong(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	assert.NoError(t, ValidatePath(filepath.Join(dir, "edge.ipc")))
+
+	path := filepath.Join(dir, strings.Repeat("d", MaxPathLength()), "edge.ipc")
+	assert.Error(t, ValidatePath(path))
+
+	_, err := Listen(path)
+	assert.ErrorContains(t, err, "longer than")
+}
+
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	lis, err := Listen(filepath.Join(t.TempDir(), "edge.ipc"))
+	assert.NoError(t, err)
+	assert.NoError(t, lis.Close())
+}
@@ -20,6 +20,11 @@ func DialTimeout(path string, timeout time.Duration) (net.Conn, error) {
 	return npipe.DialTimeout(path, timeout)
 }
 
+// ValidatePath checks the IPC path, any named pipe path is valid
+func ValidatePath(path string) error {
+	return nil
+}
+
 // Listen listens an IPC path
 func Listen(path string) (net.Listener, error) {
 	return npipe.Listen(path)
@@ -9,8 +9,9 @@ import (
 type ChainSyncType string
 
 const (
-	ChainSyncRestore ChainSyncType = "restore"
-	ChainSyncBulk    ChainSyncType = "bulk-sync"
+	ChainSyncRestore    ChainSyncType = "restore"
+	ChainSyncBulk       ChainSyncType = "bulk-sync"
+	ChainSyncCheckpoint ChainSyncType = "checkpoint-sync"
 )
 
 // Progression defines the status of the sync
@@ -28,6 +29,10 @@ type Progression struct {
 
 	// HighestBlock is the target block in the sync batch
 	HighestBlock uint64
+
+	// PulledStates is the number of state trie nodes and codes
+	// downloaded by the checkpoint sync
+	PulledStates uint64
 }
 
 type ProgressionWrapper struct {
@@ -120,6 +125,14 @@ func (pw *ProgressionWrapper) UpdateHighestProgression(highestBlock uint64) {
 	pw.progression.HighestBlock = highestBlock
 }
 
+// UpdatePulledStates sets the number of downloaded state entries in the checkpoint sync
+func (pw *ProgressionWrapper) UpdatePulledStates(pulledStates uint64) {
+	pw.lock.Lock()
+	defer pw.lock.Unlock()
+
+	pw.progression.PulledStates = pulledStates
+}
+
 // GetProgression returns the latest sync progression
 func (pw *ProgressionWrapper) GetProgression() *Progression {
 	pw.lock.RLock()
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// blobStatus is the blob returned by the edge_putBlob api method
+type blobStatus struct {
+	Hash    types.Hash `json:"hash"`
+	Missing []string   `json:"missing"`
+}
+
+func (c *JsonRpcClient) call(method string, params []interface{}, result interface{}) error {
+	postJson, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	})
+	if err != nil {
+		return err
+	}
+
+	bytes, err := c.httpClient.SendPostJsonRequest(c.rpcUrl, postJson)
+	if err != nil {
+		return err
+	}
+
+	response := &struct {
+		Result json.RawMessage `json:"result"`
+		Error  Error           `json:"error"`
+	}{}
+	if err := json.Unmarshal(bytes, response); err != nil {
+		return err
+	}
+
+	if response.Error.Code != 0 {
+		return errors.New(response.Error.Message)
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// UploadBlob uploads the data to the node in chunks, and returns the hash of the blob referenced by the edge calls.
+// The chunks stored by the node already are not uploaded again, so an interrupted upload is resumed by calling it again
+func (c *JsonRpcClient) UploadBlob(data []byte) (types.Hash, error) {
+	m, chunks := blob.NewManifest(data, blob.DefaultChunkSize)
+
+	status := &blobStatus{}
+	if err := c.call("edge_putBlob", []interface{}{map[string]interface{}{
+		"size":      hex.EncodeUint64(m.Size),
+		"chunkSize": hex.EncodeUint64(m.ChunkSize),
+		"chunks":    m.Chunks,
+	}}, status); err != nil {
+		return types.ZeroHash, err
+	}
+
+	for _, missing := range status.Missing {
+		index, err := hex.DecodeUint64(missing)
+		if err != nil {
+			return types.ZeroHash, err
+		}
+
+		if index >= uint64(len(chunks)) {
+			return types.ZeroHash, fmt.Errorf("unknown chunk %d of blob %s", index, status.Hash)
+		}
+
+		var hash types.Hash
+		if err := c.call("edge_putBlobChunk", []interface{}{hex.EncodeToHex(chunks[index])}, &hash); err != nil {
+			return types.ZeroHash, err
+		}
+
+		if hash != m.Chunks[index] {
+			return types.ZeroHash, fmt.Errorf("%w: chunk %d of blob %s", blob.ErrChunkMismatch, index, status.Hash)
+		}
+	}
+
+	return status.Hash, nil
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// EdgePaymentChannelPrecompile is an address of edge payment channel precompile
+var EdgePaymentChannelPrecompile = types.StringToAddress("0x3003")
+
+// paymentChannel is the payment channel returned by the edge_getChannel api method
+type paymentChannel struct {
+	ID          types.Hash    `json:"id"`
+	Sender      types.Address `json:"sender"`
+	Provider    types.Address `json:"provider"`
+	Deposit     string        `json:"deposit"`
+	Paid        string        `json:"paid"`
+	Expiration  string        `json:"expiration"`
+	Closed      bool          `json:"closed"`
+	BlockNumber string        `json:"blockNumber"`
+}
+
+// GetChannel returns the payment channel from the latest state and the number of its block,
+// nil if the channel is not opened
+func (c *JsonRpcClient) GetChannel(id types.Hash) (*channel.Channel, uint64, error) {
+	postJson := fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"method\":\"edge_getChannel\",\"params\":[\"%s\"],\"id\":1}", id)
+	bytes, err := c.httpClient.SendPostJsonRequest(c.rpcUrl, []byte(postJson))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response := &struct {
+		Result *paymentChannel `json:"result"`
+		Error  Error           `json:"error"`
+	}{}
+	if err := json.Unmarshal(bytes, response); err != nil {
+		return nil, 0, err
+	}
+
+	if response.Error.Code != 0 {
+		if response.Error.Message == channel.ErrUnknownChannel.Error() {
+			return nil, 0, nil
+		}
+
+		return nil, 0, errors.New(response.Error.Message)
+	}
+
+	res := response.Result
+	if res == nil {
+		return nil, 0, channel.ErrUnknownChannel
+	}
+
+	expiration, err := hex.DecodeUint64(res.Expiration)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	number, err := hex.DecodeUint64(res.BlockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &channel.Channel{
+		ID:         res.ID,
+		Sender:     res.Sender,
+		Provider:   res.Provider,
+		Deposit:    hex.DecodeHexToBig(res.Deposit[2:]),
+		Paid:       hex.DecodeHexToBig(res.Paid[2:]),
+		Expiration: expiration,
+		Closed:     res.Closed,
+	}, number, nil
+}
+
+// SendChannelAction sends the telegram of the channel action with the value to the payment channel precompile,
+// and returns the telegram hash
+func (c *JsonRpcClient) SendChannelAction(action *channel.Action, value *big.Int, privateKey *ecdsa.PrivateKey) (types.Hash, error) {
+	nonce, err := c.GetNextNonce(crypto.PubKeyToAddress(&privateKey.PublicKey).String())
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return c.sendChannelAction(action, value, nonce, privateKey)
+}
+
+// OpenChannel opens the payment channel to the provider with the deposit, expiring at the block number,
+// and returns the ID of the channel opened once the telegram is mined
+func (c *JsonRpcClient) OpenChannel(
+	provider types.Address,
+	deposit *big.Int,
+	expiration uint64,
+	privateKey *ecdsa.PrivateKey,
+) (types.Hash, error) {
+	sender := crypto.PubKeyToAddress(&privateKey.PublicKey)
+
+	nonce, err := c.GetNextNonce(sender.String())
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	action := &channel.Action{Type: channel.ActionOpen, Provider: provider, Expiration: expiration}
+	if _, err := c.sendChannelAction(action, deposit, nonce, privateKey); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return channel.ID(sender, provider, nonce), nil
+}
+
+func (c *JsonRpcClient) sendChannelAction(
+	action *channel.Action,
+	value *big.Int,
+	nonce uint64,
+	privateKey *ecdsa.PrivateKey,
+) (types.Hash, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	to := EdgePaymentChannelPrecompile
+
+	response, err := c.sendTelegram(&types.Telegram{
+		To:       &to,
+		Value:    value,
+		GasPrice: big.NewInt(0),
+		Nonce:    nonce,
+		Gas:      action.Gas(),
+		Input:    action.MarshalRLP(),
+	}, privateKey)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.StringToHash(response.Result.TelegramHash), nil
+}
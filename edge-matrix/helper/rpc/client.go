@@ -2,9 +2,11 @@ package rpc
 
 import (
 	"crypto/ecdsa"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application/channel"
 	"github.com/emc-protocol/edge-matrix/chain"
 	"github.com/emc-protocol/edge-matrix/crypto"
 	"github.com/emc-protocol/edge-matrix/helper/hex"
@@ -82,6 +84,14 @@ func NewJsonRpcClient(rpcHost string) *JsonRpcClient {
 	}
 }
 
+// NewJsonRpcClientWithChainID creates the client signing the telegrams for the given chain
+func NewJsonRpcClientWithChainID(rpcHost string, chainID uint64) *JsonRpcClient {
+	c := NewJsonRpcClient(rpcHost)
+	c.signer = crypto.NewEIP155Signer(chain.AllForksEnabled.At(0), chainID)
+
+	return c
+}
+
 // Returns next nonce value for address
 func (c *JsonRpcClient) GetNextNonce(address string) (uint64, error) {
 	postJson := fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"method\":\"edge_getTelegramCount\",\"params\":[\"%s\"],\"id\":1}", address)
@@ -116,6 +126,11 @@ func (c *JsonRpcClient) SendRawTelegram(to types.Address, nonce uint64, input st
 		return nil, errors.New("json.Marshal err: " + err.Error())
 	}
 
+	return c.sendTelegram(tele, privateKey)
+}
+
+// sendTelegram signs the telegram and sends it by the edge_sendRawTelegram api method
+func (c *JsonRpcClient) sendTelegram(tele *types.Telegram, privateKey *ecdsa.PrivateKey) (*TelegramResponse, error) {
 	signedTx, signErr := c.signer.SignTele(tele, privateKey)
 	if signErr != nil {
 		return nil, errors.New("Unable to sign transaction")
@@ -123,7 +138,7 @@ func (c *JsonRpcClient) SendRawTelegram(to types.Address, nonce uint64, input st
 
 	bytes := signedTx.MarshalRLP()
 	postJson := fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"method\":\"edge_sendRawTelegram\",\"params\":[\"%s\"],\"id\":1}", hex.EncodeToHex(bytes))
-	bytes, err = c.httpClient.SendPostJsonRequest(c.rpcUrl, []byte(postJson))
+	bytes, err := c.httpClient.SendPostJsonRequest(c.rpcUrl, []byte(postJson))
 	if err != nil {
 		return nil, errors.New("SendPostJsonRequest err:" + err.Error())
 	}
@@ -150,3 +165,91 @@ func (c *JsonRpcClient) SendRawTelegram(to types.Address, nonce uint64, input st
 	response.Result = *r
 	return response, nil
 }
+
+// apiInput is the input of an edge call to the /api endpoint, forwarded to the app of the edge node
+type apiInput struct {
+	Method  string          `json:"method"`
+	Headers []string        `json:"headers"`
+	Path    string          `json:"path"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+// SendEdgeCall calls the app of the edge node through its /api endpoint by an edge call telegram,
+// and returns the response of the app
+func (c *JsonRpcClient) SendEdgeCall(peerId string, method string, path string, body interface{}, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	return c.SendPaidEdgeCall(peerId, method, path, body, nil, privateKey)
+}
+
+// SendPaidEdgeCall calls the app of the edge node like SendEdgeCall, paying the call by the voucher if not nil
+func (c *JsonRpcClient) SendPaidEdgeCall(
+	peerId string,
+	method string,
+	path string,
+	body interface{},
+	voucher *channel.Voucher,
+	privateKey *ecdsa.PrivateKey,
+) ([]byte, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := json.Marshal(&struct {
+		PeerId   string           `json:"peerId"`
+		Endpoint string           `json:"endpoint"`
+		Input    *apiInput        `json:"input"`
+		Voucher  *channel.Voucher `json:"voucher,omitempty"`
+	}{
+		PeerId:   peerId,
+		Endpoint: "/api",
+		Input: &apiInput{
+			Method:  method,
+			Headers: []string{},
+			Path:    path,
+			Body:    bodyBytes,
+		},
+		Voucher: voucher,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := c.GetNextNonce(crypto.PubKeyToAddress(&privateKey.PublicKey).String())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.SendRawTelegram(EdgeCallPrecompile, nonce, string(input), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error.Code < 0 {
+		return nil, errors.New(response.Error.Message)
+	}
+
+	return base64.StdEncoding.DecodeString(response.Result.Response)
+}
+
+// GetAppIdl returns the parsed IDL of the app hosted by the edge node, its default app if the app is empty
+func (c *JsonRpcClient) GetAppIdl(peerId string, app string) (json.RawMessage, error) {
+	postJson := fmt.Sprintf("{\"jsonrpc\":\"2.0\",\"method\":\"edge_getAppIdl\",\"params\":[\"%s\",\"%s\"],\"id\":1}", peerId, app)
+	bytes, err := c.httpClient.SendPostJsonRequest(c.rpcUrl, []byte(postJson))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &struct {
+		Result json.RawMessage `json:"result"`
+		Error  Error           `json:"error"`
+	}{}
+	if err := json.Unmarshal(bytes, response); err != nil {
+		return nil, err
+	}
+
+	if response.Error.Code != 0 {
+		return nil, errors.New(response.Error.Message)
+	}
+
+	return response.Result, nil
+}
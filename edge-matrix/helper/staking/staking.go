@@ -128,6 +128,10 @@ func setBytesToStorage(
 type PredeployParams struct {
 	MinValidatorCount uint64
 	MaxValidatorCount uint64
+
+	// StakedBalances overrides the pre-staked balance of the validators,
+	// which is DefaultStakedBalance if not set
+	StakedBalances map[types.Address]*big.Int
 }
 
 // StorageIndexes is a wrapper for different storage indexes that
@@ -192,8 +196,13 @@ func PredeployStakingSC(
 		for idx := 0; idx < vals.Len(); idx++ {
 			validator := vals.At(uint64(idx))
 
+			stakedBalance := bigDefaultStakedBalance
+			if balance, ok := params.StakedBalances[validator.Addr()]; ok {
+				stakedBalance = balance
+			}
+
 			// Update the total staked amount
-			stakedAmount = stakedAmount.Add(stakedAmount, bigDefaultStakedBalance)
+			stakedAmount = stakedAmount.Add(stakedAmount, stakedBalance)
 
 			// Get the storage indexes
 			storageIndexes := getStorageIndexes(validator, idx)
@@ -218,7 +227,7 @@ func PredeployStakingSC(
 
 			// Set the value for the address -> staked amount mapping
 			storageMap[types.BytesToHash(storageIndexes.AddressToStakedAmountIndex)] =
-				types.StringToHash(hex.EncodeBig(bigDefaultStakedBalance))
+				types.StringToHash(hex.EncodeBig(stakedBalance))
 
 			// Set the value for the address -> validator index mapping
 			storageMap[types.BytesToHash(storageIndexes.AddressToValidatorIndexIndex)] =
@@ -0,0 +1,75 @@
+package staking
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/helper/hex"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredeployStakingSC_StakedBalances(t *testing.T) {
+	t.Parallel()
+
+	rawDefaultStake := DefaultStakedBalance
+
+	defaultStake, err := types.ParseUint256orHex(&rawDefaultStake)
+	require.NoError(t, err)
+
+	staked := validators.NewECDSAValidator(types.StringToAddress("0x1"))
+	unstaked := validators.NewECDSAValidator(types.StringToAddress("0x2"))
+
+	cases := []struct {
+		name     string
+		balances map[types.Address]*big.Int
+		stakes   []*big.Int
+	}{
+		{"default stakes", nil, []*big.Int{defaultStake, defaultStake}},
+		{
+			"stake of one validator",
+			map[types.Address]*big.Int{staked.Addr(): big.NewInt(0x100)},
+			[]*big.Int{big.NewInt(0x100), defaultStake},
+		},
+		{
+			"stakes of all validators",
+			map[types.Address]*big.Int{staked.Addr(): big.NewInt(0x100), unstaked.Addr(): big.NewInt(0x200)},
+			[]*big.Int{big.NewInt(0x100), big.NewInt(0x200)},
+		},
+	}
+
+	for _, c := range cases {
+		account, err := PredeployStakingSC(validators.NewECDSAValidatorSet(staked, unstaked), PredeployParams{
+			MinValidatorCount: 1,
+			MaxValidatorCount: 4,
+			StakedBalances:    c.balances,
+		})
+		require.NoError(t, err, c.name)
+
+		total := big.NewInt(0)
+
+		for i, validator := range []validators.Validator{staked, unstaked} {
+			indexes := getStorageIndexes(validator, i)
+
+			assert.Equal(
+				t,
+				types.StringToHash(hex.EncodeBig(c.stakes[i])),
+				account.Storage[types.BytesToHash(indexes.AddressToStakedAmountIndex)],
+				c.name,
+			)
+
+			total.Add(total, c.stakes[i])
+		}
+
+		// the contract holds the sum of the stakes
+		assert.Equal(t, total, account.Balance, c.name)
+		assert.Equal(
+			t,
+			types.BytesToHash(total.Bytes()),
+			account.Storage[types.BytesToHash(big.NewInt(stakedAmountSlot).Bytes())],
+			c.name,
+		)
+	}
+}
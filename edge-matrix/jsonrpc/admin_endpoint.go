@@ -0,0 +1,171 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/capability"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+var errEmptyTelepoolLimits = errors.New("no telepool limits to update")
+
+// adminStore provides access to the methods needed for admin endpoint
+type adminStore interface {
+	// GetPeerInfos returns the connected peers of the node networks
+	GetPeerInfos() []*PeerInfo
+
+	// JoinPeer marks the peer of the given multiaddr ready for dialing
+	JoinPeer(rawPeerMultiaddr string) error
+
+	// DisconnectPeer closes the connection to the peer of the given ID
+	DisconnectPeer(id string) error
+
+	// GetNodeInfo returns the identity and the addresses of the node
+	GetNodeInfo() *NodeInfo
+
+	// SetLogLevel changes the level of the node logger
+	SetLogLevel(level hclog.Level)
+
+	// GetAppPeers returns the application peers known to the node
+	GetAppPeers() []*AppPeerInfo
+
+	// GetRelayReservations returns the reservations of the node on the relay peers
+	GetRelayReservations() []*RelayReservation
+
+	// SetTelepoolLimits updates the limits of the telepool and returns the current ones
+	SetTelepoolLimits(limits *TelepoolLimits) *TelepoolLimits
+}
+
+// PeerInfo is a connected peer of the node
+type PeerInfo struct {
+	ID        string   `json:"id"`
+	Network   string   `json:"network"`
+	Addrs     []string `json:"addrs"`
+	Protocols []string `json:"protocols"`
+}
+
+// NodeInfo is the identity and the addresses of the node
+type NodeInfo struct {
+	ID          string   `json:"id"`
+	Version     string   `json:"version"`
+	RunningMode string   `json:"runningMode"`
+	ChainID     uint64   `json:"chainId"`
+	Addrs       []string `json:"addrs"`
+	EdgeID      string   `json:"edgeId,omitempty"`
+	EdgeAddrs   []string `json:"edgeAddrs,omitempty"`
+	LogLevel    string   `json:"logLevel"`
+}
+
+// AppPeerInfo is the last known status of an application peer
+type AppPeerInfo struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Relay        string  `json:"relay"`
+	Addr         string  `json:"addr"`
+	AppOrigin    string  `json:"appOrigin"`
+	ModelHash    string  `json:"modelHash"`
+	Mac          string  `json:"mac"`
+	MemInfo      string  `json:"memInfo"`
+	CpuInfo      string  `json:"cpuInfo"`
+	GpuInfo      string  `json:"gpuInfo"`
+	Version      string  `json:"version"`
+	StartupTime  uint64  `json:"startupTime"`
+	Uptime       uint64  `json:"uptime"`
+	Slots        uint64  `json:"slots"`
+	MaxSlots     uint64  `json:"maxSlots"`
+	AveragePower float32 `json:"averagePower"`
+
+	// LastSeen is the unix time in milliseconds of the last status or liveness proof of the app
+	LastSeen int64 `json:"lastSeen"`
+
+	// Capabilities is the hardware reported by the app, nil for the apps prior to the capability schema
+	Capabilities *capability.Capabilities `json:"capabilities,omitempty"`
+
+	// Apps are the apps hosted by the node, the first one being the default app of the legacy fields
+	Apps []*application.HostedApp `json:"apps"`
+}
+
+// RelayReservation is a reservation of the node on a relay peer
+type RelayReservation struct {
+	RelayID       string   `json:"relayId"`
+	Addrs         []string `json:"addrs"`
+	Expiration    int64    `json:"expiration"`
+	LimitDuration uint64   `json:"limitDuration"`
+	LimitData     uint64   `json:"limitData"`
+}
+
+// TelepoolLimits are the limits of the telepool adjustable at runtime,
+// the zero limits are left unchanged on update
+type TelepoolLimits struct {
+	MaxSlots           uint64 `json:"maxSlots"`
+	MaxAccountEnqueued uint64 `json:"maxAccountEnqueued"`
+	PriceLimit         uint64 `json:"priceLimit"`
+}
+
+// Admin is the admin jsonrpc endpoint, which is served on the IPC endpoint
+// and to the authenticated callers granted the admin namespace
+type Admin struct {
+	store adminStore
+}
+
+// Peers returns the connected peers of the node
+func (a *Admin) Peers() (interface{}, error) {
+	return a.store.GetPeerInfos(), nil
+}
+
+// AddPeer connects to the peer of the given multiaddr
+func (a *Admin) AddPeer(rawPeerMultiaddr string) (interface{}, error) {
+	if err := a.store.JoinPeer(rawPeerMultiaddr); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// RemovePeer disconnects from the peer of the given ID
+func (a *Admin) RemovePeer(id string) (interface{}, error) {
+	if err := a.store.DisconnectPeer(id); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// NodeInfo returns the identity and the addresses of the node
+func (a *Admin) NodeInfo() (interface{}, error) {
+	return a.store.GetNodeInfo(), nil
+}
+
+// SetLogLevel changes the level of the node logger without the restart
+func (a *Admin) SetLogLevel(level string) (interface{}, error) {
+	logLevel := hclog.LevelFromString(level)
+	if logLevel == hclog.NoLevel {
+		return nil, fmt.Errorf("invalid log level: %s", level)
+	}
+
+	a.store.SetLogLevel(logLevel)
+
+	return logLevel.String(), nil
+}
+
+// AppPeers returns the application peers known to the node
+func (a *Admin) AppPeers() (interface{}, error) {
+	return a.store.GetAppPeers(), nil
+}
+
+// RelayReservations returns the reservations of the node on the relay peers
+func (a *Admin) RelayReservations() (interface{}, error) {
+	return a.store.GetRelayReservations(), nil
+}
+
+// SetTelepoolLimits updates the limits of the telepool without the restart
+func (a *Admin) SetTelepoolLimits(limits *TelepoolLimits) (interface{}, error) {
+	if limits == nil || (limits.MaxSlots == 0 && limits.MaxAccountEnqueued == 0 && limits.PriceLimit == 0) {
+		return nil, errEmptyTelepoolLimits
+	}
+
+	return a.store.SetTelepoolLimits(limits), nil
+}
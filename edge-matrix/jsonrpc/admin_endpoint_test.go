@@ -0,0 +1,111 @@
+package jsonrpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAdminStore struct {
+	adminStore
+
+	joined       []string
+	disconnected []string
+	logLevel     hclog.Level
+	limits       TelepoolLimits
+}
+
+func (m *mockAdminStore) JoinPeer(rawPeerMultiaddr string) error {
+	if rawPeerMultiaddr == "" {
+		return errors.New("invalid multiaddr")
+	}
+
+	m.joined = append(m.joined, rawPeerMultiaddr)
+
+	return nil
+}
+
+func (m *mockAdminStore) DisconnectPeer(id string) error {
+	m.disconnected = append(m.disconnected, id)
+
+	return nil
+}
+
+func (m *mockAdminStore) SetLogLevel(level hclog.Level) {
+	m.logLevel = level
+}
+
+func (m *mockAdminStore) SetTelepoolLimits(limits *TelepoolLimits) *TelepoolLimits {
+	if limits.MaxSlots != 0 {
+		m.limits.MaxSlots = limits.MaxSlots
+	}
+
+	if limits.MaxAccountEnqueued != 0 {
+		m.limits.MaxAccountEnqueued = limits.MaxAccountEnqueued
+	}
+
+	if limits.PriceLimit != 0 {
+		m.limits.PriceLimit = limits.PriceLimit
+	}
+
+	current := m.limits
+
+	return &current
+}
+
+func TestAdminEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds and removes peers", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockAdminStore{}
+		admin := &Admin{store}
+
+		result, err := admin.AddPeer("/ip4/127.0.0.1/tcp/10001/p2p/16Uiu2HAm")
+		assert.NoError(t, err)
+		assert.Equal(t, true, result)
+
+		_, err = admin.AddPeer("")
+		assert.Error(t, err)
+
+		result, err = admin.RemovePeer("16Uiu2HAm")
+		assert.NoError(t, err)
+		assert.Equal(t, true, result)
+
+		assert.Equal(t, []string{"/ip4/127.0.0.1/tcp/10001/p2p/16Uiu2HAm"}, store.joined)
+		assert.Equal(t, []string{"16Uiu2HAm"}, store.disconnected)
+	})
+
+	t.Run("sets log level", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockAdminStore{}
+		admin := &Admin{store}
+
+		result, err := admin.SetLogLevel("DEBUG")
+		assert.NoError(t, err)
+		assert.Equal(t, "debug", result)
+		assert.Equal(t, hclog.Debug, store.logLevel)
+
+		_, err = admin.SetLogLevel("verbose")
+		assert.Error(t, err)
+		assert.Equal(t, hclog.Debug, store.logLevel)
+	})
+
+	t.Run("sets telepool limits", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockAdminStore{limits: TelepoolLimits{MaxSlots: 4096, MaxAccountEnqueued: 128, PriceLimit: 1}}
+		admin := &Admin{store}
+
+		result, err := admin.SetTelepoolLimits(&TelepoolLimits{PriceLimit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, &TelepoolLimits{MaxSlots: 4096, MaxAccountEnqueued: 128, PriceLimit: 10}, result)
+
+		_, err = admin.SetTelepoolLimits(&TelepoolLimits{})
+		assert.ErrorIs(t, err, errEmptyTelepoolLimits)
+	})
+}
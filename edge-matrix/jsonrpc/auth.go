@@ -0,0 +1,378 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+const (
+	jsonRPCMetrics = "jsonrpc"
+
+	// header and query parameter carrying the API key,
+	// the query parameter is for the websocket clients which can't set headers
+	apiKeyHeader     = "X-API-Key"
+	apiKeyQueryParam = "apikey"
+
+	// allowed clock skew for the issued time of the tokens
+	jwtIssuedAtSkew = 60 * time.Second
+)
+
+var (
+	errInvalidToken = errors.New("invalid token")
+	errTokenExpired = errors.New("token is expired")
+)
+
+// reasons of the rejected requests
+const (
+	rejectUnauthorized = "unauthorized"
+	rejectForbidden    = "forbidden"
+	rejectRateLimited  = "rate_limited"
+)
+
+// AuthConfig defines the authentication and the rate limits of the JSON-RPC server
+type AuthConfig struct {
+	// Required rejects the requests without the credentials
+	Required bool
+
+	// JWTSecret is the secret of the HS256 tokens, the tokens are not accepted if it's empty
+	JWTSecret []byte
+
+	// APIKeys are the accepted API keys
+	APIKeys []*APIKey
+
+	// IPRateLimits are the rate limits per client IP of the requests without the credentials,
+	// by method name, namespace (e.g. "edge_*") or "*" for all
+	IPRateLimits map[string]RateLimit
+
+	// KeyRateLimits are the rate limits per API key or token subject,
+	// in the same format as IPRateLimits
+	KeyRateLimits map[string]RateLimit
+}
+
+// APIKey is an API key accepted by the JSON-RPC server
+type APIKey struct {
+	Name string
+	Key  string
+
+	// Methods are the method names or namespaces (e.g. "eth_*") allowed for the key,
+	// all methods are allowed if it's empty
+	Methods []string
+
+	// RateLimits override the KeyRateLimits for the key
+	RateLimits map[string]RateLimit
+}
+
+// caller is the authenticated client of the requests
+type caller struct {
+	// id of the client for the rate limits
+	id string
+
+	// auth is the type of the credentials, for the metrics
+	auth string
+
+	// allowed methods, all methods are allowed if it's empty
+	methods []string
+
+	rateLimits map[string]RateLimit
+}
+
+// grantsPrivileged checks the credentials of the caller name the privileged method
+// or its namespace (e.g. "admin_*"), the wildcard "*" doesn't grant the privileged methods
+func (c *caller) grantsPrivileged(namespace, method string) bool {
+	if c == nil || c.auth == "anonymous" {
+		return false
+	}
+
+	for _, pattern := range c.methods {
+		if pattern == method || pattern == namespace+"_*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jwtClaims are the claims of the tokens used by the server
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	ExpiresAt int64    `json:"exp"`
+	IssuedAt  int64    `json:"iat"`
+	Methods   []string `json:"methods"`
+}
+
+// guard authenticates the clients and checks the methods and rate limits of their requests
+type guard struct {
+	config *AuthConfig
+
+	// API keys by the hash of the key
+	keys map[[sha256.Size]byte]*APIKey
+
+	limiter *rateLimiter
+	now     func() time.Time
+}
+
+// newGuard returns the guard of the given config, or nil if nothing is configured
+func newGuard(config *AuthConfig) *guard {
+	if config == nil ||
+		(!config.Required && len(config.JWTSecret) == 0 && len(config.APIKeys) == 0 &&
+			len(config.IPRateLimits) == 0 && len(config.KeyRateLimits) == 0) {
+		return nil
+	}
+
+	g := &guard{
+		config:  config,
+		keys:    make(map[[sha256.Size]byte]*APIKey, len(config.APIKeys)),
+		limiter: newRateLimiter(),
+		now:     time.Now,
+	}
+
+	for _, key := range config.APIKeys {
+		g.keys[sha256.Sum256([]byte(key.Key))] = key
+	}
+
+	return g
+}
+
+// authenticate returns the caller of the HTTP request by its API key or token.
+// The requests without the credentials are limited by the client IP
+func (g *guard) authenticate(r *http.Request) (*caller, Error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	if key := apiKeyOf(r); key != "" {
+		apiKey, ok := g.keys[sha256.Sum256([]byte(key))]
+		if !ok {
+			return nil, g.reject(rejectUnauthorized, "apikey", NewUnauthorizedError("invalid API key"))
+		}
+
+		rateLimits := apiKey.RateLimits
+		if rateLimits == nil {
+			rateLimits = g.config.KeyRateLimits
+		}
+
+		return &caller{
+			id:         "key:" + apiKey.Name,
+			auth:       "apikey",
+			methods:    apiKey.Methods,
+			rateLimits: rateLimits,
+		}, nil
+	}
+
+	if token := bearerTokenOf(r); token != "" {
+		if len(g.config.JWTSecret) == 0 {
+			return nil, g.reject(rejectUnauthorized, "jwt", NewUnauthorizedError("token authentication is disabled"))
+		}
+
+		claims, err := verifyJWT(token, g.config.JWTSecret, g.now())
+		if err != nil {
+			return nil, g.reject(rejectUnauthorized, "jwt", NewUnauthorizedError(err.Error()))
+		}
+
+		return &caller{
+			id:         "jwt:" + claims.Subject,
+			auth:       "jwt",
+			methods:    claims.Methods,
+			rateLimits: g.config.KeyRateLimits,
+		}, nil
+	}
+
+	if g.config.Required {
+		return nil, g.reject(rejectUnauthorized, "anonymous", NewUnauthorizedError("missing credentials"))
+	}
+
+	return &caller{
+		id:         "ip:" + clientIPOf(r),
+		auth:       "anonymous",
+		rateLimits: g.config.IPRateLimits,
+	}, nil
+}
+
+// authorize checks the caller is allowed to call the methods of the request body
+// and takes the tokens of the rate limits
+func (g *guard) authorize(c *caller, reqBody []byte) (interface{}, Error) {
+	if g == nil || c == nil {
+		return nil, nil
+	}
+
+	for _, req := range peekRequests(reqBody) {
+		if err := g.authorizeMethod(c, req.Method); err != nil {
+			return req.ID, err
+		}
+	}
+
+	return nil, nil
+}
+
+// authorizeMethod checks the caller is allowed to call the method at this time
+func (g *guard) authorizeMethod(c *caller, method string) Error {
+	if len(c.methods) > 0 && !matchMethods(c.methods, method) {
+		return g.reject(rejectForbidden, c.auth, NewMethodNotAllowedError(method))
+	}
+
+	rule, limit, ok := findRateLimit(c.rateLimits, method)
+	if !ok {
+		return nil
+	}
+
+	if !g.limiter.allow(c.id+"/"+rule, limit) {
+		return g.reject(rejectRateLimited, c.auth, NewRateLimitError(method))
+	}
+
+	return nil
+}
+
+// reject counts the rejected request and returns the error
+func (g *guard) reject(reason, auth string, err Error) Error {
+	metrics.IncrCounterWithLabels([]string{jsonRPCMetrics, "rejected_requests"}, 1, []metrics.Label{
+		{Name: "reason", Value: reason},
+		{Name: "auth", Value: auth},
+	})
+
+	return err
+}
+
+// statusCodeOf returns the HTTP status code of the rejection
+func statusCodeOf(err Error) int {
+	switch err.(type) {
+	case *unauthorizedError:
+		return http.StatusUnauthorized
+	case *methodNotAllowedError:
+		return http.StatusForbidden
+	case *rateLimitError:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusOK
+	}
+}
+
+// matchMethods checks the method matches any of the method names or namespaces
+func matchMethods(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == method {
+			return true
+		}
+
+		if strings.HasSuffix(pattern, "_*") && strings.HasPrefix(method, pattern[:len(pattern)-1]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// peekRequests decodes the methods and IDs of the single or batch request,
+// the invalid requests are left to the dispatcher
+func peekRequests(reqBody []byte) []Request {
+	x := bytes.TrimLeft(reqBody, " \t\r\n")
+	if len(x) == 0 {
+		return nil
+	}
+
+	if x[0] == '{' {
+		var req Request
+		if err := json.Unmarshal(x, &req); err != nil {
+			return nil
+		}
+
+		return []Request{req}
+	}
+
+	var requests []Request
+	if err := json.Unmarshal(x, &requests); err != nil {
+		return nil
+	}
+
+	return requests
+}
+
+func apiKeyOf(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	return r.URL.Query().Get(apiKeyQueryParam)
+}
+
+func bearerTokenOf(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+
+	const prefix = "Bearer "
+	if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+		return strings.TrimSpace(auth[len(prefix):])
+	}
+
+	return ""
+}
+
+func clientIPOf(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// verifyJWT verifies the HS256 token with the secret and returns its claims
+func verifyJWT(token string, secret []byte, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := json.Unmarshal(rawHeader, &header); err != nil || header.Alg != "HS256" {
+		return nil, errInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	claims := &jwtClaims{}
+	if err := json.Unmarshal(rawClaims, claims); err != nil {
+		return nil, errInvalidToken
+	}
+
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, errTokenExpired
+	}
+
+	if claims.IssuedAt != 0 && time.Unix(claims.IssuedAt, 0).After(now.Add(jwtIssuedAtSkew)) {
+		return nil, errInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,228 @@
+package jsonrpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestJWT(t *testing.T, secret []byte, claims *jwtClaims) string {
+	t.Helper()
+
+	rawClaims, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) +
+		"." + base64.RawURLEncoding.EncodeToString(rawClaims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	now := time.Unix(1700000000, 0)
+
+	claims, err := verifyJWT(signTestJWT(t, secret, &jwtClaims{
+		Subject:   "dashboard",
+		ExpiresAt: now.Unix() + 60,
+		Methods:   []string{"eth_*"},
+	}), secret, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard", claims.Subject)
+	assert.Equal(t, []string{"eth_*"}, claims.Methods)
+
+	_, err = verifyJWT(signTestJWT(t, secret, &jwtClaims{ExpiresAt: now.Unix()}), secret, now)
+	assert.ErrorIs(t, err, errTokenExpired)
+
+	_, err = verifyJWT(signTestJWT(t, []byte("other"), &jwtClaims{}), secret, now)
+	assert.ErrorIs(t, err, errInvalidToken)
+
+	_, err = verifyJWT("not.a-token", secret, now)
+	assert.ErrorIs(t, err, errInvalidToken)
+}
+
+func TestFindRateLimit(t *testing.T) {
+	t.Parallel()
+
+	limits := map[string]RateLimit{
+		"edge_sendRawTelegram": {Rate: 1, Burst: 1},
+		"edge_*":               {Rate: 5, Burst: 5},
+		"*":                    {Rate: 10, Burst: 10},
+	}
+
+	rule, _, _ := findRateLimit(limits, "edge_sendRawTelegram")
+	assert.Equal(t, "edge_sendRawTelegram", rule)
+
+	rule, _, _ = findRateLimit(limits, "edge_subscribe")
+	assert.Equal(t, "edge_*", rule)
+
+	rule, _, _ = findRateLimit(limits, "eth_blockNumber")
+	assert.Equal(t, "*", rule)
+
+	_, _, ok := findRateLimit(nil, "eth_blockNumber")
+	assert.False(t, ok)
+}
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+
+	newGuardWithClock := func(config *AuthConfig) (*guard, *time.Time) {
+		now := time.Unix(1700000000, 0)
+
+		g := newGuard(config)
+		g.now = func() time.Time { return now }
+		g.limiter.now = g.now
+
+		return g, &now
+	}
+
+	newRequest := func(header, value string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		return req
+	}
+
+	body := func(method string) []byte {
+		return []byte(`{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":[]}`)
+	}
+
+	t.Run("should not create guard without config", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, newGuard(nil))
+		assert.Nil(t, newGuard(&AuthConfig{}))
+
+		var g *guard
+
+		caller, err := g.authenticate(newRequest("", ""))
+		assert.Nil(t, caller)
+		assert.Nil(t, err)
+
+		_, err = g.authorize(caller, body("eth_blockNumber"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("should reject requests without credentials if required", func(t *testing.T) {
+		t.Parallel()
+
+		g, _ := newGuardWithClock(&AuthConfig{Required: true})
+
+		_, err := g.authenticate(newRequest("", ""))
+		assert.IsType(t, &unauthorizedError{}, err)
+		assert.Equal(t, http.StatusUnauthorized, statusCodeOf(err))
+	})
+
+	t.Run("should authenticate API key and check allowed methods", func(t *testing.T) {
+		t.Parallel()
+
+		g, _ := newGuardWithClock(&AuthConfig{
+			APIKeys: []*APIKey{
+				{Name: "reader", Key: "key1", Methods: []string{"eth_*", "edge_getNodes"}},
+			},
+		})
+
+		_, err := g.authenticate(newRequest(apiKeyHeader, "unknown"))
+		assert.IsType(t, &unauthorizedError{}, err)
+
+		caller, err := g.authenticate(newRequest(apiKeyHeader, "key1"))
+		assert.Nil(t, err)
+		assert.Equal(t, "key:reader", caller.id)
+
+		_, err = g.authorize(caller, body("eth_blockNumber"))
+		assert.Nil(t, err)
+
+		_, err = g.authorize(caller, body("edge_getNodes"))
+		assert.Nil(t, err)
+
+		id, err := g.authorize(caller, body("edge_sendRawTelegram"))
+		assert.IsType(t, &methodNotAllowedError{}, err)
+		assert.Equal(t, float64(1), id)
+
+		// a batch is rejected if any of its methods is not allowed
+		_, err = g.authorize(caller, []byte(`[
+			{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},
+			{"jsonrpc":"2.0","id":2,"method":"edge_sendRawTelegram"}]`))
+		assert.IsType(t, &methodNotAllowedError{}, err)
+	})
+
+	t.Run("should authenticate token", func(t *testing.T) {
+		t.Parallel()
+
+		g, now := newGuardWithClock(&AuthConfig{JWTSecret: secret})
+
+		token := signTestJWT(t, secret, &jwtClaims{Subject: "dashboard", ExpiresAt: now.Unix() + 60})
+
+		caller, err := g.authenticate(newRequest("Authorization", "Bearer "+token))
+		assert.Nil(t, err)
+		assert.Equal(t, "jwt:dashboard", caller.id)
+
+		_, err = g.authenticate(newRequest("Authorization", "Bearer "+token+"x"))
+		assert.IsType(t, &unauthorizedError{}, err)
+	})
+
+	t.Run("should limit rate per IP and per key", func(t *testing.T) {
+		t.Parallel()
+
+		g, now := newGuardWithClock(&AuthConfig{
+			APIKeys: []*APIKey{
+				{Name: "app", Key: "key1"},
+			},
+			IPRateLimits: map[string]RateLimit{
+				"edge_sendRawTelegram": {Rate: 1, Burst: 2},
+				"*":                    {Rate: 100, Burst: 100},
+			},
+			KeyRateLimits: map[string]RateLimit{
+				"edge_sendRawTelegram": {Rate: 10, Burst: 10},
+			},
+		})
+
+		anonymous, err := g.authenticate(newRequest("", ""))
+		assert.Nil(t, err)
+		assert.Equal(t, "ip:10.0.0.1", anonymous.id)
+
+		for i := 0; i < 2; i++ {
+			_, err = g.authorize(anonymous, body("edge_sendRawTelegram"))
+			assert.Nil(t, err)
+		}
+
+		_, err = g.authorize(anonymous, body("edge_sendRawTelegram"))
+		assert.IsType(t, &rateLimitError{}, err)
+		assert.Equal(t, http.StatusTooManyRequests, statusCodeOf(err))
+
+		// the other methods have the separate bucket
+		_, err = g.authorize(anonymous, body("eth_blockNumber"))
+		assert.Nil(t, err)
+
+		// the key has its own bucket
+		keyed, err := g.authenticate(newRequest(apiKeyHeader, "key1"))
+		assert.Nil(t, err)
+
+		_, err = g.authorize(keyed, body("edge_sendRawTelegram"))
+		assert.Nil(t, err)
+
+		// the bucket is refilled over time
+		*now = now.Add(time.Second)
+
+		_, err = g.authorize(anonymous, body("edge_sendRawTelegram"))
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,105 @@
+package jsonrpc
+
+import (
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// BlobManifest lists the chunks of a blob uploaded to the node, the blob is addressed by the hash of its manifest
+type BlobManifest struct {
+	Size      argUint64    `json:"size"`
+	ChunkSize argUint64    `json:"chunkSize"`
+	Chunks    []types.Hash `json:"chunks"`
+}
+
+// BlobStatus is a blob known to the node with the indexes of its chunks not stored yet,
+// the upload or the fetch of the blob is resumed with the missing chunks
+type BlobStatus struct {
+	Hash      types.Hash   `json:"hash"`
+	Size      argUint64    `json:"size"`
+	ChunkSize argUint64    `json:"chunkSize"`
+	Chunks    []types.Hash `json:"chunks"`
+	Missing   []argUint64  `json:"missing"`
+	Complete  bool         `json:"complete"`
+}
+
+func (e *Edge) blobStatus(hash types.Hash) (*BlobStatus, error) {
+	m, ok, err := e.store.GetBlobManifest(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, blob.ErrUnknownBlob
+	}
+
+	missing, err := e.store.GetBlobMissingChunks(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &BlobStatus{
+		Hash:      hash,
+		Size:      argUint64(m.Size),
+		ChunkSize: argUint64(m.ChunkSize),
+		Chunks:    m.Chunks,
+		Missing:   make([]argUint64, len(missing)),
+		Complete:  len(missing) == 0,
+	}
+
+	for i, index := range missing {
+		status.Missing[i] = argUint64(index)
+	}
+
+	return status, nil
+}
+
+// PutBlobChunk stores a chunk of a blob uploaded to the node, and returns its hash
+func (e *Edge) PutBlobChunk(data argBytes) (interface{}, error) {
+	hash, err := e.store.PutBlobChunk(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return hash, nil
+}
+
+// PutBlob stores the manifest of a blob uploaded to the node, and returns the blob with its missing chunks.
+// The blob is referenced by the edge calls once complete
+func (e *Edge) PutBlob(manifest *BlobManifest) (interface{}, error) {
+	hash, err := e.store.PutBlobManifest(&blob.Manifest{
+		Size:      uint64(manifest.Size),
+		ChunkSize: uint64(manifest.ChunkSize),
+		Chunks:    manifest.Chunks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e.blobStatus(hash)
+}
+
+// GetBlob returns the blob of the given hash with its missing chunks
+func (e *Edge) GetBlob(hash types.Hash) (interface{}, error) {
+	return e.blobStatus(hash)
+}
+
+// GetBlobChunk returns the chunk of the given index of the blob
+func (e *Edge) GetBlobChunk(hash types.Hash, index argUint64) (interface{}, error) {
+	data, err := e.store.ReadBlobChunk(hash, uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	return argBytes(data), nil
+}
+
+// FetchBlob fetches the blob returned by the application of the edge node into the node,
+// and returns the blob with its missing chunks, the fetch resuming with them if interrupted
+func (e *Edge) FetchBlob(peerID string, hash types.Hash) (interface{}, error) {
+	if err := e.store.FetchBlob(peerID, hash); err != nil {
+		return nil, err
+	}
+
+	return e.blobStatus(hash)
+}
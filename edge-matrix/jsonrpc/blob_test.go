@@ -0,0 +1,79 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBlobStore struct {
+	testStore
+
+	store *blob.Store
+}
+
+func (m *mockBlobStore) PutBlobChunk(data []byte) (types.Hash, error) {
+	return m.store.PutChunk(data)
+}
+
+func (m *mockBlobStore) PutBlobManifest(manifest *blob.Manifest) (types.Hash, error) {
+	return m.store.PutManifest(manifest)
+}
+
+func (m *mockBlobStore) GetBlobManifest(hash types.Hash) (*blob.Manifest, bool, error) {
+	return m.store.GetManifest(hash)
+}
+
+func (m *mockBlobStore) GetBlobMissingChunks(hash types.Hash) ([]uint64, error) {
+	return m.store.Missing(hash)
+}
+
+func (m *mockBlobStore) ReadBlobChunk(hash types.Hash, index uint64) ([]byte, error) {
+	return m.store.ReadChunk(hash, index)
+}
+
+func TestEdge_PutBlob(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlobStore{store: blob.NewMemoryStore()}
+	edge := newTestEthEndpoint(store)
+
+	manifest, chunks := blob.NewManifest([]byte("a large payload of an edge call"), 8)
+
+	// the first chunk is uploaded before the manifest
+	res, err := edge.PutBlobChunk(chunks[0])
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Chunks[0], res)
+
+	res, err = edge.PutBlob(&BlobManifest{
+		Size:      argUint64(manifest.Size),
+		ChunkSize: argUint64(manifest.ChunkSize),
+		Chunks:    manifest.Chunks,
+	})
+	require.NoError(t, err)
+
+	status, ok := res.(*BlobStatus)
+	require.True(t, ok)
+	assert.Equal(t, manifest.Hash(), status.Hash)
+	assert.Equal(t, []argUint64{1, 2, 3}, status.Missing)
+	assert.False(t, status.Complete)
+
+	for _, index := range status.Missing {
+		_, err := edge.PutBlobChunk(chunks[index])
+		require.NoError(t, err)
+	}
+
+	res, err = edge.GetBlob(status.Hash)
+	require.NoError(t, err)
+	assert.True(t, res.(*BlobStatus).Complete)
+
+	res, err = edge.GetBlobChunk(status.Hash, 3)
+	require.NoError(t, err)
+	assert.Equal(t, argBytes(chunks[3]), res)
+
+	_, err = edge.GetBlob(types.StringToHash("0x1"))
+	assert.ErrorIs(t, err, blob.ErrUnknownBlob)
+}
@@ -38,6 +38,7 @@ type endpoints struct {
 	Web3     *Web3
 	Net      *Net
 	TelePool *TelePool
+	Admin    *Admin
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -48,6 +49,7 @@ type Dispatcher struct {
 	filterManager     *FilterManager
 	rtcFilterManager  *RtcFilterManager
 	nodeFilterManager *NodeFilterManager
+	jobFilterManager  *JobFilterManager
 	endpoints         endpoints
 	params            *dispatcherParams
 	host              host.Host
@@ -60,6 +62,8 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+	wsSubscriptionLimit     uint64
+	lightClient             bool
 }
 
 func newDispatcher(
@@ -82,6 +86,9 @@ func newDispatcher(
 		d.nodeFilterManager = NewNodeFilterManager(logger, store)
 		go d.nodeFilterManager.Run()
 
+		d.jobFilterManager = NewJobFilterManager(logger, store)
+		go d.jobFilterManager.Run()
+
 		d.host = store.GetHost()
 	}
 
@@ -97,6 +104,7 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		d.params.lightClient,
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -109,11 +117,15 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.endpoints.TelePool = &TelePool{
 		store,
 	}
+	d.endpoints.Admin = &Admin{
+		store,
+	}
 
 	d.registerService("edge", d.endpoints.Edge)
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("telepool", d.endpoints.TelePool)
+	d.registerService("admin", d.endpoints.Admin)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -140,8 +152,9 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 
 type wsConn interface {
 	WriteMessage(messageType int, data []byte) error
-	GetFilterID() string
-	SetFilterID(string)
+	AddFilterID(string)
+	RemoveFilterID(string)
+	GetFilterIDs() []string
 }
 
 // as per https://www.jsonrpc.org/specification, the `id` in JSON-RPC 2.0
@@ -248,6 +261,16 @@ func (d *Dispatcher) handleEdgeSubscribe(req Request, conn wsConn) (string, Erro
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.nodeFilterManager.NewNodeFilter(nodeQuery, conn)
+	} else if subscribeMethod == "edgeCallJob" {
+		jobQuery := &JobQuery{}
+		if len(params) >= 2 {
+			query, err := decodeJobQueryFromInterface(params[1])
+			if err != nil {
+				return "", NewInvalidParamsError(err.Error())
+			}
+			jobQuery = query
+		}
+		filterID = d.jobFilterManager.NewJobFilter(jobQuery, conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -289,6 +312,17 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 	return filterID, nil
 }
 
+// checkSubscriptionLimit returns error if the connection holds
+// the maximum number of subscriptions already
+func (d *Dispatcher) checkSubscriptionLimit(conn wsConn) Error {
+	limit := d.params.wsSubscriptionLimit
+	if limit != 0 && uint64(len(conn.GetFilterIDs())) >= limit {
+		return NewInvalidRequestError(fmt.Sprintf("subscription limit of %d per connection reached", limit))
+	}
+
+	return nil
+}
+
 func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 	var params []interface{}
 	if err := json.Unmarshal(req.Params, &params); err != nil {
@@ -307,8 +341,50 @@ func (d *Dispatcher) handleUnsubscribe(req Request) (bool, Error) {
 	return d.filterManager.Uninstall(filterID), nil
 }
 
+// handleEdgeUnsubscribe removes the named subscription of the connection,
+// whichever filter manager holds it
+func (d *Dispatcher) handleEdgeUnsubscribe(req Request, conn wsConn) (bool, Error) {
+	var params []interface{}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return false, NewInvalidRequestError("Invalid json request")
+	}
+
+	if len(params) != 1 {
+		return false, NewInvalidParamsError("Invalid params")
+	}
+
+	filterID, ok := params[0].(string)
+	if !ok {
+		return false, NewSubscriptionNotFoundError(filterID)
+	}
+
+	// only the subscriptions of the connection can be removed
+	owned := false
+
+	for _, id := range conn.GetFilterIDs() {
+		if id == filterID {
+			owned = true
+
+			break
+		}
+	}
+
+	if !owned {
+		return false, nil
+	}
+
+	return d.filterManager.Uninstall(filterID) ||
+		d.rtcFilterManager.Uninstall(filterID) ||
+		d.nodeFilterManager.Uninstall(filterID) ||
+		d.jobFilterManager.Uninstall(filterID), nil
+}
+
+// RemoveFilterByWs removes all the subscriptions of the connection
 func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
+	d.rtcFilterManager.RemoveFilterByWs(conn)
+	d.nodeFilterManager.RemoveFilterByWs(conn)
+	d.jobFilterManager.RemoveFilterByWs(conn)
 }
 
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
@@ -320,6 +396,10 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
+		if err := d.checkSubscriptionLimit(conn); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
 		filterID, err := d.handleSubscribe(req, conn)
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
@@ -356,6 +436,10 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	// if the request method is edge_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "edge_subscribe" {
+		if err := d.checkSubscriptionLimit(conn); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
 		filterID, err := d.handleEdgeSubscribe(req, conn)
 		if err != nil {
 			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
@@ -370,6 +454,25 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 		return []byte(resp), nil
 	}
 
+	if req.Method == "edge_unsubscribe" {
+		ok, err := d.handleEdgeUnsubscribe(req, conn)
+		if err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		res := "false"
+		if ok {
+			res = "true"
+		}
+
+		resp, err := formatFilterResponse(req.ID, res)
+		if err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+
+		return []byte(resp), nil
+	}
+
 	// its a normal query that we handle with the dispatcher
 	resp, err := d.handleReq(req)
 	if err != nil {
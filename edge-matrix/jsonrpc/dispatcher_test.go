@@ -2,6 +2,9 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/rtc"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -453,3 +456,79 @@ func TestDispatcherBatchRequest(t *testing.T) {
 		}
 	}
 }
+
+type mockSubscribeStore struct{}
+
+func (mockSubscribeStore) SubscribeRtcEvents() rtc.Subscription {
+	return nil
+}
+
+func (mockSubscribeStore) SubscribeAppEvents() application.Subscription {
+	return application.NewMockSubscription()
+}
+
+func (mockSubscribeStore) SubscribeEdgeCallJobs() *jobs.Subscription {
+	return jobs.NewManager(hclog.NewNullLogger(), jobs.NewMemoryStore(), 0).Subscribe()
+}
+
+func TestDispatcher_WebsocketSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	logger := hclog.NewNullLogger()
+	dispatcher := &Dispatcher{
+		logger:            logger,
+		filterManager:     NewFilterManager(logger, newMockStore(), 1000),
+		rtcFilterManager:  NewRtcFilterManager(logger, mockSubscribeStore{}),
+		nodeFilterManager: NewNodeFilterManager(logger, mockSubscribeStore{}),
+		jobFilterManager:  NewJobFilterManager(logger, mockSubscribeStore{}),
+		params: &dispatcherParams{
+			wsSubscriptionLimit: 3,
+		},
+	}
+
+	conn, _ := newMockWsConnWithMsgCh()
+
+	handle := func(req string) (string, error) {
+		res, err := dispatcher.HandleWs([]byte(req), conn)
+		assert.NoError(t, err)
+
+		var result string
+
+		return result, expectJSONResult(res, &result)
+	}
+
+	headsID1, err := handle(`{"id":1,"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"]}`)
+	assert.NoError(t, err)
+
+	headsID2, err := handle(`{"id":2,"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"]}`)
+	assert.NoError(t, err)
+
+	nodeID, err := handle(`{"id":3,"jsonrpc":"2.0","method":"edge_subscribe","params":["node",{}]}`)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{headsID1, headsID2, nodeID}, conn.GetFilterIDs())
+
+	// the connection holds the maximum number of subscriptions
+	_, err = handle(`{"id":4,"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"]}`)
+	assert.Error(t, err)
+
+	// edge_unsubscribe removes only the named subscription
+	res, err := handle(`{"id":5,"jsonrpc":"2.0","method":"edge_unsubscribe","params":["` + nodeID + `"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", res)
+
+	assert.False(t, dispatcher.nodeFilterManager.Exists(nodeID))
+	assert.True(t, dispatcher.filterManager.Exists(headsID1))
+	assert.ElementsMatch(t, []string{headsID1, headsID2}, conn.GetFilterIDs())
+
+	res, err = handle(`{"id":6,"jsonrpc":"2.0","method":"edge_unsubscribe","params":["` + nodeID + `"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", res)
+
+	// closing the connection removes all its subscriptions
+	dispatcher.RemoveFilterByWs(conn)
+
+	assert.False(t, dispatcher.filterManager.Exists(headsID1))
+	assert.False(t, dispatcher.filterManager.Exists(headsID2))
+	assert.Empty(t, conn.GetFilterIDs())
+}
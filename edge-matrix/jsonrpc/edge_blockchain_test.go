@@ -1,13 +1,16 @@
 package jsonrpc
 
 import (
+	"errors"
 	"math/big"
+	"strconv"
 	"testing"
 
 	"github.com/emc-protocol/edge-matrix/blockchain"
 	"github.com/emc-protocol/edge-matrix/helper/progress"
 	"github.com/emc-protocol/edge-matrix/state/runtime"
 	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/types/buildroot"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -212,6 +215,152 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 		assert.Equal(t, block.Hash(), response.BlockHash)
 		assert.NotNil(t, response.Logs)
 	})
+
+	t.Run("returns verified receipt in light client mode", func(t *testing.T) {
+		t.Parallel()
+
+		txn := newTestTransaction(uint64(0), addr0)
+		rec := &types.Receipt{
+			Logs: []*types.Log{
+				{
+					Topics: []types.Hash{
+						hash4,
+					},
+				},
+			},
+		}
+		rec.SetStatus(types.ReceiptSuccess)
+
+		store := &mockProofStore{
+			proofs: map[types.Hash]*types.TelegramProof{
+				txn.Hash: {
+					BlockHash:   hash4,
+					BlockNumber: 3,
+					Index:       2,
+					Telegram:    txn,
+					Receipt:     rec,
+				},
+			},
+		}
+		eth := newTestEthEndpoint(store)
+		eth.lightClient = true
+
+		res, err := eth.GetTelegramReceipt(txn.Hash)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		//nolint:forcetypeassert
+		response := res.(*receipt)
+		assert.Equal(t, txn.Hash, response.TxHash)
+		assert.Equal(t, hash4, response.BlockHash)
+		assert.Equal(t, argUint64(3), response.BlockNumber)
+		assert.Equal(t, argUint64(2), response.TxIndex)
+		assert.Len(t, response.Logs, 1)
+
+		// not found in any peer
+		res, err = eth.GetTelegramReceipt(hash1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestEth_GetTelegramProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		store     = newMockBlockStore()
+		eth       = newTestEthEndpoint(store)
+		telegrams = []*types.Telegram{
+			newTestTransaction(uint64(0), addr0),
+			newTestTransaction(uint64(1), addr0),
+		}
+		receipts = make([]*types.Receipt, len(telegrams))
+	)
+
+	for i := range receipts {
+		receipts[i] = &types.Receipt{CumulativeGasUsed: uint64(i + 1)}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	block := newTestBlock(1, hash4)
+	block.Telegrams = telegrams
+	block.Header.TeleRoot = buildroot.CalculateTelegramsRoot(telegrams)
+	block.Header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(receipts)
+
+	store.add(block)
+	store.receipts[hash4] = receipts
+
+	t.Run("returns the proof of the telegram", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetTelegramProof(telegrams[1].Hash)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		//nolint:forcetypeassert
+		response := res.(*inclusionProof)
+		assert.Equal(t, hash4, response.BlockHash)
+		assert.Equal(t, argUint64(1), response.Index)
+		assert.Equal(t, block.Header.TeleRoot, response.Root)
+
+		proof := make([][]byte, len(response.Proof))
+		for i, node := range response.Proof {
+			proof[i] = node
+		}
+
+		assert.NoError(t, buildroot.VerifyTelegramInclusion(response.Root, 1, telegrams[1], proof))
+	})
+
+	t.Run("returns the proof of the receipt", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetReceiptProof(telegrams[0].Hash)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		//nolint:forcetypeassert
+		response := res.(*inclusionProof)
+		assert.Equal(t, argUint64(0), response.Index)
+		assert.Equal(t, block.Header.ReceiptsRoot, response.Root)
+
+		proof := make([][]byte, len(response.Proof))
+		for i, node := range response.Proof {
+			proof[i] = node
+		}
+
+		value, err := buildroot.VerifyProof(response.Root, 0, proof)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(response.Value), value)
+		assert.NoError(t, buildroot.VerifyReceiptInclusion(response.Root, 0, receipts[0], proof))
+	})
+
+	t.Run("returns nil if telegram is not found", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.GetReceiptProof(hash1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+type mockProofStore struct {
+	testStore
+
+	proofs map[types.Hash]*types.TelegramProof
+}
+
+func (m *mockProofStore) GetTelegramProof(hash types.Hash) (*types.TelegramProof, error) {
+	proof, ok := m.proofs[hash]
+	if !ok {
+		return nil, errors.New("proof not found")
+	}
+
+	return proof, nil
 }
 
 func TestEth_Syncing(t *testing.T) {
@@ -283,6 +432,89 @@ func TestEth_GasPrice(t *testing.T) {
 	assert.Equal(t, argUint64(store.averageGasPrice), res)
 }
 
+func TestEth_GasPrice_BaseFee(t *testing.T) {
+	store := newMockBlockStore()
+	store.averageGasPrice = 1500
+	store.baseFee = 1000
+	eth := newTestEthEndpoint(store)
+
+	// the gas price is the next base fee and the suggested tip
+	res, err := eth.GasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(1500), res)
+
+	res, err = eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(500), res)
+
+	// the base fee above the average gas price is still the lowest price
+	store.baseFee = 2000
+
+	res, err = eth.GasPrice()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(2000), res)
+
+	res, err = eth.MaxPriorityFeePerGas()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(0), res)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	store := newMockBlockStore()
+	store.baseFee = 1200
+
+	for i := uint64(0); i < 4; i++ {
+		block := newTestBlock(i, types.StringToHash(strconv.FormatUint(i+1, 10)))
+		block.Header.GasLimit = 100
+		block.Header.GasUsed = 25 * i
+		block.Header.BaseFee = 1000 + 50*i
+		store.add(block)
+	}
+
+	// the newest block has two telegrams tipping 10 and 100 above its base fee
+	newest := store.blocks[3]
+	newest.Telegrams = []*types.Telegram{
+		{Type: types.DynamicFeeTx, GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(2000)},
+		{GasPrice: big.NewInt(1160)},
+	}
+	store.receipts[newest.Hash()] = []*types.Receipt{{GasUsed: 45}, {GasUsed: 30}}
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("returns the history of the latest blocks", func(t *testing.T) {
+		res, err := eth.FeeHistory(2, LatestBlockNumber, []float64{10, 50, 90})
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		history := res.(*feeHistory)
+
+		assert.Equal(t, argUint64(2), history.OldestBlock)
+		assert.Equal(t, []argUint64{1100, 1150, 1200}, history.BaseFeePerGas)
+		assert.Equal(t, []float64{0.5, 0.75}, history.GasUsedRatio)
+		assert.Equal(t, [][]argUint64{{0, 0, 0}, {10, 100, 100}}, history.Reward)
+	})
+
+	t.Run("limits the block count to the chain", func(t *testing.T) {
+		res, err := eth.FeeHistory(10, BlockNumber(1), nil)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		history := res.(*feeHistory)
+
+		assert.Equal(t, argUint64(0), history.OldestBlock)
+		assert.Equal(t, []argUint64{1000, 1050, 1100}, history.BaseFeePerGas)
+		assert.Nil(t, history.Reward)
+	})
+
+	t.Run("rejects the invalid params", func(t *testing.T) {
+		_, err := eth.FeeHistory(0, LatestBlockNumber, nil)
+		assert.ErrorIs(t, err, ErrInvalidBlockCount)
+
+		_, err = eth.FeeHistory(1, LatestBlockNumber, []float64{50, 10})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+	})
+}
+
 //func TestEth_Call(t *testing.T) {
 //	t.Parallel()
 //
@@ -346,6 +578,7 @@ type mockBlockStore struct {
 	receipts        map[types.Hash][]*types.Receipt
 	isSyncing       bool
 	averageGasPrice int64
+	baseFee         uint64
 	ethCallError    error
 }
 
@@ -473,6 +706,14 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	if b, ok := m.GetBlockByNumber(blockNumber, false); ok {
+		return b.Header, true
+	}
+
+	return nil, false
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
@@ -516,6 +757,10 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
+func (m *mockBlockStore) GetBaseFee() uint64 {
+	return m.baseFee
+}
+
 func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Telegram) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
@@ -0,0 +1,105 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// EdgeCallJob is an edge call run asynchronously by the edge node
+type EdgeCallJob struct {
+	ID        types.Hash    `json:"id"`
+	PeerID    string        `json:"peerId"`
+	Caller    types.Address `json:"caller"`
+	Path      string        `json:"path"`
+	Status    jobs.Status   `json:"status"`
+	CreatedAt uint64        `json:"createdAt"`
+	UpdatedAt uint64        `json:"updatedAt"`
+
+	// Response is the base64 encoded response of the application, like the one of the synchronous edge calls
+	Response string `json:"response,omitempty"`
+
+	// Provider is the edge node signing the result
+	Provider *types.Address `json:"provider,omitempty"`
+
+	// Result is the signed edge response RLP of the completed job
+	Result argBytes `json:"result,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+func toEdgeCallJob(job *jobs.Job) *EdgeCallJob {
+	res := &EdgeCallJob{
+		ID:        job.ID,
+		PeerID:    job.PeerID,
+		Caller:    job.Caller,
+		Path:      job.Path,
+		Status:    job.Status,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+		Error:     job.Error,
+	}
+
+	if len(job.Result) > 0 {
+		res.Result = job.Result
+
+		resp := &application.EdgeResponse{}
+		if err := resp.UnmarshalRLP(job.Result); err == nil {
+			provider := resp.From
+			res.Provider = &provider
+			res.Response = resp.RespString
+		}
+	}
+
+	return res
+}
+
+// JobQuery filters the status changes of the edge call jobs, the empty fields match any job
+type JobQuery struct {
+	ID     *types.Hash    `json:"id"`
+	Caller *types.Address `json:"caller"`
+}
+
+// Match returns true if the job matches the query
+func (q *JobQuery) Match(job *jobs.Job) bool {
+	if q.ID != nil && *q.ID != job.ID {
+		return false
+	}
+
+	if q.Caller != nil && *q.Caller != job.Caller {
+		return false
+	}
+
+	return true
+}
+
+func decodeJobQueryFromInterface(i interface{}) (*JobQuery, error) {
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &JobQuery{}
+	if err := json.Unmarshal(raw, query); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// GetEdgeCallJob returns the edge call job of the given ID, which is the hash of the edge call telegram.
+// The jobs routed by the node are refreshed from the edge nodes running them until finished
+func (e *Edge) GetEdgeCallJob(id types.Hash) (interface{}, error) {
+	job, ok, err := e.store.GetEdgeCallJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, jobs.ErrUnknownJob
+	}
+
+	return toEdgeCallJob(job), nil
+}
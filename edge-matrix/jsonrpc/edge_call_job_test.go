@@ -0,0 +1,91 @@
+package jsonrpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockJobStore struct {
+	testStore
+
+	manager *jobs.Manager
+}
+
+func (m *mockJobStore) GetEdgeCallJob(id types.Hash) (*jobs.Job, bool, error) {
+	return m.manager.Get(id)
+}
+
+func (m *mockJobStore) SubscribeEdgeCallJobs() *jobs.Subscription {
+	return m.manager.Subscribe()
+}
+
+func TestEdge_GetEdgeCallJob(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := application.NewEIP155Signer(chain.AllForksEnabled.At(0), 100)
+
+	signed, err := signer.SignEdgeResp(&application.EdgeResponse{
+		RespString: base64.StdEncoding.EncodeToString([]byte("done")),
+	}, key)
+	require.NoError(t, err)
+
+	signed.From = crypto.PubKeyToAddress(&key.PublicKey)
+
+	manager := jobs.NewManager(hclog.NewNullLogger(), jobs.NewMemoryStore(), 0)
+	store := &mockJobStore{manager: manager}
+	edge := newTestEthEndpoint(store)
+
+	filters := NewJobFilterManager(hclog.NewNullLogger(), store)
+	go filters.Run()
+
+	defer filters.Close()
+
+	id := types.StringToHash("0x1")
+	ws, msgCh := newMockWsConnWithMsgCh()
+	filters.NewJobFilter(&JobQuery{ID: &id}, ws)
+
+	require.NoError(t, manager.Run(&jobs.Job{ID: id, PeerID: "peer", Caller: types.Address{0x1}, Path: "/v1/video"}, func() ([]byte, error) {
+		return signed.MarshalRLP(), nil
+	}))
+
+	// the subscription receives the status changes until the job is completed
+	for _, status := range []jobs.Status{jobs.StatusPending, jobs.StatusRunning, jobs.StatusCompleted} {
+		select {
+		case msg := <-msgCh:
+			assert.Contains(t, string(msg), fmt.Sprintf(`"status":"%s"`, status))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s job update", status)
+		}
+	}
+
+	res, err := edge.GetEdgeCallJob(id)
+	require.NoError(t, err)
+
+	job, ok := res.(*EdgeCallJob)
+	require.True(t, ok)
+	assert.Equal(t, jobs.StatusCompleted, job.Status)
+	assert.Equal(t, signed.RespString, job.Response)
+	assert.Equal(t, signed.From, *job.Provider)
+
+	raw, err := json.Marshal(job)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"status":"completed"`)
+
+	_, err = edge.GetEdgeCallJob(types.StringToHash("0x2"))
+	assert.ErrorIs(t, err, jobs.ErrUnknownJob)
+}
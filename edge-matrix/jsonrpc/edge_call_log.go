@@ -0,0 +1,90 @@
+package jsonrpc
+
+import (
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// EdgeCallEntry is an edge call sent by the node, with the signed response of the edge node
+type EdgeCallEntry struct {
+	Hash      types.Hash    `json:"hash"`
+	Caller    types.Address `json:"caller"`
+	PeerID    string        `json:"peerId"`
+	Request   argBytes      `json:"request"`
+	StartTime uint64        `json:"startTime"`
+	Duration  uint64        `json:"duration"`
+
+	// Provider is the edge node signing the response, and Response the base64 encoded response,
+	// decoded from the signed edge response RLP in Result
+	Provider *types.Address `json:"provider,omitempty"`
+	Response string         `json:"response,omitempty"`
+	Result   argBytes       `json:"result,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// EdgeCallPage is a page of the edge calls with the number of all the calls matching the query
+type EdgeCallPage struct {
+	Calls []*EdgeCallEntry `json:"calls"`
+	Total uint64           `json:"total"`
+}
+
+func toEdgeCallEntry(entry *calllog.Entry) *EdgeCallEntry {
+	res := &EdgeCallEntry{
+		Hash:      entry.Hash,
+		Caller:    entry.Caller,
+		PeerID:    entry.PeerID,
+		Request:   entry.Request,
+		StartTime: entry.StartTime,
+		Duration:  entry.Duration,
+		Error:     entry.Error,
+	}
+
+	if len(entry.Response) > 0 {
+		res.Result = entry.Response
+
+		resp := &application.EdgeResponse{}
+		if err := resp.UnmarshalRLP(entry.Response); err == nil {
+			provider := resp.From
+			res.Provider = &provider
+			res.Response = resp.RespString
+		}
+	}
+
+	return res
+}
+
+// GetEdgeCallResponse returns the edge call of the given telegram hash sent by the node,
+// with the signed response of the edge node
+func (e *Edge) GetEdgeCallResponse(hash types.Hash) (interface{}, error) {
+	entry, ok, err := e.store.GetEdgeCallEntry(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, calllog.ErrUnknownEntry
+	}
+
+	return toEdgeCallEntry(entry), nil
+}
+
+// GetEdgeCalls returns the page of the edge calls sent by the node matching the query
+func (e *Edge) GetEdgeCalls(query *calllog.Query) (interface{}, error) {
+	entries, total, err := e.store.GetEdgeCallEntries(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &EdgeCallPage{
+		Calls: make([]*EdgeCallEntry, len(entries)),
+		Total: total,
+	}
+
+	for i, entry := range entries {
+		page.Calls[i] = toEdgeCallEntry(entry)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,76 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCallLogStore struct {
+	testStore
+
+	store *calllog.Store
+}
+
+func (m *mockCallLogStore) GetEdgeCallEntry(hash types.Hash) (*calllog.Entry, bool, error) {
+	return m.store.Get(hash)
+}
+
+func (m *mockCallLogStore) GetEdgeCallEntries(query *calllog.Query) ([]*calllog.Entry, uint64, error) {
+	return m.store.Query(query)
+}
+
+func TestEdge_GetEdgeCallResponse(t *testing.T) {
+	t.Parallel()
+
+	store := &mockCallLogStore{store: calllog.NewMemoryStore()}
+	edge := newTestEthEndpoint(store)
+
+	caller, provider := types.StringToAddress("0x1"), types.StringToAddress("0x2")
+	resp := &application.EdgeResponse{RespString: "cmVzdWx0", From: provider}
+
+	hash := types.StringToHash("0x1")
+	require.NoError(t, store.store.Put(&calllog.Entry{
+		Hash:      hash,
+		Caller:    caller,
+		PeerID:    "peer",
+		Request:   []byte(`{"peerId":"peer"}`),
+		Response:  resp.MarshalRLP(),
+		StartTime: 1000,
+		Duration:  20,
+	}))
+	require.NoError(t, store.store.Put(&calllog.Entry{
+		Hash:      types.StringToHash("0x2"),
+		Caller:    caller,
+		PeerID:    "peer",
+		StartTime: 2000,
+		Error:     "edge node unreachable",
+	}))
+
+	res, err := edge.GetEdgeCallResponse(hash)
+	require.NoError(t, err)
+
+	entry, ok := res.(*EdgeCallEntry)
+	require.True(t, ok)
+	assert.Equal(t, caller, entry.Caller)
+	assert.Equal(t, &provider, entry.Provider)
+	assert.Equal(t, "cmVzdWx0", entry.Response)
+	assert.Equal(t, uint64(20), entry.Duration)
+
+	res, err = edge.GetEdgeCalls(&calllog.Query{Caller: &caller, FromTime: 1500})
+	require.NoError(t, err)
+
+	page, ok := res.(*EdgeCallPage)
+	require.True(t, ok)
+	require.Len(t, page.Calls, 1)
+	assert.Equal(t, uint64(1), page.Total)
+	assert.Nil(t, page.Calls[0].Provider)
+	assert.Equal(t, "edge node unreachable", page.Calls[0].Error)
+
+	_, err = edge.GetEdgeCallResponse(types.StringToHash("0x3"))
+	assert.ErrorIs(t, err, calllog.ErrUnknownEntry)
+}
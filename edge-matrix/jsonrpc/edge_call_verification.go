@@ -0,0 +1,112 @@
+package jsonrpc
+
+import (
+	"github.com/emc-protocol/edge-matrix/application/verification"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// EdgeCallAttestation is the response of one of the edge nodes an edge call is dispatched to
+type EdgeCallAttestation struct {
+	PeerID string `json:"peerId"`
+
+	// Provider is the address the edge node proves to sign its responses with, nil if it failed to prove it
+	Provider   *types.Address `json:"provider,omitempty"`
+	ResultHash *types.Hash    `json:"resultHash,omitempty"`
+
+	// Response is the signed edge response RLP
+	Response argBytes `json:"response,omitempty"`
+
+	// Agrees is true if the response is the one of the majority
+	Agrees bool   `json:"agrees"`
+	Error  string `json:"error,omitempty"`
+}
+
+// EdgeCallVerification is the result of an edge call dispatched to several edge nodes running the same model
+type EdgeCallVerification struct {
+	RequestHash types.Hash    `json:"requestHash"`
+	Caller      types.Address `json:"caller"`
+	ResultHash  types.Hash    `json:"resultHash"`
+	CreatedAt   uint64        `json:"createdAt"`
+
+	// Response is the base64 encoded response of the majority, like the one of the single edge calls
+	Response string `json:"response"`
+
+	Attestations []*EdgeCallAttestation `json:"attestations"`
+
+	// Dissenters are the edge nodes responding another result than the majority
+	Dissenters []string `json:"dissenters"`
+}
+
+func toEdgeCallVerification(result *verification.Result) *EdgeCallVerification {
+	res := &EdgeCallVerification{
+		RequestHash:  result.RequestHash,
+		Caller:       result.Caller,
+		ResultHash:   result.ResultHash,
+		CreatedAt:    result.CreatedAt,
+		Attestations: make([]*EdgeCallAttestation, len(result.Attestations)),
+		Dissenters:   make([]string, 0),
+	}
+
+	if resp := result.Response(); resp != nil {
+		res.Response = resp.RespString
+	}
+
+	for i, a := range result.Attestations {
+		attestation := &EdgeCallAttestation{
+			PeerID: a.PeerID,
+			Error:  a.Error,
+		}
+
+		if a.Provider != types.ZeroAddress {
+			provider := a.Provider
+			attestation.Provider = &provider
+		}
+
+		if a.Response != nil {
+			resultHash := a.ResultHash()
+
+			attestation.ResultHash = &resultHash
+			attestation.Response = a.Response.MarshalRLP()
+			attestation.Agrees = resultHash == result.ResultHash
+		}
+
+		res.Attestations[i] = attestation
+	}
+
+	for _, a := range result.Dissenters() {
+		res.Dissenters = append(res.Dissenters, a.PeerID)
+	}
+
+	return res
+}
+
+// GetEdgeCallVerification returns the result of the edge call dispatched to several edge nodes by the node,
+// with the signed responses of all the edge nodes
+func (e *Edge) GetEdgeCallVerification(requestHash types.Hash) (interface{}, error) {
+	result, ok, err := e.store.GetEdgeCallVerification(requestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, verification.ErrUnknownResult
+	}
+
+	return toEdgeCallVerification(result), nil
+}
+
+// GetDisagreements returns the results of the edge calls the edge node disagreed with the majority on,
+// the signed responses being the evidence for its penalization
+func (e *Edge) GetDisagreements(peerID string) (interface{}, error) {
+	results, err := e.store.GetDisagreements(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*EdgeCallVerification, len(results))
+	for i, result := range results {
+		res[i] = toEdgeCallVerification(result)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package jsonrpc
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/verification"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockVerificationStore struct {
+	testStore
+
+	store *verification.Store
+}
+
+func (m *mockVerificationStore) GetEdgeCallVerification(requestHash types.Hash) (*verification.Result, bool, error) {
+	return m.store.Get(requestHash)
+}
+
+func (m *mockVerificationStore) GetDisagreements(peerID string) ([]*verification.Result, error) {
+	return m.store.Disagreements(peerID)
+}
+
+func TestEdge_GetEdgeCallVerification(t *testing.T) {
+	t.Parallel()
+
+	signer := application.NewEIP155Signer(chain.AllForksEnabled.At(0), 100)
+
+	attest := func(peerID string, resp string) *verification.Attestation {
+		key, err := crypto.GenerateECDSAKey()
+		require.NoError(t, err)
+
+		signed, err := signer.SignEdgeResp(&application.EdgeResponse{
+			RespString: base64.StdEncoding.EncodeToString([]byte(resp)),
+		}, key)
+		require.NoError(t, err)
+
+		signed.From = crypto.PubKeyToAddress(&key.PublicKey)
+
+		return &verification.Attestation{PeerID: peerID, Provider: signed.From, Response: signed}
+	}
+
+	store := &mockVerificationStore{store: verification.NewMemoryStore()}
+	edge := newTestEthEndpoint(store)
+
+	requestHash := types.StringToHash("0x1")
+
+	_, err := verification.NewVerifier(hclog.NewNullLogger(), signer, store.store).Verify(
+		requestHash,
+		types.StringToAddress("0xca11e5"),
+		[]*verification.Attestation{
+			attest("peer0", "a"),
+			attest("peer1", "b"),
+			attest("peer2", "a"),
+			{PeerID: "peer3", Error: "app peer is offline"},
+			attest("peer4", "a"),
+		},
+	)
+	require.NoError(t, err)
+
+	res, err := edge.GetEdgeCallVerification(requestHash)
+	require.NoError(t, err)
+
+	result, ok := res.(*EdgeCallVerification)
+	require.True(t, ok)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("a")), result.Response)
+	assert.Equal(t, []string{"peer1"}, result.Dissenters)
+	assert.Len(t, result.Attestations, 5)
+	assert.True(t, result.Attestations[0].Agrees)
+	assert.NotNil(t, result.Attestations[0].Provider)
+	assert.False(t, result.Attestations[1].Agrees)
+	assert.Nil(t, result.Attestations[3].Provider)
+	assert.Equal(t, "app peer is offline", result.Attestations[3].Error)
+
+	res, err = edge.GetDisagreements("peer1")
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+
+	_, err = edge.GetEdgeCallVerification(types.StringToHash("0x2"))
+	assert.ErrorIs(t, err, verification.ErrUnknownResult)
+}
@@ -4,17 +4,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/application/verification"
 	"github.com/emc-protocol/edge-matrix/contracts"
 	"github.com/emc-protocol/edge-matrix/rtc"
 	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/fastrlp"
 	"math/big"
+	"sort"
 
 	"github.com/emc-protocol/edge-matrix/chain"
 	"github.com/emc-protocol/edge-matrix/helper/common"
 	"github.com/emc-protocol/edge-matrix/helper/progress"
 	"github.com/emc-protocol/edge-matrix/state/runtime"
 	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/types/buildroot"
 )
 
 type edgeTelePoolStore interface {
@@ -38,11 +47,33 @@ type Account struct {
 	Nonce   uint64
 }
 
+// StateProof is the account with its storage values proven by the Merkle proofs against the state root.
+// The absent account has the empty fields with the proof of its absence
+type StateProof struct {
+	Nonce         uint64
+	Balance       *big.Int
+	CodeHash      types.Hash
+	StorageRoot   types.Hash
+	AccountProof  [][]byte
+	StorageProofs []*StorageProof
+}
+
+// StorageProof is the storage value proven against the storage root of the account
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+
+	// GetProof returns the account and the given storage slots
+	// with the Merkle proofs against the state root
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*StateProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -67,6 +98,9 @@ type ethBlockchainStore interface {
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
+	// GetBaseFee returns the base fee of the next block, which is zero when the dynamic fees are not active
+	GetBaseFee() uint64
+
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Telegram) (*runtime.ExecutionResult, error)
 
@@ -74,12 +108,107 @@ type ethBlockchainStore interface {
 	GetSyncProgression() *progress.Progression
 }
 
+type edgeProofStore interface {
+	// GetTelegramProof fetches the telegram and its receipt from the full peers,
+	// verified by the Merkle proofs against the local header
+	GetTelegramProof(hash types.Hash) (*types.TelegramProof, error)
+}
+
+type edgeAppStore interface {
+	// GetAppIdl fetches the IDL of the app hosted by the edge node and parses it,
+	// the default app of the edge node if the app is empty
+	GetAppIdl(peerID string, app string) (*appidl.Schema, error)
+
+	// GetAppPeers returns the application peers known to the node
+	GetAppPeers() []*AppPeerInfo
+
+	// GetAppPeer returns the application peer of the given ID, if known to the node
+	GetAppPeer(peerID string) (*AppPeerInfo, bool)
+}
+
+type edgeUsageStore interface {
+	// GetUsageRecord returns the usage record of the given hash with its commitment
+	GetUsageRecord(hash types.Hash) (*metering.Entry, bool, error)
+
+	// GetUsageRecords returns the page of the usage records matching the query,
+	// and the number of all the matching records
+	GetUsageRecords(query *metering.Query) ([]*metering.Entry, uint64, error)
+}
+
+type edgeChannelStore interface {
+	// GetChannel returns the payment channel from the latest state and the number of its block, nil if not opened
+	GetChannel(id types.Hash) (*channel.Channel, uint64, error)
+
+	// GetLocalChannels returns the payment channels persisted by the node with their latest vouchers
+	GetLocalChannels() ([]*channel.Entry, error)
+
+	// GetLatestVoucher returns the latest voucher of the payment channel received by the node
+	GetLatestVoucher(id types.Hash) (*channel.Voucher, bool, error)
+
+	// TrackChannel persists the payment channel of the telegram submitted through the node
+	TrackChannel(ch *channel.Channel) error
+
+	// RedeemChannel submits the latest voucher of the payment channel received by the node,
+	// and returns the hash of the redeem telegram
+	RedeemChannel(id types.Hash) (types.Hash, error)
+}
+
+type edgeJobStore interface {
+	// GetEdgeCallJob returns the edge call job run or routed by the node
+	GetEdgeCallJob(id types.Hash) (*jobs.Job, bool, error)
+}
+
+type edgeVerificationStore interface {
+	// GetEdgeCallVerification returns the result of the edge call dispatched to several edge nodes by the node
+	GetEdgeCallVerification(requestHash types.Hash) (*verification.Result, bool, error)
+
+	// GetDisagreements returns the results of the edge calls the edge node disagreed with
+	GetDisagreements(peerID string) ([]*verification.Result, error)
+}
+
+type edgeBlobStore interface {
+	// PutBlobChunk stores the chunk of a blob and returns its hash
+	PutBlobChunk(data []byte) (types.Hash, error)
+
+	// PutBlobManifest stores the manifest of a blob and returns its hash
+	PutBlobManifest(m *blob.Manifest) (types.Hash, error)
+
+	// GetBlobManifest returns the manifest of the blob
+	GetBlobManifest(hash types.Hash) (*blob.Manifest, bool, error)
+
+	// GetBlobMissingChunks returns the indexes of the chunks of the blob not stored yet
+	GetBlobMissingChunks(hash types.Hash) ([]uint64, error)
+
+	// ReadBlobChunk returns the chunk of the given index of the blob
+	ReadBlobChunk(hash types.Hash, index uint64) ([]byte, error)
+
+	// FetchBlob fetches the blob from the edge node
+	FetchBlob(peerID string, hash types.Hash) error
+}
+
+type edgeCallLogStore interface {
+	// GetEdgeCallEntry returns the edge call sent by the node
+	GetEdgeCallEntry(hash types.Hash) (*calllog.Entry, bool, error)
+
+	// GetEdgeCallEntries returns the page of the edge calls sent by the node matching the query,
+	// and the number of all the matching calls
+	GetEdgeCallEntries(query *calllog.Query) ([]*calllog.Entry, uint64, error)
+}
+
 // edgeStore provides access to the methods needed by edge endpoint
 type edgeStore interface {
 	edgeTelePoolStore
 	edgeRtcStore
 	ethStateStore
 	ethBlockchainStore
+	edgeProofStore
+	edgeAppStore
+	edgeUsageStore
+	edgeChannelStore
+	edgeJobStore
+	edgeVerificationStore
+	edgeBlobStore
+	edgeCallLogStore
 }
 
 // Edge is the edge jsonrpc endpoint
@@ -89,6 +218,7 @@ type Edge struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+	lightClient   bool
 }
 
 var (
@@ -114,6 +244,7 @@ func (e *Edge) Syncing() (interface{}, error) {
 			StartingBlock: argUint64(syncProgression.StartingBlock),
 			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
 			HighestBlock:  argUint64(syncProgression.HighestBlock),
+			PulledStates:  argUint64(syncProgression.PulledStates),
 		}, nil
 	}
 
@@ -188,6 +319,60 @@ func (e *Edge) SendRawTelegram(buf argBytes) (interface{}, error) {
 	return resp, nil
 }
 
+// GetAppIdl returns the parsed IDL of the app hosted by the edge node,
+// describing the methods accepted by its /api endpoint. The app is optional, addressing the default app of the node
+func (e *Edge) GetAppIdl(peerID string, app string) (interface{}, error) {
+	return e.store.GetAppIdl(peerID, app)
+}
+
+// GetNodes returns the page of the edge nodes gossiped by their apps matching the query
+func (e *Edge) GetNodes(query *NodeDirectoryQuery) (interface{}, error) {
+	return queryNodes(e.store.GetAppPeers(), query)
+}
+
+// GetUsageRecords returns the page of the usage records of the edge calls served and routed by the node
+func (e *Edge) GetUsageRecords(query *metering.Query) (interface{}, error) {
+	entries, total, err := e.store.GetUsageRecords(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &UsageRecordPage{
+		Records: make([]*UsageRecord, len(entries)),
+		Total:   total,
+	}
+
+	for i, entry := range entries {
+		page.Records[i] = toUsageRecord(entry)
+	}
+
+	return page, nil
+}
+
+// GetUsageRecord returns the usage record of the given hash
+func (e *Edge) GetUsageRecord(hash types.Hash) (interface{}, error) {
+	entry, ok, err := e.store.GetUsageRecord(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrUsageRecordNotFound
+	}
+
+	return toUsageRecord(entry), nil
+}
+
+// GetNode returns the edge node of the given peer ID
+func (e *Edge) GetNode(peerID string) (interface{}, error) {
+	peer, ok := e.store.GetAppPeer(peerID)
+	if !ok {
+		return nil, ErrEdgeNodeNotFound
+	}
+
+	return newEdgeNode(peer), nil
+}
+
 func (e *Edge) SendRawMsg(buf argBytes) (interface{}, error) {
 	msg := &rtc.RtcMsg{}
 	if err := msg.UnmarshalRLP(buf); err != nil {
@@ -277,6 +462,10 @@ func (e *Edge) GetTelegramByHash(hash types.Hash) (interface{}, error) {
 
 // GetTelegramReceipt returns a telegram receipt by his hash
 func (e *Edge) GetTelegramReceipt(hash types.Hash) (interface{}, error) {
+	if e.lightClient {
+		return e.getVerifiedTelegramReceipt(hash)
+	}
+
 	blockHash, ok := e.store.ReadTxLookup(hash)
 	if !ok {
 		// txn not found
@@ -327,43 +516,167 @@ func (e *Edge) GetTelegramReceipt(hash types.Hash) (interface{}, error) {
 		return nil, nil
 	}
 
-	txn := block.Telegrams[indx]
-	raw := receipts[indx]
-
-	logs := make([]*Log, len(raw.Logs))
-	for indx, elem := range raw.Logs {
-		logs[indx] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      txn.Hash,
-			TxIndex:     argUint64(indx),
-			LogIndex:    argUint64(indx),
-			Removed:     false,
-		}
+	return toReceipt(receipts[indx], block.Telegrams[indx], uint64(indx), block.Hash(), block.Number()), nil
+}
+
+// getVerifiedTelegramReceipt returns a telegram receipt fetched from the full peers
+// with the Merkle proofs, which are verified against the local header
+func (e *Edge) getVerifiedTelegramReceipt(hash types.Hash) (interface{}, error) {
+	proof, err := e.store.GetTelegramProof(hash)
+	if err != nil {
+		e.logger.Warn(
+			fmt.Sprintf("Verified receipt for telegram with hash [%s] not found: %v", hash, err),
+		)
+
+		return nil, nil
+	}
+
+	return toReceipt(proof.Receipt, proof.Telegram, proof.Index, proof.BlockHash, proof.BlockNumber), nil
+}
+
+// GetTelegramProof returns the Merkle proof of the telegram with the given hash
+// against the telegrams root of its block
+func (e *Edge) GetTelegramProof(hash types.Hash) (interface{}, error) {
+	return e.getInclusionProof(hash, false)
+}
+
+// GetReceiptProof returns the Merkle proof of the receipt of the telegram with the given hash
+// against the receipts root of its block
+func (e *Edge) GetReceiptProof(hash types.Hash) (interface{}, error) {
+	return e.getInclusionProof(hash, true)
+}
+
+// getInclusionProof returns the Merkle proof of the telegram or its receipt
+func (e *Edge) getInclusionProof(hash types.Hash, ofReceipt bool) (interface{}, error) {
+	proof, err := e.findTelegramProof(hash, ofReceipt)
+	if err != nil {
+		e.logger.Warn(
+			fmt.Sprintf("Proof for telegram with hash [%s] not found: %v", hash, err),
+		)
+
+		return nil, nil
+	}
+
+	if proof == nil {
+		// txn not found
+		return nil, nil
 	}
 
-	res := &receipt{
-		Root:               raw.Root,
-		CumulativeGasUsed:  argUint64(raw.CumulativeGasUsed),
-		LogsBloom:          raw.LogsBloom,
-		Status:             argUint64(*raw.Status),
-		TxHash:             txn.Hash,
-		TxIndex:            argUint64(indx),
-		BlockHash:          block.Hash(),
-		BlockNumber:        argUint64(block.Number()),
-		GasUsed:            argUint64(raw.GasUsed),
-		ApplicationAddress: raw.ApplicationAddress,
-		FromAddr:           txn.From,
-		ToAddr:             txn.To,
-		Logs:               logs,
+	header, ok := e.store.GetHeaderByNumber(proof.BlockNumber)
+	if !ok || header.Hash != proof.BlockHash {
+		return nil, nil
+	}
+
+	ar := &fastrlp.Arena{}
+
+	res := &inclusionProof{
+		BlockHash:   proof.BlockHash,
+		BlockNumber: argUint64(proof.BlockNumber),
+		Index:       argUint64(proof.Index),
+	}
+
+	if ofReceipt {
+		res.Root = header.ReceiptsRoot
+		res.Value = proof.Receipt.MarshalRLPWith(ar).MarshalTo(nil)
+		res.Proof = toArgBytesList(proof.ReceiptProof)
+	} else {
+		res.Root = header.TeleRoot
+		res.Value = proof.Telegram.MarshalRLPWith(ar).MarshalTo(nil)
+		res.Proof = toArgBytesList(proof.TelegramProof)
 	}
 
 	return res, nil
 }
 
+// findTelegramProof builds the proof of the telegram or its receipt from the local blocks.
+// The light client has no blocks and fetches the verified proofs from the full peers instead
+func (e *Edge) findTelegramProof(hash types.Hash, ofReceipt bool) (*types.TelegramProof, error) {
+	if e.lightClient {
+		return e.store.GetTelegramProof(hash)
+	}
+
+	blockHash, ok := e.store.ReadTxLookup(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	block, ok := e.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, fmt.Errorf("block with hash [%s] not found", blockHash)
+	}
+
+	index := -1
+
+	for i, txn := range block.Telegrams {
+		if txn.Hash == hash {
+			index = i
+
+			break
+		}
+	}
+
+	if index == -1 {
+		return nil, nil
+	}
+
+	proof := &types.TelegramProof{
+		BlockHash:   block.Hash(),
+		BlockNumber: block.Number(),
+		Index:       uint64(index),
+		Telegram:    block.Telegrams[index],
+	}
+
+	if !ofReceipt {
+		teleProof, err := buildroot.CalculateTelegramsProof(block.Telegrams, index)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.TelegramProof = teleProof
+
+		return proof, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Telegrams) {
+		return nil, fmt.Errorf("receipts for block with hash [%s] not found", blockHash)
+	}
+
+	receiptProof, err := buildroot.CalculateReceiptsProof(receipts, index)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.Receipt = receipts[index]
+	proof.ReceiptProof = receiptProof
+
+	return proof, nil
+}
+
+// GetProof returns the account and the storage values with the Merkle proofs
+// against the state root of the given block, following EIP-1186
+func (e *Edge) GetProof(
+	address types.Address,
+	slots []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, slots)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProof(address, proof), nil
+}
+
 // GetStorageAt returns the contract storage at the index position
 func (e *Edge) GetStorageAt(
 	address types.Address,
@@ -405,15 +718,169 @@ func (e *Edge) GetStorageAt(
 }
 
 // GasPrice returns the average gas price based on the last x blocks
-// taking into consideration operator defined price limit
+// taking into consideration operator defined price limit.
+// With the dynamic fees active, it is the base fee of the next block and the suggested tip
 func (e *Edge) GasPrice() (interface{}, error) {
 	// Fetch average gas price in uint64
 	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
 
+	if baseFee := e.store.GetBaseFee(); baseFee != 0 {
+		return argUint64(common.Max(e.priceLimit, baseFee+e.suggestGasTip(avgGasPrice, baseFee))), nil
+	}
+
 	// Return --price-limit flag defined value if it is greater than avgGasPrice
 	return argUint64(common.Max(e.priceLimit, avgGasPrice)), nil
 }
 
+// MaxPriorityFeePerGas returns the suggested tip above the base fee for the dynamic fee telegrams
+func (e *Edge) MaxPriorityFeePerGas() (interface{}, error) {
+	avgGasPrice := e.store.GetAvgGasPrice().Uint64()
+
+	return argUint64(e.suggestGasTip(avgGasPrice, e.store.GetBaseFee())), nil
+}
+
+// suggestGasTip returns the part of the average gas price above the base fee
+func (e *Edge) suggestGasTip(avgGasPrice, baseFee uint64) uint64 {
+	if avgGasPrice <= baseFee {
+		return 0
+	}
+
+	return avgGasPrice - baseFee
+}
+
+const maxFeeHistoryBlocks = 1024
+
+var (
+	ErrInvalidBlockCount       = errors.New("block count must be greater than zero")
+	ErrInvalidRewardPercentile = errors.New("reward percentiles must be ascending values between 0 and 100")
+)
+
+// feeHistory is the base fees, the gas used ratios and the tip percentiles of a block range
+type feeHistory struct {
+	OldestBlock   argUint64     `json:"oldestBlock"`
+	BaseFeePerGas []argUint64   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64     `json:"gasUsedRatio"`
+	Reward        [][]argUint64 `json:"reward,omitempty"`
+}
+
+// FeeHistory returns the fee history of the given number of blocks up to the newest block,
+// with the base fee of the block after the newest and the tips at the given gas percentiles
+func (e *Edge) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	if blockCount == 0 {
+		return nil, ErrInvalidBlockCount
+	}
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, ErrInvalidRewardPercentile
+		}
+	}
+
+	newest, err := GetNumericBlockNumber(newestBlock, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	count := common.Min(uint64(blockCount), maxFeeHistoryBlocks)
+	if count > newest+1 {
+		count = newest + 1
+	}
+
+	oldest := newest + 1 - count
+	history := &feeHistory{
+		OldestBlock:   argUint64(oldest),
+		BaseFeePerGas: make([]argUint64, 0, count+1),
+		GasUsedRatio:  make([]float64, 0, count),
+	}
+
+	if len(rewardPercentiles) > 0 {
+		history.Reward = make([][]argUint64, 0, count)
+	}
+
+	for num := oldest; num <= newest; num++ {
+		block, ok := e.store.GetBlockByNumber(num, true)
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", num)
+		}
+
+		history.BaseFeePerGas = append(history.BaseFeePerGas, argUint64(block.Header.BaseFee))
+
+		gasUsedRatio := float64(0)
+		if block.Header.GasLimit != 0 {
+			gasUsedRatio = float64(block.Header.GasUsed) / float64(block.Header.GasLimit)
+		}
+
+		history.GasUsedRatio = append(history.GasUsedRatio, gasUsedRatio)
+
+		if len(rewardPercentiles) > 0 {
+			receipts, err := e.store.GetReceiptsByHash(block.Hash())
+			if err != nil {
+				return nil, err
+			}
+
+			history.Reward = append(history.Reward, blockRewards(block, receipts, rewardPercentiles))
+		}
+	}
+
+	// the base fee of the block after the newest one
+	nextBaseFee := e.store.GetBaseFee()
+	if next, ok := e.store.GetHeaderByNumber(newest + 1); ok {
+		nextBaseFee = next.BaseFee
+	}
+
+	history.BaseFeePerGas = append(history.BaseFeePerGas, argUint64(nextBaseFee))
+
+	return history, nil
+}
+
+// blockRewards returns the effective tips of the block at the given percentiles
+// of its used gas, the telegrams being sorted by their tips
+func blockRewards(block *types.Block, receipts []*types.Receipt, percentiles []float64) []argUint64 {
+	rewards := make([]argUint64, len(percentiles))
+	if len(block.Telegrams) == 0 {
+		return rewards
+	}
+
+	type telegramTip struct {
+		tip     uint64
+		gasUsed uint64
+	}
+
+	tips := make([]telegramTip, len(block.Telegrams))
+	for i, tele := range block.Telegrams {
+		tips[i].tip = tele.EffectiveGasTip(block.Header.BaseFee).Uint64()
+
+		if i < len(receipts) {
+			tips[i].gasUsed = receipts[i].GasUsed
+		}
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].tip < tips[j].tip
+	})
+
+	var (
+		idx     = 0
+		sumUsed = tips[0].gasUsed
+	)
+
+	for i, p := range percentiles {
+		threshold := uint64(float64(block.Header.GasUsed) * p / 100)
+		for sumUsed < threshold && idx < len(tips)-1 {
+			idx++
+			sumUsed += tips[idx].gasUsed
+		}
+
+		rewards[i] = argUint64(tips[idx].tip)
+	}
+
+	return rewards
+}
+
 //// Call executes a smart contract call using the transaction object data
 //func (e *Edge) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
 //	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/emc-protocol/edge-matrix/application/appidl"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -232,13 +233,13 @@ func TestEth_DecodeTxn(t *testing.T) {
 
 func newTestEthEndpoint(store testStore) *Edge {
 	return &Edge{
-		hclog.NewNullLogger(), store, 100, nil, 0,
+		hclog.NewNullLogger(), store, 100, nil, 0, false,
 	}
 }
 
 func newTestEthEndpointWithPriceLimit(store testStore, priceLimit uint64) *Edge {
 	return &Edge{
-		hclog.NewNullLogger(), store, 100, nil, priceLimit,
+		hclog.NewNullLogger(), store, 100, nil, priceLimit, false,
 	}
 }
 
@@ -298,3 +299,45 @@ func TestEth_HeaderResolveBlock(t *testing.T) {
 		}
 	}
 }
+
+type mockAppStore struct {
+	testStore
+
+	idls map[string]*appidl.Schema
+}
+
+func (m *mockAppStore) GetAppIdl(peerID string, app string) (*appidl.Schema, error) {
+	schema, ok := m.idls[peerID+"/"+app]
+	if !ok {
+		return nil, errors.New("app peer not found")
+	}
+
+	return schema, nil
+}
+
+func TestEdge_GetAppIdl(t *testing.T) {
+	t.Parallel()
+
+	schema := &appidl.Schema{
+		Format:  appidl.FormatJSON,
+		Methods: []*appidl.Method{{Name: "echo", Path: "/echo", Method: "POST"}},
+	}
+
+	edge := newTestEthEndpoint(&mockAppStore{
+		idls: map[string]*appidl.Schema{"peer-1/": schema, "peer-1/llama": schema},
+	})
+
+	res, err := edge.GetAppIdl("peer-1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, schema, res)
+
+	res, err = edge.GetAppIdl("peer-1", "llama")
+	assert.NoError(t, err)
+	assert.Equal(t, schema, res)
+
+	_, err = edge.GetAppIdl("peer-1", "sdxl")
+	assert.Error(t, err)
+
+	_, err = edge.GetAppIdl("peer-2", "")
+	assert.Error(t, err)
+}
@@ -609,6 +609,52 @@ func getExampleStore() *mockSpecialStore {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   2,
+			},
+			storage: map[types.Hash][]byte{
+				hash1: {0x1},
+			},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: types.EmptyRootHash,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+
+	res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{BlockNumber: &latest})
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := res.(*accountProof)
+	assert.Equal(t, addr0, response.Address)
+	assert.Equal(t, argUint64(2), response.Nonce)
+	assert.Equal(t, argBig(*big.NewInt(100)), response.Balance)
+	assert.Len(t, response.AccountProof, 1)
+	assert.Len(t, response.StorageProof, 2)
+	assert.Equal(t, argBig(*big.NewInt(1)), response.StorageProof[0].Value)
+	assert.Equal(t, 0, (*big.Int)(&response.StorageProof[1].Value).Sign())
+
+	// the block doesn't exist
+	invalid := BlockNumber(0x1)
+
+	_, err = eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &invalid})
+	assert.Error(t, err)
+}
+
 type mockSpecialStore struct {
 	edgeStore
 	account *mockAccount
@@ -680,6 +726,29 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*StateProof, error) {
+	proof := &StateProof{
+		Balance:       big.NewInt(0),
+		StorageRoot:   types.EmptyRootHash,
+		AccountProof:  [][]byte{{0x1}},
+		StorageProofs: make([]*StorageProof, 0, len(slots)),
+	}
+
+	if m.account.address == addr {
+		proof.Nonce = m.account.account.Nonce
+		proof.Balance = m.account.account.Balance
+	}
+
+	for _, slot := range slots {
+		proof.StorageProofs = append(proof.StorageProofs, &StorageProof{
+			Key:   slot,
+			Value: types.BytesToHash(m.account.storage[slot]),
+		})
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
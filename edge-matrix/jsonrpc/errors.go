@@ -76,6 +76,42 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type unauthorizedError struct {
+	err string
+}
+
+func (e *unauthorizedError) Error() string {
+	return e.err
+}
+
+func (e *unauthorizedError) ErrorCode() int {
+	return -32001
+}
+
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32004
+}
+
+type rateLimitError struct {
+	err string
+}
+
+func (e *rateLimitError) Error() string {
+	return e.err
+}
+
+func (e *rateLimitError) ErrorCode() int {
+	return -32005
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -94,6 +130,18 @@ func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
+func NewUnauthorizedError(msg string) *unauthorizedError {
+	return &unauthorizedError{msg}
+}
+
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
+
+func NewRateLimitError(method string) *rateLimitError {
+	return &rateLimitError{fmt.Sprintf("rate limit of the method %s exceeded", method)}
+}
+
 func NewCallNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("edge method %s not found", method)}
 }
@@ -338,7 +338,7 @@ func (f *FilterManager) NewBlockFilter(ws wsConn) string {
 	}
 
 	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
+		ws.AddFilterID(filter.id)
 	}
 
 	return f.addFilter(filter)
@@ -352,7 +352,7 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	}
 
 	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
+		ws.AddFilterID(filter.id)
 	}
 
 	return f.addFilter(filter)
@@ -547,19 +547,26 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
-	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
+	base := filter.getFilterBase()
+	if base.hasWSConn() {
+		base.ws.RemoveFilterID(id)
+	}
+
+	if removed := f.timeouts.removeFilter(base); removed {
 		f.emitSignalToUpdateCh()
 	}
 
 	return true
 }
 
-// RemoveFilterByWs removes the filter with given WS [Thread safe]
+// RemoveFilterByWs removes all the filters with given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
 	defer f.Unlock()
 
-	f.removeFilterByID(ws.GetFilterID())
+	for _, id := range ws.GetFilterIDs() {
+		f.removeFilterByID(id)
+	}
 }
 
 // refreshFilterTimeout updates the timeout for a filter to the current time
@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"net"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -362,17 +363,7 @@ func Test_flushWsFilters(t *testing.T) {
 	runTest := func(t *testing.T, flushErr error, shouldExist bool) {
 		t.Helper()
 
-		var (
-			filterID string
-		)
-
 		mock := &mockWsConn{
-			SetFilterIDFn: func(s string) {
-				filterID = s
-			},
-			GetFilterIDFn: func() string {
-				return filterID
-			},
 			WriteMessageFn: func(i int, b []byte) error {
 				return flushErr
 			},
@@ -470,17 +461,40 @@ func TestFilterWebsocket(t *testing.T) {
 }
 
 type mockWsConn struct {
-	SetFilterIDFn  func(string)
-	GetFilterIDFn  func() string
+	sync.Mutex
+
+	filterIDs      map[string]struct{}
 	WriteMessageFn func(int, []byte) error
 }
 
-func (m *mockWsConn) SetFilterID(filterID string) {
-	m.SetFilterIDFn(filterID)
+func (m *mockWsConn) AddFilterID(filterID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.filterIDs == nil {
+		m.filterIDs = make(map[string]struct{})
+	}
+
+	m.filterIDs[filterID] = struct{}{}
 }
 
-func (m *mockWsConn) GetFilterID() string {
-	return m.GetFilterIDFn()
+func (m *mockWsConn) RemoveFilterID(filterID string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.filterIDs, filterID)
+}
+
+func (m *mockWsConn) GetFilterIDs() []string {
+	m.Lock()
+	defer m.Unlock()
+
+	ids := make([]string, 0, len(m.filterIDs))
+	for id := range m.filterIDs {
+		ids = append(ids, id)
+	}
+
+	return ids
 }
 
 func (m *mockWsConn) WriteMessage(messageType int, b []byte) error {
@@ -488,18 +502,9 @@ func (m *mockWsConn) WriteMessage(messageType int, b []byte) error {
 }
 
 func newMockWsConnWithMsgCh() (*mockWsConn, <-chan []byte) {
-	var (
-		filterID string
-		msgCh    = make(chan []byte, 1)
-	)
+	msgCh := make(chan []byte, 1)
 
 	mock := &mockWsConn{
-		SetFilterIDFn: func(s string) {
-			filterID = s
-		},
-		GetFilterIDFn: func() string {
-			return filterID
-		},
 		WriteMessageFn: func(i int, b []byte) error {
 			msgCh <- b
 
@@ -598,10 +603,12 @@ func TestHeadStream_Concurrent(t *testing.T) {
 
 type MockClosedWSConnection struct{}
 
-func (m *MockClosedWSConnection) SetFilterID(_filterID string) {}
+func (m *MockClosedWSConnection) AddFilterID(_filterID string) {}
+
+func (m *MockClosedWSConnection) RemoveFilterID(_filterID string) {}
 
-func (m *MockClosedWSConnection) GetFilterID() string {
-	return ""
+func (m *MockClosedWSConnection) GetFilterIDs() []string {
+	return nil
 }
 
 func (m *MockClosedWSConnection) WriteMessage(_messageType int, _data []byte) error {
@@ -0,0 +1,137 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/helper/ipc"
+	"github.com/hashicorp/go-hclog"
+)
+
+// privilegedNamespaces are the namespaces served on the IPC endpoint, which is reachable
+// by the local users only, and to the authenticated callers granted them explicitly
+var privilegedNamespaces = map[string]bool{
+	"admin":    true,
+	"debug":    true,
+	"personal": true,
+}
+
+// rejectPrivileged returns error if the request calls any method of the privileged namespaces
+// which is not granted to the caller
+func rejectPrivileged(c *caller, reqBody []byte) (interface{}, Error) {
+	for _, req := range peekRequests(reqBody) {
+		namespace := strings.SplitN(req.Method, "_", 2)[0]
+		if privilegedNamespaces[namespace] && !c.grantsPrivileged(namespace, req.Method) {
+			return req.ID, NewMethodNotAllowedError(req.Method)
+		}
+	}
+
+	return nil, nil
+}
+
+// ipcConn is a wrapping object for the IPC connection, which holds the subscriptions
+// like the WS connection. The messages are written as the newline delimited JSON
+type ipcConn struct {
+	sync.Mutex
+
+	subscriptions
+
+	conn   net.Conn     // the actual IPC connection
+	logger hclog.Logger // module logger
+}
+
+// WriteMessage writes out the message to the IPC peer, the message type is ignored
+func (c *ipcConn) WriteMessage(_ int, data []byte) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		c.logger.Debug(fmt.Sprintf("Unable to write IPC message, %s", err.Error()))
+
+		return err
+	}
+
+	return nil
+}
+
+func (j *JSONRPC) setupIPC() error {
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return fmt.Errorf("unable to listen IPC endpoint, %w", err)
+	}
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC serves the JSON-RPC requests on the IPC connection, including the subscriptions
+// and the methods of the privileged namespaces
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	wrapConn := &ipcConn{conn: conn, logger: j.logger}
+
+	defer func() {
+		j.dispatcher.RemoveFilterByWs(wrapConn)
+
+		if err := conn.Close(); err != nil {
+			j.logger.Debug(fmt.Sprintf("Unable to close IPC connection, %s", err.Error()))
+		}
+	}()
+
+	decoder := json.NewDecoder(conn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				j.logger.Debug(fmt.Sprintf("Unable to read IPC message, %s", err.Error()))
+			}
+
+			return
+		}
+
+		go func() {
+			var (
+				resp      []byte
+				handleErr error
+			)
+
+			// the subscriptions are handled with the connection like WS,
+			// and the batch requests are handled like HTTP
+			if bytes.HasPrefix(bytes.TrimLeft(message, " \t\r\n"), []byte("[")) {
+				resp, handleErr = j.dispatcher.Handle(message)
+			} else {
+				resp, handleErr = j.dispatcher.HandleWs(message, wrapConn)
+			}
+
+			if handleErr != nil {
+				j.logger.Error(fmt.Sprintf("Unable to handle IPC request, %s", handleErr.Error()))
+
+				resp, _ = NewRPCResponse(nil, "2.0", nil, NewInternalError(handleErr.Error())).Bytes()
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
@@ -0,0 +1,111 @@
+//go:build !windows
+// +build !windows
+
+package jsonrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/helper/ipc"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockIPCDispatcher struct {
+	removedConn wsConn
+}
+
+func (d *mockIPCDispatcher) RemoveFilterByWs(conn wsConn) {
+	d.removedConn = conn
+}
+
+func (d *mockIPCDispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+	conn.AddFilterID("sub")
+
+	return []byte(`{"jsonrpc":"2.0","id":1,"result":"ws"}`), nil
+}
+
+func (d *mockIPCDispatcher) Handle(reqBody []byte) ([]byte, error) {
+	return []byte(`[{"jsonrpc":"2.0","id":1,"result":"batch"}]`), nil
+}
+
+func TestIPC(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "edge.ipc")
+
+	srv := &JSONRPC{
+		logger:     hclog.NewNullLogger(),
+		config:     &Config{IPCPath: path},
+		dispatcher: &mockIPCDispatcher{},
+	}
+
+	assert.NoError(t, srv.setupIPC())
+
+	conn, err := ipc.Dial(path)
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	readResult := func() interface{} {
+		line, err := reader.ReadBytes('\n')
+		assert.NoError(t, err)
+
+		var resp interface{}
+		assert.NoError(t, json.Unmarshal(line, &resp))
+
+		return resp
+	}
+
+	// the single requests are handled with the connection for the subscriptions
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"edge_subscribe","params":["node",{}]}`))
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	assert.Equal(t, "ws", readResult().(map[string]interface{})["result"])
+
+	// the batch requests are handled like HTTP
+	_, err = conn.Write([]byte(`[{"jsonrpc":"2.0","id":1,"method":"admin_peers","params":[]}]`))
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	assert.Equal(t, "batch", readResult().([]interface{})[0].(map[string]interface{})["result"])
+}
+
+func TestRejectPrivileged(t *testing.T) {
+	t.Parallel()
+
+	id, err := rejectPrivileged(nil, []byte(`{"jsonrpc":"2.0","id":3,"method":"admin_peers","params":[]}`))
+	assert.IsType(t, &methodNotAllowedError{}, err)
+	assert.Equal(t, float64(3), id)
+
+	_, err = rejectPrivileged(nil, []byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"edge_blockNumber"},
+		{"jsonrpc":"2.0","id":2,"method":"debug_traceTelegram"}]`))
+	assert.IsType(t, &methodNotAllowedError{}, err)
+
+	_, err = rejectPrivileged(nil, []byte(`{"jsonrpc":"2.0","id":1,"method":"edge_blockNumber","params":[]}`))
+	assert.Nil(t, err)
+
+	// the privileged methods are served to the callers granted them explicitly
+	operator := &caller{id: "key:operator", auth: "apikey", methods: []string{"admin_*", "debug_traceTelegram"}}
+
+	_, err = rejectPrivileged(operator, []byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"admin_nodeInfo"},
+		{"jsonrpc":"2.0","id":2,"method":"debug_traceTelegram"}]`))
+	assert.Nil(t, err)
+
+	_, err = rejectPrivileged(operator, []byte(`{"jsonrpc":"2.0","id":1,"method":"personal_listAccounts"}`))
+	assert.IsType(t, &methodNotAllowedError{}, err)
+
+	// the wildcard doesn't grant the privileged methods
+	reader := &caller{id: "key:reader", auth: "apikey", methods: []string{"*"}}
+
+	_, err = rejectPrivileged(reader, []byte(`{"jsonrpc":"2.0","id":1,"method":"admin_peers"}`))
+	assert.IsType(t, &methodNotAllowedError{}, err)
+}
@@ -0,0 +1,201 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+)
+
+// jobFilterManagerStore provides methods required by JobFilterManager
+type jobFilterManagerStore interface {
+	// SubscribeEdgeCallJobs subscribes for the status changes of the edge call jobs
+	SubscribeEdgeCallJobs() *jobs.Subscription
+}
+
+// JobFilterManager manages the websocket subscriptions to the status changes of the edge call jobs
+type JobFilterManager struct {
+	sync.RWMutex
+
+	logger hclog.Logger
+
+	subscription *jobs.Subscription
+
+	filters map[string]*jobFilter
+}
+
+// jobFilter is a filter to store the jobs that meet the conditions in query
+type jobFilter struct {
+	filterBase
+	sync.Mutex
+
+	query *JobQuery
+	jobs  []*EdgeCallJob
+}
+
+// appendJob appends the updated job to the filter
+func (f *jobFilter) appendJob(job *EdgeCallJob) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.jobs = append(f.jobs, job)
+}
+
+// takeJobUpdates returns all the updated jobs in filter
+func (f *jobFilter) takeJobUpdates() []*EdgeCallJob {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.jobs
+	f.jobs = []*EdgeCallJob{}
+
+	return updates
+}
+
+// getUpdates returns stored jobs
+func (f *jobFilter) getUpdates() (interface{}, error) {
+	return f.takeJobUpdates(), nil
+}
+
+// sendUpdates writes stored jobs to web socket stream
+func (f *jobFilter) sendUpdates() error {
+	for _, job := range f.takeJobUpdates() {
+		res, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewJobFilterManager(logger hclog.Logger, store jobFilterManagerStore) *JobFilterManager {
+	return &JobFilterManager{
+		logger:       logger.Named("job-filter"),
+		subscription: store.SubscribeEdgeCallJobs(),
+		filters:      make(map[string]*jobFilter),
+	}
+}
+
+// Run dispatches the job updates to the filters until the subscription is closed
+func (f *JobFilterManager) Run() {
+	for {
+		job := f.subscription.GetEvent()
+		if job == nil {
+			return
+		}
+
+		f.dispatchJob(job)
+	}
+}
+
+// Close closes the job subscription so that terminate worker
+func (f *JobFilterManager) Close() {
+	f.subscription.Close()
+}
+
+// NewJobFilter adds new job filter of the web socket connection
+func (f *JobFilterManager) NewJobFilter(query *JobQuery, ws wsConn) string {
+	filter := &jobFilter{
+		filterBase: filterBase{
+			id:        uuid.New().String(),
+			ws:        ws,
+			heapIndex: NoIndexInHeap,
+		},
+		query: query,
+	}
+
+	ws.AddFilterID(filter.id)
+
+	f.Lock()
+	f.filters[filter.id] = filter
+	f.Unlock()
+
+	return filter.id
+}
+
+// Exists checks the filter with given ID exists
+func (f *JobFilterManager) Exists(id string) bool {
+	f.RLock()
+	defer f.RUnlock()
+
+	_, ok := f.filters[id]
+
+	return ok
+}
+
+// Uninstall removes the filter with given ID from list
+func (f *JobFilterManager) Uninstall(id string) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.removeFilterByID(id)
+}
+
+// removeFilterByID removes the filter with given ID [NOT Thread Safe]
+func (f *JobFilterManager) removeFilterByID(id string) bool {
+	filter, ok := f.filters[id]
+	if !ok {
+		return false
+	}
+
+	delete(f.filters, id)
+	filter.ws.RemoveFilterID(id)
+
+	return true
+}
+
+// RemoveFilterByWs removes all the filters with given WS [Thread safe]
+func (f *JobFilterManager) RemoveFilterByWs(ws wsConn) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, id := range ws.GetFilterIDs() {
+		f.removeFilterByID(id)
+	}
+}
+
+// dispatchJob writes the job to the matching filters,
+// and removes the filters whose connections are closed
+func (f *JobFilterManager) dispatchJob(job *jobs.Job) {
+	update := toEdgeCallJob(job)
+	closedFilterIDs := make([]string, 0)
+
+	f.RLock()
+
+	for id, filter := range f.filters {
+		if !filter.query.Match(job) {
+			continue
+		}
+
+		filter.appendJob(update)
+
+		if flushErr := filter.sendUpdates(); flushErr != nil {
+			f.logger.Error(fmt.Sprintf("Unable to process flush, %v", flushErr))
+
+			if errors.Is(flushErr, websocket.ErrCloseSent) || errors.Is(flushErr, net.ErrClosed) {
+				closedFilterIDs = append(closedFilterIDs, id)
+			}
+		}
+	}
+
+	f.RUnlock()
+
+	if len(closedFilterIDs) > 0 {
+		f.Lock()
+		for _, id := range closedFilterIDs {
+			f.removeFilterByID(id)
+		}
+		f.Unlock()
+	}
+}
@@ -40,6 +40,7 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+	guard      *guard
 }
 
 type dispatcher interface {
@@ -57,6 +58,8 @@ type JSONRPCStore interface {
 	filterManagerStore
 	rtcFilterManagerStore
 	nodeFilterManagerStore
+	jobFilterManagerStore
+	adminStore
 	//bridgeStore
 	//debugStore
 }
@@ -70,6 +73,12 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	WSSubscriptionLimit      uint64
+	LightClient              bool
+	Auth                     *AuthConfig
+
+	// IPCPath is the path of the IPC endpoint, which is disabled if it's empty
+	IPCPath string
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -86,8 +95,11 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 				priceLimit:              config.PriceLimit,
 				jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 				blockRangeLimit:         config.BlockRangeLimit,
+				wsSubscriptionLimit:     config.WSSubscriptionLimit,
+				lightClient:             config.LightClient,
 			},
 		),
+		guard: newGuard(config.Auth),
 	}
 
 	// start http server
@@ -95,6 +107,13 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	// start ipc server
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
@@ -169,17 +188,50 @@ var wsUpgrader = websocket.Upgrader{
 type wsWrapper struct {
 	sync.Mutex
 
-	ws       *websocket.Conn // the actual WS connection
-	logger   hclog.Logger    // module logger
-	filterID string          // filter ID
+	subscriptions
+
+	ws     *websocket.Conn // the actual WS connection
+	logger hclog.Logger    // module logger
+	caller *caller         // authenticated client of the connection
+}
+
+// subscriptions is the set of the filter IDs held by a connection
+type subscriptions struct {
+	lock sync.RWMutex
+	ids  map[string]struct{}
 }
 
-func (w *wsWrapper) SetFilterID(filterID string) {
-	w.filterID = filterID
+// AddFilterID adds the ID of a new subscription of the connection
+func (s *subscriptions) AddFilterID(filterID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ids == nil {
+		s.ids = make(map[string]struct{})
+	}
+
+	s.ids[filterID] = struct{}{}
+}
+
+// RemoveFilterID removes the ID of a subscription of the connection
+func (s *subscriptions) RemoveFilterID(filterID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.ids, filterID)
 }
 
-func (w *wsWrapper) GetFilterID() string {
-	return w.filterID
+// GetFilterIDs returns the IDs of all the subscriptions of the connection
+func (s *subscriptions) GetFilterIDs() []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ids := make([]string, 0, len(s.ids))
+	for id := range s.ids {
+		ids = append(ids, id)
+	}
+
+	return ids
 }
 
 // WriteMessage writes out the message to the WS peer
@@ -203,12 +255,60 @@ func isSupportedWSType(messageType int) bool {
 		messageType == websocket.BinaryMessage
 }
 
+// checkOrigin is the CORS rule of the WS connections, same as the one of the HTTP requests.
+// The requests without the origin are not from the browsers and allowed
+func (j *JSONRPC) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowedOrigin := range j.config.AccessControlAllowOrigin {
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeRejection writes the error response of the rejected request with its HTTP status
+func (j *JSONRPC) writeRejection(w http.ResponseWriter, id interface{}, rejectErr Error) {
+	resp, err := NewRPCResponse(id, "2.0", nil, rejectErr).Bytes()
+	if err != nil {
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeOf(rejectErr))
+	_, _ = w.Write(resp)
+}
+
+// authorize checks the request on the network endpoints,
+// which serve the privileged namespaces only to the callers granted them
+func (j *JSONRPC) authorize(c *caller, reqBody []byte) (interface{}, Error) {
+	if id, err := rejectPrivileged(c, reqBody); err != nil {
+		return id, err
+	}
+
+	return j.guard.authorize(c, reqBody)
+}
+
 func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
-	// CORS rule - Allow requests from anywhere
-	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
+	caller, authErr := j.guard.authenticate(req)
+	if authErr != nil {
+		j.writeRejection(w, nil, authErr)
+
+		return
+	}
+
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = j.checkOrigin
 
 	// Upgrade the connection to a WS one
-	ws, err := wsUpgrader.Upgrade(w, req, nil)
+	ws, err := upgrader.Upgrade(w, req, nil)
 	if err != nil {
 		j.logger.Error(fmt.Sprintf("Unable to upgrade to a WS connection, %s", err.Error()))
 
@@ -225,7 +325,7 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 	}(ws)
 
-	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
+	wrapConn := &wsWrapper{ws: ws, logger: j.logger, caller: caller}
 
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
@@ -252,6 +352,13 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
+				if id, rejectErr := j.authorize(wrapConn.caller, message); rejectErr != nil {
+					resp, _ := NewRPCResponse(id, "2.0", nil, rejectErr).Bytes()
+					_ = wrapConn.WriteMessage(msgType, resp)
+
+					return
+				}
+
 				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
@@ -273,7 +380,7 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
 	w.Header().Set(
 		"Access-Control-Allow-Headers",
-		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization",
+		"Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key",
 	)
 
 	switch req.Method {
@@ -289,6 +396,13 @@ func (j *JSONRPC) handle(w http.ResponseWriter, req *http.Request) {
 }
 
 func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request) {
+	caller, authErr := j.guard.authenticate(req)
+	if authErr != nil {
+		j.writeRejection(w, nil, authErr)
+
+		return
+	}
+
 	data, err := io.ReadAll(req.Body)
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -296,6 +410,12 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	if id, rejectErr := j.authorize(caller, data); rejectErr != nil {
+		j.writeRejection(w, id, rejectErr)
+
+		return
+	}
+
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
@@ -0,0 +1,458 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/emc-protocol/edge-matrix/application"
+)
+
+const (
+	// defaultNodesLimit is the size of the page of nodes if the query sets no limit
+	defaultNodesLimit = 100
+
+	// maxNodesLimit is the maximum size of a page of nodes
+	maxNodesLimit = 1000
+)
+
+// Keys sorting the nodes of the directory
+const (
+	NodeSortByID           = "id"
+	NodeSortByUptime       = "uptime"
+	NodeSortByAveragePower = "averagePower"
+	NodeSortByLoad         = "load"
+	NodeSortByFreeMemory   = "freeMemory"
+	NodeSortByGpus         = "gpus"
+	NodeSortByVram         = "vram"
+	NodeSortByCpuCores     = "cpuCores"
+	NodeSortByVersion      = "version"
+)
+
+const (
+	sortOrderAsc  = "asc"
+	sortOrderDesc = "desc"
+)
+
+var (
+	ErrEdgeNodeNotFound = errors.New("edge node not found")
+)
+
+// EdgeNode is an edge node of the directory, the status gossiped by its app
+// with the hardware summarized from its capabilities, or parsed from the memory and GPU infos of the older apps
+type EdgeNode struct {
+	*AppPeerInfo
+
+	Gpus        int      `json:"gpus"`
+	GpuModels   []string `json:"gpuModels"`
+	TotalMemory uint64   `json:"totalMemory"`
+	FreeMemory  uint64   `json:"freeMemory"`
+
+	// MaxVram is the largest video memory of a GPU, zero if unknown
+	MaxVram  uint64 `json:"maxVram"`
+	CpuCores uint32 `json:"cpuCores"`
+
+	// Load is the ratio of the occupied slots of the app, in [0, 1]
+	Load float64 `json:"load"`
+}
+
+// memInfo is the memory info published by the apps
+type memInfo struct {
+	Total uint64 `json:"total"`
+	Free  uint64 `json:"free"`
+}
+
+// gpuInfo is the GPU info published by the apps
+type gpuInfo struct {
+	Gpus         int      `json:"gpus"`
+	GraphicsCard []string `json:"graphics_card"`
+}
+
+// newEdgeNode summarizes the hardware of the app peer, the malformed infos are left empty
+func newEdgeNode(peer *AppPeerInfo) *EdgeNode {
+	node := &EdgeNode{
+		AppPeerInfo: peer,
+		GpuModels:   []string{},
+	}
+
+	if peer.MaxSlots != 0 {
+		node.Load = float64(peer.Slots) / float64(peer.MaxSlots)
+	}
+
+	if caps := peer.Capabilities; caps != nil {
+		node.Gpus = len(caps.Gpus)
+		node.MaxVram = caps.MaxVram()
+
+		for _, gpu := range caps.Gpus {
+			node.GpuModels = append(node.GpuModels, gpu.Model)
+		}
+
+		if caps.Memory != nil {
+			node.TotalMemory, node.FreeMemory = caps.Memory.Total, caps.Memory.Free
+		}
+
+		if caps.Cpu != nil {
+			node.CpuCores = caps.Cpu.Cores
+		}
+
+		return node
+	}
+
+	mem := memInfo{}
+	if err := json.Unmarshal([]byte(peer.MemInfo), &mem); err == nil {
+		node.TotalMemory, node.FreeMemory = mem.Total, mem.Free
+	}
+
+	gpu := gpuInfo{}
+	if err := json.Unmarshal([]byte(peer.GpuInfo), &gpu); err == nil {
+		node.Gpus = gpu.Gpus
+
+		if gpu.GraphicsCard != nil {
+			node.GpuModels = gpu.GraphicsCard
+		}
+	}
+
+	return node
+}
+
+// NodeDirectoryQuery filters, sorts and paginates the edge nodes of the directory,
+// the zero filters match any node
+type NodeDirectoryQuery struct {
+	// App, AppOrigin and ModelHash match the nodes hosting an app matching all of them
+	App       string `json:"app"`
+	AppOrigin string `json:"appOrigin"`
+	ModelHash string `json:"modelHash"`
+
+	// GpuModel matches the nodes having a GPU whose model contains it, ignoring the case
+	GpuModel string `json:"gpuModel"`
+	MinGpus  int    `json:"minGpus"`
+
+	// MinVram matches the nodes having a GPU with at least this video memory in bytes
+	MinVram uint64 `json:"minVram"`
+
+	MinCpuCores   uint32 `json:"minCpuCores"`
+	MinFreeMemory uint64 `json:"minFreeMemory"`
+
+	// Runtime matches the nodes providing the accelerator runtime, such as cuda or rocm
+	Runtime string `json:"runtime"`
+
+	// MaxLoad matches the nodes whose ratio of occupied slots is at most it, if set
+	MaxLoad *float64 `json:"maxLoad"`
+
+	// MinVersion and MaxVersion are the inclusive bounds of the node versions, such as "v1.2.0"
+	MinVersion string `json:"minVersion"`
+	MaxVersion string `json:"maxVersion"`
+
+	MinUptime       uint64  `json:"minUptime"`
+	MinAveragePower float32 `json:"minAveragePower"`
+
+	// SortBy is the key sorting the nodes, by id if not set, and Order is either asc (default) or desc
+	SortBy string `json:"sortBy"`
+	Order  string `json:"order"`
+
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+// NodeDirectoryPage is a page of the edge nodes matching a query
+type NodeDirectoryPage struct {
+	Nodes []*EdgeNode `json:"nodes"`
+
+	// Total is the number of matching nodes, regardless of the pagination
+	Total uint64 `json:"total"`
+}
+
+// nodeMatcher is the validated query matching the nodes
+type nodeMatcher struct {
+	query      *NodeDirectoryQuery
+	minVersion []uint64
+	maxVersion []uint64
+}
+
+func newNodeMatcher(query *NodeDirectoryQuery) (*nodeMatcher, error) {
+	m := &nodeMatcher{query: query}
+
+	var err error
+
+	if query.MinVersion != "" {
+		if m.minVersion, err = parseVersion(query.MinVersion); err != nil {
+			return nil, fmt.Errorf("invalid minVersion: %w", err)
+		}
+	}
+
+	if query.MaxVersion != "" {
+		if m.maxVersion, err = parseVersion(query.MaxVersion); err != nil {
+			return nil, fmt.Errorf("invalid maxVersion: %w", err)
+		}
+	}
+
+	if query.MaxLoad != nil && *query.MaxLoad < 0 {
+		return nil, errors.New("maxLoad must not be negative")
+	}
+
+	return m, nil
+}
+
+// hostsApp returns true if an app hosted by the node matches the app filters of the query,
+// the nodes advertising no apps host the single app of their legacy fields
+func (m *nodeMatcher) hostsApp(node *EdgeNode) bool {
+	q := m.query
+
+	apps := node.Apps
+	if len(apps) == 0 {
+		apps = []*application.HostedApp{{AppOrigin: node.AppOrigin, ModelHash: node.ModelHash}}
+	}
+
+	for _, app := range apps {
+		if (q.App == "" || app.Name == q.App) &&
+			(q.AppOrigin == "" || app.AppOrigin == q.AppOrigin) &&
+			(q.ModelHash == "" || app.ModelHash == q.ModelHash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *nodeMatcher) match(node *EdgeNode) bool {
+	q := m.query
+
+	if (q.App != "" || q.AppOrigin != "" || q.ModelHash != "") && !m.hostsApp(node) {
+		return false
+	}
+
+	if q.GpuModel != "" && !hasGpuModel(node.GpuModels, q.GpuModel) {
+		return false
+	}
+
+	if node.Gpus < q.MinGpus || node.FreeMemory < q.MinFreeMemory {
+		return false
+	}
+
+	if node.MaxVram < q.MinVram || node.CpuCores < q.MinCpuCores {
+		return false
+	}
+
+	// only the capabilities report the runtimes
+	if q.Runtime != "" && (node.Capabilities == nil || node.Capabilities.Runtime(q.Runtime) == nil) {
+		return false
+	}
+
+	if q.MaxLoad != nil && node.Load > *q.MaxLoad {
+		return false
+	}
+
+	if node.Uptime < q.MinUptime || node.AveragePower < q.MinAveragePower {
+		return false
+	}
+
+	if m.minVersion == nil && m.maxVersion == nil {
+		return true
+	}
+
+	// the nodes of unknown version are out of any version range
+	version, err := parseVersion(node.Version)
+	if err != nil {
+		return false
+	}
+
+	if m.minVersion != nil && compareVersions(version, m.minVersion) < 0 {
+		return false
+	}
+
+	if m.maxVersion != nil && compareVersions(version, m.maxVersion) > 0 {
+		return false
+	}
+
+	return true
+}
+
+func hasGpuModel(models []string, model string) bool {
+	model = strings.ToLower(model)
+
+	for _, m := range models {
+		if strings.Contains(strings.ToLower(m), model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// queryNodes returns the page of the nodes matching the query
+func queryNodes(peers []*AppPeerInfo, query *NodeDirectoryQuery) (*NodeDirectoryPage, error) {
+	if query == nil {
+		query = &NodeDirectoryQuery{}
+	}
+
+	matcher, err := newNodeMatcher(query)
+	if err != nil {
+		return nil, err
+	}
+
+	less, err := nodeLess(query.SortBy, query.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultNodesLimit
+	}
+
+	if limit > maxNodesLimit {
+		return nil, fmt.Errorf("limit must be at most %d", maxNodesLimit)
+	}
+
+	nodes := make([]*EdgeNode, 0, len(peers))
+
+	for _, peer := range peers {
+		if node := newEdgeNode(peer); matcher.match(node) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return less(nodes[i], nodes[j])
+	})
+
+	page := &NodeDirectoryPage{
+		Nodes: []*EdgeNode{},
+		Total: uint64(len(nodes)),
+	}
+
+	if query.Offset < page.Total {
+		end := query.Offset + limit
+		if end > page.Total {
+			end = page.Total
+		}
+
+		page.Nodes = nodes[query.Offset:end]
+	}
+
+	return page, nil
+}
+
+// nodeLess returns the ordering of the nodes by the sort key, the equal nodes are ordered by id
+func nodeLess(sortBy, order string) (func(a, b *EdgeNode) bool, error) {
+	var compare func(a, b *EdgeNode) int
+
+	switch sortBy {
+	case "", NodeSortByID:
+		compare = func(a, b *EdgeNode) int { return 0 }
+	case NodeSortByUptime:
+		compare = func(a, b *EdgeNode) int { return compareUint64(a.Uptime, b.Uptime) }
+	case NodeSortByAveragePower:
+		compare = func(a, b *EdgeNode) int { return compareFloat64(float64(a.AveragePower), float64(b.AveragePower)) }
+	case NodeSortByLoad:
+		compare = func(a, b *EdgeNode) int { return compareFloat64(a.Load, b.Load) }
+	case NodeSortByFreeMemory:
+		compare = func(a, b *EdgeNode) int { return compareUint64(a.FreeMemory, b.FreeMemory) }
+	case NodeSortByGpus:
+		compare = func(a, b *EdgeNode) int { return compareUint64(uint64(a.Gpus), uint64(b.Gpus)) }
+	case NodeSortByVram:
+		compare = func(a, b *EdgeNode) int { return compareUint64(a.MaxVram, b.MaxVram) }
+	case NodeSortByCpuCores:
+		compare = func(a, b *EdgeNode) int { return compareUint64(uint64(a.CpuCores), uint64(b.CpuCores)) }
+	case NodeSortByVersion:
+		compare = func(a, b *EdgeNode) int {
+			// the nodes of unknown version are the oldest
+			va, _ := parseVersion(a.Version)
+			vb, _ := parseVersion(b.Version)
+
+			return compareVersions(va, vb)
+		}
+	default:
+		return nil, fmt.Errorf("unknown sort key %q", sortBy)
+	}
+
+	sign := 1
+
+	switch order {
+	case "", sortOrderAsc:
+	case sortOrderDesc:
+		sign = -1
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", order)
+	}
+
+	return func(a, b *EdgeNode) bool {
+		if c := compare(a, b); c != 0 {
+			return sign*c < 0
+		}
+
+		return sign*strings.Compare(a.ID, b.ID) < 0
+	}, nil
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseVersion parses the numbers of a version such as "v1.2.3" or "1.2.3-rc1", ignoring the suffix
+func parseVersion(version string) ([]uint64, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	if version == "" {
+		return nil, errors.New("empty version")
+	}
+
+	parts := strings.Split(version, ".")
+	numbers := make([]uint64, len(parts))
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed version %q", version)
+		}
+
+		numbers[i] = n
+	}
+
+	return numbers, nil
+}
+
+// compareVersions compares the versions number by number, the missing numbers being zero
+func compareVersions(a, b []uint64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y uint64
+
+		if i < len(a) {
+			x = a[i]
+		}
+
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if c := compareUint64(x, y); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,304 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/capability"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockNodeStore struct {
+	testStore
+
+	peers []*AppPeerInfo
+}
+
+func (m *mockNodeStore) GetAppPeers() []*AppPeerInfo {
+	return m.peers
+}
+
+func (m *mockNodeStore) GetAppPeer(peerID string) (*AppPeerInfo, bool) {
+	for _, peer := range m.peers {
+		if peer.ID == peerID {
+			return peer, true
+		}
+	}
+
+	return nil, false
+}
+
+func testAppPeers() []*AppPeerInfo {
+	return []*AppPeerInfo{
+		{
+			ID:           "node-a",
+			AppOrigin:    "sd",
+			ModelHash:    "model-1",
+			MemInfo:      `{"total": 32000, "free":16000, "used_percent":50.000000}`,
+			GpuInfo:      `{"gpus":2,"graphics_card":["NVIDIA GeForce RTX 4090","NVIDIA GeForce RTX 4090"]}`,
+			Version:      "v1.2.0",
+			Uptime:       3000,
+			Slots:        50,
+			MaxSlots:     200,
+			AveragePower: 1.5,
+		},
+		{
+			ID:           "node-b",
+			AppOrigin:    "sd",
+			ModelHash:    "model-2",
+			MemInfo:      `{"total": 64000, "free":48000, "used_percent":25.000000}`,
+			GpuInfo:      `{"gpus":1,"graphics_card":["NVIDIA A100"]}`,
+			Version:      "v1.3.1-rc1",
+			Uptime:       1000,
+			Slots:        150,
+			MaxSlots:     200,
+			AveragePower: 3,
+		},
+		{
+			ID:        "node-c",
+			AppOrigin: "llm",
+			MemInfo:   `{"total": 8000, "free":1000, "used_percent":87.500000}`,
+			Version:   "dev",
+			Uptime:    2000,
+		},
+	}
+}
+
+func nodeIDs(page *NodeDirectoryPage) []string {
+	ids := make([]string, len(page.Nodes))
+	for i, node := range page.Nodes {
+		ids[i] = node.ID
+	}
+
+	return ids
+}
+
+func TestQueryNodes_Filter(t *testing.T) {
+	t.Parallel()
+
+	maxLoad := 0.5
+
+	cases := []struct {
+		name  string
+		query *NodeDirectoryQuery
+		ids   []string
+	}{
+		{"no query", nil, []string{"node-a", "node-b", "node-c"}},
+		{"app origin", &NodeDirectoryQuery{AppOrigin: "sd"}, []string{"node-a", "node-b"}},
+		{"model hash", &NodeDirectoryQuery{ModelHash: "model-2"}, []string{"node-b"}},
+		{"gpu model", &NodeDirectoryQuery{GpuModel: "rtx 4090"}, []string{"node-a"}},
+		{"gpu count", &NodeDirectoryQuery{MinGpus: 1}, []string{"node-a", "node-b"}},
+		{"free memory", &NodeDirectoryQuery{MinFreeMemory: 20000}, []string{"node-b"}},
+		{"load", &NodeDirectoryQuery{MaxLoad: &maxLoad}, []string{"node-a", "node-c"}},
+		{"min version", &NodeDirectoryQuery{MinVersion: "1.3"}, []string{"node-b"}},
+		{"version range", &NodeDirectoryQuery{MinVersion: "v1.0.0", MaxVersion: "v1.2"}, []string{"node-a"}},
+		{"uptime", &NodeDirectoryQuery{MinUptime: 2000}, []string{"node-a", "node-c"}},
+		{"average power", &NodeDirectoryQuery{MinAveragePower: 2}, []string{"node-b"}},
+		{"combined", &NodeDirectoryQuery{AppOrigin: "sd", MinUptime: 2000}, []string{"node-a"}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			page, err := queryNodes(testAppPeers(), c.query)
+			assert.NoError(t, err)
+			assert.Equal(t, c.ids, nodeIDs(page))
+			assert.Equal(t, uint64(len(c.ids)), page.Total)
+		})
+	}
+}
+
+func TestQueryNodes_SortAndPaginate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		query *NodeDirectoryQuery
+		ids   []string
+	}{
+		{"uptime", &NodeDirectoryQuery{SortBy: NodeSortByUptime}, []string{"node-b", "node-c", "node-a"}},
+		{"free memory desc", &NodeDirectoryQuery{SortBy: NodeSortByFreeMemory, Order: "desc"}, []string{"node-b", "node-a", "node-c"}},
+		{"load", &NodeDirectoryQuery{SortBy: NodeSortByLoad}, []string{"node-c", "node-a", "node-b"}},
+		{"version desc", &NodeDirectoryQuery{SortBy: NodeSortByVersion, Order: "desc"}, []string{"node-b", "node-a", "node-c"}},
+		{"offset", &NodeDirectoryQuery{Offset: 1}, []string{"node-b", "node-c"}},
+		{"limit", &NodeDirectoryQuery{SortBy: NodeSortByGpus, Order: "desc", Limit: 2}, []string{"node-a", "node-b"}},
+		{"offset past the end", &NodeDirectoryQuery{Offset: 5}, []string{}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			page, err := queryNodes(testAppPeers(), c.query)
+			assert.NoError(t, err)
+			assert.Equal(t, c.ids, nodeIDs(page))
+			assert.Equal(t, uint64(3), page.Total)
+		})
+	}
+}
+
+func TestQueryNodes_HostedApps(t *testing.T) {
+	t.Parallel()
+
+	peers := []*AppPeerInfo{
+		{
+			ID:        "node-a",
+			AppOrigin: "sd",
+			ModelHash: "model-1",
+			Apps: []*application.HostedApp{
+				{Name: "", AppOrigin: "sd", ModelHash: "model-1"},
+				{Name: "llama", AppOrigin: "llm", ModelHash: "model-2"},
+			},
+		},
+		{
+			// a node prior to the hosting of several apps
+			ID:        "node-b",
+			AppOrigin: "llm",
+			ModelHash: "model-2",
+		},
+	}
+
+	cases := []struct {
+		name  string
+		query *NodeDirectoryQuery
+		ids   []string
+	}{
+		{"origin of any app", &NodeDirectoryQuery{AppOrigin: "llm"}, []string{"node-a", "node-b"}},
+		{"model of any app", &NodeDirectoryQuery{ModelHash: "model-2"}, []string{"node-a", "node-b"}},
+		{"app name", &NodeDirectoryQuery{App: "llama"}, []string{"node-a"}},
+		{"same app", &NodeDirectoryQuery{AppOrigin: "llm", ModelHash: "model-2"}, []string{"node-a", "node-b"}},
+		{"different apps", &NodeDirectoryQuery{AppOrigin: "sd", ModelHash: "model-2"}, []string{}},
+	}
+
+	for _, c := range cases {
+		page, err := queryNodes(peers, c.query)
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.ids, nodeIDs(page), c.name)
+	}
+}
+
+func TestQueryNodes_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	negative := -1.0
+
+	for _, query := range []*NodeDirectoryQuery{
+		{SortBy: "name"},
+		{Order: "up"},
+		{MinVersion: "latest"},
+		{MaxVersion: "1.x"},
+		{MaxLoad: &negative},
+		{Limit: maxNodesLimit + 1},
+	} {
+		_, err := queryNodes(testAppPeers(), query)
+		assert.Error(t, err, "%+v", query)
+	}
+}
+
+func TestEdge_GetNode(t *testing.T) {
+	t.Parallel()
+
+	edge := newTestEthEndpoint(&mockNodeStore{peers: testAppPeers()})
+
+	res, err := edge.GetNode("node-a")
+	assert.NoError(t, err)
+
+	node, ok := res.(*EdgeNode)
+	assert.True(t, ok)
+	assert.Equal(t, 2, node.Gpus)
+	assert.Equal(t, []string{"NVIDIA GeForce RTX 4090", "NVIDIA GeForce RTX 4090"}, node.GpuModels)
+	assert.Equal(t, uint64(32000), node.TotalMemory)
+	assert.Equal(t, uint64(16000), node.FreeMemory)
+	assert.Equal(t, 0.25, node.Load)
+
+	_, err = edge.GetNode("node-x")
+	assert.ErrorIs(t, err, ErrEdgeNodeNotFound)
+
+	res, err = edge.GetNodes(&NodeDirectoryQuery{AppOrigin: "llm"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-c"}, nodeIDs(res.(*NodeDirectoryPage)))
+}
+
+func TestDecodeNodeQueryFromInterface(t *testing.T) {
+	t.Parallel()
+
+	query, err := decodeNodeQueryFromInterface(map[string]interface{}{
+		"name":    "edge",
+		"tag":     "sd",
+		"id":      "node-a",
+		"version": "v1.2.0",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &NodeQuery{Name: "edge", Tag: "sd", ID: "node-a", Version: "v1.2.0"}, query)
+}
+
+func TestQueryNodes_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	peers := []*AppPeerInfo{
+		{
+			ID: "node-a",
+			// the capabilities take precedence over the info strings
+			GpuInfo: `{"gpus":1,"graphics_card":["legacy"]}`,
+			Capabilities: &capability.Capabilities{
+				Version: capability.SchemaVersion,
+				Cpu:     &capability.Cpu{Model: "EPYC", Cores: 64, Threads: 128},
+				Gpus: []*capability.Gpu{
+					{Model: "NVIDIA A100", Vram: 80 << 30},
+					{Model: "NVIDIA A100", Vram: 80 << 30},
+				},
+				Memory:   &capability.Memory{Total: 512 << 30, Free: 256 << 30},
+				Runtimes: []*capability.Runtime{{Name: capability.RuntimeCuda, Version: "12.2"}},
+			},
+		},
+		{
+			ID: "node-b",
+			Capabilities: &capability.Capabilities{
+				Version:  capability.SchemaVersion,
+				Cpu:      &capability.Cpu{Model: "Ryzen", Cores: 16, Threads: 32},
+				Gpus:     []*capability.Gpu{{Model: "AMD Radeon RX 7900 XTX", Vram: 24 << 30}},
+				Runtimes: []*capability.Runtime{{Name: capability.RuntimeRocm}},
+			},
+		},
+		{
+			ID:      "node-c",
+			GpuInfo: `{"gpus":1,"graphics_card":["NVIDIA T4"]}`,
+		},
+	}
+
+	cases := []struct {
+		name  string
+		query *NodeDirectoryQuery
+		ids   []string
+	}{
+		{"vram", &NodeDirectoryQuery{MinVram: 32 << 30}, []string{"node-a"}},
+		{"cpu cores", &NodeDirectoryQuery{MinCpuCores: 16}, []string{"node-a", "node-b"}},
+		{"runtime", &NodeDirectoryQuery{Runtime: capability.RuntimeRocm}, []string{"node-b"}},
+		{"gpu model", &NodeDirectoryQuery{GpuModel: "a100"}, []string{"node-a"}},
+		{"legacy gpu model", &NodeDirectoryQuery{GpuModel: "t4"}, []string{"node-c"}},
+		{"sort by vram", &NodeDirectoryQuery{SortBy: NodeSortByVram, Order: "desc"}, []string{"node-a", "node-b", "node-c"}},
+		{"sort by cores", &NodeDirectoryQuery{SortBy: NodeSortByCpuCores}, []string{"node-c", "node-b", "node-a"}},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			page, err := queryNodes(peers, c.query)
+			assert.NoError(t, err)
+			assert.Equal(t, c.ids, nodeIDs(page))
+		})
+	}
+
+	node := newEdgeNode(peers[0])
+	assert.Equal(t, 2, node.Gpus)
+	assert.Equal(t, uint64(80<<30), node.MaxVram)
+	assert.Equal(t, uint64(256<<30), node.FreeMemory)
+}
@@ -0,0 +1,177 @@
+package jsonrpc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+var ErrNotChannelTelegram = errors.New("telegram is not sent to the payment channel precompile")
+
+// PaymentChannel is a payment channel as of the block number, with the latest voucher received by the node
+type PaymentChannel struct {
+	ID         types.Hash    `json:"id"`
+	Sender     types.Address `json:"sender"`
+	Provider   types.Address `json:"provider"`
+	Deposit    argBig        `json:"deposit"`
+	Paid       argBig        `json:"paid"`
+	Expiration argUint64     `json:"expiration"`
+	Closed     bool          `json:"closed"`
+
+	// BlockNumber is the number of the block the channel is read at, zero if the channel is not opened yet
+	BlockNumber argUint64 `json:"blockNumber"`
+
+	LatestVoucher *channel.Voucher `json:"latestVoucher,omitempty"`
+}
+
+// ChannelTelegram is the channel action telegram submitted to the pool
+type ChannelTelegram struct {
+	TelegramHash types.Hash `json:"telegramHash"`
+	ChannelID    types.Hash `json:"channelId"`
+}
+
+func toPaymentChannel(ch *channel.Channel, number uint64, voucher *channel.Voucher) *PaymentChannel {
+	return &PaymentChannel{
+		ID:            ch.ID,
+		Sender:        ch.Sender,
+		Provider:      ch.Provider,
+		Deposit:       argBig(*ch.Deposit),
+		Paid:          argBig(*ch.Paid),
+		Expiration:    argUint64(ch.Expiration),
+		Closed:        ch.Closed,
+		BlockNumber:   argUint64(number),
+		LatestVoucher: voucher,
+	}
+}
+
+// submitChannelTelegram submits the signed telegram of the channel action of the given type
+func (e *Edge) submitChannelTelegram(buf argBytes, actionType channel.ActionType) (interface{}, error) {
+	tele := &types.Telegram{}
+	if err := tele.UnmarshalRLP(buf); err != nil {
+		return nil, err
+	}
+
+	if tele.To == nil || *tele.To != contracts.EdgePaymentChannelPrecompile {
+		return nil, ErrNotChannelTelegram
+	}
+
+	action := &channel.Action{}
+	if err := action.UnmarshalRLP(tele.Input); err != nil {
+		return nil, err
+	}
+
+	if action.Type != actionType {
+		return nil, channel.ErrInvalidAction
+	}
+
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+
+	if tele.Gas < action.Gas() {
+		return nil, channel.ErrActionGas
+	}
+
+	tele.ComputeHash()
+
+	if _, err := e.store.AddTele(tele); err != nil {
+		return nil, err
+	}
+
+	// the sender is recovered by the pool
+	id := action.ChannelID
+	if action.Type == channel.ActionOpen {
+		id = channel.ID(tele.From, action.Provider, tele.Nonce)
+
+		if err := e.store.TrackChannel(&channel.Channel{
+			ID:         id,
+			Sender:     tele.From,
+			Provider:   action.Provider,
+			Deposit:    new(big.Int).Set(tele.Value),
+			Paid:       big.NewInt(0),
+			Expiration: action.Expiration,
+		}); err != nil {
+			return nil, err
+		}
+	} else if ch, _, err := e.store.GetChannel(id); err == nil && ch != nil {
+		if err := e.store.TrackChannel(ch); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ChannelTelegram{TelegramHash: tele.Hash, ChannelID: id}, nil
+}
+
+// OpenChannel submits the signed telegram opening a payment channel,
+// and returns the ID of the channel opened once the telegram is mined
+func (e *Edge) OpenChannel(buf argBytes) (interface{}, error) {
+	return e.submitChannelTelegram(buf, channel.ActionOpen)
+}
+
+// TopUpChannel submits the signed telegram adding to the deposit of a payment channel
+func (e *Edge) TopUpChannel(buf argBytes) (interface{}, error) {
+	return e.submitChannelTelegram(buf, channel.ActionTopUp)
+}
+
+// CloseChannel submits the signed telegram closing a payment channel
+func (e *Edge) CloseChannel(buf argBytes) (interface{}, error) {
+	return e.submitChannelTelegram(buf, channel.ActionClose)
+}
+
+// RedeemChannel redeems the latest voucher of the payment channel received by the node as its provider
+func (e *Edge) RedeemChannel(id types.Hash) (interface{}, error) {
+	hash, err := e.store.RedeemChannel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelTelegram{TelegramHash: hash, ChannelID: id}, nil
+}
+
+// GetChannel returns the payment channel from the latest state, with the latest voucher received by the node
+func (e *Edge) GetChannel(id types.Hash) (interface{}, error) {
+	ch, number, err := e.store.GetChannel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ch == nil {
+		return nil, channel.ErrUnknownChannel
+	}
+
+	voucher, _, err := e.store.GetLatestVoucher(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPaymentChannel(ch, number, voucher), nil
+}
+
+// GetChannels returns the payment channels persisted by the node, opened through the node or paying it,
+// from the latest state if opened
+func (e *Edge) GetChannels() (interface{}, error) {
+	entries, err := e.store.GetLocalChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]*PaymentChannel, len(entries))
+
+	for i, entry := range entries {
+		ch, number, err := e.store.GetChannel(entry.Channel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if ch == nil {
+			ch, number = entry.Channel, 0
+		}
+
+		channels[i] = toPaymentChannel(ch, number, entry.Voucher)
+	}
+
+	return channels, nil
+}
@@ -0,0 +1,159 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockChannelStore struct {
+	testStore
+
+	signer   crypto.TxSigner
+	store    *channel.Store
+	onChain  map[types.Hash]*channel.Channel
+	number   uint64
+	redeemed []types.Hash
+	teles    []*types.Telegram
+}
+
+func (m *mockChannelStore) AddTele(tele *types.Telegram) (string, error) {
+	from, err := m.signer.Sender(tele)
+	if err != nil {
+		return "", err
+	}
+
+	tele.From = from
+	m.teles = append(m.teles, tele)
+
+	return "", nil
+}
+
+func (m *mockChannelStore) GetChannel(id types.Hash) (*channel.Channel, uint64, error) {
+	return m.onChain[id], m.number, nil
+}
+
+func (m *mockChannelStore) GetLocalChannels() ([]*channel.Entry, error) {
+	return m.store.Channels()
+}
+
+func (m *mockChannelStore) GetLatestVoucher(id types.Hash) (*channel.Voucher, bool, error) {
+	return m.store.LatestVoucher(id)
+}
+
+func (m *mockChannelStore) TrackChannel(ch *channel.Channel) error {
+	return m.store.PutChannel(ch)
+}
+
+func (m *mockChannelStore) RedeemChannel(id types.Hash) (types.Hash, error) {
+	m.redeemed = append(m.redeemed, id)
+
+	return types.StringToHash("0xbeef"), nil
+}
+
+func TestEdge_PaymentChannels(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sender := crypto.PubKeyToAddress(&key.PublicKey)
+	provider := types.StringToAddress("0x2")
+	signer := crypto.NewEIP155Signer(chain.AllForksEnabled.At(0), 100)
+
+	store := channel.NewMemoryStore()
+	defer store.Close()
+
+	mock := &mockChannelStore{signer: signer, store: store, onChain: map[types.Hash]*channel.Channel{}, number: 7}
+	edge := newTestEthEndpoint(mock)
+
+	signActionWithGas := func(to types.Address, nonce uint64, action *channel.Action, gas uint64) argBytes {
+		tele, err := signer.SignTele(&types.Telegram{
+			To:       &to,
+			Nonce:    nonce,
+			Value:    big.NewInt(100),
+			GasPrice: big.NewInt(0),
+			Gas:      gas,
+			Input:    action.MarshalRLP(),
+		}, key)
+		require.NoError(t, err)
+
+		return tele.MarshalRLP()
+	}
+
+	signAction := func(to types.Address, nonce uint64, action *channel.Action) argBytes {
+		return signActionWithGas(to, nonce, action, action.Gas())
+	}
+
+	open := &channel.Action{Type: channel.ActionOpen, Provider: provider, Expiration: 1000}
+
+	_, err = edge.OpenChannel(signAction(contracts.EdgeCallPrecompile, 3, open))
+	assert.ErrorIs(t, err, ErrNotChannelTelegram)
+
+	_, err = edge.TopUpChannel(signAction(contracts.EdgePaymentChannelPrecompile, 3, open))
+	assert.ErrorIs(t, err, channel.ErrInvalidAction)
+
+	_, err = edge.OpenChannel(signActionWithGas(contracts.EdgePaymentChannelPrecompile, 3, open, open.Gas()-1))
+	assert.ErrorIs(t, err, channel.ErrActionGas)
+
+	res, err := edge.OpenChannel(signAction(contracts.EdgePaymentChannelPrecompile, 3, open))
+	require.NoError(t, err)
+
+	submitted, ok := res.(*ChannelTelegram)
+	require.True(t, ok)
+
+	id := channel.ID(sender, provider, 3)
+	assert.Equal(t, id, submitted.ChannelID)
+	assert.Equal(t, mock.teles[0].Hash, submitted.TelegramHash)
+
+	// the channel is not opened until the telegram is mined
+	_, err = edge.GetChannel(id)
+	assert.ErrorIs(t, err, channel.ErrUnknownChannel)
+
+	res, err = edge.GetChannels()
+	require.NoError(t, err)
+
+	channels, ok := res.([]*PaymentChannel)
+	require.True(t, ok)
+	require.Len(t, channels, 1)
+	assert.Equal(t, argUint64(0), channels[0].BlockNumber)
+	assert.Equal(t, argBig(*big.NewInt(100)), channels[0].Deposit)
+
+	mock.onChain[id] = &channel.Channel{
+		ID:         id,
+		Sender:     sender,
+		Provider:   provider,
+		Deposit:    big.NewInt(100),
+		Paid:       big.NewInt(10),
+		Expiration: 1000,
+	}
+
+	voucher := &channel.Voucher{ChannelID: id, Amount: big.NewInt(25)}
+	require.NoError(t, voucher.Sign(key, 100))
+	require.NoError(t, store.PutVoucher(voucher))
+
+	res, err = edge.GetChannel(id)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	view := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(raw, &view))
+	assert.Equal(t, "0xa", view["paid"])
+	assert.Equal(t, "0x7", view["blockNumber"])
+	assert.Equal(t, "0x19", view["latestVoucher"].(map[string]interface{})["amount"])
+
+	res, err = edge.RedeemChannel(id)
+	require.NoError(t, err)
+	assert.Equal(t, &ChannelTelegram{TelegramHash: types.StringToHash("0xbeef"), ChannelID: id}, res)
+	assert.Equal(t, []types.Hash{id}, mock.redeemed)
+}
@@ -0,0 +1,127 @@
+package jsonrpc
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// default rule of the rate limits applied to all the methods
+	defaultRateLimitRule = "*"
+
+	// interval to drop the buckets refilled fully
+	bucketPruneInterval = time.Minute
+)
+
+// RateLimit is the token bucket refilled with Rate tokens per second
+// up to Burst tokens, each request takes a token
+type RateLimit struct {
+	Rate  float64
+	Burst uint64
+}
+
+// findRateLimit returns the rule and the rate limit applied to the method.
+// The rule of the method name has priority over the one of its namespace (e.g. "edge_*"),
+// and the default rule "*" is applied to the methods without their own rules
+func findRateLimit(limits map[string]RateLimit, method string) (string, RateLimit, bool) {
+	if limit, ok := limits[method]; ok {
+		return method, limit, true
+	}
+
+	if idx := strings.Index(method, "_"); idx > 0 {
+		rule := method[:idx+1] + "*"
+		if limit, ok := limits[rule]; ok {
+			return rule, limit, true
+		}
+	}
+
+	if limit, ok := limits[defaultRateLimitRule]; ok {
+		return defaultRateLimitRule, limit, true
+	}
+
+	return "", RateLimit{}, false
+}
+
+// tokenBucket is the state of a rate limit for a client
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+// full checks the bucket has been refilled fully at the given time
+func (b *tokenBucket) full(now time.Time) bool {
+	return b.limit.Rate <= 0 ||
+		b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+// rateLimiter keeps the token buckets of the clients
+type rateLimiter struct {
+	sync.Mutex
+
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+
+	now func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets:   make(map[string]*tokenBucket),
+		lastPrune: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// allow takes a token from the bucket with the given ID,
+// and returns false if the bucket is empty
+func (r *rateLimiter) allow(id string, limit RateLimit) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+
+	r.prune(now)
+
+	bucket, ok := r.buckets[id]
+	if !ok || bucket.limit != limit {
+		bucket = &tokenBucket{
+			tokens: float64(limit.Burst),
+			last:   now,
+			limit:  limit,
+		}
+		r.buckets[id] = bucket
+	}
+
+	// refill the bucket since the last request
+	bucket.tokens += now.Sub(bucket.last).Seconds() * limit.Rate
+	if bucket.tokens > float64(limit.Burst) {
+		bucket.tokens = float64(limit.Burst)
+	}
+
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+
+	bucket.tokens--
+
+	return true
+}
+
+// prune drops the buckets refilled fully, which are same as the new ones [NOT Thread Safe]
+func (r *rateLimiter) prune(now time.Time) {
+	if now.Sub(r.lastPrune) < bucketPruneInterval {
+		return
+	}
+
+	r.lastPrune = now
+
+	for id, bucket := range r.buckets {
+		if bucket.full(now) {
+			delete(r.buckets, id)
+		}
+	}
+}
@@ -14,6 +14,19 @@ type telePoolStore interface {
 
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
+
+	// GetLanes returns the occupancy of each pool lane
+	GetLanes() []*TelePoolLane
+}
+
+// TelePoolLane is the occupancy of a pool lane
+type TelePoolLane struct {
+	Name        string `json:"name"`
+	Slots       uint64 `json:"slots"`
+	MaxSlots    uint64 `json:"maxSlots"`
+	Pending     uint64 `json:"pending"`
+	Queued      uint64 `json:"queued"`
+	MinGasPrice uint64 `json:"minGasPrice"`
 }
 
 // TelePool is the txpool jsonrpc endpoint
@@ -31,11 +44,13 @@ type InspectResponse struct {
 	Queued          map[string]map[string]string `json:"queued"`
 	CurrentCapacity uint64                       `json:"currentCapacity"`
 	MaxCapacity     uint64                       `json:"maxCapacity"`
+	Lanes           []*TelePoolLane              `json:"lanes"`
 }
 
 type StatusResponse struct {
-	Pending uint64 `json:"pending"`
-	Queued  uint64 `json:"queued"`
+	Pending uint64          `json:"pending"`
+	Queued  uint64          `json:"queued"`
+	Lanes   []*TelePoolLane `json:"lanes"`
 }
 
 type telepoolTelegram struct {
@@ -146,6 +161,7 @@ func (t *TelePool) Inspect() (interface{}, error) {
 		Queued:          queuedRPCTxs,
 		CurrentCapacity: current,
 		MaxCapacity:     max,
+		Lanes:           t.store.GetLanes(),
 	}
 
 	return resp, nil
@@ -171,6 +187,7 @@ func (t *TelePool) Status() (interface{}, error) {
 	resp := StatusResponse{
 		Pending: pendingCount,
 		Queued:  queuedCount,
+		Lanes:   t.store.GetLanes(),
 	}
 
 	return resp, nil
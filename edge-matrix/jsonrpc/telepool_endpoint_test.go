@@ -226,6 +226,24 @@ func TestStatusEndpoint(t *testing.T) {
 		assert.Equal(t, uint64(3), response.Pending)
 		assert.Equal(t, uint64(2), response.Queued)
 	})
+
+	t.Run("returns occupancy of the pool lanes", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := newMockTxPoolStore()
+		mockStore.lanes = []*TelePoolLane{
+			{Name: "edgeCall", Slots: 3, MaxSlots: 1024, Pending: 2, Queued: 1, MinGasPrice: 10},
+			{Name: "rtcSubject", MaxSlots: 1024},
+			{Name: "transfer", MaxSlots: 4096},
+		}
+		txPoolEndpoint := &TelePool{mockStore}
+
+		result, _ := txPoolEndpoint.Status()
+		//nolint:forcetypeassert
+		response := result.(StatusResponse)
+
+		assert.Equal(t, mockStore.lanes, response.Lanes)
+	})
 }
 
 type mockTxPoolStore struct {
@@ -233,6 +251,7 @@ type mockTxPoolStore struct {
 	queued        map[types.Address][]*types.Telegram
 	capacity      uint64
 	maxSlots      uint64
+	lanes         []*TelePoolLane
 	includeQueued bool
 }
 
@@ -253,6 +272,10 @@ func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
 
+func (s *mockTxPoolStore) GetLanes() []*TelePoolLane {
+	return s.lanes
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Telegram {
 	txn := &types.Telegram{
 		Nonce:    nonce,
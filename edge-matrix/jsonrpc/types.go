@@ -36,6 +36,12 @@ type transaction struct {
 	RespS    *big.Int      `json:"RespS"`
 	RespHash types.Hash    `json:"RespHash"`
 	RespFrom types.Address `json:"RespFrom"`
+
+	Type                 *argUint64         `json:"type,omitempty"`
+	ChainID              *argBig            `json:"chainId,omitempty"`
+	MaxFeePerGas         *argBig            `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *argBig            `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList           types.TxAccessList `json:"accessList,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -91,6 +97,20 @@ func toTransaction(
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
 
+	if t.Type.IsEnvelope() {
+		res.Type = argUintPtr(uint64(t.Type))
+		res.AccessList = t.AccessList
+
+		if t.ChainID != nil {
+			res.ChainID = argBigPtr(t.ChainID)
+		}
+	}
+
+	if t.Type == types.DynamicFeeTx {
+		res.MaxFeePerGas = argBigPtr(t.GetGasFeeCap())
+		res.MaxPriorityFeePerGas = argBigPtr(t.GetGasTipCap())
+	}
+
 	return res
 }
 
@@ -115,6 +135,7 @@ type block struct {
 	Hash      types.Hash       `json:"hash"`
 	Telegrams []telegramOrHash `json:"telegrams"`
 	Uncles    []types.Hash     `json:"uncles"`
+	BaseFee   *argUint64       `json:"baseFeePerGas,omitempty"`
 }
 
 func (b *block) Copy() *block {
@@ -155,6 +176,10 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		Uncles:    []types.Hash{},
 	}
 
+	if h.BaseFee != 0 {
+		res.BaseFee = argUintPtr(h.BaseFee)
+	}
+
 	for idx, txn := range b.Telegrams {
 		if fullTx {
 			res.Telegrams = append(
@@ -197,6 +222,103 @@ type receipt struct {
 	ToAddr             *types.Address `json:"to"`
 }
 
+func toReceipt(
+	raw *types.Receipt,
+	txn *types.Telegram,
+	txIndex uint64,
+	blockHash types.Hash,
+	blockNumber uint64,
+) *receipt {
+	logs := make([]*Log, len(raw.Logs))
+	for indx, elem := range raw.Logs {
+		logs[indx] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   blockHash,
+			BlockNumber: argUint64(blockNumber),
+			TxHash:      txn.Hash,
+			TxIndex:     argUint64(indx),
+			LogIndex:    argUint64(indx),
+			Removed:     false,
+		}
+	}
+
+	return &receipt{
+		Root:               raw.Root,
+		CumulativeGasUsed:  argUint64(raw.CumulativeGasUsed),
+		LogsBloom:          raw.LogsBloom,
+		Status:             argUint64(*raw.Status),
+		TxHash:             txn.Hash,
+		TxIndex:            argUint64(txIndex),
+		BlockHash:          blockHash,
+		BlockNumber:        argUint64(blockNumber),
+		GasUsed:            argUint64(raw.GasUsed),
+		ApplicationAddress: raw.ApplicationAddress,
+		FromAddr:           txn.From,
+		ToAddr:             txn.To,
+		Logs:               logs,
+	}
+}
+
+// inclusionProof is the Merkle proof of the RLP encoded telegram or receipt
+// at the index of its block against the root in the block header
+type inclusionProof struct {
+	BlockHash   types.Hash `json:"blockHash"`
+	BlockNumber argUint64  `json:"blockNumber"`
+	Index       argUint64  `json:"index"`
+	Root        types.Hash `json:"root"`
+	Value       argBytes   `json:"value"`
+	Proof       []argBytes `json:"proof"`
+}
+
+// accountProof is the account with its storage values proven against the state root, following EIP-1186
+type accountProof struct {
+	Address      types.Address   `json:"address"`
+	AccountProof []argBytes      `json:"accountProof"`
+	Balance      argBig          `json:"balance"`
+	CodeHash     types.Hash      `json:"codeHash"`
+	Nonce        argUint64       `json:"nonce"`
+	StorageHash  types.Hash      `json:"storageHash"`
+	StorageProof []*storageProof `json:"storageProof"`
+}
+
+type storageProof struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
+}
+
+func toAccountProof(addr types.Address, raw *StateProof) *accountProof {
+	storage := make([]*storageProof, len(raw.StorageProofs))
+	for i, elem := range raw.StorageProofs {
+		storage[i] = &storageProof{
+			Key:   elem.Key,
+			Value: argBig(*new(big.Int).SetBytes(elem.Value.Bytes())),
+			Proof: toArgBytesList(elem.Proof),
+		}
+	}
+
+	return &accountProof{
+		Address:      addr,
+		AccountProof: toArgBytesList(raw.AccountProof),
+		Balance:      argBig(*raw.Balance),
+		CodeHash:     raw.CodeHash,
+		Nonce:        argUint64(raw.Nonce),
+		StorageHash:  raw.StorageRoot,
+		StorageProof: storage,
+	}
+}
+
 type Log struct {
 	Address     types.Address `json:"address"`
 	Topics      []types.Hash  `json:"topics"`
@@ -344,4 +466,5 @@ type progression struct {
 	StartingBlock argUint64 `json:"startingBlock"`
 	CurrentBlock  argUint64 `json:"currentBlock"`
 	HighestBlock  argUint64 `json:"highestBlock"`
+	PulledStates  argUint64 `json:"pulledStates,omitempty"`
 }
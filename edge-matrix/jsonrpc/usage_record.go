@@ -0,0 +1,69 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+var ErrUsageRecordNotFound = errors.New("usage record not found")
+
+// UsageRecord is the usage of an edge call signed by its provider,
+// and countersigned by the node routing the call if the caller node is set
+type UsageRecord struct {
+	Hash        types.Hash    `json:"hash"`
+	RequestHash types.Hash    `json:"requestHash"`
+	Caller      types.Address `json:"caller"`
+	Provider    types.Address `json:"provider"`
+	Path        string        `json:"path"`
+	StartTime   uint64        `json:"startTime"`
+	Duration    uint64        `json:"duration"`
+	InputBytes  uint64        `json:"inputBytes"`
+	OutputBytes uint64        `json:"outputBytes"`
+	Status      uint64        `json:"status"`
+	ProviderSig argBytes      `json:"providerSig"`
+
+	CallerNode *types.Address `json:"callerNode,omitempty"`
+	CallerSig  argBytes       `json:"callerSig,omitempty"`
+
+	// Commitment is the hash of the usage commitment submitted on chain, if committed
+	Commitment *types.Hash `json:"commitment,omitempty"`
+}
+
+// UsageRecordPage is a page of the usage records with the number of all the records matching the query
+type UsageRecordPage struct {
+	Records []*UsageRecord `json:"records"`
+	Total   uint64         `json:"total"`
+}
+
+func toUsageRecord(entry *metering.Entry) *UsageRecord {
+	record := entry.Record
+
+	res := &UsageRecord{
+		Hash:        record.Hash(),
+		RequestHash: record.RequestHash,
+		Caller:      record.Caller,
+		Provider:    record.Provider,
+		Path:        record.Path,
+		StartTime:   record.StartTime,
+		Duration:    record.Duration,
+		InputBytes:  record.InputBytes,
+		OutputBytes: record.OutputBytes,
+		Status:      record.Status,
+		ProviderSig: record.ProviderSig,
+	}
+
+	if record.IsCountersigned() {
+		callerNode := record.CallerNode
+		res.CallerNode = &callerNode
+		res.CallerSig = record.CallerSig
+	}
+
+	if entry.Commitment != types.ZeroHash {
+		commitment := entry.Commitment
+		res.Commitment = &commitment
+	}
+
+	return res
+}
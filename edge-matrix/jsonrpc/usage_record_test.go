@@ -0,0 +1,92 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUsageStore struct {
+	testStore
+
+	store *metering.Store
+}
+
+func (m *mockUsageStore) GetUsageRecord(hash types.Hash) (*metering.Entry, bool, error) {
+	return m.store.Get(hash)
+}
+
+func (m *mockUsageStore) GetUsageRecords(query *metering.Query) ([]*metering.Entry, uint64, error) {
+	return m.store.Query(query)
+}
+
+func TestEdge_GetUsageRecords(t *testing.T) {
+	t.Parallel()
+
+	providerKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	callerKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	store := metering.NewMemoryStore()
+	defer store.Close()
+
+	served := &metering.UsageRecord{Caller: types.Address{0x1}, Path: "/v1/generate", StartTime: 1000, Status: 200}
+	require.NoError(t, served.SignProvider(providerKey))
+
+	routed := &metering.UsageRecord{Caller: types.Address{0x2}, Path: "/v1/generate", StartTime: 2000, Status: 502}
+	require.NoError(t, routed.SignProvider(providerKey))
+	require.NoError(t, routed.Countersign(callerKey))
+
+	require.NoError(t, store.Put(served))
+	require.NoError(t, store.Put(routed))
+
+	commitment := metering.NewCommitment(routed.Provider, []*metering.UsageRecord{routed})
+	require.NoError(t, store.PutCommitment(commitment, []*metering.UsageRecord{routed}))
+
+	edge := newTestEthEndpoint(&mockUsageStore{store: store})
+
+	// the query is decoded from the request params
+	query := &metering.Query{}
+	require.NoError(t, json.Unmarshal([]byte(`{"caller":"0x0200000000000000000000000000000000000000","fromTime":1500}`), query))
+
+	res, err := edge.GetUsageRecords(query)
+	require.NoError(t, err)
+
+	page, ok := res.(*UsageRecordPage)
+	require.True(t, ok)
+	require.Len(t, page.Records, 1)
+	assert.Equal(t, uint64(1), page.Total)
+
+	record := page.Records[0]
+	assert.Equal(t, routed.Hash(), record.Hash)
+	assert.Equal(t, uint64(502), record.Status)
+	assert.Equal(t, crypto.PubKeyToAddress(&callerKey.PublicKey), *record.CallerNode)
+	assert.Equal(t, commitment.Hash(), *record.Commitment)
+
+	res, err = edge.GetUsageRecord(served.Hash())
+	require.NoError(t, err)
+
+	record, ok = res.(*UsageRecord)
+	require.True(t, ok)
+	assert.Nil(t, record.CallerNode)
+	assert.Nil(t, record.Commitment)
+
+	// the signatures are encoded as hex
+	data, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"providerSig":"0x`)
+	assert.NotContains(t, string(data), "callerSig")
+
+	_, err = edge.GetUsageRecord(types.Hash{0x1})
+	assert.ErrorIs(t, err, ErrUsageRecordNotFound)
+
+	_, err = edge.GetUsageRecords(&metering.Query{Limit: metering.MaxQueryLimit + 1})
+	assert.ErrorIs(t, err, metering.ErrLimitTooLarge)
+}
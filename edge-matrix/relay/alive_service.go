@@ -3,7 +3,9 @@ package relay
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	appProto "github.com/emc-protocol/edge-matrix/application/proto"
 	"github.com/emc-protocol/edge-matrix/network/common"
 	"github.com/emc-protocol/edge-matrix/network/grpc"
@@ -83,6 +85,15 @@ func (d *AliveService) Hello(ctx context.Context, status *proto.AliveStatus) (*p
 	}
 
 	from := grpcContext.PeerID
+
+	if _, err := capability.FromProto(status.Capabilities); err != nil {
+		return nil, fmt.Errorf("invalid capabilities: %w", err)
+	}
+
+	if _, err := application.HostedAppsFromProto(status.Apps); err != nil {
+		return nil, fmt.Errorf("invalid apps: %w", err)
+	}
+
 	addr := ""
 	innerIp := false
 	addrInfo := d.baseServer.GetPeerAddrInfo(from)
@@ -107,6 +118,8 @@ func (d *AliveService) Hello(ctx context.Context, status *proto.AliveStatus) (*p
 			ModelHash:    status.ModelHash,
 			AveragePower: status.AveragePower,
 			Version:      status.Version,
+			Capabilities: status.Capabilities,
+			Apps:         status.Apps,
 		})
 	}
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/capability"
 	emcNetwork "github.com/emc-protocol/edge-matrix/network"
 	"github.com/emc-protocol/edge-matrix/network/common"
 	"github.com/emc-protocol/edge-matrix/network/grpc"
@@ -203,6 +204,38 @@ func (s *RelayClient) RelayPeers() []*RelayConnInfo {
 	return peers
 }
 
+// RelayReservation holds the reservation slot on a relay peer
+type RelayReservation struct {
+	ID            peer.ID
+	Addrs         []multiaddr.Multiaddr
+	Expiration    time.Time
+	LimitDuration time.Duration
+	LimitData     uint64
+}
+
+// Reservations returns the reservations on the relay peers [Thread safe]
+func (s *RelayClient) Reservations() []*RelayReservation {
+	s.relayPeersLock.Lock()
+	defer s.relayPeersLock.Unlock()
+
+	reservations := make([]*RelayReservation, 0, len(s.relayPeers))
+	for id, relayPeer := range s.relayPeers {
+		if relayPeer.reservation == nil {
+			continue
+		}
+
+		reservations = append(reservations, &RelayReservation{
+			ID:            id,
+			Addrs:         relayPeer.reservation.Addrs,
+			Expiration:    relayPeer.reservation.Expiration,
+			LimitDuration: relayPeer.reservation.LimitDuration,
+			LimitData:     relayPeer.reservation.LimitData,
+		})
+	}
+
+	return reservations
+}
+
 // hasRelayPeer checks if the peer is present in the relay peers list [Thread safe]
 func (s *RelayClient) hasRelayPeer(peerID peer.ID) bool {
 	s.relayPeersLock.Lock()
@@ -658,6 +691,8 @@ func (s *RelayClient) sayHello(
 			ModelHash:    s.application.ModelHash,
 			AveragePower: s.application.AveragePower,
 			Version:      s.application.Version,
+			Capabilities: capability.ToProto(s.application.Capabilities),
+			Apps:         application.HostedAppsToProto(s.application.Apps),
 		},
 	)
 	if err != nil {
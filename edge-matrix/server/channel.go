@@ -0,0 +1,136 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/blockchain"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/helper/rpc"
+	"github.com/emc-protocol/edge-matrix/jsonrpc"
+	"github.com/emc-protocol/edge-matrix/state"
+	"github.com/emc-protocol/edge-matrix/telepool"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+var (
+	errChannelsNotRedeemed = errors.New("payment channels are not redeemed by the node")
+	errNoChannelRPC        = errors.New("the edge node serving paid calls requires the JSON-RPC of a full node reading the payment channels")
+)
+
+// channelReader reads the payment channels from the state of the latest block of the node
+type channelReader struct {
+	state      state.State
+	blockchain *blockchain.Blockchain
+}
+
+func (r *channelReader) GetChannel(id types.Hash) (*channel.Channel, uint64, error) {
+	header := r.blockchain.Header()
+
+	account, err := getAccountImpl(r.state, header.StateRoot, contracts.EdgePaymentChannelPrecompile)
+	if errors.Is(err, jsonrpc.ErrStateNotFound) {
+		// no channel is opened yet
+		return nil, header.Number, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	snap, err := r.state.NewSnapshotAt(header.StateRoot)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ch := channel.ReadChannel(id, func(key types.Hash) types.Hash {
+		return snap.GetStorage(contracts.EdgePaymentChannelPrecompile, account.Root, key)
+	})
+
+	return ch, header.Number, nil
+}
+
+// channelSubmitter submits the channel actions as the telegrams of the node to the payment channel precompile
+type channelSubmitter struct {
+	telepool *telepool.TelegramPool
+	signer   crypto.TxSigner
+	key      *ecdsa.PrivateKey
+}
+
+func (s *channelSubmitter) SubmitAction(action *channel.Action) (types.Hash, error) {
+	to := contracts.EdgePaymentChannelPrecompile
+	from := crypto.PubKeyToAddress(&s.key.PublicKey)
+
+	tele, err := s.signer.SignTele(&types.Telegram{
+		To:       &to,
+		Value:    big.NewInt(0),
+		GasPrice: new(big.Int).SetUint64(s.telepool.GetLimits().PriceLimit),
+		Nonce:    s.telepool.GetNonce(from),
+		Gas:      action.Gas(),
+		Input:    action.MarshalRLP(),
+		From:     from,
+	}, s.key)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	tele.ComputeHash()
+
+	if _, err := s.telepool.AddTele(tele); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return tele.Hash, nil
+}
+
+// rpcChannelSubmitter submits the channel actions of the edge node through the JSON-RPC of a full node
+type rpcChannelSubmitter struct {
+	client *rpc.JsonRpcClient
+	key    *ecdsa.PrivateKey
+}
+
+func (s *rpcChannelSubmitter) SubmitAction(action *channel.Action) (types.Hash, error) {
+	return s.client.SendChannelAction(action, nil, s.key)
+}
+
+// setupChannels sets up the verification of the vouchers paying the edge calls served by the node,
+// and the redemption of the verified vouchers, if the node serves paid calls.
+// The edge node reads the channels and redeems the vouchers through the JSON-RPC of a full node
+func (s *Server) setupChannels(key *ecdsa.PrivateKey, endpoint *application.Endpoint, signer crypto.TxSigner) error {
+	if !s.config.PaidCalls {
+		return nil
+	}
+
+	chainID := uint64(s.config.Chain.Params.ChainID)
+
+	var submitter channel.Submitter
+
+	if s.runningMode == RunningModeEdge {
+		if s.config.ChannelRPC == "" {
+			return errNoChannelRPC
+		}
+
+		client := rpc.NewJsonRpcClientWithChainID(s.config.ChannelRPC, chainID)
+		s.channelReader = client
+		submitter = &rpcChannelSubmitter{client: client, key: key}
+	} else {
+		submitter = &channelSubmitter{telepool: s.telepool, signer: signer, key: key}
+	}
+
+	provider := crypto.PubKeyToAddress(&key.PublicKey)
+
+	endpoint.SetVoucherVerifier(channel.NewVerifier(provider, chainID, s.channelReader, s.channelStore))
+
+	s.channelRedeemer = channel.NewRedeemer(
+		s.logger,
+		provider,
+		s.channelStore,
+		s.channelReader,
+		submitter,
+		channel.DefaultRedeemInterval,
+	)
+	s.channelRedeemer.Start()
+
+	return nil
+}
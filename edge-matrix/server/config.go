@@ -1,13 +1,17 @@
 package server
 
 import (
+	"github.com/emc-protocol/edge-matrix/application"
 	"github.com/emc-protocol/edge-matrix/chain"
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/emc-protocol/edge-matrix/jsonrpc"
 	"github.com/emc-protocol/edge-matrix/network"
 	"github.com/emc-protocol/edge-matrix/secrets"
+	"github.com/emc-protocol/edge-matrix/telepool"
 )
 
 const DefaultGRPCPort int = 50000
@@ -23,8 +27,10 @@ type Config struct {
 	RelayAddr  *net.TCPAddr // the relay address
 
 	PriceLimit         uint64
+	PriceBump          uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	TelePoolLanes      telepool.LaneConfig
 	BlockTime          uint64
 
 	Telemetry   *Telemetry
@@ -49,11 +55,28 @@ type Config struct {
 
 	NumBlockConfirmations uint64
 
+	SyncMode string
+
 	AppName     string
 	AppUrl      string
 	AppOrigin   string
 	RunningMode string
 
+	// Apps are the apps hosted by the edge node besides the default app of AppName and AppUrl
+	Apps []*application.AppConfig
+
+	// ChannelRPC is the JSON-RPC address of the full node the edge node reads the payment channels from
+	ChannelRPC string
+
+	// PaidCalls requires the edge calls served by the node to be paid by the vouchers of payment channels
+	PaidCalls bool
+
+	// JobRetention is the period the finished edge call jobs are kept for
+	JobRetention time.Duration
+
+	// CallRetention is the period the edge calls sent by the node are kept in its audit log for
+	CallRetention time.Duration
+
 	EmcHost string
 }
 
@@ -68,4 +91,7 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	WSSubscriptionLimit      uint64
+	Auth                     *jsonrpc.AuthConfig
+	IPCPath                  string
 }
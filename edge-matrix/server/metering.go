@@ -0,0 +1,53 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/telepool"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// usageSubmitter submits the usage commitments as the telegrams of the node to the metering precompile
+type usageSubmitter struct {
+	telepool *telepool.TelegramPool
+	signer   crypto.TxSigner
+	key      *ecdsa.PrivateKey
+}
+
+func (s *usageSubmitter) SubmitCommitment(commitment *metering.Commitment) error {
+	to := contracts.EdgeMeteringPrecompile
+	from := crypto.PubKeyToAddress(&s.key.PublicKey)
+
+	tele, err := s.signer.SignTele(&types.Telegram{
+		To:       &to,
+		Value:    big.NewInt(0),
+		GasPrice: new(big.Int).SetUint64(s.minGasPrice()),
+		Nonce:    s.telepool.GetNonce(from),
+		Input:    commitment.MarshalRLP(),
+		From:     from,
+	}, s.key)
+	if err != nil {
+		return err
+	}
+
+	tele.ComputeHash()
+
+	_, err = s.telepool.AddTele(tele)
+
+	return err
+}
+
+// minGasPrice returns the minimum gas price of the lane of the commitment telegrams
+func (s *usageSubmitter) minGasPrice() uint64 {
+	for _, lane := range s.telepool.GetLaneStatus() {
+		if lane.Name == "transfer" {
+			return lane.MinGasPrice
+		}
+	}
+
+	return s.telepool.GetLimits().PriceLimit
+}
@@ -2,9 +2,18 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/appidl"
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/application/verification"
 	"github.com/emc-protocol/edge-matrix/blockchain"
 	"github.com/emc-protocol/edge-matrix/chain"
 	cmdConfig "github.com/emc-protocol/edge-matrix/command/server/config"
@@ -21,7 +30,9 @@ import (
 	"github.com/emc-protocol/edge-matrix/state/runtime"
 	"github.com/emc-protocol/edge-matrix/telepool"
 	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/versioning"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"math/big"
 	"net"
@@ -44,8 +55,9 @@ import (
 type RunningModeType string
 
 const (
-	RunningModeFull RunningModeType = "full"
-	RunningModeEdge RunningModeType = "edge"
+	RunningModeFull  RunningModeType = "full"
+	RunningModeEdge  RunningModeType = "edge"
+	RunningModeLight RunningModeType = "light"
 )
 const (
 	BaseDiscProto     = "/base/disc/0.1"
@@ -91,9 +103,39 @@ type Server struct {
 	// application syncer Client
 	syncAppPeerClient application.SyncAppPeerClient
 
+	// application peers syncer
+	appSyncer application.Syncer
+
+	// application endpoint
+	appEndpoint *application.Endpoint
+
 	// telegram pool
 	telepool *telepool.TelegramPool
 
+	// usage records of the served and routed edge calls
+	usageStore     *metering.Store
+	usageCommitter *metering.Committer
+
+	// payment channels paying the served edge calls or opened through the node
+	channelStore    *channel.Store
+	channelReader   channel.Reader
+	channelRedeemer *channel.Redeemer
+
+	// edge calls run as jobs by the node or routed as jobs by the node
+	jobStore   *jobs.Store
+	jobManager *jobs.Manager
+
+	// results of the edge calls dispatched to several edge nodes by the node
+	verificationStore *verification.Store
+
+	// blobs uploaded to the node or fetched by the node, referenced by the edge calls
+	blobStore     *blob.Store
+	blobCollector *blob.Collector
+
+	// audit log of the edge calls sent by the node
+	callLog       *calllog.Store
+	callLogPruner *calllog.Pruner
+
 	// secrets manager
 	secretsManager secrets.SecretsManager
 
@@ -165,9 +207,12 @@ func NewServer(config *Config) (*Server, error) {
 		restoreProgression: progress.NewProgressionWrapper(progress.ChainSyncRestore),
 	}
 
-	if m.config.RunningMode == cmdConfig.DefaultRunningMode {
+	switch m.config.RunningMode {
+	case cmdConfig.DefaultRunningMode:
 		m.runningMode = RunningModeFull
-	} else {
+	case cmdConfig.LightRunningMode:
+		m.runningMode = RunningModeLight
+	default:
 		m.runningMode = RunningModeEdge
 	}
 	m.logger.Info("Node running", "mode", m.runningMode)
@@ -228,6 +273,55 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
+	usageStore, err := metering.NewLevelDBStore(filepath.Join(m.config.DataDir, "metering"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.usageStore = usageStore
+
+	channelStore, err := channel.NewLevelDBStore(filepath.Join(m.config.DataDir, "channels"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.channelStore = channelStore
+	m.channelReader = &channelReader{state: m.state, blockchain: m.blockchain}
+
+	jobStore, err := jobs.NewLevelDBStore(filepath.Join(m.config.DataDir, "jobs"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.jobStore = jobStore
+	m.jobManager = jobs.NewManager(m.logger, jobStore, m.config.JobRetention)
+	m.jobManager.Start()
+
+	verificationStore, err := verification.NewLevelDBStore(filepath.Join(m.config.DataDir, "verifications"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.verificationStore = verificationStore
+
+	blobStore, err := blob.NewLevelDBStore(filepath.Join(m.config.DataDir, "blobs"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.blobStore = blobStore
+	m.blobCollector = blob.NewCollector(m.logger, blobStore, blob.DefaultRetention)
+	m.blobCollector.Start()
+
+	callLog, err := calllog.NewLevelDBStore(filepath.Join(m.config.DataDir, "calls"))
+	if err != nil {
+		return nil, err
+	}
+
+	m.callLog = callLog
+	m.callLogPruner = calllog.NewPruner(m.logger, callLog, m.config.CallRetention)
+	m.callLogPruner.Start()
+
 	if m.runningMode == RunningModeFull {
 		// setup edge libp2p network
 		edgeNetConfig := config.EdgeNetwork
@@ -259,8 +353,12 @@ func NewServer(config *Config) (*Server, error) {
 			m.network,
 			m.edgeNetwork,
 			&telepool.Config{
+				PriceLimit:         m.config.PriceLimit,
+				PriceBump:          m.config.PriceBump,
 				MaxSlots:           m.config.MaxSlots,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
+				Lanes:              m.config.TelePoolLanes,
+				Forks:              m.config.Chain.Params.Forks,
 			},
 			m.config.Chain.TeleVersion,
 		)
@@ -278,7 +376,7 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	{
-		if m.runningMode == RunningModeFull {
+		if m.runningMode == RunningModeFull || m.runningMode == RunningModeLight {
 			//after consensus is done, we can mine the genesis block in blockchain
 			//This is done because consensus might use a custom Hash function so we need
 			//to wait for consensus because we do any block hashing like genesis
@@ -341,6 +439,25 @@ func NewServer(config *Config) (*Server, error) {
 				return nil, err
 			}
 		}
+
+		if m.runningMode == RunningModeLight {
+			// start base network to follow the headers
+			if err := m.network.Start("Base", m.config.Chain.BaseBootnodes); err != nil {
+				return nil, err
+			}
+
+			// start header syncing
+			if err := m.consensus.Start(); err != nil {
+				return nil, err
+			}
+
+			// setup and start jsonrpc server serving the verified telegram receipts
+			if err := m.setupJSONRPC(); err != nil {
+				return nil, err
+			}
+
+			return m, nil
+		}
 	}
 
 	{
@@ -372,12 +489,26 @@ func NewServer(config *Config) (*Server, error) {
 			}
 		}
 
-		endpoint, err := application.NewApplicationEndpoint(m.logger, key, endpointHost, m.config.AppName, m.config.AppUrl, m.blockchain, minerAgent, m.runningMode == RunningModeEdge)
+		apps := append([]*application.AppConfig{{Name: m.config.AppName, Url: m.config.AppUrl}}, m.config.Apps...)
+
+		endpoint, err := application.NewApplicationEndpoint(m.logger, key, endpointHost, apps, m.blockchain, minerAgent, m.runningMode == RunningModeEdge)
 		if err != nil {
 			return nil, err
 		}
 
 		endpoint.SetSigner(application.NewEIP155Signer(chain.AllForksEnabled.At(0), uint64(m.config.Chain.Params.ChainID)))
+		endpoint.SetUsageStore(m.usageStore)
+		m.appEndpoint = endpoint
+
+		if err := m.setupChannels(key, endpoint, signer); err != nil {
+			return nil, err
+		}
+
+		if err := endpoint.SetJobManager(m.jobManager); err != nil {
+			return nil, err
+		}
+
+		endpoint.SetBlobStore(m.blobStore)
 
 		if m.runningMode == RunningModeEdge {
 			// keep edge peer alive
@@ -405,6 +536,8 @@ func NewServer(config *Config) (*Server, error) {
 				return nil, err
 			}
 
+			m.appSyncer = syncer
+
 			// setup and start jsonrpc server
 			if err := m.setupJSONRPC(); err != nil {
 				return nil, err
@@ -458,8 +591,26 @@ func NewServer(config *Config) (*Server, error) {
 
 			// start telepool
 			m.telepool.SetAppSyncer(syncer)
+			m.telepool.SetUsageStore(m.usageStore, key)
+			m.telepool.SetJobManager(m.jobManager)
+			m.jobManager.SetFetcher(m.telepool)
+			respSigner := application.NewEIP155Signer(chain.AllForksEnabled.At(0), uint64(m.config.Chain.Params.ChainID))
+			m.telepool.SetResponseSigner(respSigner)
+			m.telepool.SetVerifier(verification.NewVerifier(m.logger, respSigner, m.verificationStore))
+			m.telepool.SetBlobStore(m.blobStore)
+			m.telepool.SetCallLog(m.callLog)
+			blob.Serve(m.logger, m.edgeNetwork.GetHost(), m.blobStore)
 			m.telepool.Start()
 
+			// start usage commitments of the routed edge calls
+			m.usageCommitter = metering.NewCommitter(
+				m.logger,
+				m.usageStore,
+				&usageSubmitter{telepool: m.telepool, signer: signer, key: key},
+				metering.DefaultCommitInterval,
+			)
+			m.usageCommitter.Start()
+
 		}
 	}
 
@@ -598,11 +749,14 @@ func (s *Server) setupConsensus() error {
 			Network:               s.network,
 			Blockchain:            s.blockchain,
 			Executor:              s.executor,
+			StateStorage:          s.stateStorage,
 			Grpc:                  s.grpcServer,
 			Logger:                s.logger,
 			SecretsManager:        s.secretsManager,
 			BlockTime:             s.config.BlockTime,
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			CheckpointSync:        s.config.SyncMode == cmdConfig.CheckpointSyncMode,
+			LightClient:           s.runningMode == RunningModeLight,
 		},
 	)
 
@@ -651,7 +805,27 @@ func (s *Server) initMinerService(minerAgent *miner.MinerHubAgent, host host.Hos
 
 type jsonRPCHub struct {
 	state              state.State
+	stateStorage       itrie.Storage
 	restoreProgression *progress.ProgressionWrapper
+	signer             crypto.TxSigner
+
+	// references for the admin endpoint
+	logger      hclog.Logger
+	chainID     uint64
+	runningMode RunningModeType
+	edgeNetwork *network.Server
+	relayClient *relay.RelayClient
+	appSyncer   application.Syncer
+	usageStore  *metering.Store
+
+	channelStore    *channel.Store
+	channelReader   channel.Reader
+	channelRedeemer *channel.Redeemer
+	jobManager      *jobs.Manager
+
+	verificationStore *verification.Store
+	blobStore         *blob.Store
+	callLog           *calllog.Store
 
 	*blockchain.Blockchain
 	*telepool.TelegramPool
@@ -663,6 +837,358 @@ type jsonRPCHub struct {
 	//consensus.BridgeDataProvider
 }
 
+// GetBaseFee returns the base fee of the next block
+func (j *jsonRPCHub) GetBaseFee() uint64 {
+	return j.Blockchain.CalculateBaseFee(j.Blockchain.Header())
+}
+
+// GetTelegramProof fetches the verified telegram proof from peers
+// and recovers the sender of the telegram
+func (j *jsonRPCHub) GetTelegramProof(hash types.Hash) (*types.TelegramProof, error) {
+	proof, err := j.Consensus.GetTelegramProof(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if proof.Telegram.From, err = j.signer.Sender(proof.Telegram); err != nil {
+		return nil, fmt.Errorf("unable to recover sender: %w", err)
+	}
+
+	return proof, nil
+}
+
+// GetLanes returns the occupancy of each telepool lane
+func (j *jsonRPCHub) GetLanes() []*jsonrpc.TelePoolLane {
+	statuses := j.TelegramPool.GetLaneStatus()
+
+	lanes := make([]*jsonrpc.TelePoolLane, len(statuses))
+	for i, status := range statuses {
+		lanes[i] = &jsonrpc.TelePoolLane{
+			Name:        status.Name,
+			Slots:       status.Slots,
+			MaxSlots:    status.MaxSlots,
+			Pending:     status.Pending,
+			Queued:      status.Queued,
+			MinGasPrice: status.MinGasPrice,
+		}
+	}
+
+	return lanes
+}
+
+// GetPeerInfos returns the connected peers of the base and edge networks
+func (j *jsonRPCHub) GetPeerInfos() []*jsonrpc.PeerInfo {
+	peers := toPeerInfos("base", j.Server)
+
+	if j.edgeNetwork != nil {
+		peers = append(peers, toPeerInfos("edge", j.edgeNetwork)...)
+	}
+
+	return peers
+}
+
+func toPeerInfos(networkName string, srv *network.Server) []*jsonrpc.PeerInfo {
+	conns := srv.Peers()
+
+	peers := make([]*jsonrpc.PeerInfo, 0, len(conns))
+	for _, conn := range conns {
+		info := &jsonrpc.PeerInfo{
+			ID:        conn.Info.ID.String(),
+			Network:   networkName,
+			Addrs:     make([]string, 0, len(conn.Info.Addrs)),
+			Protocols: make([]string, 0),
+		}
+
+		for _, addr := range conn.Info.Addrs {
+			info.Addrs = append(info.Addrs, addr.String())
+		}
+
+		if protocols, err := srv.GetProtocols(conn.Info.ID); err == nil {
+			for _, p := range protocols {
+				info.Protocols = append(info.Protocols, string(p))
+			}
+		}
+
+		peers = append(peers, info)
+	}
+
+	return peers
+}
+
+// DisconnectPeer closes the connections to the peer in the base and edge networks
+func (j *jsonRPCHub) DisconnectPeer(id string) error {
+	peerID, err := peer.Decode(id)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID: %w", err)
+	}
+
+	connected := false
+
+	for _, srv := range []*network.Server{j.Server, j.edgeNetwork} {
+		if srv != nil && srv.IsConnected(peerID) {
+			srv.DisconnectFromPeer(peerID, "removed by admin")
+
+			connected = true
+		}
+	}
+
+	if !connected {
+		return fmt.Errorf("not connected to peer %s", id)
+	}
+
+	return nil
+}
+
+// GetNodeInfo returns the identity and the addresses of the node
+func (j *jsonRPCHub) GetNodeInfo() *jsonrpc.NodeInfo {
+	info := &jsonrpc.NodeInfo{
+		Version:     versioning.Version,
+		RunningMode: string(j.runningMode),
+		ChainID:     j.chainID,
+		LogLevel:    logLevelOf(j.logger).String(),
+	}
+
+	info.ID, info.Addrs = toAddrInfo(j.Server.AddrInfo())
+
+	if j.edgeNetwork != nil {
+		info.EdgeID, info.EdgeAddrs = toAddrInfo(j.edgeNetwork.AddrInfo())
+	}
+
+	return info
+}
+
+func toAddrInfo(addrInfo *peer.AddrInfo) (string, []string) {
+	addrs := make([]string, 0, len(addrInfo.Addrs))
+	for _, addr := range addrInfo.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+
+	return addrInfo.ID.String(), addrs
+}
+
+// logLevelOf returns the lowest level enabled in the logger
+func logLevelOf(logger hclog.Logger) hclog.Level {
+	switch {
+	case logger.IsTrace():
+		return hclog.Trace
+	case logger.IsDebug():
+		return hclog.Debug
+	case logger.IsInfo():
+		return hclog.Info
+	case logger.IsWarn():
+		return hclog.Warn
+	default:
+		return hclog.Error
+	}
+}
+
+// SetLogLevel changes the level of the node logger, which is shared by its named loggers
+func (j *jsonRPCHub) SetLogLevel(level hclog.Level) {
+	j.logger.SetLevel(level)
+	j.logger.Info("log level changed", "level", level.String())
+}
+
+// GetAppPeers returns the application peers known to the app syncer
+func (j *jsonRPCHub) GetAppPeers() []*jsonrpc.AppPeerInfo {
+	if j.appSyncer == nil {
+		return []*jsonrpc.AppPeerInfo{}
+	}
+
+	appPeers := j.appSyncer.GetAppPeers()
+
+	peers := make([]*jsonrpc.AppPeerInfo, len(appPeers))
+	for i, p := range appPeers {
+		peers[i] = toAppPeerInfo(p)
+	}
+
+	return peers
+}
+
+// GetAppPeer returns the application peer of the given ID, if known to the app syncer
+func (j *jsonRPCHub) GetAppPeer(peerID string) (*jsonrpc.AppPeerInfo, bool) {
+	if j.appSyncer == nil {
+		return nil, false
+	}
+
+	p := j.appSyncer.GetAppPeer(peerID)
+	if p == nil {
+		return nil, false
+	}
+
+	return toAppPeerInfo(p), true
+}
+
+func (j *jsonRPCHub) GetUsageRecord(hash types.Hash) (*metering.Entry, bool, error) {
+	return j.usageStore.Get(hash)
+}
+
+func (j *jsonRPCHub) GetUsageRecords(query *metering.Query) ([]*metering.Entry, uint64, error) {
+	return j.usageStore.Query(query)
+}
+
+func (j *jsonRPCHub) GetChannel(id types.Hash) (*channel.Channel, uint64, error) {
+	return j.channelReader.GetChannel(id)
+}
+
+func (j *jsonRPCHub) GetLocalChannels() ([]*channel.Entry, error) {
+	return j.channelStore.Channels()
+}
+
+func (j *jsonRPCHub) GetLatestVoucher(id types.Hash) (*channel.Voucher, bool, error) {
+	return j.channelStore.LatestVoucher(id)
+}
+
+func (j *jsonRPCHub) TrackChannel(ch *channel.Channel) error {
+	return j.channelStore.PutChannel(ch)
+}
+
+func (j *jsonRPCHub) RedeemChannel(id types.Hash) (types.Hash, error) {
+	if j.channelRedeemer == nil {
+		return types.ZeroHash, errChannelsNotRedeemed
+	}
+
+	return j.channelRedeemer.Redeem(id)
+}
+
+func (j *jsonRPCHub) GetEdgeCallJob(id types.Hash) (*jobs.Job, bool, error) {
+	return j.jobManager.Get(id)
+}
+
+func (j *jsonRPCHub) SubscribeEdgeCallJobs() *jobs.Subscription {
+	return j.jobManager.Subscribe()
+}
+
+func (j *jsonRPCHub) GetEdgeCallVerification(requestHash types.Hash) (*verification.Result, bool, error) {
+	return j.verificationStore.Get(requestHash)
+}
+
+func (j *jsonRPCHub) GetDisagreements(peerID string) ([]*verification.Result, error) {
+	return j.verificationStore.Disagreements(peerID)
+}
+
+func (j *jsonRPCHub) PutBlobChunk(data []byte) (types.Hash, error) {
+	return j.blobStore.PutChunk(data)
+}
+
+func (j *jsonRPCHub) PutBlobManifest(m *blob.Manifest) (types.Hash, error) {
+	return j.blobStore.PutManifest(m)
+}
+
+func (j *jsonRPCHub) GetBlobManifest(hash types.Hash) (*blob.Manifest, bool, error) {
+	return j.blobStore.GetManifest(hash)
+}
+
+func (j *jsonRPCHub) GetBlobMissingChunks(hash types.Hash) ([]uint64, error) {
+	return j.blobStore.Missing(hash)
+}
+
+func (j *jsonRPCHub) ReadBlobChunk(hash types.Hash, index uint64) ([]byte, error) {
+	return j.blobStore.ReadChunk(hash, index)
+}
+
+func (j *jsonRPCHub) GetEdgeCallEntry(hash types.Hash) (*calllog.Entry, bool, error) {
+	return j.callLog.Get(hash)
+}
+
+func (j *jsonRPCHub) GetEdgeCallEntries(query *calllog.Query) ([]*calllog.Entry, uint64, error) {
+	return j.callLog.Query(query)
+}
+
+func toAppPeerInfo(p *application.AppPeer) *jsonrpc.AppPeerInfo {
+	return &jsonrpc.AppPeerInfo{
+		ID:           p.ID,
+		Name:         p.Name,
+		Relay:        p.Relay,
+		Addr:         p.Addr,
+		AppOrigin:    p.AppOrigin,
+		ModelHash:    p.ModelHash,
+		Mac:          p.Mac,
+		MemInfo:      p.MemInfo,
+		CpuInfo:      p.CpuInfo,
+		GpuInfo:      p.GpuInfo,
+		Version:      p.Version,
+		StartupTime:  p.Starup_time,
+		Uptime:       p.Uptime,
+		Slots:        p.Guage_height,
+		MaxSlots:     p.Guage_max,
+		AveragePower: p.AveragePower,
+		LastSeen:     p.LastSeen.UnixMilli(),
+		Capabilities: p.Capabilities,
+		Apps:         p.HostedApps(),
+	}
+}
+
+// GetAppIdl fetches the IDL of the app hosted by the edge node and parses it
+func (j *jsonRPCHub) GetAppIdl(peerID string, app string) (*appidl.Schema, error) {
+	if j.TelegramPool == nil {
+		return nil, errors.New("edge calls are not available on this node")
+	}
+
+	respBuf, err := j.TelegramPool.CallApp(&application.EdgeCall{
+		PeerId:   peerID,
+		App:      app,
+		Endpoint: "/idl",
+		Input:    json.RawMessage("{}"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &application.EdgeResponse{}
+	if err := resp.UnmarshalRLP(respBuf); err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(resp.RespString)
+	if err != nil {
+		return nil, err
+	}
+
+	return appidl.Parse(data)
+}
+
+// GetRelayReservations returns the reservations of the relay client
+func (j *jsonRPCHub) GetRelayReservations() []*jsonrpc.RelayReservation {
+	if j.relayClient == nil {
+		return []*jsonrpc.RelayReservation{}
+	}
+
+	reservations := j.relayClient.Reservations()
+
+	result := make([]*jsonrpc.RelayReservation, len(reservations))
+	for i, resv := range reservations {
+		addrs := make([]string, 0, len(resv.Addrs))
+		for _, addr := range resv.Addrs {
+			addrs = append(addrs, addr.String())
+		}
+
+		result[i] = &jsonrpc.RelayReservation{
+			RelayID:       resv.ID.String(),
+			Addrs:         addrs,
+			Expiration:    resv.Expiration.Unix(),
+			LimitDuration: uint64(resv.LimitDuration.Seconds()),
+			LimitData:     resv.LimitData,
+		}
+	}
+
+	return result
+}
+
+// SetTelepoolLimits updates the limits of the telepool
+func (j *jsonRPCHub) SetTelepoolLimits(limits *jsonrpc.TelepoolLimits) *jsonrpc.TelepoolLimits {
+	current := j.TelegramPool.SetLimits(&telepool.Limits{
+		MaxSlots:           limits.MaxSlots,
+		MaxAccountEnqueued: limits.MaxAccountEnqueued,
+		PriceLimit:         limits.PriceLimit,
+	})
+
+	return &jsonrpc.TelepoolLimits{
+		MaxSlots:           current.MaxSlots,
+		MaxAccountEnqueued: current.MaxAccountEnqueued,
+		PriceLimit:         current.PriceLimit,
+	}
+}
+
 func (j *jsonRPCHub) SendMsg(msg *rtc.RtcMsg) error {
 	return j.AddRtcMsg(msg)
 }
@@ -720,6 +1246,51 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the account and the given storage slots
+// with the Merkle proofs against the state root
+func (j *jsonRPCHub) GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*jsonrpc.StateProof, error) {
+	account, accountProof, err := itrie.ProveAccount(j.stateStorage, root, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove account: %w", err)
+	}
+
+	res := &jsonrpc.StateProof{
+		Balance:       big.NewInt(0),
+		CodeHash:      types.BytesToHash(crypto.Keccak256(nil)),
+		StorageRoot:   types.EmptyRootHash,
+		AccountProof:  accountProof,
+		StorageProofs: make([]*jsonrpc.StorageProof, 0, len(slots)),
+	}
+
+	if account != nil {
+		res.Nonce = account.Nonce
+		res.Balance = new(big.Int).Set(account.Balance)
+
+		if len(account.CodeHash) != 0 {
+			res.CodeHash = types.BytesToHash(account.CodeHash)
+		}
+
+		if account.Root != types.ZeroHash {
+			res.StorageRoot = account.Root
+		}
+	}
+
+	for _, slot := range slots {
+		value, storageProof, err := itrie.ProveStorage(j.stateStorage, res.StorageRoot, slot)
+		if err != nil {
+			return nil, fmt.Errorf("unable to prove storage slot %s: %w", slot, err)
+		}
+
+		res.StorageProofs = append(res.StorageProofs, &jsonrpc.StorageProof{
+			Key:   slot,
+			Value: value,
+			Proof: storageProof,
+		})
+	}
+
+	return res, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Telegram,
@@ -879,7 +1450,23 @@ func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
 func (s *Server) setupJSONRPC() error {
 	hub := &jsonRPCHub{
 		state:              s.state,
+		stateStorage:       s.stateStorage,
 		restoreProgression: s.restoreProgression,
+		signer:             crypto.NewEIP155Signer(chain.AllForksEnabled.At(0), uint64(s.config.Chain.Params.ChainID)),
+		logger:             s.logger,
+		chainID:            uint64(s.config.Chain.Params.ChainID),
+		runningMode:        s.runningMode,
+		edgeNetwork:        s.edgeNetwork,
+		relayClient:        s.relayClient,
+		appSyncer:          s.appSyncer,
+		usageStore:         s.usageStore,
+		channelStore:       s.channelStore,
+		channelReader:      s.channelReader,
+		channelRedeemer:    s.channelRedeemer,
+		jobManager:         s.jobManager,
+		verificationStore:  s.verificationStore,
+		blobStore:          s.blobStore,
+		callLog:            s.callLog,
 		Blockchain:         s.blockchain,
 		TelegramPool:       s.telepool,
 		Executor:           s.executor,
@@ -904,6 +1491,10 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		WSSubscriptionLimit:      s.config.JSONRPC.WSSubscriptionLimit,
+		Auth:                     s.config.JSONRPC.Auth,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
+		LightClient:              s.runningMode == RunningModeLight,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -918,7 +1509,7 @@ func (s *Server) setupJSONRPC() error {
 
 // setupGRPC sets up the grpc server and listens on tcp
 func (s *Server) setupGRPC() error {
-	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
+	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s, node: s})
 
 	lis, err := net.Listen("tcp", s.config.GRPCAddr.String())
 	if err != nil {
@@ -982,6 +1573,73 @@ func (s *Server) Close() {
 	// Close the txpool's main loop
 	//s.txpool.Close()
 
+	// Stop the usage commitments
+	if s.usageCommitter != nil {
+		s.usageCommitter.Close()
+	}
+
+	// Close the usage records
+	if s.usageStore != nil {
+		if err := s.usageStore.Close(); err != nil {
+			s.logger.Error("failed to close usage store", "err", err.Error())
+		}
+	}
+
+	// Stop the redemption of the payment channels
+	if s.channelRedeemer != nil {
+		s.channelRedeemer.Close()
+	}
+
+	// Close the payment channels
+	if s.channelStore != nil {
+		if err := s.channelStore.Close(); err != nil {
+			s.logger.Error("failed to close channel store", "err", err.Error())
+		}
+	}
+
+	// Stop the edge call jobs
+	if s.jobManager != nil {
+		s.jobManager.Close()
+	}
+
+	// Close the edge call jobs
+	if s.jobStore != nil {
+		if err := s.jobStore.Close(); err != nil {
+			s.logger.Error("failed to close job store", "err", err.Error())
+		}
+	}
+
+	// Close the edge call verifications
+	if s.verificationStore != nil {
+		if err := s.verificationStore.Close(); err != nil {
+			s.logger.Error("failed to close verification store", "err", err.Error())
+		}
+	}
+
+	// Stop the blob garbage collection
+	if s.blobCollector != nil {
+		s.blobCollector.Close()
+	}
+
+	// Close the blobs
+	if s.blobStore != nil {
+		if err := s.blobStore.Close(); err != nil {
+			s.logger.Error("failed to close blob store", "err", err.Error())
+		}
+	}
+
+	// Stop the edge call log pruning
+	if s.callLogPruner != nil {
+		s.callLogPruner.Close()
+	}
+
+	// Close the edge call log
+	if s.callLog != nil {
+		if err := s.callLog.Close(); err != nil {
+			s.logger.Error("failed to close edge call log", "err", err.Error())
+		}
+	}
+
 	// Close DataDog profiler
 	s.closeDataDogProfiler()
 }
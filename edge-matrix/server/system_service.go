@@ -2,15 +2,59 @@ package server
 
 import (
 	"context"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/blockchain"
+	"github.com/emc-protocol/edge-matrix/helper/progress"
+	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/network/common"
 	"github.com/emc-protocol/edge-matrix/server/proto"
+	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
+// statusBlockchain is the blockchain reported and streamed by the system service
+type statusBlockchain interface {
+	Genesis() types.Hash
+	Header() *types.Header
+	SubscribeEvents() blockchain.Subscription
+}
+
+// statusNetwork is a peer network of the node
+type statusNetwork interface {
+	AddrInfo() *peer.AddrInfo
+	Peers() []*network.PeerConnInfo
+}
+
+// statusEndpoint is the app endpoint of the node
+type statusEndpoint interface {
+	GetEndpointApplication() *application.Application
+	GetAppUrl() string
+	Health() application.AppHealth
+}
+
+// systemNode is the node reported by the system service. The components are read on each request,
+// as some of them are set up after the service is registered
+type systemNode interface {
+	nodeChainID() int64
+	nodeRunningMode() RunningModeType
+	nodeBlockchain() statusBlockchain
+	// nodeNetworks returns the base network and the edge network, nil if the node does not join it
+	nodeNetworks() (statusNetwork, statusNetwork)
+	nodeRelayPeers() int
+	// nodeSyncProgression returns the progression of the running sync, nil if none
+	nodeSyncProgression() *progress.Progression
+	// nodeAppEndpoint returns the app endpoint, nil if the node does not serve an app
+	nodeAppEndpoint() statusEndpoint
+}
+
 type systemService struct {
 	proto.UnimplementedSystemServer
 
 	server *Server
+	node   systemNode
 }
 
 // GetStatus returns the current system status, in the form of:
@@ -20,61 +64,179 @@ type systemService struct {
 // Current: { Number: <blockNumber>; Hash: <headerHash> }
 //
 // P2PAddr: <libp2pAddress>
-//func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto.ServerStatus, error) {
-//	header := s.server.blockchain.Header()
 //
-//	status := &proto.ServerStatus{
-//		Network: s.server.chain.Params.ChainID,
-//		Current: &proto.ServerStatus_Block{
-//			Number: int64(header.Number),
-//			Hash:   header.Hash.String(),
-//		},
-//		P2PAddr: common.AddrInfoToString(s.server.network.AddrInfo()),
-//	}
-//
-//	return status, nil
-//}
+// with the running mode, the peer counts, the sync progression and the app endpoint health
+func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto.ServerStatus, error) {
+	chain := s.node.nodeBlockchain()
+	base, _ := s.node.nodeNetworks()
+
+	status := &proto.ServerStatus{
+		Network:     s.node.nodeChainID(),
+		Genesis:     chain.Genesis().String(),
+		P2PAddr:     common.AddrInfoToString(base.AddrInfo()),
+		RunningMode: string(s.node.nodeRunningMode()),
+		Peers:       s.getPeerCount(),
+	}
+
+	if header := chain.Header(); header != nil {
+		status.Current = &proto.ServerStatus_Block{
+			Number: int64(header.Number),
+			Hash:   header.Hash.String(),
+		}
+	}
+
+	if p := s.node.nodeSyncProgression(); p != nil {
+		status.SyncProgression = &proto.ServerStatus_SyncProgression{
+			Type:          string(p.SyncType),
+			StartingBlock: p.StartingBlock,
+			CurrentBlock:  p.CurrentBlock,
+			HighestBlock:  p.HighestBlock,
+			PulledStates:  p.PulledStates,
+		}
+	}
+
+	if endpoint := s.node.nodeAppEndpoint(); endpoint != nil {
+		app := endpoint.GetEndpointApplication()
+		health := endpoint.Health()
+
+		status.AppEndpoint = &proto.ServerStatus_AppEndpoint{
+			Name:    app.Name,
+			Url:     endpoint.GetAppUrl(),
+			Origin:  app.AppOrigin,
+			Healthy: !health.LastCheck.IsZero() && health.Err == nil,
+			Uptime:  uint64(time.Now().UnixMilli()) - app.StartupTime,
+		}
+
+		if !health.LastCheck.IsZero() {
+			status.AppEndpoint.LastCheck = uint64(health.LastCheck.UnixMilli())
+		}
+
+		if health.Err != nil {
+			status.AppEndpoint.Error = health.Err.Error()
+		}
+	}
+
+	return status, nil
+}
+
+// getPeerCount returns the number of the connected peers in each network
+func (s *systemService) getPeerCount() *proto.ServerStatus_PeerCount {
+	base, edge := s.node.nodeNetworks()
+
+	count := &proto.ServerStatus_PeerCount{
+		Base:  int64(len(base.Peers())),
+		Relay: int64(s.node.nodeRelayPeers()),
+	}
+
+	if edge != nil {
+		count.Edge = int64(len(edge.Peers()))
+	}
+
+	return count
+}
 
 // Subscribe implements the blockchain event subscription service
-//func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_SubscribeServer) error {
-//	sub := s.server.blockchain.SubscribeEvents()
-//
-//	for {
-//		evnt := sub.GetEvent()
-//		if evnt == nil {
-//			break
-//		}
-//
-//		pEvent := &proto.BlockchainEvent{
-//			Added:   []*proto.BlockchainEvent_Header{},
-//			Removed: []*proto.BlockchainEvent_Header{},
-//		}
-//
-//		for _, h := range evnt.NewChain {
-//			pEvent.Added = append(
-//				pEvent.Added,
-//				&proto.BlockchainEvent_Header{Hash: h.Hash.String(), Number: int64(h.Number)},
-//			)
-//		}
-//
-//		for _, h := range evnt.OldChain {
-//			pEvent.Removed = append(
-//				pEvent.Removed,
-//				&proto.BlockchainEvent_Header{Hash: h.Hash.String(), Number: int64(h.Number)},
-//			)
-//		}
-//
-//		err := stream.Send(pEvent)
-//
-//		if err != nil {
-//			break
-//		}
-//	}
-//
-//	sub.Close()
-//
-//	return nil
-//}
+func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_SubscribeServer) error {
+	sub := s.node.nodeBlockchain().SubscribeEvents()
+	defer sub.Close()
+
+	for {
+		var evnt *blockchain.Event
+
+		select {
+		case evnt = <-sub.GetEventCh():
+		case <-stream.Context().Done():
+			return nil
+		}
+
+		if evnt == nil {
+			return nil
+		}
+
+		if err := stream.Send(toProtoBlockchainEvent(evnt)); err != nil {
+			return err
+		}
+	}
+}
+
+func toProtoBlockchainEvent(evnt *blockchain.Event) *proto.BlockchainEvent {
+	pEvent := &proto.BlockchainEvent{
+		Added:   []*proto.BlockchainEvent_Header{},
+		Removed: []*proto.BlockchainEvent_Header{},
+		Source:  evnt.Source,
+	}
+
+	switch evnt.Type {
+	case blockchain.EventReorg:
+		pEvent.Type = proto.BlockchainEvent_REORG
+	case blockchain.EventFork:
+		pEvent.Type = proto.BlockchainEvent_FORK
+	default:
+		pEvent.Type = proto.BlockchainEvent_HEAD
+	}
+
+	for _, h := range evnt.NewChain {
+		pEvent.Added = append(
+			pEvent.Added,
+			&proto.BlockchainEvent_Header{Hash: h.Hash.String(), Number: int64(h.Number)},
+		)
+	}
+
+	for _, h := range evnt.OldChain {
+		pEvent.Removed = append(
+			pEvent.Removed,
+			&proto.BlockchainEvent_Header{Hash: h.Hash.String(), Number: int64(h.Number)},
+		)
+	}
+
+	return pEvent
+}
+
+func (s *Server) nodeChainID() int64 {
+	return s.chain.Params.ChainID
+}
+
+func (s *Server) nodeRunningMode() RunningModeType {
+	return s.runningMode
+}
+
+func (s *Server) nodeBlockchain() statusBlockchain {
+	return s.blockchain
+}
+
+func (s *Server) nodeNetworks() (statusNetwork, statusNetwork) {
+	if s.edgeNetwork == nil {
+		return s.network, nil
+	}
+
+	return s.network, s.edgeNetwork
+}
+
+func (s *Server) nodeRelayPeers() int {
+	if s.relayClient != nil {
+		return len(s.relayClient.RelayPeers())
+	} else if s.relayServer != nil {
+		return len(s.relayServer.GetHost().Network().Peers())
+	}
+
+	return 0
+}
+
+func (s *Server) nodeSyncProgression() *progress.Progression {
+	if s.consensus == nil {
+		return nil
+	}
+
+	return s.consensus.GetSyncProgression()
+}
+
+func (s *Server) nodeAppEndpoint() statusEndpoint {
+	if s.appEndpoint == nil {
+		return nil
+	}
+
+	return s.appEndpoint
+}
 
 // PeersAdd implements the 'peers add' operator service
 func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest) (*proto.PeersAddResponse, error) {
@@ -118,7 +280,7 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		addrs = append(addrs, addr.String())
 	}
 	protoList := make([]string, 0)
-	for prot := range protocols {
+	for _, prot := range protocols {
 		protoList = append(protoList, string(prot))
 	}
 
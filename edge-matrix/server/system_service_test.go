@@ -0,0 +1,333 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/blockchain"
+	"github.com/emc-protocol/edge-matrix/helper/progress"
+	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/server/proto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockSystemNode struct {
+	chainID     int64
+	runningMode RunningModeType
+	blockchain  *mockStatusBlockchain
+	base        statusNetwork
+	edge        statusNetwork
+	relayPeers  int
+	progression *progress.Progression
+	endpoint    statusEndpoint
+}
+
+func (m *mockSystemNode) nodeChainID() int64 {
+	return m.chainID
+}
+
+func (m *mockSystemNode) nodeRunningMode() RunningModeType {
+	return m.runningMode
+}
+
+func (m *mockSystemNode) nodeBlockchain() statusBlockchain {
+	return m.blockchain
+}
+
+func (m *mockSystemNode) nodeNetworks() (statusNetwork, statusNetwork) {
+	return m.base, m.edge
+}
+
+func (m *mockSystemNode) nodeRelayPeers() int {
+	return m.relayPeers
+}
+
+func (m *mockSystemNode) nodeSyncProgression() *progress.Progression {
+	return m.progression
+}
+
+func (m *mockSystemNode) nodeAppEndpoint() statusEndpoint {
+	return m.endpoint
+}
+
+type mockStatusBlockchain struct {
+	genesis types.Hash
+	header  *types.Header
+	sub     *blockchain.MockSubscription
+}
+
+func (m *mockStatusBlockchain) Genesis() types.Hash {
+	return m.genesis
+}
+
+func (m *mockStatusBlockchain) Header() *types.Header {
+	return m.header
+}
+
+func (m *mockStatusBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+type mockStatusNetwork struct {
+	addr  *peer.AddrInfo
+	peers int
+}
+
+func (m *mockStatusNetwork) AddrInfo() *peer.AddrInfo {
+	return m.addr
+}
+
+func (m *mockStatusNetwork) Peers() []*network.PeerConnInfo {
+	return make([]*network.PeerConnInfo, m.peers)
+}
+
+type mockStatusEndpoint struct {
+	app    *application.Application
+	url    string
+	health application.AppHealth
+}
+
+func (m *mockStatusEndpoint) GetEndpointApplication() *application.Application {
+	return m.app
+}
+
+func (m *mockStatusEndpoint) GetAppUrl() string {
+	return m.url
+}
+
+func (m *mockStatusEndpoint) Health() application.AppHealth {
+	return m.health
+}
+
+// mockSubscribeStream collects the events sent to the subscriber
+type mockSubscribeStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	sentCh chan *proto.BlockchainEvent
+}
+
+func (m *mockSubscribeStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockSubscribeStream) Send(evnt *proto.BlockchainEvent) error {
+	m.sentCh <- evnt
+
+	return nil
+}
+
+func newTestSystemNode(t *testing.T) *mockSystemNode {
+	t.Helper()
+
+	addr, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/50001")
+	require.NoError(t, err)
+
+	return &mockSystemNode{
+		chainID:     100,
+		runningMode: RunningModeEdge,
+		blockchain: &mockStatusBlockchain{
+			genesis: types.StringToHash("0x1"),
+			header:  &types.Header{Number: 10, Hash: types.StringToHash("0xa")},
+			sub:     blockchain.NewMockSubscription(),
+		},
+		base: &mockStatusNetwork{addr: &peer.AddrInfo{ID: peer.ID("base"), Addrs: []multiaddr.Multiaddr{addr}}, peers: 3},
+	}
+}
+
+func TestSystemService_GetStatus(t *testing.T) {
+	t.Parallel()
+
+	node := newTestSystemNode(t)
+	service := &systemService{node: node}
+
+	status, err := service.GetStatus(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(100), status.Network)
+	assert.Equal(t, types.StringToHash("0x1").String(), status.Genesis)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/50001/p2p/"+peer.ID("base").String(), status.P2PAddr)
+	assert.Equal(t, string(RunningModeEdge), status.RunningMode)
+	assert.Equal(t, int64(10), status.Current.Number)
+	assert.Equal(t, &proto.ServerStatus_PeerCount{Base: 3}, status.Peers)
+	assert.Nil(t, status.SyncProgression)
+	assert.Nil(t, status.AppEndpoint)
+
+	node.edge = &mockStatusNetwork{peers: 5}
+	node.relayPeers = 1
+	node.progression = &progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  10,
+		HighestBlock:  20,
+	}
+
+	checkedAt := time.Now().Add(-time.Minute)
+	node.endpoint = &mockStatusEndpoint{
+		app: &application.Application{
+			Name:        "sd",
+			AppOrigin:   "stable-diffusion",
+			StartupTime: uint64(time.Now().Add(-time.Hour).UnixMilli()),
+		},
+		url:    "http://127.0.0.1:7860",
+		health: application.AppHealth{LastCheck: checkedAt},
+	}
+
+	status, err = service.GetStatus(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &proto.ServerStatus_PeerCount{Base: 3, Edge: 5, Relay: 1}, status.Peers)
+
+	require.NotNil(t, status.SyncProgression)
+	assert.Equal(t, string(progress.ChainSyncBulk), status.SyncProgression.Type)
+	assert.Equal(t, uint64(1), status.SyncProgression.StartingBlock)
+	assert.Equal(t, uint64(10), status.SyncProgression.CurrentBlock)
+	assert.Equal(t, uint64(20), status.SyncProgression.HighestBlock)
+
+	require.NotNil(t, status.AppEndpoint)
+	assert.Equal(t, "sd", status.AppEndpoint.Name)
+	assert.Equal(t, "http://127.0.0.1:7860", status.AppEndpoint.Url)
+	assert.Equal(t, "stable-diffusion", status.AppEndpoint.Origin)
+	assert.True(t, status.AppEndpoint.Healthy)
+	assert.Equal(t, uint64(checkedAt.UnixMilli()), status.AppEndpoint.LastCheck)
+	assert.Empty(t, status.AppEndpoint.Error)
+	assert.GreaterOrEqual(t, status.AppEndpoint.Uptime, uint64(time.Hour.Milliseconds()))
+
+	// the failed check marks the app unhealthy
+	node.endpoint.(*mockStatusEndpoint).health.Err = errors.New("connection refused")
+
+	status, err = service.GetStatus(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	assert.False(t, status.AppEndpoint.Healthy)
+	assert.Equal(t, "connection refused", status.AppEndpoint.Error)
+
+	// the app not checked yet is not healthy
+	node.endpoint.(*mockStatusEndpoint).health = application.AppHealth{}
+
+	status, err = service.GetStatus(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	assert.False(t, status.AppEndpoint.Healthy)
+	assert.Zero(t, status.AppEndpoint.LastCheck)
+}
+
+func TestSystemService_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	node := newTestSystemNode(t)
+	service := &systemService{node: node}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &mockSubscribeStream{ctx: ctx, sentCh: make(chan *proto.BlockchainEvent, 1)}
+
+	doneCh := make(chan error, 1)
+
+	go func() {
+		doneCh <- service.Subscribe(&empty.Empty{}, stream)
+	}()
+
+	header := func(number uint64, hash string) *types.Header {
+		return &types.Header{Number: number, Hash: types.StringToHash(hash)}
+	}
+
+	protoHeader := func(number int64, hash string) *proto.BlockchainEvent_Header {
+		return &proto.BlockchainEvent_Header{Number: number, Hash: types.StringToHash(hash).String()}
+	}
+
+	cases := []struct {
+		name     string
+		event    *blockchain.Event
+		expected *proto.BlockchainEvent
+	}{
+		{
+			"sealed head",
+			&blockchain.Event{
+				Type:     blockchain.EventHead,
+				Source:   "consensus",
+				NewChain: []*types.Header{header(11, "0xb")},
+			},
+			&proto.BlockchainEvent{
+				Type:    proto.BlockchainEvent_HEAD,
+				Source:  "consensus",
+				Added:   []*proto.BlockchainEvent_Header{protoHeader(11, "0xb")},
+				Removed: []*proto.BlockchainEvent_Header{},
+			},
+		},
+		{
+			"head synced from a peer",
+			&blockchain.Event{
+				Type:     blockchain.EventHead,
+				Source:   "syncer",
+				NewChain: []*types.Header{header(12, "0xc")},
+			},
+			&proto.BlockchainEvent{
+				Type:    proto.BlockchainEvent_HEAD,
+				Source:  "syncer",
+				Added:   []*proto.BlockchainEvent_Header{protoHeader(12, "0xc")},
+				Removed: []*proto.BlockchainEvent_Header{},
+			},
+		},
+		{
+			"reorg",
+			&blockchain.Event{
+				Type:     blockchain.EventReorg,
+				Source:   "syncer",
+				NewChain: []*types.Header{header(12, "0xd"), header(13, "0xe")},
+				OldChain: []*types.Header{header(12, "0xc")},
+			},
+			&proto.BlockchainEvent{
+				Type:    proto.BlockchainEvent_REORG,
+				Source:  "syncer",
+				Added:   []*proto.BlockchainEvent_Header{protoHeader(12, "0xd"), protoHeader(13, "0xe")},
+				Removed: []*proto.BlockchainEvent_Header{protoHeader(12, "0xc")},
+			},
+		},
+		{
+			"fork",
+			&blockchain.Event{
+				Type:     blockchain.EventFork,
+				Source:   "syncer",
+				OldChain: []*types.Header{header(13, "0xf")},
+			},
+			&proto.BlockchainEvent{
+				Type:    proto.BlockchainEvent_FORK,
+				Source:  "syncer",
+				Added:   []*proto.BlockchainEvent_Header{},
+				Removed: []*proto.BlockchainEvent_Header{protoHeader(13, "0xf")},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		node.blockchain.sub.Push(c.event)
+
+		select {
+		case sent := <-stream.sentCh:
+			assert.Equal(t, c.expected, sent, c.name)
+		case <-time.After(time.Second):
+			t.Fatalf("%s: event not sent", c.name)
+		}
+	}
+
+	// the subscription ends without error once the subscriber goes away
+	cancel()
+
+	select {
+	case err := <-doneCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("subscription not ended")
+	}
+}
@@ -22,6 +22,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address of the EIP-2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key of the EIP-2930 access list
 )
 
 var emptyCodeHashTwo = types.BytesToHash(crypto.Keccak256(nil))
@@ -170,6 +173,13 @@ func (e *Executor) BeginTxn(
 		//Difficulty: types.BytesToHash(new(big.Int).SetUint64(header.Difficulty).Bytes()),
 		GasLimit: int64(header.GasLimit),
 		ChainID:  e.config.ChainID,
+		BaseFee:  header.BaseFee,
+	}
+
+	// the base fees are burnt unless the chain redirects them to the recipient
+	var baseFeeRecipient *types.Address
+	if e.config.IsBaseFeeActive(header.Number) {
+		baseFeeRecipient = e.config.BaseFee.Recipient
 	}
 
 	txn := &Transition{
@@ -182,6 +192,8 @@ func (e *Executor) BeginTxn(
 		config:   forkConfig,
 		gasPool:  uint64(txCtx.GasLimit),
 
+		baseFeeRecipient: baseFeeRecipient,
+
 		receipts: []*types.Receipt{},
 		totalGas: 0,
 
@@ -206,6 +218,9 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// baseFeeRecipient receives the base fees, which are burnt when it is nil
+	baseFeeRecipient *types.Address
+
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
@@ -240,7 +255,7 @@ var emptyFrom = types.Address{}
 func (t *Transition) WriteFailedReceipt(txn *types.Telegram) error {
 	signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
-	if txn.From == emptyFrom && txn.Type == types.LegacyTx {
+	if txn.From == emptyFrom && (txn.Type == types.LegacyTx || txn.Type.IsEnvelope()) {
 		// Decrypt the from address
 		from, err := signer.Sender(txn)
 		if err != nil {
@@ -272,7 +287,7 @@ func (t *Transition) WriteFailedReceipt(txn *types.Telegram) error {
 func (t *Transition) Write(tele *types.Telegram) error {
 	var err error
 
-	if tele.From == emptyFrom && tele.Type == types.LegacyTx {
+	if tele.From == emptyFrom && (tele.Type == types.LegacyTx || tele.Type.IsEnvelope()) {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -360,6 +375,9 @@ func (t *Transition) Apply(tele *types.Telegram) (*runtime.ExecutionResult, erro
 		t.state.RevertToSnapshot(s)
 	}
 
+	// the transient storage lives for a single transaction
+	t.state.ClearTransientStates()
+
 	if t.PostHook != nil {
 		t.PostHook(t)
 	}
@@ -375,7 +393,7 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 
 func (t *Transition) subGasLimitPrice(msg *types.Telegram) error {
 	// deduct the upfront max gas cost
-	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
+	upfrontGasCost := new(big.Int).Set(msg.GetGasFeeCap())
 	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
 
 	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
@@ -409,6 +427,9 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrTxTypeNotSupported    = fmt.Errorf("telegram type not supported before the london fork")
+	ErrFeeCapTooLow          = fmt.Errorf("max fee per gas less than block base fee")
+	ErrTipAboveFeeCap        = fmt.Errorf("max priority fee per gas higher than max fee per gas")
 )
 
 type TransitionApplicationError struct {
@@ -464,8 +485,11 @@ func (t *Transition) apply(tele *types.Telegram) (*runtime.ExecutionResult, erro
 	//	return nil, NewTransitionApplicationError(err, false)
 	//}
 
-	//	for gas free
-	intrinsicGasCost := uint64(0)
+	//	for gas free, except the access lists of the typed telegrams, whose payload is not free
+	intrinsicGasCost, err := AccessListGasCost(tele)
+	if err != nil {
+		return nil, NewTransitionApplicationError(err, false)
+	}
 
 	// the purchased gas is enough to cover intrinsic usage
 	gasLeft := tele.Gas - intrinsicGasCost
@@ -474,7 +498,8 @@ func (t *Transition) apply(tele *types.Telegram) (*runtime.ExecutionResult, erro
 		return nil, NewTransitionApplicationError(ErrNotEnoughIntrinsicGas, false)
 	}
 
-	gasPrice := new(big.Int).Set(tele.GasPrice)
+	// the sender pays the effective gas price, the base fee part of which is not paid to the coinbase
+	gasPrice := tele.EffectiveGasPrice(t.ctx.BaseFee)
 	value := new(big.Int).Set(tele.Value)
 
 	// set the specific transaction fields in the context
@@ -497,13 +522,30 @@ func (t *Transition) apply(tele *types.Telegram) (*runtime.ExecutionResult, erro
 	}
 
 	// refund the sender
-	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), tele.GasPrice)
+	// the upfront cost is charged with the fee cap, so the sender is refunded the difference too
+	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
+	remaining.Add(remaining, new(big.Int).Mul(
+		new(big.Int).SetUint64(tele.Gas),
+		new(big.Int).Sub(tele.GetGasFeeCap(), gasPrice),
+	))
 	t.state.AddBalance(tele.From, remaining)
 
 	// pay the coinbase
-	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), tele.GasPrice)
+	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), new(big.Int).Sub(
+		gasPrice,
+		new(big.Int).SetUint64(t.ctx.BaseFee),
+	))
 	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
 
+	// redirect the base fee, which is burnt otherwise
+	if t.baseFeeRecipient != nil && t.ctx.BaseFee != 0 {
+		baseFee := new(big.Int).Mul(
+			new(big.Int).SetUint64(result.GasUsed),
+			new(big.Int).SetUint64(t.ctx.BaseFee),
+		)
+		t.state.AddBalance(*t.baseFeeRecipient, baseFee)
+	}
+
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
@@ -751,6 +793,14 @@ func (t *Transition) GetStorage(addr types.Address, key types.Hash) types.Hash {
 	return t.state.GetState(addr, key)
 }
 
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) AccountExists(addr types.Address) bool {
 	return t.state.Exist(addr)
 }
@@ -865,19 +915,65 @@ func TransactionGasCost(msg *types.Telegram, isHomestead, isIstanbul bool) (uint
 		cost += zeros * 4
 	}
 
-	return cost, nil
+	accessListCost, err := AccessListGasCost(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.MaxUint64-cost < accessListCost {
+		return 0, ErrIntrinsicGasOverflow
+	}
+
+	return cost + accessListCost, nil
+}
+
+// AccessListGasCost returns the intrinsic gas of the access list of the typed telegram
+func AccessListGasCost(msg *types.Telegram) (uint64, error) {
+	if !msg.Type.IsEnvelope() || len(msg.AccessList) == 0 {
+		return 0, nil
+	}
+
+	addresses := uint64(len(msg.AccessList))
+	if math.MaxUint64/TxAccessListAddressGas < addresses {
+		return 0, ErrIntrinsicGasOverflow
+	}
+
+	cost := addresses * TxAccessListAddressGas
+
+	keys := uint64(msg.AccessList.StorageKeys())
+	if (math.MaxUint64-cost)/TxAccessListStorageKeyGas < keys {
+		return 0, ErrIntrinsicGasOverflow
+	}
+
+	return cost + keys*TxAccessListStorageKeyGas, nil
 }
 
 // checkAndProcessLegacyTx - first check if this message satisfies all consensus rules before
 // applying the message. The rules include these clauses:
 // 1. the nonce of the message caller is correct
 // 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
+// 3. the typed telegrams are accepted from the london fork
+// 4. the fee cap covers the block base fee
 func checkAndProcessLegacyTx(msg *types.Telegram, t *Transition) error {
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
 
+	// 3. the typed telegrams are accepted from the london fork
+	if msg.Type.IsEnvelope() && !t.config.London {
+		return NewTransitionApplicationError(ErrTxTypeNotSupported, false)
+	}
+
+	if msg.GetGasTipCap().Cmp(msg.GetGasFeeCap()) > 0 {
+		return NewTransitionApplicationError(ErrTipAboveFeeCap, false)
+	}
+
+	// 4. the fee cap covers the block base fee
+	if msg.GetGasFeeCap().Cmp(new(big.Int).SetUint64(t.ctx.BaseFee)) < 0 {
+		return NewTransitionApplicationError(ErrFeeCapTooLow, true)
+	}
+
 	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionGasCost_AccessList(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("0x1")
+	accessList := types.TxAccessList{
+		{Address: types.StringToAddress("0x2"), StorageKeys: []types.Hash{types.StringToHash("0x1"), types.StringToHash("0x2")}},
+		{Address: types.StringToAddress("0x3")},
+	}
+
+	cases := []struct {
+		name string
+		tele *types.Telegram
+		cost uint64
+	}{
+		{"legacy", &types.Telegram{Type: types.LegacyTx, To: &to, AccessList: accessList}, TxGas},
+		{"no access list", &types.Telegram{Type: types.DynamicFeeTx, To: &to}, TxGas},
+		{"access list", &types.Telegram{Type: types.AccessListTx, To: &to, AccessList: accessList}, TxGas + 2*2400 + 2*1900},
+		{"dynamic fee", &types.Telegram{Type: types.DynamicFeeTx, To: &to, AccessList: accessList}, TxGas + 2*2400 + 2*1900},
+	}
+
+	for _, c := range cases {
+		cost, err := TransactionGasCost(c.tele, true, true)
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.cost, cost, c.name)
+
+		accessListCost, err := AccessListGasCost(c.tele)
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.cost-TxGas, accessListCost, c.name)
+	}
+}
+
+func TestTransition_AccessListIntrinsicGas(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{addr1: {Balance: 1000}})
+	transition.config = chain.ForksInTime{London: true}
+
+	to := addr2
+	tele := &types.Telegram{
+		Type:       types.AccessListTx,
+		From:       addr1,
+		To:         &to,
+		GasPrice:   big.NewInt(0),
+		Value:      big.NewInt(0),
+		Gas:        TxAccessListAddressGas - 1,
+		AccessList: types.TxAccessList{{Address: addr2}},
+	}
+
+	_, err := transition.Apply(tele)
+	assert.EqualError(t, err, ErrNotEnoughIntrinsicGas.Error())
+}
@@ -0,0 +1,236 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/state"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrKeyNotFound  = errors.New("key not found in trie")
+	ErrInvalidProof = errors.New("invalid merkle proof")
+)
+
+// WriteTrie inserts the given key values into a new trie, stores all its nodes
+// in the storage and returns the root of the trie
+func WriteTrie(storage Storage, keys, values [][]byte) (types.Hash, error) {
+	if len(keys) != len(values) {
+		return types.ZeroHash, fmt.Errorf("%d keys given for %d values", len(keys), len(values))
+	}
+
+	batch := storage.Batch()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = batch
+
+	for i := range keys {
+		txn.Insert(keys[i], values[i])
+	}
+
+	root, err := txn.Hash()
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	batch.Write()
+
+	return types.BytesToHash(root), nil
+}
+
+// Prove returns the stored trie nodes on the path from the root to the given key,
+// which are enough to verify the value of the key against the root.
+// If the key is not in the trie, it returns the proof of the absence along with ErrKeyNotFound
+func Prove(storage Storage, root types.Hash, key []byte) ([][]byte, error) {
+	proof := make([][]byte, 0)
+
+	if _, err := walkPath(storage, root.Bytes(), bytesToHexNibbles(key), func(data []byte) {
+		proof = append(proof, data)
+	}); err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return proof, err
+		}
+
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// ProveAccount returns the account at the given address with the Merkle proof against
+// the state root. The account is nil if it doesn't exist, and then the proof shows its absence
+func ProveAccount(storage Storage, root types.Hash, addr types.Address) (*state.Account, [][]byte, error) {
+	if isEmptyRoot(root) {
+		return nil, [][]byte{}, nil
+	}
+
+	key := crypto.Keccak256(addr.Bytes())
+
+	proof, err := Prove(storage, root, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, proof, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	account, err := VerifyAccountProof(root, addr, proof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return account, proof, nil
+}
+
+// ProveStorage returns the value of the storage slot with the Merkle proof
+// against the storage root of the account
+func ProveStorage(storage Storage, storageRoot types.Hash, slot types.Hash) (types.Hash, [][]byte, error) {
+	if isEmptyRoot(storageRoot) {
+		return types.ZeroHash, [][]byte{}, nil
+	}
+
+	key := crypto.Keccak256(slot.Bytes())
+
+	proof, err := Prove(storage, storageRoot, key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return types.ZeroHash, proof, nil
+	} else if err != nil {
+		return types.ZeroHash, nil, err
+	}
+
+	value, err := VerifyStorageProof(storageRoot, slot, proof)
+	if err != nil {
+		return types.ZeroHash, nil, err
+	}
+
+	return value, proof, nil
+}
+
+// VerifyAccountProof checks the proof of the account at the given address
+// against the state root and returns the account, or nil if the proof shows its absence
+func VerifyAccountProof(root types.Hash, addr types.Address, proof [][]byte) (*state.Account, error) {
+	if isEmptyRoot(root) {
+		return nil, nil
+	}
+
+	data, err := VerifyProof(root, crypto.Keccak256(addr.Bytes()), proof)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return nil, fmt.Errorf("failed to decode account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// VerifyStorageProof checks the proof of the storage slot against the storage root
+// of the account and returns the value, which is zero if the proof shows its absence
+func VerifyStorageProof(storageRoot types.Hash, slot types.Hash, proof [][]byte) (types.Hash, error) {
+	if isEmptyRoot(storageRoot) {
+		return types.ZeroHash, nil
+	}
+
+	data, err := VerifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proof)
+	if errors.Is(err, ErrKeyNotFound) {
+		return types.ZeroHash, nil
+	} else if err != nil {
+		return types.ZeroHash, err
+	}
+
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return types.ZeroHash, fmt.Errorf("failed to decode storage value: %w", err)
+	}
+
+	value, err := v.Bytes()
+	if err != nil {
+		return types.ZeroHash, fmt.Errorf("failed to decode storage value: %w", err)
+	}
+
+	return types.BytesToHash(value), nil
+}
+
+// VerifyProof checks the proof against the given root and returns the value of the key.
+// It returns ErrInvalidProof if the proof doesn't lead from the root to the key
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	storage := NewMemoryStorage()
+
+	for _, node := range proof {
+		storage.Put(crypto.Keccak256(node), node)
+	}
+
+	value, err := walkPath(storage, root.Bytes(), bytesToHexNibbles(key), nil)
+	if errors.Is(err, ErrMissingNode) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	return value, err
+}
+
+func isEmptyRoot(root types.Hash) bool {
+	return root == types.EmptyRootHash || root == types.ZeroHash
+}
+
+// walkPath follows the given key nibbles from the node with the given hash,
+// calls onNode with every stored node on the way and returns the value of the key
+func walkPath(storage Storage, hash []byte, key []byte, onNode func([]byte)) ([]byte, error) {
+	data, ok := storage.Get(hash)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingNode, types.BytesToHash(hash))
+	}
+
+	if onNode != nil {
+		onNode(data)
+	}
+
+	node, _, err := GetNode(hash, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch n := node.(type) {
+		case nil:
+			return nil, ErrKeyNotFound
+
+		case *ValueNode:
+			if n.hash {
+				return walkPath(storage, n.buf, key, onNode)
+			}
+
+			if len(key) != 0 {
+				return nil, ErrKeyNotFound
+			}
+
+			return n.buf, nil
+
+		case *ShortNode:
+			plen := len(n.key)
+			if plen > len(key) || !bytes.Equal(key[:plen], n.key) {
+				return nil, ErrKeyNotFound
+			}
+
+			node, key = n.child, key[plen:]
+
+		case *FullNode:
+			if len(key) == 0 {
+				node = n.value
+			} else {
+				node, key = n.getEdge(key[0]), key[1:]
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown node type %T", node)
+		}
+	}
+}
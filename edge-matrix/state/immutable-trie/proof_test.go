@@ -0,0 +1,160 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/state"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		keys   = make([][]byte, 0, 100)
+		values = make([][]byte, 0, 100)
+	)
+
+	for i := 0; i < 100; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("key-%d", i)))
+		values = append(values, []byte(fmt.Sprintf("value-%d", i)))
+	}
+
+	storage := NewMemoryStorage()
+
+	root, err := WriteTrie(storage, keys, values)
+	assert.NoError(t, err)
+
+	// the root must be same as the one calculated without the storage
+	txn := NewTrie().Txn(nil)
+	for i := range keys {
+		txn.Insert(keys[i], values[i])
+	}
+
+	expectedRoot, err := txn.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, types.BytesToHash(expectedRoot), root)
+
+	t.Run("should verify the proof of every key", func(t *testing.T) {
+		t.Parallel()
+
+		for i := range keys {
+			proof, err := Prove(storage, root, keys[i])
+			assert.NoError(t, err)
+
+			value, err := VerifyProof(root, keys[i], proof)
+			assert.NoError(t, err)
+			assert.Equal(t, values[i], value)
+		}
+	})
+
+	t.Run("should return ErrKeyNotFound for the absent key", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := Prove(storage, root, []byte("unknown"))
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+		// the proof shows the absence of the key
+		_, err = VerifyProof(root, []byte("unknown"), proof)
+		assert.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("should return ErrInvalidProof for the wrong proof", func(t *testing.T) {
+		t.Parallel()
+
+		proof, err := Prove(storage, root, keys[0])
+		assert.NoError(t, err)
+
+		// the proof of another key
+		_, err = VerifyProof(root, keys[1], proof)
+		assert.Error(t, err)
+
+		// a tampered proof
+		tampered := make([][]byte, len(proof))
+		copy(tampered, proof)
+		tampered[len(tampered)-1] = append([]byte{}, proof[len(proof)-1]...)
+		tampered[len(tampered)-1][len(tampered[len(tampered)-1])-1]++
+
+		_, err = VerifyProof(root, keys[0], tampered)
+		assert.True(t, errors.Is(err, ErrInvalidProof))
+
+		// another root
+		_, err = VerifyProof(types.StringToHash("1"), keys[0], proof)
+		assert.True(t, errors.Is(err, ErrInvalidProof))
+	})
+}
+
+func TestStateProof(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storage = NewMemoryStorage()
+		snap    = NewState(storage).NewSnapshot()
+		txn     = state.NewTxn(snap)
+
+		addr = types.StringToAddress("1")
+		slot = types.StringToHash("2")
+	)
+
+	txn.SetBalance(addr, big.NewInt(100))
+	txn.SetNonce(addr, 3)
+	txn.SetState(addr, slot, types.StringToHash("3"))
+	txn.SetBalance(types.StringToAddress("4"), big.NewInt(1))
+
+	_, rootBytes := snap.Commit(txn.Commit(false))
+	root := types.BytesToHash(rootBytes)
+
+	t.Run("should prove the account and its storage", func(t *testing.T) {
+		t.Parallel()
+
+		account, accountProof, err := ProveAccount(storage, root, addr)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), account.Nonce)
+		assert.Equal(t, big.NewInt(100), account.Balance)
+
+		verified, err := VerifyAccountProof(root, addr, accountProof)
+		assert.NoError(t, err)
+		assert.Equal(t, account.Root, verified.Root)
+
+		value, storageProof, err := ProveStorage(storage, account.Root, slot)
+		assert.NoError(t, err)
+		assert.Equal(t, types.StringToHash("3"), value)
+
+		value, err = VerifyStorageProof(account.Root, slot, storageProof)
+		assert.NoError(t, err)
+		assert.Equal(t, types.StringToHash("3"), value)
+
+		// the proof of the other slot
+		_, err = VerifyStorageProof(types.StringToHash("5"), slot, storageProof)
+		assert.True(t, errors.Is(err, ErrInvalidProof))
+	})
+
+	t.Run("should prove the absence of the account and the slot", func(t *testing.T) {
+		t.Parallel()
+
+		unknown := types.StringToAddress("5")
+
+		account, accountProof, err := ProveAccount(storage, root, unknown)
+		assert.NoError(t, err)
+		assert.Nil(t, account)
+
+		verified, err := VerifyAccountProof(root, unknown, accountProof)
+		assert.NoError(t, err)
+		assert.Nil(t, verified)
+
+		account, _, err = ProveAccount(storage, root, addr)
+		assert.NoError(t, err)
+
+		value, storageProof, err := ProveStorage(storage, account.Root, types.StringToHash("6"))
+		assert.NoError(t, err)
+		assert.Equal(t, types.ZeroHash, value)
+
+		value, err = VerifyStorageProof(account.Root, types.StringToHash("6"), storageProof)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ZeroHash, value)
+	})
+}
@@ -0,0 +1,141 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/state"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+var (
+	ErrMissingNode = errors.New("trie node not found")
+	ErrMissingCode = errors.New("contract code not found")
+)
+
+var emptyCodeHashBytes = types.BytesToHash(crypto.Keccak256(nil))
+
+// NodeHandler is called with the raw (RLP encoded) data of every stored trie node
+type NodeHandler func(data []byte) error
+
+// CodeHandler is called with every contract code referenced by an account
+type CodeHandler func(hash types.Hash, code []byte) error
+
+// TraverseState walks the world state trie below the given root, including the storage
+// tries of all the accounts, and calls the handlers for every stored node and code.
+// It returns ErrMissingNode or ErrMissingCode if the state is not complete in the storage
+func TraverseState(storage Storage, root types.Hash, onNode NodeHandler, onCode CodeHandler) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	return traverseTrie(storage, root.Bytes(), onNode, func(leaf []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(leaf); err != nil {
+			return fmt.Errorf("failed to decode account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash && account.Root != types.ZeroHash {
+			if err := traverseTrie(storage, account.Root.Bytes(), onNode, nil); err != nil {
+				return err
+			}
+		}
+
+		codeHash := types.BytesToHash(account.CodeHash)
+		if len(account.CodeHash) == 0 || codeHash == emptyCodeHashBytes {
+			return nil
+		}
+
+		code, ok := storage.GetCode(codeHash)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMissingCode, codeHash)
+		}
+
+		if onCode != nil {
+			return onCode(codeHash, code)
+		}
+
+		return nil
+	})
+}
+
+// VerifyState checks that every node and code reachable from the given state root
+// is present in the storage
+func VerifyState(storage Storage, root types.Hash) error {
+	return TraverseState(storage, root, nil, nil)
+}
+
+// WriteStateNodes stores the given trie nodes under their hashes. As the nodes
+// are content addressed, data sent by a peer can't overwrite any other node
+func WriteStateNodes(storage Storage, nodes [][]byte) {
+	batch := storage.Batch()
+
+	for _, node := range nodes {
+		batch.Put(crypto.Keccak256(node), node)
+	}
+
+	batch.Write()
+}
+
+// WriteStateCodes stores the given contract codes under their hashes
+func WriteStateCodes(storage Storage, codes [][]byte) {
+	for _, code := range codes {
+		storage.SetCode(types.BytesToHash(crypto.Keccak256(code)), code)
+	}
+}
+
+// traverseTrie walks a single trie in depth first order,
+// and calls onLeaf with the value of every leaf
+func traverseTrie(storage Storage, hash []byte, onNode NodeHandler, onLeaf func([]byte) error) error {
+	data, ok := storage.Get(hash)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingNode, types.BytesToHash(hash))
+	}
+
+	if onNode != nil {
+		if err := onNode(data); err != nil {
+			return err
+		}
+	}
+
+	node, _, err := GetNode(hash, storage)
+	if err != nil {
+		return err
+	}
+
+	return traverseNode(storage, node, onNode, onLeaf)
+}
+
+func traverseNode(storage Storage, node Node, onNode NodeHandler, onLeaf func([]byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return traverseTrie(storage, n.buf, onNode, onLeaf)
+		}
+
+		if onLeaf != nil {
+			return onLeaf(n.buf)
+		}
+
+		return nil
+
+	case *ShortNode:
+		return traverseNode(storage, n.child, onNode, onLeaf)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := traverseNode(storage, child, onNode, onLeaf); err != nil {
+				return err
+			}
+		}
+
+		return traverseNode(storage, n.value, onNode, onLeaf)
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
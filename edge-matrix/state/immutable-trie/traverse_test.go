@@ -0,0 +1,91 @@
+package itrie
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/state"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestState(t *testing.T, storage Storage) types.Hash {
+	t.Helper()
+
+	snap := NewState(storage).NewSnapshot()
+	txn := state.NewTxn(snap)
+
+	for i := 0; i < 20; i++ {
+		addr := types.StringToAddress(string(rune('a' + i)))
+
+		txn.SetBalance(addr, big.NewInt(int64(i+1)))
+		txn.SetState(addr, types.StringToHash("1"), types.StringToHash(string(rune('a'+i))))
+	}
+
+	txn.SetCode(types.StringToAddress("a"), []byte{0x60, 0x00, 0x60, 0x00})
+
+	_, root := snap.Commit(txn.Commit(false))
+
+	return types.BytesToHash(root)
+}
+
+func TestTraverseState(t *testing.T) {
+	t.Parallel()
+
+	source := NewMemoryStorage()
+	root := buildTestState(t, source)
+
+	var (
+		nodes [][]byte
+		codes [][]byte
+	)
+
+	assert.NoError(t, TraverseState(source, root, func(data []byte) error {
+		nodes = append(nodes, data)
+
+		return nil
+	}, func(hash types.Hash, code []byte) error {
+		codes = append(codes, code)
+
+		return nil
+	}))
+
+	assert.Len(t, codes, 1)
+
+	t.Run("should verify the copied state", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+		WriteStateNodes(target, nodes)
+		WriteStateCodes(target, codes)
+
+		assert.NoError(t, VerifyState(target, root))
+
+		snap, err := NewState(target).NewSnapshotAt(root)
+		assert.NoError(t, err)
+
+		account, err := snap.GetAccount(types.StringToAddress("c"))
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(3), account.Balance)
+	})
+
+	t.Run("should return ErrMissingNode for the incomplete state", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+		WriteStateNodes(target, nodes[:len(nodes)/2])
+		WriteStateCodes(target, codes)
+
+		assert.True(t, errors.Is(VerifyState(target, root), ErrMissingNode))
+	})
+
+	t.Run("should return ErrMissingCode for the missing code", func(t *testing.T) {
+		t.Parallel()
+
+		target := NewMemoryStorage()
+		WriteStateNodes(target, nodes)
+
+		assert.True(t, errors.Is(VerifyState(target, root), ErrMissingCode))
+	})
+}
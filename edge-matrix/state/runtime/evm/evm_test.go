@@ -46,6 +46,14 @@ func (m *mockHost) SetStorage(
 	panic("Not implemented in tests")
 }
 
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	panic("Not implemented in tests")
+}
+
 func (m *mockHost) GetBalance(addr types.Address) *big.Int {
 	panic("Not implemented in tests")
 }
@@ -168,6 +176,72 @@ func TestRun(t *testing.T) {
 				Err:     errRevert,
 			},
 		},
+		{
+			name:  "should push zero by PUSH0 after Shanghai",
+			value: big.NewInt(0),
+			gas:   5000,
+			code: []byte{
+				PUSH0, PUSH0, MSTORE8,
+				PUSH1, 0x01, PUSH0, RETURN,
+			},
+			config: &chain.ForksInTime{
+				Shanghai: true,
+			},
+			expected: &runtime.ExecutionResult{
+				ReturnValue: []uint8{0x00},
+				GasLeft:     4985,
+				GasUsed:     15,
+			},
+		},
+		{
+			name:  "should fail by PUSH0 before Shanghai",
+			value: big.NewInt(0),
+			gas:   5000,
+			code:  []byte{PUSH0},
+			expected: &runtime.ExecutionResult{
+				ReturnValue: nil,
+				GasLeft:     0,
+				GasUsed:     5000,
+				Err:         errOpCodeNotFound,
+			},
+		},
+		{
+			name:  "should copy memory by MCOPY after Cancun",
+			value: big.NewInt(0),
+			gas:   5000,
+			// copies the byte at 0x1f to 0x00 in a single word of memory
+			code: []byte{
+				PUSH1, 0x02, PUSH1, 0x1f, MSTORE8,
+				PUSH1, 0x01, PUSH1, 0x1f, PUSH0, MCOPY,
+				PUSH1, 0x01, PUSH0, RETURN,
+			},
+			config: &chain.ForksInTime{
+				Shanghai: true,
+				Cancun:   true,
+			},
+			expected: &runtime.ExecutionResult{
+				ReturnValue: []uint8{0x02},
+				GasLeft:     4969,
+				GasUsed:     31,
+			},
+		},
+		{
+			name:  "should fail by MCOPY before Cancun",
+			value: big.NewInt(0),
+			gas:   5000,
+			code: []byte{
+				PUSH1, 0x01, PUSH1, 0x00, PUSH1, 0x00, MCOPY,
+			},
+			config: &chain.ForksInTime{
+				Shanghai: true,
+			},
+			expected: &runtime.ExecutionResult{
+				ReturnValue: nil,
+				GasLeft:     0,
+				GasUsed:     5000,
+				Err:         errOpCodeNotFound,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,260 @@
+// the state tests run the telegrams through the transition of the state package, which imports this package
+package evm_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/state"
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/require"
+)
+
+// generalStateTestsDir holds the state tests in the filler format of the GeneralStateTests of ethereum/tests.
+// Their results are checked on the post state of the accounts rather than on the state root,
+// as the telegrams do not pay the intrinsic gas of the ethereum transactions
+const generalStateTestsDir = "testdata/GeneralStateTests"
+
+// networks are the forks the state tests are run on, in their order of activation
+var networks = []string{"Berlin", "London", "Shanghai", "Cancun"}
+
+type stateEnv struct {
+	Coinbase  types.Address `json:"currentCoinbase"`
+	BaseFee   string        `json:"currentBaseFee"`
+	GasLimit  string        `json:"currentGasLimit"`
+	Number    string        `json:"currentNumber"`
+	Timestamp string        `json:"currentTimestamp"`
+}
+
+type stateAccount struct {
+	Balance string                    `json:"balance"`
+	Code    string                    `json:"code"`
+	Nonce   string                    `json:"nonce"`
+	Storage map[types.Hash]types.Hash `json:"storage"`
+}
+
+type stateTransaction struct {
+	Data      []string      `json:"data"`
+	GasLimit  []string      `json:"gasLimit"`
+	GasPrice  string        `json:"gasPrice"`
+	Nonce     string        `json:"nonce"`
+	SecretKey string        `json:"secretKey"`
+	To        types.Address `json:"to"`
+	Value     []string      `json:"value"`
+}
+
+type stateIndexes struct {
+	Data  int `json:"data"`
+	Gas   int `json:"gas"`
+	Value int `json:"value"`
+}
+
+// match returns whether the indexes select the transaction of the data, gas and value indexes, -1 selecting all of them
+func (i stateIndexes) match(data, gas, value int) bool {
+	return (i.Data == -1 || i.Data == data) && (i.Gas == -1 || i.Gas == gas) && (i.Value == -1 || i.Value == value)
+}
+
+type stateExpect struct {
+	Indexes stateIndexes                    `json:"indexes"`
+	Network []string                        `json:"network"`
+	Result  map[types.Address]*stateAccount `json:"result"`
+}
+
+// hasNetwork returns whether the expectation applies to the network, written as a fork or a range of forks
+func (e *stateExpect) hasNetwork(network string) bool {
+	index := func(name string) int {
+		for i, n := range networks {
+			if n == name {
+				return i
+			}
+		}
+
+		panic(fmt.Sprintf("unknown network %s", name))
+	}
+
+	for _, n := range e.Network {
+		switch {
+		case strings.HasPrefix(n, ">="):
+			if index(network) >= index(n[2:]) {
+				return true
+			}
+		case strings.HasPrefix(n, "<"):
+			if index(network) < index(n[1:]) {
+				return true
+			}
+		case index(n) == index(network):
+			return true
+		}
+	}
+
+	return false
+}
+
+type stateTest struct {
+	Env         stateEnv                        `json:"env"`
+	Pre         map[types.Address]*stateAccount `json:"pre"`
+	Transaction stateTransaction                `json:"transaction"`
+	Expect      []*stateExpect                  `json:"expect"`
+}
+
+// forksAt returns the forks enabled on the network
+func forksAt(network string) chain.ForksInTime {
+	forks := chain.AllForksEnabled.At(0)
+
+	switch network {
+	case "Berlin":
+		forks.London = false
+
+		fallthrough
+	case "London":
+		forks.Shanghai = false
+
+		fallthrough
+	case "Shanghai":
+		forks.Cancun = false
+	}
+
+	return forks
+}
+
+func parseUint64(t *testing.T, val string) uint64 {
+	t.Helper()
+
+	n, err := types.ParseUint64orHex(&val)
+	require.NoError(t, err)
+
+	return n
+}
+
+func parseBig(t *testing.T, val string) *big.Int {
+	t.Helper()
+
+	n, err := types.ParseUint256orHex(&val)
+	require.NoError(t, err)
+
+	return n
+}
+
+// parseCode returns the code of an account, written as raw bytes
+func parseCode(t *testing.T, code string) []byte {
+	t.Helper()
+
+	code = strings.TrimPrefix(code, ":raw ")
+	require.True(t, strings.HasPrefix(code, "0x"), "code %s is not raw bytes", code)
+
+	buf, err := types.ParseBytes(&code)
+	require.NoError(t, err)
+
+	return buf
+}
+
+// run applies the transaction of the indexes to the pre state on the network, and checks its post state
+func (s *stateTest) run(t *testing.T, network string, data, gas, value int) {
+	t.Helper()
+
+	snap := itrie.NewState(itrie.NewMemoryStorage()).NewSnapshot()
+	txn := state.NewTxn(snap)
+
+	for addr, account := range s.Pre {
+		txn.SetBalance(addr, parseBig(t, account.Balance))
+		txn.SetNonce(addr, parseUint64(t, account.Nonce))
+
+		if code := parseCode(t, account.Code); len(code) != 0 {
+			txn.SetCode(addr, code)
+		}
+
+		for key, val := range account.Storage {
+			txn.SetState(addr, key, val)
+		}
+	}
+
+	transition := state.NewTransition(forksAt(network), snap, txn)
+
+	ctx := transition.ContextPtr()
+	ctx.Coinbase = s.Env.Coinbase
+	ctx.BaseFee = parseUint64(t, s.Env.BaseFee)
+	ctx.GasLimit = int64(parseUint64(t, s.Env.GasLimit))
+	ctx.Number = int64(parseUint64(t, s.Env.Number))
+	ctx.Timestamp = int64(parseUint64(t, s.Env.Timestamp))
+
+	key, err := crypto.ParseECDSAPrivateKey(types.StringToBytes(s.Transaction.SecretKey))
+	require.NoError(t, err)
+
+	input, err := types.ParseBytes(&s.Transaction.Data[data])
+	require.NoError(t, err)
+
+	to := s.Transaction.To
+	_, err = transition.Apply(&types.Telegram{
+		Nonce:    parseUint64(t, s.Transaction.Nonce),
+		GasPrice: parseBig(t, s.Transaction.GasPrice),
+		Gas:      parseUint64(t, s.Transaction.GasLimit[gas]),
+		To:       &to,
+		Value:    parseBig(t, s.Transaction.Value[value]),
+		Input:    input,
+		From:     crypto.PubKeyToAddress(&key.PublicKey),
+	})
+	require.NoError(t, err)
+
+	for _, expect := range s.Expect {
+		if !expect.hasNetwork(network) || !expect.Indexes.match(data, gas, value) {
+			continue
+		}
+
+		for addr, account := range expect.Result {
+			for key, val := range account.Storage {
+				require.Equal(t, val, transition.GetStorage(addr, key), "storage %s of %s", key, addr)
+			}
+		}
+	}
+}
+
+func TestGeneralStateTests(t *testing.T) {
+	t.Parallel()
+
+	err := filepath.Walk(generalStateTestsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		tests := map[string]*stateTest{}
+		if err := json.Unmarshal(raw, &tests); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		for name, test := range tests {
+			test := test
+
+			for _, network := range networks {
+				network := network
+
+				t.Run(fmt.Sprintf("%s/%s/%s", filepath.Base(path), name, network), func(t *testing.T) {
+					t.Parallel()
+
+					for data := range test.Transaction.Data {
+						for gas := range test.Transaction.GasLimit {
+							for value := range test.Transaction.Value {
+								test.run(t, network, data, gas, value)
+							}
+						}
+					}
+				})
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -482,6 +482,37 @@ func opSload(c *state) {
 	loc.SetBytes(val.Bytes())
 }
 
+func opTload(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+	val := c.host.GetTransientStorage(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTstore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 func opSStore(c *state) {
 	if c.inStaticCall() {
 		c.exit(errWriteProtection)
@@ -611,6 +642,16 @@ func opChainID(c *state) {
 	c.push1().SetUint64(uint64(c.host.GetTxContext().ChainID))
 }
 
+func opBaseFee(c *state) {
+	if !c.config.London {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetUint64(c.host.GetTxContext().BaseFee)
+}
+
 func opOrigin(c *state) {
 	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
 }
@@ -783,6 +824,33 @@ func opExtCodeCopy(c *state) {
 	}
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dstOffset := c.pop()
+	srcOffset := c.pop()
+	length := c.pop()
+
+	// the memory is expanded to cover both the source and the destination areas
+	if !c.allocateMemory(srcOffset, length) || !c.allocateMemory(dstOffset, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		dst, src := dstOffset.Uint64(), srcOffset.Uint64()
+		copy(c.memory[dst:dst+size], c.memory[src:src+size])
+	}
+}
+
 func opCallDataCopy(c *state) {
 	memOffset := c.pop()
 	dataOffset := c.pop()
@@ -965,6 +1033,16 @@ func opJumpi(c *state) {
 func opJumpDest(c *state) {
 }
 
+func opPush0(c *state) {
+	if !c.config.Shanghai {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetUint64(0)
+}
+
 func opPush(n int) instruction {
 	return func(c *state) {
 		ins := c.code
@@ -106,6 +106,23 @@ type mockHostForInstructions struct {
 	nonce       uint64
 	code        []byte
 	callxResult *runtime.ExecutionResult
+	transient   map[types.Address]map[types.Hash]types.Hash
+}
+
+func (m *mockHostForInstructions) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.transient[addr][key]
+}
+
+func (m *mockHostForInstructions) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	if m.transient == nil {
+		m.transient = map[types.Address]map[types.Hash]types.Hash{}
+	}
+
+	if m.transient[addr] == nil {
+		m.transient[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.transient[addr][key] = value
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -124,6 +141,157 @@ var (
 	addr1 = types.StringToAddress("1")
 )
 
+func TestMCopy(t *testing.T) {
+	// the vectors of EIP-5656
+	seq := make([]byte, 32)
+	for i := range seq {
+		seq[i] = byte(i)
+	}
+
+	tests := []struct {
+		name     string
+		dst      int64
+		src      int64
+		length   int64
+		memory   []byte
+		expected []byte
+		gasUsed  uint64
+	}{
+		{
+			name:     "should copy the second word to the first one",
+			dst:      0,
+			src:      32,
+			length:   32,
+			memory:   append(make([]byte, 32), seq...),
+			expected: append(append([]byte{}, seq...), seq...),
+			gasUsed:  3,
+		},
+		{
+			name:     "should copy a word onto itself",
+			dst:      0,
+			src:      0,
+			length:   32,
+			memory:   append([]byte{}, seq...),
+			expected: append([]byte{}, seq...),
+			gasUsed:  3,
+		},
+		{
+			name:     "should copy the overlapping area forwards",
+			dst:      0,
+			src:      1,
+			length:   8,
+			memory:   append([]byte{}, seq...),
+			expected: append([]byte{1, 2, 3, 4, 5, 6, 7, 8, 8}, seq[9:]...),
+			gasUsed:  3,
+		},
+		{
+			name:     "should copy the overlapping area backwards",
+			dst:      1,
+			src:      0,
+			length:   8,
+			memory:   append([]byte{}, seq...),
+			expected: append([]byte{0, 0, 1, 2, 3, 4, 5, 6, 7}, seq[9:]...),
+			gasUsed:  3,
+		},
+		{
+			name:     "should not expand the memory with zero length",
+			dst:      0x10000,
+			src:      0x10000,
+			length:   0,
+			memory:   []byte{},
+			expected: []byte{},
+			gasUsed:  0,
+		},
+		{
+			name:     "should expand the memory to cover the destination",
+			dst:      32,
+			src:      0,
+			length:   32,
+			memory:   append([]byte{}, seq...),
+			expected: append(append([]byte{}, seq...), seq...),
+			gasUsed:  6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.config = &chain.ForksInTime{Cancun: true}
+			s.gas = 1000
+			s.memory = tt.memory
+			s.lastGasCost = 3 * uint64(len(tt.memory)/32)
+
+			s.push(big.NewInt(tt.length))
+			s.push(big.NewInt(tt.src))
+			s.push(big.NewInt(tt.dst))
+
+			opMCopy(s)
+
+			assert.NoError(t, s.err)
+			assert.Equal(t, tt.expected, s.memory)
+			assert.Equal(t, 1000-tt.gasUsed, s.gas)
+		})
+	}
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Run("should store and load the transient value", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForInstructions{}
+
+		s.push(big.NewInt(0x2a)) // value
+		s.push(big.NewInt(0x01)) // key
+		opTstore(s)
+
+		assert.NoError(t, s.err)
+
+		s.push(big.NewInt(0x01))
+		opTload(s)
+
+		assert.Equal(t, big.NewInt(0x2a), s.pop())
+
+		s.push(big.NewInt(0x02))
+		opTload(s)
+
+		assert.Equal(t, uint64(0), s.pop().Uint64())
+	})
+
+	t.Run("should fail to store in static call", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.host = &mockHostForInstructions{}
+
+		s.push(big.NewInt(0x2a))
+		s.push(big.NewInt(0x01))
+		opTstore(s)
+
+		assert.Equal(t, errWriteProtection, s.err)
+	})
+
+	t.Run("should fail before Cancun", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForInstructions{}
+
+		s.push(big.NewInt(0x01))
+		opTload(s)
+
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	type state struct {
 		gas    uint64
@@ -23,5 +23,10 @@ func TestOpcodesString(t *testing.T) {
 	assert(DUP1, "DUP1")
 	assert(DUP16, "DUP16")
 
+	assert(TLOAD, "TLOAD")
+	assert(TSTORE, "TSTORE")
+	assert(MCOPY, "MCOPY")
+	assert(PUSH0, "PUSH0")
+
 	assert(OpCode(0xA5), "")
 }
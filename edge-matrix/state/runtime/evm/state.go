@@ -101,7 +101,8 @@ func (c *state) reset() {
 	}
 
 	c.stack = c.stack[:0]
-	c.tmp = c.tmp[:0]
+	// drop the scratch buffer, the released state must not keep the data of its last execution
+	c.tmp = nil
 	c.ret = c.ret[:0]
 	c.code = c.code[:0]
 	// c.returnData = c.returnData[:0]
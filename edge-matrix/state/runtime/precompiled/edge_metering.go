@@ -0,0 +1,29 @@
+package precompiled
+
+import (
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/state/runtime"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// edgeMetering records the usage commitments of the edge calls,
+// the commitment is kept in the telegram input and only checked to decode
+type edgeMetering struct{}
+
+func (c *edgeMetering) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	return 0
+}
+
+func (c *edgeMetering) run(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+	commitment := &metering.Commitment{}
+	if err := commitment.UnmarshalRLP(input); err != nil {
+		return abiBoolFalse, runtime.ErrInvalidInputData
+	}
+
+	if commitment.Count == 0 {
+		return abiBoolFalse, runtime.ErrInvalidInputData
+	}
+
+	return abiBoolTrue, nil
+}
@@ -0,0 +1,30 @@
+package precompiled
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/state/runtime"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EdgeMeteringPrecompile(t *testing.T) {
+	contract := &edgeMetering{}
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := contract.run([]byte{0x1}, types.Address{}, nil)
+		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+	})
+	t.Run("Empty commitment", func(t *testing.T) {
+		_, err := contract.run((&metering.Commitment{}).MarshalRLP(), types.Address{}, nil)
+		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+	})
+	t.Run("Correct commitment", func(t *testing.T) {
+		commitment := &metering.Commitment{Provider: types.Address{0x1}, Root: types.Hash{0x2}, Count: 3}
+
+		res, err := contract.run(commitment.MarshalRLP(), types.Address{0x3}, nil)
+		require.NoError(t, err)
+		require.Equal(t, abiBoolTrue, res)
+	})
+}
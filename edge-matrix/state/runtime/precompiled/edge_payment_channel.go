@@ -0,0 +1,186 @@
+package precompiled
+
+import (
+	"math/big"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/state/runtime"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// edgePaymentChannel keeps the payment channels of the edge calls in its storage,
+// holding their deposits in its balance
+type edgePaymentChannel struct{}
+
+// gas charges the storage and the signature recovery of the action.
+// The invalid input is not charged, as the failing action consumes all the gas of the telegram
+func (c *edgePaymentChannel) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	action := &channel.Action{}
+	if err := action.UnmarshalRLP(input); err != nil {
+		return 0
+	}
+
+	return action.Gas()
+}
+
+// run is not used, as the channel actions are run with the value of the telegram
+func (c *edgePaymentChannel) run(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+	return abiBoolFalse, runtime.ErrInvalidInputData
+}
+
+func (c *edgePaymentChannel) runPayable(
+	contract *runtime.Contract,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) ([]byte, error) {
+	ctx := host.GetTxContext()
+
+	// the channel IDs are derived from the nonces of the telegrams, so only the telegrams call the precompile
+	if contract.Caller != ctx.Origin {
+		return abiBoolFalse, runtime.ErrUnauthorizedCaller
+	}
+
+	action := &channel.Action{}
+	if err := action.UnmarshalRLP(contract.Input); err != nil {
+		return abiBoolFalse, runtime.ErrInvalidInputData
+	}
+
+	if err := action.Validate(); err != nil {
+		return abiBoolFalse, runtime.ErrInvalidInputData
+	}
+
+	value := contract.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	// only the open and top up actions deposit the value
+	if value.Sign() != 0 && action.Type != channel.ActionOpen && action.Type != channel.ActionTopUp {
+		return abiBoolFalse, runtime.ErrInvalidInputData
+	}
+
+	number := uint64(ctx.Number)
+	caller := contract.Caller
+
+	if action.Type == channel.ActionOpen {
+		if value.Sign() == 0 || action.Provider == caller {
+			return abiBoolFalse, runtime.ErrInvalidInputData
+		}
+
+		if action.Expiration <= number {
+			return abiBoolFalse, channel.ErrChannelExpired
+		}
+
+		// the nonce is incremented before the telegram runs
+		id := channel.ID(caller, action.Provider, host.GetNonce(caller)-1)
+		if readChannel(id, host) != nil {
+			return abiBoolFalse, channel.ErrChannelExists
+		}
+
+		writeChannel(&channel.Channel{
+			ID:         id,
+			Sender:     caller,
+			Provider:   action.Provider,
+			Deposit:    new(big.Int).Set(value),
+			Paid:       big.NewInt(0),
+			Expiration: action.Expiration,
+		}, host, config)
+
+		return id.Bytes(), nil
+	}
+
+	ch := readChannel(action.ChannelID, host)
+	if ch == nil {
+		return abiBoolFalse, channel.ErrUnknownChannel
+	}
+
+	if ch.Closed {
+		return abiBoolFalse, channel.ErrChannelClosed
+	}
+
+	chainID := uint64(ctx.ChainID)
+
+	switch action.Type {
+	case channel.ActionTopUp:
+		if caller != ch.Sender {
+			return abiBoolFalse, runtime.ErrUnauthorizedCaller
+		}
+
+		ch.Deposit.Add(ch.Deposit, value)
+
+		if action.Expiration > ch.Expiration {
+			ch.Expiration = action.Expiration
+		}
+	case channel.ActionRedeem:
+		if caller != ch.Provider {
+			return abiBoolFalse, runtime.ErrUnauthorizedCaller
+		}
+
+		if err := redeemVoucher(ch, action.Voucher, chainID, host); err != nil {
+			return abiBoolFalse, err
+		}
+	case channel.ActionClose:
+		switch caller {
+		case ch.Provider:
+			if action.Voucher != nil {
+				if err := redeemVoucher(ch, action.Voucher, chainID, host); err != nil {
+					return abiBoolFalse, err
+				}
+			}
+		case ch.Sender:
+			if number <= ch.Expiration {
+				return abiBoolFalse, channel.ErrChannelNotExpired
+			}
+		default:
+			return abiBoolFalse, runtime.ErrUnauthorizedCaller
+		}
+
+		if err := host.Transfer(contracts.EdgePaymentChannelPrecompile, ch.Sender, ch.Unpaid()); err != nil {
+			return abiBoolFalse, err
+		}
+
+		ch.Closed = true
+	}
+
+	writeChannel(ch, host, config)
+
+	return abiBoolTrue, nil
+}
+
+// redeemVoucher pays the provider the amount of the voucher not paid yet
+func redeemVoucher(ch *channel.Channel, voucher *channel.Voucher, chainID uint64, host runtime.Host) error {
+	if signer, err := voucher.Signer(chainID); err != nil || signer != ch.Sender {
+		return channel.ErrInvalidVoucherSig
+	}
+
+	if voucher.Amount.Cmp(ch.Deposit) > 0 {
+		return channel.ErrInsufficientDeposit
+	}
+
+	if voucher.Amount.Cmp(ch.Paid) <= 0 {
+		return channel.ErrStaleVoucher
+	}
+
+	amount := new(big.Int).Sub(voucher.Amount, ch.Paid)
+	if err := host.Transfer(contracts.EdgePaymentChannelPrecompile, ch.Provider, amount); err != nil {
+		return err
+	}
+
+	ch.Paid = new(big.Int).Set(voucher.Amount)
+
+	return nil
+}
+
+func readChannel(id types.Hash, host runtime.Host) *channel.Channel {
+	return channel.ReadChannel(id, func(key types.Hash) types.Hash {
+		return host.GetStorage(contracts.EdgePaymentChannelPrecompile, key)
+	})
+}
+
+func writeChannel(ch *channel.Channel, host runtime.Host, config *chain.ForksInTime) {
+	for _, slot := range ch.Slots() {
+		host.SetStorage(contracts.EdgePaymentChannelPrecompile, slot.Key, slot.Value, config)
+	}
+}
@@ -0,0 +1,231 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/application/channel"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/state/runtime"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 2
+
+// channelHost is the dummy host keeping the storage, the nonces and the block number
+type channelHost struct {
+	*dummyHost
+	storage map[types.Hash]types.Hash
+	nonces  map[types.Address]uint64
+	origin  types.Address
+	number  int64
+}
+
+func newChannelHost() *channelHost {
+	return &channelHost{
+		dummyHost: newDummyHost(),
+		storage:   map[types.Hash]types.Hash{},
+		nonces:    map[types.Address]uint64{},
+	}
+}
+
+func (h *channelHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return h.storage[key]
+}
+
+func (h *channelHost) SetStorage(
+	addr types.Address,
+	key types.Hash,
+	value types.Hash,
+	config *chain.ForksInTime,
+) runtime.StorageStatus {
+	h.storage[key] = value
+
+	return runtime.StorageModified
+}
+
+func (h *channelHost) GetNonce(addr types.Address) uint64 {
+	return h.nonces[addr]
+}
+
+func (h *channelHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Origin: h.origin, Number: h.number, ChainID: testChainID}
+}
+
+// send runs the action as the telegram of the caller with the value,
+// transferred to the precompile before it runs as by the executor
+func (h *channelHost) send(caller types.Address, action *channel.Action, value int64) ([]byte, error) {
+	h.origin = caller
+	h.nonces[caller]++
+
+	if value > 0 {
+		if err := h.Transfer(caller, contracts.EdgePaymentChannelPrecompile, big.NewInt(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	contract := &runtime.Contract{
+		Caller: caller,
+		Input:  action.MarshalRLP(),
+		Value:  big.NewInt(value),
+	}
+
+	return (&edgePaymentChannel{}).runPayable(contract, h, &chain.ForksInTime{})
+}
+
+func Test_EdgePaymentChannelPrecompile(t *testing.T) {
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	var (
+		sender   = crypto.PubKeyToAddress(&senderKey.PublicKey)
+		provider = types.Address{0x2}
+	)
+
+	voucher := func(id types.Hash, amount int64) *channel.Voucher {
+		v := &channel.Voucher{ChannelID: id, Amount: big.NewInt(amount)}
+		require.NoError(t, v.Sign(senderKey, testChainID))
+
+		return v
+	}
+
+	open := func(t *testing.T, h *channelHost) types.Hash {
+		t.Helper()
+
+		h.AddBalance(sender, big.NewInt(1000))
+
+		res, err := h.send(sender, &channel.Action{Type: channel.ActionOpen, Provider: provider, Expiration: 10}, 100)
+		require.NoError(t, err)
+
+		id := types.BytesToHash(res)
+		require.Equal(t, channel.ID(sender, provider, 0), id)
+
+		return id
+	}
+
+	t.Run("Invalid input", func(t *testing.T) {
+		h := newChannelHost()
+		h.origin = sender
+
+		_, err := (&edgePaymentChannel{}).runPayable(&runtime.Contract{Caller: sender, Input: []byte{0x1}}, h, nil)
+		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+
+		_, err = h.send(sender, &channel.Action{Type: channel.ActionOpen, Provider: provider, Expiration: 10}, 0)
+		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+	})
+	t.Run("Caller not the origin", func(t *testing.T) {
+		h := newChannelHost()
+		h.origin = provider
+
+		input := (&channel.Action{Type: channel.ActionOpen, Provider: provider, Expiration: 10}).MarshalRLP()
+
+		_, err := (&edgePaymentChannel{}).runPayable(&runtime.Contract{Caller: sender, Input: input}, h, nil)
+		require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+	})
+	t.Run("Open and top up", func(t *testing.T) {
+		h := newChannelHost()
+		id := open(t, h)
+
+		_, err := h.send(sender, &channel.Action{Type: channel.ActionTopUp, ChannelID: id, Expiration: 20}, 50)
+		require.NoError(t, err)
+
+		expected := &channel.Channel{
+			ID:         id,
+			Sender:     sender,
+			Provider:   provider,
+			Deposit:    big.NewInt(150),
+			Paid:       big.NewInt(0),
+			Expiration: 20,
+		}
+		require.Equal(t, expected.MarshalRLP(), readChannel(id, h).MarshalRLP())
+		require.Equal(t, big.NewInt(150), h.GetBalance(contracts.EdgePaymentChannelPrecompile))
+
+		_, err = h.send(provider, &channel.Action{Type: channel.ActionTopUp, ChannelID: id}, 0)
+		require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+	})
+	t.Run("Redeem", func(t *testing.T) {
+		h := newChannelHost()
+		id := open(t, h)
+
+		redeem := func(v *channel.Voucher) error {
+			_, err := h.send(provider, &channel.Action{Type: channel.ActionRedeem, ChannelID: id, Voucher: v}, 0)
+
+			return err
+		}
+
+		require.NoError(t, redeem(voucher(id, 30)))
+		require.NoError(t, redeem(voucher(id, 70)))
+		require.Equal(t, big.NewInt(70), h.GetBalance(provider))
+		require.Equal(t, big.NewInt(70), readChannel(id, h).Paid)
+
+		require.ErrorIs(t, redeem(voucher(id, 70)), channel.ErrStaleVoucher)
+		require.ErrorIs(t, redeem(voucher(id, 101)), channel.ErrInsufficientDeposit)
+
+		forged := voucher(id, 90)
+		forged.Amount = big.NewInt(100)
+		require.ErrorIs(t, redeem(forged), channel.ErrInvalidVoucherSig)
+
+		_, err := h.send(sender, &channel.Action{Type: channel.ActionRedeem, ChannelID: id, Voucher: voucher(id, 90)}, 0)
+		require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+	})
+	t.Run("Provider closes", func(t *testing.T) {
+		h := newChannelHost()
+		id := open(t, h)
+
+		_, err := h.send(provider, &channel.Action{Type: channel.ActionClose, ChannelID: id, Voucher: voucher(id, 40)}, 0)
+		require.NoError(t, err)
+
+		require.Equal(t, big.NewInt(40), h.GetBalance(provider))
+		require.Equal(t, big.NewInt(960), h.GetBalance(sender))
+		require.True(t, readChannel(id, h).Closed)
+
+		_, err = h.send(sender, &channel.Action{Type: channel.ActionTopUp, ChannelID: id}, 10)
+		require.ErrorIs(t, err, channel.ErrChannelClosed)
+	})
+	t.Run("Sender closes after expiration", func(t *testing.T) {
+		h := newChannelHost()
+		id := open(t, h)
+
+		closeAction := &channel.Action{Type: channel.ActionClose, ChannelID: id}
+
+		h.number = 10
+		_, err := h.send(sender, closeAction, 0)
+		require.ErrorIs(t, err, channel.ErrChannelNotExpired)
+
+		h.number = 11
+		_, err = h.send(sender, closeAction, 0)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1000), h.GetBalance(sender))
+	})
+}
+
+func Test_EdgePaymentChannelPrecompile_Gas(t *testing.T) {
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	id := types.StringToHash("0x1")
+
+	v := &channel.Voucher{ChannelID: id, Amount: big.NewInt(10)}
+	require.NoError(t, v.Sign(key, testChainID))
+
+	cases := []struct {
+		name   string
+		action *channel.Action
+		gas    uint64
+	}{
+		{"open", &channel.Action{Type: channel.ActionOpen, Provider: types.Address{0x2}, Expiration: 10}, 800 + 6*20000},
+		{"top up", &channel.Action{Type: channel.ActionTopUp, ChannelID: id}, 6 * (800 + 5000)},
+		{"redeem", &channel.Action{Type: channel.ActionRedeem, ChannelID: id, Voucher: v}, 6*(800+5000) + 3000},
+		{"close", &channel.Action{Type: channel.ActionClose, ChannelID: id}, 6 * (800 + 5000)},
+		{"close with voucher", &channel.Action{Type: channel.ActionClose, ChannelID: id, Voucher: v}, 6*(800+5000) + 3000},
+	}
+
+	for _, c := range cases {
+		require.Equal(t, c.gas, (&edgePaymentChannel{}).gas(c.action.MarshalRLP(), &chain.ForksInTime{}), c.name)
+	}
+
+	require.Zero(t, (&edgePaymentChannel{}).gas([]byte{0x1}, &chain.ForksInTime{}))
+}
@@ -83,6 +83,14 @@ func (d dummyHost) SetStorage(addr types.Address, key types.Hash, value types.Ha
 	panic("not implemented")
 }
 
+func (d dummyHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	panic("not implemented")
+}
+
+func (d dummyHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	panic("not implemented")
+}
+
 func (d dummyHost) GetBalance(addr types.Address) *big.Int {
 	balance, exists := d.balances[addr]
 	if !exists {
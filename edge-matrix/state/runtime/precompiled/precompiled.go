@@ -25,6 +25,13 @@ type contract interface {
 	run(input []byte, caller types.Address, host runtime.Host) ([]byte, error)
 }
 
+// payableContract is the contract receiving the value of the call,
+// transferred to the contract address before it runs
+type payableContract interface {
+	contract
+	runPayable(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) ([]byte, error)
+}
+
 // Precompiled is the runtime for the precompiled contracts
 type Precompiled struct {
 	buf       []byte
@@ -66,6 +73,12 @@ func (p *Precompiled) setupContracts() {
 	// EdgeCall precompile
 	p.register(contracts.EdgeCallPrecompile.String(), &edgeCall{})
 
+	// edgeMetering precompile
+	p.register(contracts.EdgeMeteringPrecompile.String(), &edgeMetering{})
+
+	// edgePaymentChannel precompile
+	p.register(contracts.EdgePaymentChannelPrecompile.String(), &edgePaymentChannel{})
+
 	// edgeRtcSubject precompile
 	p.register(contracts.EdgeRtcSubjectPrecompile.String(), &edgeRtcSubject{})
 
@@ -136,7 +149,17 @@ func (p *Precompiled) Run(c *runtime.Contract, host runtime.Host, config *chain.
 	}
 
 	c.Gas = c.Gas - gasCost
-	returnValue, err := contract.run(c.Input, c.Caller, host)
+
+	var (
+		returnValue []byte
+		err         error
+	)
+
+	if payable, ok := contract.(payableContract); ok {
+		returnValue, err = payable.runPayable(c, host, config)
+	} else {
+		returnValue, err = contract.run(c.Input, c.Caller, host)
+	}
 
 	result := &runtime.ExecutionResult{
 		ReturnValue: returnValue,
@@ -26,6 +26,10 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// transientIndex is the prefix of the EIP-1153 transient storage in the trie,
+	// which is reverted with the snapshots and cleared after each transaction
+	transientIndex = types.BytesToHash([]byte{4}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -452,6 +456,37 @@ func (txn *Txn) SubRefund(gas uint64) {
 	txn.txn.Insert(refundIndex, refund)
 }
 
+// Transient storage
+
+func transientKey(addr types.Address, key types.Hash) []byte {
+	k := make([]byte, 0, len(transientIndex)+types.AddressLength+types.HashLength)
+	k = append(k, transientIndex...)
+	k = append(k, addr.Bytes()...)
+
+	return append(k, key.Bytes()...)
+}
+
+// GetTransientState returns the transient storage value of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	data, exists := txn.txn.Get(transientKey(addr, key))
+	if !exists {
+		return types.ZeroHash
+	}
+
+	//nolint:forcetypeassert
+	return data.(types.Hash)
+}
+
+// SetTransientState sets the transient storage value of the address
+func (txn *Txn) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	txn.txn.Insert(transientKey(addr, key), value)
+}
+
+// ClearTransientStates removes the transient storage of all the addresses
+func (txn *Txn) ClearTransientStates() {
+	txn.txn.DeletePrefix(transientIndex)
+}
+
 func (txn *Txn) Logs() []*types.Log {
 	data, exists := txn.txn.Get(logIndex)
 	if !exists {
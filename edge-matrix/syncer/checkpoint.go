@@ -0,0 +1,235 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// CheckpointSync syncs the chain up to the latest checkpoint of the best peer without
+// executing the blocks. It downloads the state snapshot at the checkpoint from the peer,
+// then writes the headers up to the checkpoint, each verified by the committed seals
+// of the validator quorum, and finally checks the checkpoint header is the one
+// whose state root has been downloaded.
+// The blocks after the checkpoint are synced by Sync as usual.
+// It returns immediately if the local chain is less than a checkpoint interval behind
+func (s *syncer) CheckpointSync() error {
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		checkpoint, err := s.readPendingCheckpoint()
+		if err != nil {
+			return err
+		}
+
+		latest := s.blockchain.Header()
+
+		// pick one best peer
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil || (checkpoint != nil && bestPeer.Number < checkpoint.Number) {
+			// Empty skipList map if there are no best peers
+			skipList = make(map[peer.ID]bool)
+
+			// Wait for a new event to arrive
+			if _, ok := <-s.newStatusCh; !ok {
+				return errSyncerClosed
+			}
+
+			continue
+		}
+
+		if checkpoint == nil {
+			// there is no interrupted checkpoint sync, start new one
+			number := bestPeer.Number - bestPeer.Number%s.checkpointInterval
+
+			if !s.hasState(latest.StateRoot) {
+				// the headers have been written without the state, any height above them works
+				number = bestPeer.Number
+			} else if number < latest.Number+s.checkpointInterval {
+				// local chain is close to the peer, the remaining blocks are synced by bulk sync
+				return nil
+			}
+
+			if number <= latest.Number {
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			if checkpoint, err = s.syncCheckpointState(bestPeer.ID, number); err != nil {
+				s.logger.Warn("failed to sync checkpoint state with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+		}
+
+		if err := s.syncCheckpointHeaders(bestPeer.ID, checkpoint); err != nil {
+			s.logger.Warn("failed to sync checkpoint headers with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+			if errors.Is(err, errCheckpointMismatch) {
+				// the checkpoint is not on the sealed chain, start over from the new one
+				s.writePendingCheckpoint(nil)
+			}
+
+			skipList[bestPeer.ID] = true
+
+			continue
+		}
+
+		s.logger.Info("checkpoint sync completed", "number", checkpoint.Number, "hash", checkpoint.Hash)
+
+		return nil
+	}
+}
+
+// syncCheckpointState fetches the header at the checkpoint height and
+// downloads the state snapshot at its state root from the peer.
+// The header is saved as pending checkpoint once the snapshot is verified to be complete
+func (s *syncer) syncCheckpointState(peerID peer.ID, number uint64) (*types.Header, error) {
+	headerCh, err := s.syncPeerClient.GetHeaders(peerID, number, number, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, ok := <-headerCh
+
+	if closeErr := s.syncPeerClient.CloseStream(peerID); closeErr != nil {
+		s.logger.Error("Failed to close stream: ", closeErr)
+	}
+
+	if !ok || checkpoint.Number != number {
+		return nil, fmt.Errorf("failed to get checkpoint header %d", number)
+	}
+
+	s.logger.Info("downloading checkpoint state", "number", number, "root", checkpoint.StateRoot)
+
+	s.checkpointProgression.StartProgression(s.blockchain.Header().Number, s.blockchain.SubscribeEvents())
+	s.checkpointProgression.UpdateHighestProgression(number)
+
+	defer s.checkpointProgression.StopProgression()
+
+	chunkCh, err := s.syncPeerClient.GetStateSnapshot(peerID, checkpoint.StateRoot, s.blockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	var pulledStates uint64
+
+	for chunk := range chunkCh {
+		// trie nodes and codes are content addressed,
+		// so they are verified by storing under their own hash
+		itrie.WriteStateNodes(s.stateStorage, chunk.Nodes)
+		itrie.WriteStateCodes(s.stateStorage, chunk.Codes)
+
+		pulledStates += uint64(len(chunk.Nodes) + len(chunk.Codes))
+		s.checkpointProgression.UpdatePulledStates(pulledStates)
+	}
+
+	// make sure all the nodes below the root has been received
+	if err := itrie.VerifyState(s.stateStorage, checkpoint.StateRoot); err != nil {
+		return nil, fmt.Errorf("incomplete state snapshot: %w", err)
+	}
+
+	s.writePendingCheckpoint(checkpoint)
+
+	return checkpoint, nil
+}
+
+// syncCheckpointHeaders verifies and writes the headers from local latest to the checkpoint
+func (s *syncer) syncCheckpointHeaders(peerID peer.ID, checkpoint *types.Header) error {
+	localLatest := s.blockchain.Header().Number
+
+	if localLatest < checkpoint.Number {
+		s.checkpointProgression.StartProgression(localLatest, s.blockchain.SubscribeEvents())
+		s.checkpointProgression.UpdateHighestProgression(checkpoint.Number)
+
+		defer s.checkpointProgression.StopProgression()
+
+		headerCh, err := s.syncPeerClient.GetHeaders(peerID, localLatest+1, checkpoint.Number, s.blockTimeout)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if err := s.syncPeerClient.CloseStream(peerID); err != nil {
+				s.logger.Error("Failed to close stream: ", err)
+			}
+		}()
+
+		for header := range headerCh {
+			if header.Number == checkpoint.Number && header.Hash != checkpoint.Hash {
+				return errCheckpointMismatch
+			}
+
+			if err := s.blockchain.VerifyFinalizedHeader(header); err != nil {
+				return fmt.Errorf("unable to verify header, %w", err)
+			}
+
+			if err := s.blockchain.WriteHeader(header, syncerName); err != nil {
+				return fmt.Errorf("failed to write header while checkpoint syncing: %w", err)
+			}
+		}
+	}
+
+	latest := s.blockchain.Header()
+	if latest.Number < checkpoint.Number {
+		return errIncompleteHeaders
+	}
+
+	if latest.Number == checkpoint.Number && latest.Hash != checkpoint.Hash {
+		return errCheckpointMismatch
+	}
+
+	s.writePendingCheckpoint(nil)
+
+	return nil
+}
+
+// hasState returns whether the root node of the given state exists in the local storage
+func (s *syncer) hasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok := s.stateStorage.Get(root.Bytes())
+
+	return ok
+}
+
+// readPendingCheckpoint returns the checkpoint whose state has been downloaded
+// but whose headers are not written yet, e.g. because the node has been stopped
+func (s *syncer) readPendingCheckpoint() (*types.Header, error) {
+	data, ok := s.stateStorage.Get(checkpointSyncKey)
+	if !ok || len(data) == 0 {
+		return nil, nil
+	}
+
+	header := &types.Header{}
+	if err := header.UnmarshalRLP(data); err != nil {
+		return nil, fmt.Errorf("failed to decode pending checkpoint: %w", err)
+	}
+
+	return header, nil
+}
+
+// writePendingCheckpoint saves the pending checkpoint, or clears it if nil is given
+func (s *syncer) writePendingCheckpoint(header *types.Header) {
+	if header == nil {
+		s.stateStorage.Put(checkpointSyncKey, []byte{})
+
+		return
+	}
+
+	s.stateStorage.Put(checkpointSyncKey, header.MarshalRLP())
+}
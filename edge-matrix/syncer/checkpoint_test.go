@@ -0,0 +1,237 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// createMockHeaders returns the linked headers from 1 to num,
+// the last one refers the given state root
+func createMockHeaders(num int, stateRoot types.Hash) []*types.Header {
+	headers := make([]*types.Header, num)
+	parentHash := types.ZeroHash
+
+	for i := 0; i < num; i++ {
+		headers[i] = &types.Header{
+			Number:     uint64(i + 1),
+			ParentHash: parentHash,
+			StateRoot:  types.EmptyRootHash,
+		}
+
+		if i == num-1 {
+			headers[i].StateRoot = stateRoot
+		}
+
+		headers[i].ComputeHash()
+		parentHash = headers[i].Hash
+	}
+
+	return headers
+}
+
+func headersToCh(headers []*types.Header) <-chan *types.Header {
+	ch := make(chan *types.Header)
+
+	go func() {
+		for _, h := range headers {
+			ch <- h
+		}
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+func stateToCh(t *testing.T, storage itrie.Storage, root types.Hash) <-chan *StateSnapshotChunk {
+	t.Helper()
+
+	chunk := &StateSnapshotChunk{}
+
+	assert.NoError(t, itrie.TraverseState(storage, root, func(data []byte) error {
+		chunk.Nodes = append(chunk.Nodes, data)
+
+		return nil
+	}, func(_ types.Hash, code []byte) error {
+		chunk.Codes = append(chunk.Codes, code)
+
+		return nil
+	}))
+
+	ch := make(chan *StateSnapshotChunk, 1)
+	ch <- chunk
+
+	close(ch)
+
+	return ch
+}
+
+func TestCheckpointSync(t *testing.T) {
+	t.Parallel()
+
+	source := itrie.NewMemoryStorage()
+	stateRoot := createMockState(t, source, 100)
+	headers := createMockHeaders(20, stateRoot)
+
+	tests := []struct {
+		name string
+
+		// peer
+		peerNumber   uint64
+		peerHeaders  []*types.Header
+		peerHasState bool
+
+		// results
+		writtenHeaders int
+		hasPending     bool
+	}{
+		{
+			name:           "should sync the state and headers up to the checkpoint",
+			peerNumber:     25,
+			peerHeaders:    headers,
+			peerHasState:   true,
+			writtenHeaders: 20,
+			hasPending:     false,
+		},
+		{
+			name:           "should skip if the local chain is close to the peer",
+			peerNumber:     9,
+			peerHeaders:    headers,
+			peerHasState:   true,
+			writtenHeaders: 0,
+			hasPending:     false,
+		},
+		{
+			name:           "should keep the pending checkpoint if the peer doesn't send all headers",
+			peerNumber:     25,
+			peerHeaders:    append(append([]*types.Header{}, headers[:10]...), headers[19]),
+			peerHasState:   true,
+			writtenHeaders: 10,
+			hasPending:     true,
+		},
+		{
+			name:           "should not write any header if the state snapshot is incomplete",
+			peerNumber:     25,
+			peerHeaders:    headers,
+			peerHasState:   false,
+			writtenHeaders: 0,
+			hasPending:     false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				lock           sync.Mutex
+				writtenHeaders = make([]*types.Header, 0)
+				latest         = &types.Header{Number: 0, StateRoot: types.EmptyRootHash}
+			)
+
+			headerMap := make(map[uint64]*types.Header)
+			for _, h := range test.peerHeaders {
+				headerMap[h.Number] = h
+			}
+
+			syncer := NewTestSyncer(
+				nil,
+				&mockBlockchain{
+					headerHandler: func() *types.Header {
+						lock.Lock()
+						defer lock.Unlock()
+
+						return latest
+					},
+					verifyFinalizedHeaderHandler: func(h *types.Header) error {
+						if h.ParentHash != latest.Hash {
+							return errors.New("invalid parent hash")
+						}
+
+						return nil
+					},
+					writeHeaderHandler: func(h *types.Header) error {
+						lock.Lock()
+						defer lock.Unlock()
+
+						writtenHeaders = append(writtenHeaders, h)
+						latest = h
+
+						return nil
+					},
+				},
+				time.Second,
+				&mockSyncPeerClient{
+					getHeadersHandler: func(_ peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Header, error) {
+						result := make([]*types.Header, 0)
+
+						for i := from; i <= to; i++ {
+							h, ok := headerMap[i]
+							if !ok {
+								break
+							}
+
+							result = append(result, h)
+						}
+
+						return headersToCh(result), nil
+					},
+					getStateSnapshotHandler: func(_ peer.ID, root types.Hash, _ time.Duration) (<-chan *StateSnapshotChunk, error) {
+						if !test.peerHasState {
+							return nil, errors.New("state not found")
+						}
+
+						return stateToCh(t, source, root), nil
+					},
+				},
+				&mockProgression{},
+			)
+
+			syncer.checkpointInterval = 10
+			syncer.peerMap.Put(&NoForkPeer{
+				ID:       peer.ID("A"),
+				Number:   test.peerNumber,
+				Distance: big.NewInt(0),
+			})
+
+			errCh := make(chan error, 1)
+
+			go func() {
+				errCh <- syncer.CheckpointSync()
+			}()
+
+			if test.writtenHeaders == 20 || test.peerNumber < 10 {
+				assert.NoError(t, <-errCh)
+			} else {
+				// the only peer has been skipped, stop waiting for the new one
+				time.Sleep(100 * time.Millisecond)
+				close(syncer.newStatusCh)
+
+				assert.ErrorIs(t, <-errCh, errSyncerClosed)
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			assert.Len(t, writtenHeaders, test.writtenHeaders)
+
+			checkpoint, err := syncer.readPendingCheckpoint()
+			assert.NoError(t, err)
+			assert.Equal(t, test.hasPending, checkpoint != nil)
+
+			if test.peerHasState && test.peerNumber >= 10 {
+				assert.NoError(t, itrie.VerifyState(syncer.stateStorage, stateRoot))
+			}
+		})
+	}
+}
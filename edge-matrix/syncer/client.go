@@ -336,6 +336,138 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns a stream of headers in the given range
+func (m *syncPeerClient) GetHeaders(
+	peerID peer.ID,
+	from, to uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := clt.GetHeaders(ctx, &proto.GetHeadersRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to open GetHeaders stream: %w", err)
+	}
+
+	// input channel
+	streamHeaderCh, streamErrorCh := headerStreamToChannel(stream)
+
+	// output channel
+	headerCh := make(chan *types.Header, 1)
+
+	go func() {
+		defer cancel()
+		defer close(headerCh)
+
+		for {
+			select {
+			case header, ok := <-streamHeaderCh:
+				if !ok {
+					return
+				}
+
+				headerCh <- header
+			case err := <-streamErrorCh:
+				m.logger.Error("failed to get header from gRPC stream", "peer", peerID, "err", err)
+
+				return
+			case <-time.After(timeoutPerHeader):
+				m.logger.Warn("header doesn't reach within timeout", "timeout", timeoutPerHeader)
+
+				return
+			}
+		}
+	}()
+
+	return headerCh, nil
+}
+
+// GetStateSnapshot returns a stream of state snapshot chunks below the given state root
+func (m *syncPeerClient) GetStateSnapshot(
+	peerID peer.ID,
+	root types.Hash,
+	timeoutPerChunk time.Duration,
+) (<-chan *StateSnapshotChunk, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync peer client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := clt.GetStateSnapshot(ctx, &proto.GetStateSnapshotRequest{
+		Root: root.Bytes(),
+	})
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("failed to open GetStateSnapshot stream: %w", err)
+	}
+
+	// input channel
+	streamChunkCh, streamErrorCh := stateSnapshotStreamToChannel(stream)
+
+	// output channel
+	chunkCh := make(chan *StateSnapshotChunk, 1)
+
+	go func() {
+		defer cancel()
+		defer close(chunkCh)
+
+		for {
+			select {
+			case chunk, ok := <-streamChunkCh:
+				if !ok {
+					return
+				}
+
+				chunkCh <- chunk
+			case err := <-streamErrorCh:
+				m.logger.Error("failed to get state snapshot from gRPC stream", "peer", peerID, "err", err)
+
+				return
+			case <-time.After(timeoutPerChunk):
+				m.logger.Warn("state snapshot doesn't reach within timeout", "timeout", timeoutPerChunk)
+
+				return
+			}
+		}
+	}()
+
+	return chunkCh, nil
+}
+
+// GetTelegramProof fetches the telegram and its receipt with the Merkle proofs from the peer.
+// The proofs are not verified here
+func (m *syncPeerClient) GetTelegramProof(peerID peer.ID, hash types.Hash) (*types.TelegramProof, error) {
+	clt, err := m.newSyncPeerClient(peerID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForStatus)
+	defer cancel()
+
+	protoProof, err := clt.GetTelegramProof(timeoutCtx, &proto.GetTelegramProofRequest{
+		Hash: hash.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromProtoTelegramProof(protoProof)
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -358,6 +490,29 @@ func fromProto(protoBlock *proto.Block) (*types.Block, error) {
 	return block, nil
 }
 
+// fromProtoTelegramProof gets telegram proof from gRPC response data
+func fromProtoTelegramProof(protoProof *proto.TelegramProof) (*types.TelegramProof, error) {
+	tele := &types.Telegram{}
+	if err := tele.UnmarshalRLP(protoProof.Telegram); err != nil {
+		return nil, err
+	}
+
+	receipt := &types.Receipt{}
+	if err := receipt.UnmarshalRLP(protoProof.Receipt); err != nil {
+		return nil, err
+	}
+
+	return &types.TelegramProof{
+		BlockHash:     types.BytesToHash(protoProof.BlockHash),
+		BlockNumber:   protoProof.BlockNumber,
+		Index:         protoProof.Index,
+		Telegram:      tele,
+		Receipt:       receipt,
+		TelegramProof: protoProof.TelegramProof,
+		ReceiptProof:  protoProof.ReceiptProof,
+	}, nil
+}
+
 func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.Block, <-chan error) {
 	blockCh := make(chan *types.Block)
 	errorCh := make(chan error, 1)
@@ -390,3 +545,67 @@ func blockStreamToChannel(stream proto.SyncPeer_GetBlocksClient) (<-chan *types.
 
 	return blockCh, errorCh
 }
+
+func headerStreamToChannel(stream proto.SyncPeer_GetHeadersClient) (<-chan *types.Header, <-chan error) {
+	headerCh := make(chan *types.Header)
+	errorCh := make(chan error, 1)
+
+	go func() {
+		defer close(headerCh)
+
+		for {
+			protoHeader, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				errorCh <- err
+
+				break
+			}
+
+			header := &types.Header{}
+			if err := header.UnmarshalRLP(protoHeader.Header); err != nil {
+				errorCh <- err
+
+				break
+			}
+
+			headerCh <- header
+		}
+	}()
+
+	return headerCh, errorCh
+}
+
+func stateSnapshotStreamToChannel(
+	stream proto.SyncPeer_GetStateSnapshotClient,
+) (<-chan *StateSnapshotChunk, <-chan error) {
+	chunkCh := make(chan *StateSnapshotChunk)
+	errorCh := make(chan error, 1)
+
+	go func() {
+		defer close(chunkCh)
+
+		for {
+			protoChunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				errorCh <- err
+
+				break
+			}
+
+			chunkCh <- &StateSnapshotChunk{
+				Nodes: protoChunk.Nodes,
+				Codes: protoChunk.Codes,
+			}
+		}
+	}()
+
+	return chunkCh, errorCh
+}
@@ -0,0 +1,178 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/types/buildroot"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var (
+	errNoProofPeer       = errors.New("no peer could provide valid proof")
+	errUnknownProofBlock = errors.New("block of proof is not synced yet")
+)
+
+// HeaderSync syncs headers with the best peer until callback returns true.
+// The headers are verified by the committed seals of the validators and written
+// without the block bodies, which is used by the light client
+func (s *syncer) HeaderSync(callback func(*types.Header) bool) error {
+	localLatest := s.blockchain.Header().Number
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		// Wait for a new event to arrive
+		if _, ok := <-s.newStatusCh; !ok {
+			return nil
+		}
+
+		// fetch local latest header
+		if header := s.blockchain.Header(); header != nil {
+			localLatest = header.Number
+		}
+
+		// pick one best peer
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil {
+			// Empty skipList map if there are no best peers
+			skipList = make(map[peer.ID]bool)
+
+			continue
+		}
+
+		// if the bestPeer does not have a new header continue
+		if bestPeer.Number <= localLatest {
+			continue
+		}
+
+		// fetch headers from the peer
+		lastNumber, shouldTerminate, err := s.headerSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		if err != nil {
+			s.logger.Warn("failed to complete header sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+		}
+
+		if lastNumber < bestPeer.Number {
+			skipList[bestPeer.ID] = true
+
+			// continue to next peer
+			continue
+		}
+
+		if shouldTerminate {
+			break
+		}
+	}
+
+	return nil
+}
+
+// headerSyncWithPeer syncs headers up to the given height with a given peer
+func (s *syncer) headerSyncWithPeer(
+	peerID peer.ID,
+	to uint64,
+	newHeaderCallback func(*types.Header) bool,
+) (uint64, bool, error) {
+	localLatest := s.blockchain.Header().Number
+	shouldTerminate := false
+
+	headerCh, err := s.syncPeerClient.GetHeaders(peerID, localLatest+1, to, s.blockTimeout)
+	if err != nil {
+		return 0, false, err
+	}
+
+	defer func() {
+		err := s.syncPeerClient.CloseStream(peerID)
+		if err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	var lastReceivedNumber uint64
+
+	for {
+		select {
+		case header, ok := <-headerCh:
+			if !ok {
+				return lastReceivedNumber, shouldTerminate, nil
+			}
+
+			// safe check
+			if header.Number == 0 {
+				continue
+			}
+
+			if err := s.blockchain.VerifyFinalizedHeader(header); err != nil {
+				return lastReceivedNumber, false, fmt.Errorf("unable to verify header, %w", err)
+			}
+
+			if err := s.blockchain.WriteHeader(header, syncerName); err != nil {
+				return lastReceivedNumber, false, fmt.Errorf("failed to write header while header syncing: %w", err)
+			}
+
+			shouldTerminate = newHeaderCallback(header)
+
+			lastReceivedNumber = header.Number
+		case <-time.After(s.blockTimeout):
+			return lastReceivedNumber, shouldTerminate, errTimeout
+		}
+	}
+}
+
+// GetTelegramProof fetches the telegram with the given hash and its receipt from the peers,
+// and verifies their Merkle proofs against the local header.
+// It tries the next peer if a peer fails to provide the valid proof
+func (s *syncer) GetTelegramProof(hash types.Hash) (*types.TelegramProof, error) {
+	skipList := make(map[peer.ID]bool)
+
+	for {
+		bestPeer := s.peerMap.BestPeer(skipList)
+		if bestPeer == nil {
+			return nil, errNoProofPeer
+		}
+
+		skipList[bestPeer.ID] = true
+
+		proof, err := s.syncPeerClient.GetTelegramProof(bestPeer.ID, hash)
+		if err != nil {
+			s.logger.Debug("failed to get telegram proof from peer", "peer ID", bestPeer.ID, "hash", hash, "error", err)
+
+			continue
+		}
+
+		if err := s.verifyTelegramProof(hash, proof); err != nil {
+			if errors.Is(err, errUnknownProofBlock) {
+				return nil, err
+			}
+
+			s.logger.Warn("peer sent invalid telegram proof", "peer ID", bestPeer.ID, "hash", hash, "error", err)
+
+			continue
+		}
+
+		return proof, nil
+	}
+}
+
+// verifyTelegramProof checks the proof is the one of the telegram with the given hash
+// and included in the block of the local chain
+func (s *syncer) verifyTelegramProof(hash types.Hash, proof *types.TelegramProof) error {
+	header, ok := s.blockchain.GetHeaderByNumber(proof.BlockNumber)
+	if !ok {
+		return errUnknownProofBlock
+	}
+
+	if proof.Telegram.Hash != hash {
+		return buildroot.ErrTelegramMismatch
+	}
+
+	if err := buildroot.VerifyTelegramProof(header, proof); err != nil {
+		return err
+	}
+
+	// the context fields are not covered by the proof
+	proof.Receipt.TxHash = hash
+
+	return nil
+}
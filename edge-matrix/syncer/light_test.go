@@ -0,0 +1,247 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/syncer/proto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/types/buildroot"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// createMockTelegramBlock returns a block with the given number of telegrams and their receipts
+func createMockTelegramBlock(number uint64, num int) (*types.Block, []*types.Receipt) {
+	telegrams := make([]*types.Telegram, num)
+	receipts := make([]*types.Receipt, num)
+
+	for i := 0; i < num; i++ {
+		to := types.StringToAddress("1")
+
+		telegrams[i] = (&types.Telegram{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(int64(i)),
+			V:        big.NewInt(27),
+			R:        big.NewInt(1),
+			S:        big.NewInt(2),
+		}).ComputeHash()
+
+		receipts[i] = &types.Receipt{
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			TxHash:            telegrams[i].Hash,
+		}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	header := (&types.Header{
+		Number:       number,
+		TeleRoot:     buildroot.CalculateTelegramsRoot(telegrams),
+		ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+	}).ComputeHash()
+
+	return &types.Block{Header: header, Telegrams: telegrams}, receipts
+}
+
+func newTelegramProofBlockchain(block *types.Block, receipts []*types.Receipt) *mockBlockchain {
+	return &mockBlockchain{
+		headerHandler: newSimpleHeaderHandler(block.Number()),
+		getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+			if u != block.Number() {
+				return nil, false
+			}
+
+			return block.Header, true
+		},
+		getBlockByHashHandler: func(h types.Hash, _ bool) (*types.Block, bool) {
+			return block, h == block.Hash()
+		},
+		getReceiptsByHashHandler: func(h types.Hash) ([]*types.Receipt, error) {
+			return receipts, nil
+		},
+		readTxLookupHandler: func(h types.Hash) (types.Hash, bool) {
+			for _, tele := range block.Telegrams {
+				if tele.Hash == h {
+					return block.Hash(), true
+				}
+			}
+
+			return types.ZeroHash, false
+		},
+	}
+}
+
+func TestHeaderSync(t *testing.T) {
+	t.Parallel()
+
+	headers := createMockHeaders(10, types.EmptyRootHash)
+
+	var (
+		writtenHeaders = make([]*types.Header, 0, len(headers))
+		latest         = &types.Header{Number: 0}
+		progression    = &mockProgression{}
+	)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				return latest
+			},
+			verifyFinalizedHeaderHandler: func(h *types.Header) error {
+				if h.ParentHash != latest.Hash {
+					return errors.New("invalid parent hash")
+				}
+
+				return nil
+			},
+			writeHeaderHandler: func(h *types.Header) error {
+				writtenHeaders = append(writtenHeaders, h)
+				latest = h
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getHeadersHandler: func(_ peer.ID, from, to uint64, _ time.Duration) (<-chan *types.Header, error) {
+				return headersToCh(headers[from-1 : to]), nil
+			},
+		},
+		progression,
+	)
+
+	syncer.peerMap.Put(&NoForkPeer{
+		ID:       peer.ID("A"),
+		Number:   10,
+		Distance: big.NewInt(0),
+	})
+
+	go func() {
+		syncer.newStatusCh <- struct{}{}
+	}()
+
+	err := syncer.HeaderSync(func(h *types.Header) bool {
+		return h.Number >= 10
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, headers, writtenHeaders)
+}
+
+func TestGetTelegramProof(t *testing.T) {
+	t.Parallel()
+
+	block, receipts := createMockTelegramBlock(5, 10)
+	target := block.Telegrams[3]
+
+	service := &syncPeerService{
+		blockchain: newTelegramProofBlockchain(block, receipts),
+	}
+
+	validProof := func() *types.TelegramProof {
+		protoProof, err := service.GetTelegramProof(context.Background(), &proto.GetTelegramProofRequest{
+			Hash: target.Hash.Bytes(),
+		})
+		assert.NoError(t, err)
+
+		proof, err := fromProtoTelegramProof(protoProof)
+		assert.NoError(t, err)
+
+		return proof
+	}
+
+	tests := []struct {
+		name string
+		// proofs returned by peers
+		proofs map[peer.ID]func() (*types.TelegramProof, error)
+		err    error
+	}{
+		{
+			name: "should return the verified proof",
+			proofs: map[peer.ID]func() (*types.TelegramProof, error){
+				peer.ID("A"): func() (*types.TelegramProof, error) {
+					return validProof(), nil
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "should try next peer if the proof is invalid",
+			proofs: map[peer.ID]func() (*types.TelegramProof, error){
+				peer.ID("A"): func() (*types.TelegramProof, error) {
+					proof := validProof()
+					proof.Receipt.CumulativeGasUsed++
+
+					return proof, nil
+				},
+				peer.ID("B"): func() (*types.TelegramProof, error) {
+					return validProof(), nil
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "should return error if no peer has valid proof",
+			proofs: map[peer.ID]func() (*types.TelegramProof, error){
+				peer.ID("A"): func() (*types.TelegramProof, error) {
+					return nil, ErrTeleNotFound
+				},
+				peer.ID("B"): func() (*types.TelegramProof, error) {
+					proof := validProof()
+					proof.Telegram = block.Telegrams[4]
+
+					return proof, nil
+				},
+			},
+			err: errNoProofPeer,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := NewTestSyncer(
+				nil,
+				newTelegramProofBlockchain(block, receipts),
+				time.Second,
+				&mockSyncPeerClient{
+					getTelegramProofHandler: func(id peer.ID, _ types.Hash) (*types.TelegramProof, error) {
+						return test.proofs[id]()
+					},
+				},
+				&mockProgression{},
+			)
+
+			distance := int64(0)
+			for id := range test.proofs {
+				syncer.peerMap.Put(&NoForkPeer{
+					ID:       id,
+					Number:   block.Number(),
+					Distance: big.NewInt(distance),
+				})
+
+				distance++
+			}
+
+			proof, err := syncer.GetTelegramProof(target.Hash)
+			assert.ErrorIs(t, err, test.err)
+
+			if test.err == nil {
+				assert.Equal(t, target.Hash, proof.Telegram.Hash)
+				assert.Equal(t, target.Hash, proof.Receipt.TxHash)
+				assert.Equal(t, block.Hash(), proof.BlockHash)
+				assert.Equal(t, uint64(3), proof.Index)
+			}
+		})
+	}
+}
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: syncer/proto/syncer.proto
 
 package proto
 
@@ -12,6 +16,7 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
 // SyncPeerClient is the client API for SyncPeer service.
@@ -22,6 +27,12 @@ type SyncPeerClient interface {
 	GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error)
 	// Returns server's status
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error)
+	// Returns stream of state trie nodes and codes below the specified state root
+	GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (SyncPeer_GetStateSnapshotClient, error)
+	// Returns the telegram and its receipt with the Merkle proofs against the block header
+	GetTelegramProof(ctx context.Context, in *GetTelegramProofRequest, opts ...grpc.CallOption) (*TelegramProof, error)
 }
 
 type syncPeerClient struct {
@@ -33,7 +44,7 @@ func NewSyncPeerClient(cc grpc.ClientConnInterface) SyncPeerClient {
 }
 
 func (c *syncPeerClient) GetBlocks(ctx context.Context, in *GetBlocksRequest, opts ...grpc.CallOption) (SyncPeer_GetBlocksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SyncPeer_serviceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
+	stream, err := c.cc.NewStream(ctx, &SyncPeer_ServiceDesc.Streams[0], "/v1.SyncPeer/GetBlocks", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +84,79 @@ func (c *syncPeerClient) GetStatus(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *syncPeerClient) GetHeaders(ctx context.Context, in *GetHeadersRequest, opts ...grpc.CallOption) (SyncPeer_GetHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SyncPeer_ServiceDesc.Streams[1], "/v1.SyncPeer/GetHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetHeadersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetHeadersClient interface {
+	Recv() (*Header, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetHeadersClient) Recv() (*Header, error) {
+	m := new(Header)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *syncPeerClient) GetStateSnapshot(ctx context.Context, in *GetStateSnapshotRequest, opts ...grpc.CallOption) (SyncPeer_GetStateSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SyncPeer_ServiceDesc.Streams[2], "/v1.SyncPeer/GetStateSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &syncPeerGetStateSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SyncPeer_GetStateSnapshotClient interface {
+	Recv() (*StateSnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type syncPeerGetStateSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *syncPeerGetStateSnapshotClient) Recv() (*StateSnapshotChunk, error) {
+	m := new(StateSnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *syncPeerClient) GetTelegramProof(ctx context.Context, in *GetTelegramProofRequest, opts ...grpc.CallOption) (*TelegramProof, error) {
+	out := new(TelegramProof)
+	err := c.cc.Invoke(ctx, "/v1.SyncPeer/GetTelegramProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SyncPeerServer is the server API for SyncPeer service.
 // All implementations must embed UnimplementedSyncPeerServer
 // for forward compatibility
@@ -81,6 +165,12 @@ type SyncPeerServer interface {
 	GetBlocks(*GetBlocksRequest, SyncPeer_GetBlocksServer) error
 	// Returns server's status
 	GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error)
+	// Returns stream of headers in the specified range
+	GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error
+	// Returns stream of state trie nodes and codes below the specified state root
+	GetStateSnapshot(*GetStateSnapshotRequest, SyncPeer_GetStateSnapshotServer) error
+	// Returns the telegram and its receipt with the Merkle proofs against the block header
+	GetTelegramProof(context.Context, *GetTelegramProofRequest) (*TelegramProof, error)
 	mustEmbedUnimplementedSyncPeerServer()
 }
 
@@ -94,6 +184,15 @@ func (UnimplementedSyncPeerServer) GetBlocks(*GetBlocksRequest, SyncPeer_GetBloc
 func (UnimplementedSyncPeerServer) GetStatus(context.Context, *emptypb.Empty) (*SyncPeerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedSyncPeerServer) GetHeaders(*GetHeadersRequest, SyncPeer_GetHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (UnimplementedSyncPeerServer) GetStateSnapshot(*GetStateSnapshotRequest, SyncPeer_GetStateSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetStateSnapshot not implemented")
+}
+func (UnimplementedSyncPeerServer) GetTelegramProof(context.Context, *GetTelegramProofRequest) (*TelegramProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTelegramProof not implemented")
+}
 func (UnimplementedSyncPeerServer) mustEmbedUnimplementedSyncPeerServer() {}
 
 // UnsafeSyncPeerServer may be embedded to opt out of forward compatibility for this service.
@@ -104,7 +203,7 @@ type UnsafeSyncPeerServer interface {
 }
 
 func RegisterSyncPeerServer(s grpc.ServiceRegistrar, srv SyncPeerServer) {
-	s.RegisterService(&_SyncPeer_serviceDesc, srv)
+	s.RegisterService(&SyncPeer_ServiceDesc, srv)
 }
 
 func _SyncPeer_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
@@ -146,7 +245,70 @@ func _SyncPeer_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SyncPeer_serviceDesc = grpc.ServiceDesc{
+func _SyncPeer_GetHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetHeadersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetHeaders(m, &syncPeerGetHeadersServer{stream})
+}
+
+type SyncPeer_GetHeadersServer interface {
+	Send(*Header) error
+	grpc.ServerStream
+}
+
+type syncPeerGetHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetHeadersServer) Send(m *Header) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SyncPeer_GetStateSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStateSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SyncPeerServer).GetStateSnapshot(m, &syncPeerGetStateSnapshotServer{stream})
+}
+
+type SyncPeer_GetStateSnapshotServer interface {
+	Send(*StateSnapshotChunk) error
+	grpc.ServerStream
+}
+
+type syncPeerGetStateSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *syncPeerGetStateSnapshotServer) Send(m *StateSnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SyncPeer_GetTelegramProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTelegramProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncPeerServer).GetTelegramProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SyncPeer/GetTelegramProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncPeerServer).GetTelegramProof(ctx, req.(*GetTelegramProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SyncPeer_ServiceDesc is the grpc.ServiceDesc for SyncPeer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SyncPeer_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "v1.SyncPeer",
 	HandlerType: (*SyncPeerServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -154,6 +316,10 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _SyncPeer_GetStatus_Handler,
 		},
+		{
+			MethodName: "GetTelegramProof",
+			Handler:    _SyncPeer_GetTelegramProof_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -161,6 +327,16 @@ var _SyncPeer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _SyncPeer_GetBlocks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetHeaders",
+			Handler:       _SyncPeer_GetHeaders_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetStateSnapshot",
+			Handler:       _SyncPeer_GetStateSnapshot_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "syncer/proto/syncer.proto",
 }
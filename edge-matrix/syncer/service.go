@@ -5,30 +5,43 @@ import (
 	"errors"
 
 	"github.com/emc-protocol/edge-matrix/network/grpc"
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
 	"github.com/emc-protocol/edge-matrix/syncer/proto"
 	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/emc-protocol/edge-matrix/types/buildroot"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// stateSnapshotChunkSize is the maximum number of trie nodes and codes in one chunk
+	stateSnapshotChunkSize = 512
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound  = errors.New("block not found")
+	ErrHeaderNotFound = errors.New("header not found")
+	ErrInvalidRange   = errors.New("invalid header range")
+	ErrTeleNotFound   = errors.New("telegram not found")
 )
 
 type syncPeerService struct {
 	proto.UnimplementedSyncPeerServer
 
-	blockchain Blockchain       // reference to the blockchain module
-	network    Network          // reference to the network module
-	stream     *grpc.GrpcStream // reference to the grpc stream
+	blockchain   Blockchain       // reference to the blockchain module
+	network      Network          // reference to the network module
+	stateStorage itrie.Storage    // reference to the state storage
+	stream       *grpc.GrpcStream // reference to the grpc stream
 }
 
 func NewSyncPeerService(
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 ) SyncPeerService {
 	return &syncPeerService{
-		blockchain: blockchain,
-		network:    network,
+		blockchain:   blockchain,
+		network:      network,
+		stateStorage: stateStorage,
 	}
 }
 
@@ -89,6 +102,145 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetHeaders is a gRPC endpoint to return the headers in the specific range via stream
+func (s *syncPeerService) GetHeaders(
+	req *proto.GetHeadersRequest,
+	stream proto.SyncPeer_GetHeadersServer,
+) error {
+	if req.From > req.To {
+		return ErrInvalidRange
+	}
+
+	to := req.To
+	if latest := s.blockchain.Header().Number; to > latest {
+		to = latest
+	}
+
+	for i := req.From; i <= to; i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return ErrHeaderNotFound
+		}
+
+		// if client closes stream, context.Canceled is given
+		if err := stream.Send(&proto.Header{
+			Header: header.MarshalRLP(),
+		}); err != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetStateSnapshot is a gRPC endpoint to return all the trie nodes and codes
+// of the state at the given root via stream
+func (s *syncPeerService) GetStateSnapshot(
+	req *proto.GetStateSnapshotRequest,
+	stream proto.SyncPeer_GetStateSnapshotServer,
+) error {
+	if s.stateStorage == nil {
+		return itrie.ErrMissingNode
+	}
+
+	chunk := &proto.StateSnapshotChunk{}
+
+	flush := func(force bool) error {
+		if !force && len(chunk.Nodes)+len(chunk.Codes) < stateSnapshotChunkSize {
+			return nil
+		}
+
+		if len(chunk.Nodes)+len(chunk.Codes) == 0 {
+			return nil
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+
+		chunk = &proto.StateSnapshotChunk{}
+
+		return nil
+	}
+
+	if err := itrie.TraverseState(
+		s.stateStorage,
+		types.BytesToHash(req.Root),
+		func(data []byte) error {
+			chunk.Nodes = append(chunk.Nodes, data)
+
+			return flush(false)
+		},
+		func(_ types.Hash, code []byte) error {
+			chunk.Codes = append(chunk.Codes, code)
+
+			return flush(false)
+		},
+	); err != nil {
+		return err
+	}
+
+	return flush(true)
+}
+
+// GetTelegramProof is a gRPC endpoint to return the telegram and its receipt
+// with the Merkle proofs against the block header
+func (s *syncPeerService) GetTelegramProof(
+	ctx context.Context,
+	req *proto.GetTelegramProofRequest,
+) (*proto.TelegramProof, error) {
+	hash := types.BytesToHash(req.Hash)
+
+	blockHash, ok := s.blockchain.ReadTxLookup(hash)
+	if !ok {
+		return nil, ErrTeleNotFound
+	}
+
+	block, ok := s.blockchain.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	receipts, err := s.blockchain.GetReceiptsByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+
+	for i, tele := range block.Telegrams {
+		if tele.Hash == hash {
+			index = i
+
+			break
+		}
+	}
+
+	if index == -1 || len(receipts) != len(block.Telegrams) {
+		return nil, ErrTeleNotFound
+	}
+
+	teleProof, err := buildroot.CalculateTelegramsProof(block.Telegrams, index)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptProof, err := buildroot.CalculateReceiptsProof(receipts, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.TelegramProof{
+		BlockHash:     blockHash.Bytes(),
+		BlockNumber:   block.Number(),
+		Index:         uint64(index),
+		Telegram:      block.Telegrams[index].MarshalRLP(),
+		TelegramProof: teleProof,
+		Receipt:       receipts[index].MarshalRLP(),
+		ReceiptProof:  receiptProof,
+	}, nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
@@ -7,6 +7,7 @@ import (
 	"net"
 	"testing"
 
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
 	"github.com/emc-protocol/edge-matrix/syncer/proto"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/stretchr/testify/assert"
@@ -154,3 +155,149 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func Test_syncPeerService_GetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(10)
+
+	tests := []struct {
+		name            string
+		from            uint64
+		to              uint64
+		latest          uint64
+		blocks          []*types.Block
+		receivedHeaders []*types.Block
+		err             error
+	}{
+		{
+			name:            "should send the headers in the range",
+			from:            3,
+			to:              6,
+			latest:          10,
+			blocks:          blocks,
+			receivedHeaders: blocks[2:6], // from 3 to 6
+			err:             io.EOF,
+		},
+		{
+			name:            "should send the headers to the latest",
+			from:            5,
+			to:              20,
+			latest:          10,
+			blocks:          blocks,
+			receivedHeaders: blocks[4:], // from 5
+			err:             io.EOF,
+		},
+		{
+			name:            "should return ErrHeaderNotFound",
+			from:            5,
+			to:              10,
+			latest:          10,
+			blocks:          blocks[:8],
+			receivedHeaders: blocks[4:8], // from 5
+			err:             ErrHeaderNotFound,
+		},
+		{
+			name:            "should return ErrInvalidRange",
+			from:            5,
+			to:              4,
+			latest:          10,
+			blocks:          blocks,
+			receivedHeaders: nil,
+			err:             ErrInvalidRange,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			headerMap := make(map[uint64]*types.Header)
+
+			for _, b := range test.blocks {
+				headerMap[b.Number()] = b.Header
+			}
+
+			service := &syncPeerService{
+				blockchain: &mockBlockchain{
+					headerHandler: newSimpleHeaderHandler(test.latest),
+					getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+						header, ok := headerMap[u]
+
+						return header, ok
+					},
+				},
+			}
+
+			client := newMockGrpcClient(t, service)
+
+			stream, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+				From: test.from,
+				To:   test.to,
+			})
+
+			assert.NoError(t, err)
+
+			count := 0
+
+			for {
+				protoHeader, err := stream.Recv()
+				if err != nil {
+					assert.Contains(t, err.Error(), test.err.Error())
+
+					break
+				}
+
+				expected := test.receivedHeaders[count].Header.MarshalRLP()
+
+				assert.Equal(t, expected, protoHeader.Header)
+
+				count++
+			}
+
+			assert.Equal(t, len(test.receivedHeaders), count)
+		})
+	}
+}
+
+func Test_syncPeerService_GetStateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	source := itrie.NewMemoryStorage()
+	root := createMockState(t, source, 1000)
+
+	service := &syncPeerService{
+		stateStorage: source,
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	stream, err := client.GetStateSnapshot(context.Background(), &proto.GetStateSnapshotRequest{
+		Root: root.Bytes(),
+	})
+
+	assert.NoError(t, err)
+
+	target := itrie.NewMemoryStorage()
+	chunks := 0
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+
+			break
+		}
+
+		assert.LessOrEqual(t, len(chunk.Nodes)+len(chunk.Codes), stateSnapshotChunkSize)
+
+		itrie.WriteStateNodes(target, chunk.Nodes)
+		itrie.WriteStateCodes(target, chunk.Codes)
+
+		chunks++
+	}
+
+	assert.Greater(t, chunks, 1)
+	assert.NoError(t, itrie.VerifyState(target, root))
+}
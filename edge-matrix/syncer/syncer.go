@@ -7,6 +7,7 @@ import (
 
 	"github.com/emc-protocol/edge-matrix/helper/progress"
 	"github.com/emc-protocol/edge-matrix/network/event"
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -15,18 +16,30 @@ import (
 const (
 	syncerName  = "syncer"
 	syncerProto = "/syncer/0.2"
+
+	// DefaultCheckpointInterval is the interval of the block heights used as checkpoints
+	DefaultCheckpointInterval uint64 = 1000
 )
 
 var (
-	errTimeout = errors.New("timeout awaiting block from peer")
+	errTimeout            = errors.New("timeout awaiting block from peer")
+	errSyncerClosed       = errors.New("syncer has been closed")
+	errCheckpointMismatch = errors.New("synced header doesn't match checkpoint")
+	errIncompleteHeaders  = errors.New("peer didn't send all headers up to checkpoint")
+
+	// checkpointSyncKey is the key in the state storage to save the checkpoint header
+	// whose state has been downloaded but whose ancestors are not written yet
+	checkpointSyncKey = []byte("checkpoint-sync")
 )
 
 // XXX: Don't use this syncer for the consensus that may cause fork.
 // This syncer doesn't assume forks
 type syncer struct {
-	logger          hclog.Logger
-	blockchain      Blockchain
-	syncProgression Progression
+	logger                hclog.Logger
+	blockchain            Blockchain
+	stateStorage          itrie.Storage
+	syncProgression       Progression
+	checkpointProgression Progression
 
 	peerMap         *PeerMap
 	syncPeerService SyncPeerService
@@ -35,6 +48,9 @@ type syncer struct {
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
+	// Interval of the checkpoint heights
+	checkpointInterval uint64
+
 	// Channel to notify Sync that a new status arrived
 	newStatusCh chan struct{}
 }
@@ -43,17 +59,21 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
 	blockTimeout time.Duration,
 ) Syncer {
 	return &syncer{
-		logger:          logger.Named(syncerName),
-		blockchain:      blockchain,
-		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
-		syncPeerService: NewSyncPeerService(network, blockchain),
-		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
-		blockTimeout:    blockTimeout,
-		newStatusCh:     make(chan struct{}),
-		peerMap:         new(PeerMap),
+		logger:                logger.Named(syncerName),
+		blockchain:            blockchain,
+		stateStorage:          stateStorage,
+		syncProgression:       progress.NewProgressionWrapper(progress.ChainSyncBulk),
+		checkpointProgression: progress.NewProgressionWrapper(progress.ChainSyncCheckpoint),
+		syncPeerService:       NewSyncPeerService(network, blockchain, stateStorage),
+		syncPeerClient:        NewSyncPeerClient(logger, network, blockchain),
+		blockTimeout:          blockTimeout,
+		checkpointInterval:    DefaultCheckpointInterval,
+		newStatusCh:           make(chan struct{}),
+		peerMap:               new(PeerMap),
 	}
 }
 
@@ -146,6 +166,10 @@ func (s *syncer) notifyNewStatusEvent() {
 
 // GetSyncProgression returns progression
 func (s *syncer) GetSyncProgression() *progress.Progression {
+	if progression := s.checkpointProgression.GetProgression(); progression != nil {
+		return progression
+	}
+
 	return s.syncProgression.GetProgression()
 }
 
@@ -11,6 +11,8 @@ import (
 	"github.com/emc-protocol/edge-matrix/blockchain"
 	"github.com/emc-protocol/edge-matrix/helper/progress"
 	"github.com/emc-protocol/edge-matrix/network/event"
+	"github.com/emc-protocol/edge-matrix/state"
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -20,6 +22,7 @@ import (
 type mockProgression struct {
 	startingBlock uint64
 	highestBlock  uint64
+	pulledStates  uint64
 }
 
 func (m *mockProgression) StartProgression(startingBlock uint64, subscription blockchain.Subscription) {
@@ -30,18 +33,28 @@ func (m *mockProgression) UpdateHighestProgression(highestBlock uint64) {
 	m.highestBlock = highestBlock
 }
 
+func (m *mockProgression) UpdatePulledStates(pulledStates uint64) {
+	m.pulledStates = pulledStates
+}
+
 func (m *mockProgression) GetProgression() *progress.Progression {
 	// Syncer doesn't use this method. It just exports
 	return nil
 }
 
 type mockBlockchain struct {
-	subscription                blockchain.Subscription
-	headerHandler               func() *types.Header
-	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
-	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
-	writeBlockHandler           func(*types.Block) error
-	writeFullBlockHandler       func(*types.FullBlock) error
+	subscription                 blockchain.Subscription
+	headerHandler                func() *types.Header
+	getBlockByNumberHandler      func(uint64, bool) (*types.Block, bool)
+	getHeaderByNumberHandler     func(uint64) (*types.Header, bool)
+	getBlockByHashHandler        func(types.Hash, bool) (*types.Block, bool)
+	getReceiptsByHashHandler     func(types.Hash) ([]*types.Receipt, error)
+	readTxLookupHandler          func(types.Hash) (types.Hash, bool)
+	verifyFinalizedBlockHandler  func(*types.Block) (*types.FullBlock, error)
+	verifyFinalizedHeaderHandler func(*types.Header) error
+	writeBlockHandler            func(*types.Block) error
+	writeFullBlockHandler        func(*types.FullBlock) error
+	writeHeaderHandler           func(*types.Header) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -56,10 +69,30 @@ func (m *mockBlockchain) GetBlockByNumber(number uint64, full bool) (*types.Bloc
 	return m.getBlockByNumberHandler(number, full)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
+func (m *mockBlockchain) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
+	return m.getBlockByHashHandler(hash, full)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
+func (m *mockBlockchain) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
+	return m.readTxLookupHandler(hash)
+}
+
 func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock, error) {
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedHeader(h *types.Header) error {
+	return m.verifyFinalizedHeaderHandler(h)
+}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
@@ -68,6 +101,10 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) WriteHeader(h *types.Header, s string) error {
+	return m.writeHeaderHandler(h)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -90,6 +127,9 @@ type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Header, error)
+	getStateSnapshotHandler               func(peer.ID, types.Hash, time.Duration) (<-chan *StateSnapshotChunk, error)
+	getTelegramProofHandler               func(peer.ID, types.Hash) (*types.TelegramProof, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -120,6 +160,26 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetHeaders(
+	id peer.ID,
+	from, to uint64,
+	timeoutPerHeader time.Duration,
+) (<-chan *types.Header, error) {
+	return m.getHeadersHandler(id, from, to, timeoutPerHeader)
+}
+
+func (m *mockSyncPeerClient) GetStateSnapshot(
+	id peer.ID,
+	root types.Hash,
+	timeoutPerChunk time.Duration,
+) (<-chan *StateSnapshotChunk, error) {
+	return m.getStateSnapshotHandler(id, root, timeoutPerChunk)
+}
+
+func (m *mockSyncPeerClient) GetTelegramProof(id peer.ID, hash types.Hash) (*types.TelegramProof, error) {
+	return m.getTelegramProofHandler(id, hash)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -165,14 +225,17 @@ func NewTestSyncer(
 	mockProgression Progression,
 ) *syncer {
 	return &syncer{
-		logger:          hclog.NewNullLogger(),
-		blockchain:      blockchain,
-		syncProgression: mockProgression,
-		syncPeerService: &mockSyncPeerService{},
-		syncPeerClient:  mockSyncPeerClient,
-		blockTimeout:    blockTimeout,
-		newStatusCh:     make(chan struct{}),
-		peerMap:         new(PeerMap),
+		logger:                hclog.NewNullLogger(),
+		blockchain:            blockchain,
+		stateStorage:          itrie.NewMemoryStorage(),
+		syncProgression:       mockProgression,
+		checkpointProgression: mockProgression,
+		syncPeerService:       &mockSyncPeerService{},
+		syncPeerClient:        mockSyncPeerClient,
+		blockTimeout:          blockTimeout,
+		checkpointInterval:    DefaultCheckpointInterval,
+		newStatusCh:           make(chan struct{}),
+		peerMap:               new(PeerMap),
 	}
 }
 
@@ -478,6 +541,26 @@ func blocksToCh(blocks []*types.Block, delay time.Duration) <-chan *types.Block
 	return ch
 }
 
+func createMockState(t *testing.T, storage itrie.Storage, accounts int) types.Hash {
+	t.Helper()
+
+	snap := itrie.NewState(storage).NewSnapshot()
+	txn := state.NewTxn(snap)
+
+	for i := 0; i < accounts; i++ {
+		addr := types.StringToAddress(fmt.Sprintf("%d", i+1))
+
+		txn.SetBalance(addr, big.NewInt(int64(i+1)))
+		txn.SetState(addr, types.StringToHash("1"), types.StringToHash(fmt.Sprintf("%d", i+1)))
+	}
+
+	txn.SetCode(types.StringToAddress("1"), []byte{0x60, 0x00, 0x60, 0x00})
+
+	_, root := snap.Commit(txn.Commit(false))
+
+	return types.BytesToHash(root)
+}
+
 func createMockBlocks(num int) []*types.Block {
 	blocks := make([]*types.Block, num)
 	for i := 0; i < num; i++ {
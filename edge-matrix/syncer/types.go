@@ -23,12 +23,24 @@ type Blockchain interface {
 	Header() *types.Header
 	// GetBlockByNumber returns block by number
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	// GetBlockByHash returns block by hash
+	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
+	// GetReceiptsByHash returns the receipts of the block by hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	// ReadTxLookup returns the hash of the block including the telegram
+	ReadTxLookup(types.Hash) (types.Hash, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
+	// VerifyFinalizedHeader verifies finalized header without block body
+	VerifyFinalizedHeader(header *types.Header) error
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// WriteHeader writes a given header to chain without block body
+	WriteHeader(*types.Header, string) error
 }
 
 type Network interface {
@@ -66,6 +78,13 @@ type Syncer interface {
 	HasSyncPeer() bool
 	// Sync starts routine to sync blocks
 	Sync(func(*types.FullBlock) bool) error
+	// CheckpointSync syncs state snapshot and headers up to the latest checkpoint
+	CheckpointSync() error
+	// HeaderSync starts routine to sync headers without block bodies
+	HeaderSync(func(*types.Header) bool) error
+	// GetTelegramProof fetches the telegram and its receipt from peers
+	// and verifies them against the local header
+	GetTelegramProof(types.Hash) (*types.TelegramProof, error)
 }
 
 type Progression interface {
@@ -73,6 +92,8 @@ type Progression interface {
 	StartProgression(startingBlock uint64, subscription blockchain.Subscription)
 	// UpdateHighestProgression updates highest block number
 	UpdateHighestProgression(highestBlock uint64)
+	// UpdatePulledStates updates number of downloaded state entries
+	UpdatePulledStates(pulledStates uint64)
 	// GetProgression returns Progression
 	GetProgression() *progress.Progression
 	// StopProgression finishes progression
@@ -97,6 +118,12 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns a stream of headers in given range
+	GetHeaders(peer.ID, uint64, uint64, time.Duration) (<-chan *types.Header, error)
+	// GetStateSnapshot returns a stream of state snapshot chunks below given state root
+	GetStateSnapshot(peer.ID, types.Hash, time.Duration) (<-chan *StateSnapshotChunk, error)
+	// GetTelegramProof fetches the telegram and its receipt with the Merkle proofs
+	GetTelegramProof(peer.ID, types.Hash) (*types.TelegramProof, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
@@ -108,3 +135,11 @@ type SyncPeerClient interface {
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
 	EnablePublishingPeerStatus()
 }
+
+// StateSnapshotChunk is a part of state snapshot sent by peer
+type StateSnapshotChunk struct {
+	// RLP encoded trie nodes
+	Nodes [][]byte
+	// Contract codes
+	Codes [][]byte
+}
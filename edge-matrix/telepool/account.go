@@ -1,6 +1,7 @@
 package telepool
 
 import (
+	"math/big"
 	"sync"
 	"sync/atomic"
 
@@ -14,6 +15,7 @@ type accountsMap struct {
 
 	count uint64
 
+	// accessed with atomics
 	maxEnqueuedLimit uint64
 }
 
@@ -22,7 +24,7 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 	a, loaded := m.LoadOrStore(addr, &account{
 		enqueued:    newAccountQueue(),
 		promoted:    newAccountQueue(),
-		maxEnqueued: m.maxEnqueuedLimit,
+		maxEnqueued: m.getMaxEnqueuedLimit(),
 		nextNonce:   nonce,
 	})
 	newAccount := a.(*account) //nolint:forcetypeassert
@@ -82,6 +84,24 @@ func (m *accountsMap) get(addr types.Address) *account {
 	return fetchedAccount
 }
 
+// getMaxEnqueuedLimit returns the maximum number of enqueued transactions of the new accounts.
+func (m *accountsMap) getMaxEnqueuedLimit() uint64 {
+	return atomic.LoadUint64(&m.maxEnqueuedLimit)
+}
+
+// setMaxEnqueuedLimit sets the maximum number of enqueued transactions of all the accounts.
+func (m *accountsMap) setMaxEnqueuedLimit(max uint64) {
+	atomic.StoreUint64(&m.maxEnqueuedLimit, max)
+
+	m.Range(func(_, value interface{}) bool {
+		if account, ok := value.(*account); ok {
+			account.setMaxEnqueued(max)
+		}
+
+		return true
+	})
+}
+
 // promoted returns the number of all promoted transactons.
 func (m *accountsMap) promoted() (total uint64) {
 	m.Range(func(key, value interface{}) bool {
@@ -154,10 +174,20 @@ type account struct {
 	// the number of consecutive blocks that don't contain account's transaction
 	skips uint64
 
-	//	maximum number of enqueued transactions
+	//	maximum number of enqueued transactions, accessed with atomics
 	maxEnqueued uint64
 }
 
+// getMaxEnqueued returns the maximum number of enqueued transactions for this account.
+func (a *account) getMaxEnqueued() uint64 {
+	return atomic.LoadUint64(&a.maxEnqueued)
+}
+
+// setMaxEnqueued sets the maximum number of enqueued transactions for this account.
+func (a *account) setMaxEnqueued(max uint64) {
+	atomic.StoreUint64(&a.maxEnqueued, max)
+}
+
 // getNonce returns the next expected nonce for this account.
 func (a *account) getNonce() uint64 {
 	return atomic.LoadUint64(&a.nextNonce)
@@ -227,7 +257,7 @@ func (a *account) enqueue(tele *types.Telegram) error {
 	a.enqueued.lock(true)
 	defer a.enqueued.unlock()
 
-	if a.enqueued.length() == a.maxEnqueued {
+	if a.enqueued.length() >= a.getMaxEnqueued() {
 		return ErrMaxEnqueuedLimitReached
 	}
 
@@ -237,7 +267,7 @@ func (a *account) enqueue(tele *types.Telegram) error {
 	}
 
 	// Check max nonce
-	if tele.Nonce >= (a.getNonce() + a.maxEnqueued) {
+	if tele.Nonce >= (a.getNonce() + a.getMaxEnqueued()) {
 		// don't signal promotion for
 		// higher nonce txs
 		return ErrNonceTooHigh
@@ -249,6 +279,74 @@ func (a *account) enqueue(tele *types.Telegram) error {
 	return nil
 }
 
+// getByNonce returns the promoted or enqueued transaction with the given nonce, if any.
+func (a *account) getByNonce(nonce uint64) *types.Telegram {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	if tx := a.promoted.getByNonce(nonce); tx != nil {
+		return tx
+	}
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	return a.enqueued.getByNonce(nonce)
+}
+
+// replace swaps the promoted or enqueued transaction of the same nonce with the given one,
+// if the gas price is bumped enough and the slots of the given one fit in the pool once the existing one is released.
+// It returns the replaced transaction and whether it is promoted,
+// or nil if the account has no transaction with the nonce.
+func (a *account) replace(
+	tele *types.Telegram,
+	priceBump uint64,
+	fits func(replaced *types.Telegram) error,
+) (*types.Telegram, bool, error) {
+	a.promoted.lock(true)
+	a.enqueued.lock(true)
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		existing := queue.getByNonce(tele.Nonce)
+		if existing == nil {
+			continue
+		}
+
+		if !isReplacement(existing, tele, priceBump) {
+			return nil, false, ErrReplacementUnderpriced
+		}
+
+		if err := fits(existing); err != nil {
+			return nil, false, err
+		}
+
+		return queue.replace(tele), queue == a.promoted, nil
+	}
+
+	return nil, false, nil
+}
+
+// isReplacement checks the new transaction pays at least priceBump percent more
+// fee cap and tip cap than the existing one of the same nonce.
+func isReplacement(existing, tele *types.Telegram, priceBump uint64) bool {
+	return isPriceBumped(existing.GetGasFeeCap(), tele.GetGasFeeCap(), priceBump) &&
+		isPriceBumped(existing.GetGasTipCap(), tele.GetGasTipCap(), priceBump)
+}
+
+// isPriceBumped checks the new price is at least priceBump percent above the existing one.
+func isPriceBumped(existing, price *big.Int, priceBump uint64) bool {
+	// threshold = existing * (100 + priceBump) / 100
+	threshold := new(big.Int).Mul(existing, new(big.Int).SetUint64(100+priceBump))
+	threshold.Div(threshold, big.NewInt(100))
+
+	return price.Cmp(threshold) >= 0 && price.Cmp(existing) > 0
+}
+
 // Promote moves eligible transactions from enqueued to promoted.
 //
 // Eligible transactions are all sequential in order of nonce
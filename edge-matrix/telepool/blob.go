@@ -0,0 +1,69 @@
+package telepool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// blobFetchTimeout is the timeout of the fetch of a blob from an edge node
+const blobFetchTimeout = 5 * time.Minute
+
+var ErrBlobsNotSupported = errors.New("blobs not supported by the node")
+
+// checkBlobs checks the blobs referenced by the edge call are complete in the store, before the edge node fetches them
+func (p *TelegramPool) checkBlobs(hashes []types.Hash) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	if p.blobStore == nil {
+		return ErrBlobsNotSupported
+	}
+
+	if len(hashes) > blob.MaxCallBlobs {
+		return blob.ErrTooManyBlobs
+	}
+
+	for _, hash := range hashes {
+		missing, err := p.blobStore.Missing(hash)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, hash)
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %d chunks of %s", blob.ErrIncompleteBlob, len(missing), hash)
+		}
+	}
+
+	return nil
+}
+
+// FetchBlob fetches the blob returned by the application of the edge node into the store,
+// resuming the fetch of the chunks missing from the store
+func (p *TelegramPool) FetchBlob(peerId string, hash types.Hash) error {
+	if p.blobStore == nil {
+		return ErrBlobsNotSupported
+	}
+
+	peerID, err := peer.Decode(peerId)
+	if err != nil {
+		return err
+	}
+
+	host, closeHost, err := p.appPeerHost(peerId)
+	if err != nil {
+		return err
+	}
+	defer closeHost()
+
+	ctx, cancel := context.WithTimeout(context.Background(), blobFetchTimeout)
+	defer cancel()
+
+	return blob.Fetch(ctx, host, peerID, hash, p.blobStore)
+}
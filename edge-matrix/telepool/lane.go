@@ -0,0 +1,228 @@
+package telepool
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+const (
+	// lane occupancy in percent the minimum gas price is adjusted towards
+	laneTargetMark = 50
+
+	// the minimum gas price changes by 1/laneMinPriceChangeDenom at most per block
+	laneMinPriceChangeDenom = 8
+
+	// DefaultPriceBump is the minimum percentage of the gas price increase
+	// for replacing the telegram of the same nonce
+	DefaultPriceBump uint64 = 10
+)
+
+// lane is the class of telegrams with the separate slot budget and minimum gas price,
+// so that a burst in one lane doesn't starve the others
+type lane int
+
+const (
+	edgeCallLane   lane = iota // telegrams to EdgeCallPrecompile
+	rtcSubjectLane             // telegrams to EdgeRtcSubjectPrecompile
+	transferLane               // all the other telegrams
+
+	numLanes
+)
+
+func (l lane) String() (s string) {
+	switch l {
+	case edgeCallLane:
+		s = "edgeCall"
+	case rtcSubjectLane:
+		s = "rtcSubject"
+	case transferLane:
+		s = "transfer"
+	}
+
+	return
+}
+
+// laneOf returns the lane the given telegram belongs to
+func laneOf(tele *types.Telegram) lane {
+	if tele.To == nil {
+		return transferLane
+	}
+
+	switch *tele.To {
+	case contracts.EdgeCallPrecompile:
+		return edgeCallLane
+	case contracts.EdgeRtcSubjectPrecompile:
+		return rtcSubjectLane
+	default:
+		return transferLane
+	}
+}
+
+// LaneConfig defines the slot budgets of the lanes.
+// Zero budget limits the lane only by the max slots of the pool
+type LaneConfig struct {
+	EdgeCallSlots   uint64
+	RtcSubjectSlots uint64
+	TransferSlots   uint64
+}
+
+func (c LaneConfig) slots(l lane) uint64 {
+	switch l {
+	case edgeCallLane:
+		return c.EdgeCallSlots
+	case rtcSubjectLane:
+		return c.RtcSubjectSlots
+	default:
+		return c.TransferSlots
+	}
+}
+
+// maxSlots returns the slot budget of the lane within the max slots of the pool
+func (c LaneConfig) maxSlots(l lane, poolMaxSlots uint64) uint64 {
+	if slots := c.slots(l); slots != 0 && slots <= poolMaxSlots {
+		return slots
+	}
+
+	return poolMaxSlots
+}
+
+// laneState keeps the occupancy and the minimum gas price of a lane
+type laneState struct {
+	// gauge for measuring lane capacity
+	gauge slotGauge
+
+	// minimum gas price of new telegrams, accessed with atomics
+	minGasPrice uint64
+}
+
+func newLaneState(maxSlots, priceLimit uint64) *laneState {
+	return &laneState{
+		gauge:       slotGauge{height: 0, max: maxSlots},
+		minGasPrice: priceLimit,
+	}
+}
+
+// getMinGasPrice returns the current minimum gas price of the lane
+func (l *laneState) getMinGasPrice() uint64 {
+	return atomic.LoadUint64(&l.minGasPrice)
+}
+
+// adjustMinGasPrice raises the minimum gas price while the lane occupancy
+// is above the target and lowers it back to the price limit otherwise,
+// like the base fee of EIP-1559
+func (l *laneState) adjustMinGasPrice(priceLimit uint64) {
+	current := l.getMinGasPrice()
+	delta := current / laneMinPriceChangeDenom
+
+	next := current
+
+	if l.gauge.read()*100 > laneTargetMark*l.gauge.limit() {
+		if delta == 0 {
+			delta = 1
+		}
+
+		next = current + delta
+	} else if current > priceLimit {
+		next = current - delta
+		if delta == 0 || next < priceLimit {
+			next = priceLimit
+		}
+	}
+
+	atomic.StoreUint64(&l.minGasPrice, next)
+}
+
+// LaneStatus is the occupancy of a pool lane
+type LaneStatus struct {
+	Name        string
+	Slots       uint64
+	MaxSlots    uint64
+	Pending     uint64
+	Queued      uint64
+	MinGasPrice uint64
+}
+
+// GetLaneStatus returns the occupancy of each lane in the priority order
+func (p *TelegramPool) GetLaneStatus() []*LaneStatus {
+	promoted, enqueued := p.accounts.allTxs(true)
+
+	statuses := make([]*LaneStatus, numLanes)
+	for l := lane(0); l < numLanes; l++ {
+		statuses[l] = &LaneStatus{
+			Name:        l.String(),
+			Slots:       p.lanes[l].gauge.read(),
+			MaxSlots:    p.lanes[l].gauge.limit(),
+			MinGasPrice: p.lanes[l].getMinGasPrice(),
+		}
+	}
+
+	for _, teles := range promoted {
+		for _, tele := range teles {
+			statuses[laneOf(tele)].Pending++
+		}
+	}
+
+	for _, teles := range enqueued {
+		for _, tele := range teles {
+			statuses[laneOf(tele)].Queued++
+		}
+	}
+
+	return statuses
+}
+
+// increaseGauge adds the slots of the telegrams to the pool and their lanes
+func (p *TelegramPool) increaseGauge(teles ...*types.Telegram) {
+	for _, tele := range teles {
+		slots := slotsRequired(tele)
+
+		p.gauge.increase(slots)
+		p.lanes[laneOf(tele)].gauge.increase(slots)
+	}
+}
+
+// decreaseGauge removes the slots of the telegrams from the pool and their lanes
+func (p *TelegramPool) decreaseGauge(teles ...*types.Telegram) {
+	for _, tele := range teles {
+		slots := slotsRequired(tele)
+
+		p.gauge.decrease(slots)
+		p.lanes[laneOf(tele)].gauge.decrease(slots)
+	}
+}
+
+// checkSlots checks the pool and the lane of the telegram have room for its slots,
+// besides the slots released by the telegram it replaces, if any
+func (p *TelegramPool) checkSlots(tele, replaced *types.Telegram) error {
+	slots := slotsRequired(tele)
+	teleLane := laneOf(tele)
+
+	var poolReleased, laneReleased uint64
+	if replaced != nil {
+		poolReleased = slotsRequired(replaced)
+
+		if laneOf(replaced) == teleLane {
+			laneReleased = poolReleased
+		}
+	}
+
+	if p.gauge.read()+slots > p.gauge.limit()+poolReleased {
+		return ErrTxPoolOverflow
+	}
+
+	if gauge := &p.lanes[teleLane].gauge; gauge.read()+slots > gauge.limit()+laneReleased {
+		return fmt.Errorf("%w: %s", ErrLaneOverflow, teleLane)
+	}
+
+	return nil
+}
+
+// adjustLanePrices updates the minimum gas prices of the lanes with their occupancy
+func (p *TelegramPool) adjustLanePrices() {
+	for _, l := range p.lanes {
+		l.adjustMinGasPrice(p.getPriceLimit())
+	}
+}
@@ -0,0 +1,85 @@
+package telepool
+
+import (
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/contracts"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaneState_AdjustMinGasPrice(t *testing.T) {
+	t.Parallel()
+
+	const priceLimit = 8
+
+	cases := []struct {
+		name     string
+		height   uint64
+		minPrice uint64
+		expected uint64
+	}{
+		{"above the target", 6, 80, 90},
+		{"above the target at a low price", 6, 1, 2},
+		{"at the target", 5, 80, 70},
+		{"below the target", 1, 80, 70},
+		{"back to the price limit", 1, 9, priceLimit},
+		{"at the price limit", 0, priceLimit, priceLimit},
+	}
+
+	for _, c := range cases {
+		l := newLaneState(10, c.minPrice)
+		l.gauge.increase(c.height)
+
+		l.adjustMinGasPrice(priceLimit)
+		assert.Equal(t, c.expected, l.getMinGasPrice(), c.name)
+	}
+}
+
+func TestLaneConfig_MaxSlots(t *testing.T) {
+	t.Parallel()
+
+	config := LaneConfig{EdgeCallSlots: 10, RtcSubjectSlots: 200}
+
+	assert.Equal(t, uint64(10), config.maxSlots(edgeCallLane, 100))
+	assert.Equal(t, uint64(100), config.maxSlots(rtcSubjectLane, 100))
+	assert.Equal(t, uint64(100), config.maxSlots(transferLane, 100))
+}
+
+func TestTelegramPool_LaneOverflow(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestPool(t, 10, LaneConfig{EdgeCallSlots: 2})
+
+	edgeCall := contracts.EdgeCallPrecompile
+	sender := types.StringToAddress("0x1")
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		require.NoError(t, addTestTele(t, pool, newTestTele(sender, nonce, 10, &edgeCall)))
+	}
+
+	// the full lane rejects the telegrams of its class only
+	err := addTestTele(t, pool, newTestTele(sender, 2, 10, &edgeCall))
+	assert.ErrorIs(t, err, ErrLaneOverflow)
+
+	other := types.StringToAddress("0x2")
+	assert.NoError(t, addTestTele(t, pool, newTestTele(other, 0, 10, nil)))
+
+	// the replacement in the full lane takes the slot of the replaced telegram
+	assert.NoError(t, addTestTele(t, pool, newTestTele(sender, 1, 11, &edgeCall)))
+	assert.Equal(t, uint64(2), pool.lanes[edgeCallLane].gauge.read())
+
+	// the replacement moving to the full lane is rejected, the replaced telegram is kept
+	err = addTestTele(t, pool, newTestTele(other, 0, 20, &edgeCall))
+	assert.ErrorIs(t, err, ErrLaneOverflow)
+
+	moved := newTestTele(other, 0, 20, &edgeCall)
+
+	_, _, err = pool.accounts.get(other).replace(moved, pool.priceBump, func(existing *types.Telegram) error {
+		return pool.checkSlots(moved, existing)
+	})
+	assert.ErrorIs(t, err, ErrLaneOverflow)
+	assert.Nil(t, pool.accounts.get(other).getByNonce(0).To)
+	assert.Equal(t, uint64(1), pool.lanes[transferLane].gauge.read())
+}
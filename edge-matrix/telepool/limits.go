@@ -0,0 +1,66 @@
+package telepool
+
+import (
+	"sync/atomic"
+)
+
+// Limits are the limits of the pool adjustable at runtime
+type Limits struct {
+	MaxSlots           uint64
+	MaxAccountEnqueued uint64
+	PriceLimit         uint64
+}
+
+// getPriceLimit returns the lowest minimum gas price of the lanes
+func (p *TelegramPool) getPriceLimit() uint64 {
+	return atomic.LoadUint64(&p.priceLimit)
+}
+
+// GetLimits returns the current limits of the pool
+func (p *TelegramPool) GetLimits() *Limits {
+	return &Limits{
+		MaxSlots:           p.gauge.limit(),
+		MaxAccountEnqueued: p.accounts.getMaxEnqueuedLimit(),
+		PriceLimit:         p.getPriceLimit(),
+	}
+}
+
+// SetLimits updates the limits of the pool, the zero limits are left unchanged.
+// The telegrams already in the pool are kept even if they exceed the new limits,
+// the new limits are applied to the incoming telegrams
+func (p *TelegramPool) SetLimits(limits *Limits) *Limits {
+	if limits.MaxSlots != 0 {
+		p.gauge.setLimit(limits.MaxSlots)
+
+		for l := lane(0); l < numLanes; l++ {
+			p.lanes[l].gauge.setLimit(p.laneConfig.maxSlots(l, limits.MaxSlots))
+		}
+	}
+
+	if limits.MaxAccountEnqueued != 0 {
+		p.accounts.setMaxEnqueuedLimit(limits.MaxAccountEnqueued)
+	}
+
+	if limits.PriceLimit != 0 {
+		atomic.StoreUint64(&p.priceLimit, limits.PriceLimit)
+
+		// the lanes below the new limit are raised at once,
+		// the ones above it are lowered block by block
+		for _, l := range p.lanes {
+			if l.getMinGasPrice() < limits.PriceLimit {
+				atomic.StoreUint64(&l.minGasPrice, limits.PriceLimit)
+			}
+		}
+	}
+
+	current := p.GetLimits()
+
+	p.logger.Info(
+		"telepool limits updated",
+		"max_slots", current.MaxSlots,
+		"max_account_enqueued", current.MaxAccountEnqueued,
+		"price_limit", current.PriceLimit,
+	)
+
+	return current
+}
@@ -25,7 +25,7 @@ func (p *TelegramPool) GetNonce(addr types.Address) uint64 {
 // GetCapacity returns the current number of slots
 // occupied in the pool as well as the max limit
 func (p *TelegramPool) GetCapacity() (uint64, uint64) {
-	return p.gauge.read(), p.gauge.max
+	return p.gauge.read(), p.gauge.limit()
 }
 
 // GetPendingTx returns the transaction by hash in the TxPool (pending txn) [Thread-safe]
@@ -99,6 +99,32 @@ func (q *accountQueue) pop() *types.Telegram {
 	return transaction
 }
 
+// getByNonce returns the transaction with the given nonce in the queue, if any.
+func (q *accountQueue) getByNonce(nonce uint64) *types.Telegram {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace swaps the transaction of the same nonce in the queue with the given one,
+// and returns the replaced transaction or nil if there is none.
+func (q *accountQueue) replace(tx *types.Telegram) *types.Telegram {
+	for i, old := range q.queue {
+		if old.Nonce == tx.Nonce {
+			q.queue[i] = tx
+			heap.Fix(&q.queue, i)
+
+			return old
+		}
+	}
+
+	return nil
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
@@ -128,7 +154,7 @@ func (q *minNonceQueue) Swap(i, j int) {
 func (q *minNonceQueue) Less(i, j int) bool {
 	// The higher gas price Tx comes first if the nonces are same
 	if (*q)[i].Nonce == (*q)[j].Nonce {
-		return (*q)[i].GasPrice.Cmp((*q)[j].GasPrice) > 0
+		return (*q)[i].GetGasFeeCap().Cmp((*q)[j].GetGasFeeCap()) > 0
 	}
 
 	return (*q)[i].Nonce < (*q)[j].Nonce
@@ -152,38 +178,54 @@ func (q *minNonceQueue) Pop() interface{} {
 	return x
 }
 
+// A thread-safe wrapper of a maxPriceQueue, as the primaries
+// are replaced while the block is being built
 type pricedQueue struct {
-	queue maxPriceQueue
+	sync.Mutex
+	queue *maxPriceQueue
 }
 
 func newPricedQueue() *pricedQueue {
 	q := pricedQueue{
-		queue: make(maxPriceQueue, 0),
+		queue: &maxPriceQueue{
+			txs: make([]*types.Telegram, 0),
+		},
 	}
 
-	heap.Init(&q.queue)
+	heap.Init(q.queue)
 
 	return &q
 }
 
-// clear empties the underlying queue.
-func (q *pricedQueue) clear() {
-	q.queue = q.queue[:0]
+// clear empties the underlying queue and sets the base fee
+// the transactions pushed next are ordered with.
+func (q *pricedQueue) clear(baseFee uint64) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.queue.baseFee = baseFee
+	q.queue.txs = q.queue.txs[:0]
 }
 
 // Pushes the given transactions onto the queue.
 func (q *pricedQueue) push(tx *types.Telegram) {
-	heap.Push(&q.queue, tx)
+	q.Lock()
+	defer q.Unlock()
+
+	heap.Push(q.queue, tx)
 }
 
 // Pop removes the first transaction from the queue
 // or nil if the queue is empty.
 func (q *pricedQueue) pop() *types.Telegram {
-	if q.length() == 0 {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.queue.Len() == 0 {
 		return nil
 	}
 
-	transaction, ok := heap.Pop(&q.queue).(*types.Telegram)
+	transaction, ok := heap.Pop(q.queue).(*types.Telegram)
 	if !ok {
 		return nil
 	}
@@ -191,13 +233,37 @@ func (q *pricedQueue) pop() *types.Telegram {
 	return transaction
 }
 
+// remove removes the given transaction from the queue,
+// and returns false if the queue does not hold it.
+func (q *pricedQueue) remove(tx *types.Telegram) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	for i, queued := range q.queue.txs {
+		if queued == tx {
+			heap.Remove(q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // length returns the number of transactions in the queue.
 func (q *pricedQueue) length() uint64 {
+	q.Lock()
+	defer q.Unlock()
+
 	return uint64(q.queue.Len())
 }
 
-// transactions sorted by gas price (descending)
-type maxPriceQueue []*types.Telegram
+// transactions sorted by the effective tip (descending),
+// which is the gas price without the base fee
+type maxPriceQueue struct {
+	baseFee uint64
+	txs     []*types.Telegram
+}
 
 /* Queue methods required by the heap interface */
 
@@ -206,19 +272,19 @@ func (q *maxPriceQueue) Peek() *types.Telegram {
 		return nil
 	}
 
-	return (*q)[0]
+	return q.txs[0]
 }
 
 func (q *maxPriceQueue) Len() int {
-	return len(*q)
+	return len(q.txs)
 }
 
 func (q *maxPriceQueue) Swap(i, j int) {
-	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
 }
 
 func (q *maxPriceQueue) Less(i, j int) bool {
-	return (*q)[i].GasPrice.Uint64() > (*q)[j].GasPrice.Uint64()
+	return q.txs[i].EffectiveGasTip(q.baseFee).Cmp(q.txs[j].EffectiveGasTip(q.baseFee)) > 0
 }
 
 func (q *maxPriceQueue) Push(x interface{}) {
@@ -227,14 +293,13 @@ func (q *maxPriceQueue) Push(x interface{}) {
 		return
 	}
 
-	*q = append(*q, transaction)
+	q.txs = append(q.txs, transaction)
 }
 
 func (q *maxPriceQueue) Pop() interface{} {
-	old := q
-	n := len(*old)
-	x := (*old)[n-1]
-	*q = (*old)[0 : n-1]
+	n := len(q.txs)
+	x := q.txs[n-1]
+	q.txs = q.txs[0 : n-1]
 
 	return x
 }
@@ -0,0 +1,196 @@
+package telepool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/verification"
+	"github.com/emc-protocol/edge-matrix/types"
+)
+
+// redundantTarget is an app running the model of the redundant edge call, hosted by one of the edge nodes
+type redundantTarget struct {
+	peerID string
+	app    string
+
+	// slots occupying the app
+	slots uint64
+}
+
+// providerMap caches the providers proven by the edge nodes, by their peer IDs
+type providerMap struct {
+	sync.RWMutex
+	all map[string]types.Address
+}
+
+func (m *providerMap) get(peerID string) (types.Address, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	provider, ok := m.all[peerID]
+
+	return provider, ok
+}
+
+func (m *providerMap) set(peerID string, provider types.Address) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.all == nil {
+		m.all = make(map[string]types.Address)
+	}
+
+	m.all[peerID] = provider
+}
+
+// callRedundant dispatches the edge call to the called edge node and to other edge nodes running the same model,
+// and returns the result of their majority with the signed responses of all the edge nodes.
+// The result is verified and stored
+func (p *TelegramPool) callRedundant(
+	call *application.EdgeCall,
+	from types.Address,
+	requestHash types.Hash,
+) (*verification.Result, error) {
+	if p.verifier == nil || p.appSyncer == nil {
+		return nil, ErrRedundancyUnsupported
+	}
+
+	if call.Redundancy < 2 || call.Redundancy > verification.MaxRedundancy {
+		return nil, verification.ErrInvalidRedundancy
+	}
+
+	// the jobs are fetched from a single edge node, and the vouchers pay a single provider
+	if call.Async || call.Voucher != nil {
+		return nil, fmt.Errorf("%w: async or paid edge call", ErrRedundancyUnsupported)
+	}
+
+	targets, err := p.selectRedundantPeers(call, int(call.Redundancy))
+	if err != nil {
+		return nil, err
+	}
+
+	attestations := make([]*verification.Attestation, len(targets))
+
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+
+		go func(i int, target redundantTarget) {
+			defer wg.Done()
+
+			peerCall := call.Copy()
+			peerCall.PeerId = target.peerID
+			peerCall.App = target.app
+
+			attestations[i] = p.attest(peerCall, from, requestHash)
+		}(i, target)
+	}
+
+	wg.Wait()
+
+	return p.verifier.Verify(requestHash, from, attestations)
+}
+
+// attest sends the edge call to one of the edge nodes and returns its response with the provider proven by the edge node,
+// the usage records of the calls are stored as for the single edge calls
+func (p *TelegramPool) attest(call *application.EdgeCall, from types.Address, requestHash types.Hash) *verification.Attestation {
+	attestation := &verification.Attestation{PeerID: call.PeerId}
+
+	provider, err := p.peerProvider(call.PeerId)
+	if err != nil {
+		attestation.Error = err.Error()
+
+		return attestation
+	}
+
+	attestation.Provider = provider
+
+	respBuf, usage, err := p.callApp(call, from, requestHash)
+	if usage != nil {
+		p.recordUsage(usage, call.PeerId, from, requestHash)
+	}
+
+	if err != nil {
+		attestation.Error = err.Error()
+
+		return attestation
+	}
+
+	resp := &application.EdgeResponse{}
+	if err := resp.UnmarshalRLP(respBuf); err != nil {
+		attestation.Error = err.Error()
+
+		return attestation
+	}
+
+	attestation.Response = resp
+
+	return attestation
+}
+
+// peerProvider returns the provider signing the edge responses of the edge node, as proven by the edge node
+// over the stream authenticating its peer ID
+func (p *TelegramPool) peerProvider(peerId string) (types.Address, error) {
+	if provider, ok := p.providers.get(peerId); ok {
+		return provider, nil
+	}
+
+	host, closeHost, err := p.appPeerHost(peerId)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+	defer closeHost()
+
+	provider, err := application.ProviderOf(host, application.ProtoTagEcApp, peerId, appPeerProbeTimeout)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	p.providers.set(peerId, provider)
+
+	return provider, nil
+}
+
+// selectRedundantPeers returns the called app followed by the least busy apps of other edge nodes running its model
+func (p *TelegramPool) selectRedundantPeers(call *application.EdgeCall, n int) ([]redundantTarget, error) {
+	var calledApp *application.HostedApp
+	if called := p.appSyncer.GetAppPeer(call.PeerId); called != nil {
+		calledApp = called.App(call.App)
+	}
+
+	if calledApp == nil || calledApp.ModelHash == "" {
+		return nil, fmt.Errorf("%w: unknown model of %s", ErrInsufficientAppPeers, call.PeerId)
+	}
+
+	now := time.Now()
+	candidates := make([]redundantTarget, 0)
+
+	for _, appPeer := range p.appSyncer.GetAppPeers() {
+		if appPeer.ID == call.PeerId || appPeer.IsExpired(now) {
+			continue
+		}
+
+		// a single app of each edge node, as its apps share the same hardware
+		for _, app := range appPeer.HostedApps() {
+			if app.ModelHash == calledApp.ModelHash {
+				candidates = append(candidates, redundantTarget{peerID: appPeer.ID, app: app.Name, slots: app.GuageHeight})
+
+				break
+			}
+		}
+	}
+
+	if len(candidates) < n-1 {
+		return nil, fmt.Errorf("%w: %d of %d", ErrInsufficientAppPeers, len(candidates)+1, n)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].slots < candidates[j].slots
+	})
+
+	return append([]redundantTarget{{peerID: call.PeerId, app: call.App}}, candidates[:n-1]...), nil
+}
@@ -12,7 +12,7 @@ const (
 // Gauge for measuring pool capacity in slots
 type slotGauge struct {
 	height uint64 // amount of slots currently occupying the pool
-	max    uint64 // max limit
+	max    uint64 // max limit, accessed with atomics
 }
 
 // read returns the current height of the gauge.
@@ -30,10 +30,20 @@ func (g *slotGauge) decrease(slots uint64) {
 	atomic.AddUint64(&g.height, ^(slots - 1))
 }
 
+// limit returns the max limit of the gauge.
+func (g *slotGauge) limit() uint64 {
+	return atomic.LoadUint64(&g.max)
+}
+
+// setLimit sets the max limit of the gauge.
+func (g *slotGauge) setLimit(max uint64) {
+	atomic.StoreUint64(&g.max, max)
+}
+
 // highPressure checks if the gauge level
 // is higher than the 0.8*max threshold
 func (g *slotGauge) highPressure() bool {
-	return g.read() > (highPressureMark*g.max)/100
+	return g.read() > (highPressureMark*g.limit())/100
 }
 
 // slotsRequired calculates the number of slots required for given transaction(s).
@@ -1,15 +1,24 @@
 package telepool
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/armon/go-metrics"
 	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/blob"
+	"github.com/emc-protocol/edge-matrix/application/calllog"
+	"github.com/emc-protocol/edge-matrix/application/jobs"
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/application/verification"
 	"github.com/emc-protocol/edge-matrix/blockchain"
+	"github.com/emc-protocol/edge-matrix/chain"
 	"github.com/emc-protocol/edge-matrix/contracts"
 	"github.com/emc-protocol/edge-matrix/network"
+	"github.com/emc-protocol/edge-matrix/state"
 	"github.com/emc-protocol/edge-matrix/telepool/proto"
 	"github.com/emc-protocol/edge-matrix/types"
 	"github.com/golang/protobuf/ptypes/any"
@@ -55,6 +64,14 @@ var (
 	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
 	ErrSmartContractRestricted = errors.New("smart contract deployment restricted")
+	ErrLaneOverflow            = errors.New("telepool lane is full")
+	ErrReplacementUnderpriced  = errors.New("replacement telegram underpriced")
+	ErrTxTypeNotSupported      = errors.New("telegram type not supported")
+	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrAppPeerOffline          = errors.New("app peer is offline")
+	ErrRedundancyUnsupported   = errors.New("edge call redundancy not supported")
+	ErrInsufficientAppPeers    = errors.New("not enough app peers running the model")
+	ErrInvalidEdgeResponse     = errors.New("edge response not signed by its provider")
 )
 
 func (o teleOrigin) String() (s string) {
@@ -81,6 +98,9 @@ const (
 	maxAccountSkips = uint64(10)
 	pruningCooldown = 5000 * time.Millisecond
 
+	// appPeerProbeTimeout is the timeout of the liveness probe of a stale app peer, and of the proof of its provider
+	appPeerProbeTimeout = 5 * time.Second
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "telepool"
 )
@@ -126,6 +146,15 @@ type promoteRequest struct {
 type Config struct {
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
+
+	// PriceLimit is the lowest minimum gas price of the lanes
+	PriceLimit uint64
+	// PriceBump is the minimum percentage of the gas price increase to replace a telegram
+	PriceBump uint64
+	// Lanes is the slot budgets of the lanes
+	Lanes LaneConfig
+	// Forks are the forks of the chain, the typed telegrams are accepted from London
+	Forks *chain.Forks
 }
 
 type TelegramPool struct {
@@ -136,8 +165,23 @@ type TelegramPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
-	executables *pricedQueue
+	// all the primaries sorted by max gas price in each lane
+	executables [numLanes]*pricedQueue
+
+	// lanes with the separate slot budgets and minimum gas prices
+	lanes [numLanes]*laneState
+
+	// slot budgets of the lanes
+	laneConfig LaneConfig
+
+	// forks gate the typed telegrams
+	forks *chain.Forks
+
+	// lowest minimum gas price of the lanes, accessed with atomics
+	priceLimit uint64
+
+	// minimum percentage of the gas price increase for replacement
+	priceBump uint64
 
 	// lookup map keeping track of all
 	// transactions present in the pool
@@ -150,6 +194,28 @@ type TelegramPool struct {
 
 	appSyncer application.Syncer
 
+	// store of the countersigned usage records of the routed edge calls, and the countersigning key
+	usageStore *metering.Store
+	usageKey   *ecdsa.PrivateKey
+
+	// manager tracking the edge calls routed as jobs
+	jobs *jobs.Manager
+
+	// verifier of the edge calls dispatched to several edge nodes
+	verifier *verification.Verifier
+
+	// signer recovering the providers of the edge responses
+	respSigner application.Signer
+
+	// providers proven by the edge nodes the redundant edge calls are dispatched to
+	providers providerMap
+
+	// store of the blobs referenced by the routed edge calls and fetched from the edge nodes
+	blobStore *blob.Store
+
+	// audit log of the edge calls sent by the node
+	callLog *calllog.Store
+
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
@@ -187,12 +253,16 @@ func NewTelegramPool(
 	teleVesion string,
 ) (*TelegramPool, error) {
 	pool := &TelegramPool{
-		logger:      logger.Named("telepool"),
-		store:       store,
-		executables: newPricedQueue(),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Telegram)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
+		logger:     logger.Named("telepool"),
+		store:      store,
+		priceLimit: config.PriceLimit,
+		priceBump:  config.PriceBump,
+		laneConfig: config.Lanes,
+		forks:      config.Forks,
+		accounts:   accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index:      lookupMap{all: make(map[types.Hash]*types.Telegram)},
+		providers:  providerMap{all: make(map[string]types.Address)},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
 		promoteReqCh: make(chan promoteRequest),
@@ -202,6 +272,11 @@ func NewTelegramPool(
 		edgeNetwork:  edgeNetwork,
 	}
 
+	for l := lane(0); l < numLanes; l++ {
+		pool.executables[l] = newPricedQueue()
+		pool.lanes[l] = newLaneState(config.Lanes.maxSlots(l, config.MaxSlots), config.PriceLimit)
+	}
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
@@ -277,10 +352,41 @@ func (p *TelegramPool) SetAppSyncer(appSyncer application.Syncer) {
 	p.appSyncer = appSyncer
 }
 
+// SetUsageStore enables the metering of the routed edge calls,
+// their usage records are countersigned by the key and stored
+func (p *TelegramPool) SetUsageStore(store *metering.Store, key *ecdsa.PrivateKey) {
+	p.usageStore = store
+	p.usageKey = key
+}
+
+// SetJobManager enables the tracking of the edge calls routed as jobs
+func (p *TelegramPool) SetJobManager(m *jobs.Manager) {
+	p.jobs = m
+}
+
+// SetBlobStore enables the blobs referenced by the routed edge calls, served to the edge nodes from the store
+func (p *TelegramPool) SetBlobStore(store *blob.Store) {
+	p.blobStore = store
+}
+
+// SetCallLog enables the audit log of the edge calls sent by the node, kept in the store
+func (p *TelegramPool) SetCallLog(store *calllog.Store) {
+	p.callLog = store
+}
+
+// SetVerifier enables the dispatch of the edge calls to several edge nodes, verified by the verifier
+func (p *TelegramPool) SetVerifier(v *verification.Verifier) {
+	p.verifier = v
+}
+
+// SetResponseSigner sets the signer recovering the providers of the edge responses fetched by the pool
+func (p *TelegramPool) SetResponseSigner(s application.Signer) {
+	p.respSigner = s
+}
+
 // AddTele adds a new telegram to the pool (sent from json-RPC/gRPC endpoints)
 // and broadcasts it to the network (if enabled).
 func (p *TelegramPool) AddTele(tele *types.Telegram) (string, error) {
-	resp := &application.EdgeResponse{}
 	if tele.To != nil && *tele.To == contracts.EdgeCallPrecompile {
 		input := tele.Input
 		call := &application.EdgeCall{}
@@ -289,43 +395,12 @@ func (p *TelegramPool) AddTele(tele *types.Telegram) (string, error) {
 		}
 		//if call.Endpoint != "/api" {
 		// do not gossip tele
-		host := p.edgeNetwork.GetHost()
-
-		relayAddr, addr := p.getAppPeerAddr(call.PeerId)
-		p.logger.Debug("edge call", "PeerId", call.PeerId, "Endpoint", call.Endpoint, "addr", addr, "Relay", relayAddr)
-		if relayAddr != "" || addr != "" {
-			clientHost, err2 := p.newTempHost()
-			if err2 != nil {
-				return "", err2
-			}
-			defer clientHost.Close()
-
-			host = clientHost
-			err := p.addAddrToHost(call.PeerId, host, addr, relayAddr)
-			if err != nil {
-				return "", err
-			}
-		}
-
-		respBuf, callErr := application.Call(host, application.ProtoTagEcApp, call)
+		respBuf, callErr := p.callEdgeApp(tele, call)
 		if callErr != nil {
 			return "", callErr
 		}
 
-		err := resp.UnmarshalRLP(respBuf)
-		if err != nil {
-			return "", err
-		}
-		tele.RespFrom = resp.From
-		tele.RespR = resp.R
-		tele.RespV = resp.V
-		tele.RespS = resp.S
-		tele.RespHash = resp.Hash
-		if len(resp.RespString) > 0 {
-			return resp.RespString, nil
-		} else {
-			return "", nil
-		}
+		return setEdgeCallResponse(tele, call, respBuf)
 		//}
 	}
 
@@ -401,6 +476,279 @@ func (p *TelegramPool) newTempHost() (host.Host, error) {
 	return clientHost, nil
 }
 
+// CallApp sends the edge call to the endpoint of the app peer,
+// through its relay or its address if the app peer is known
+func (p *TelegramPool) CallApp(call *application.EdgeCall) ([]byte, error) {
+	resp, _, err := p.callApp(call, types.ZeroAddress, types.ZeroHash)
+
+	return resp, err
+}
+
+// callEdgeApp sends the edge call of the telegram on behalf of its sender,
+// and countersigns and stores the usage record of the call
+func (p *TelegramPool) callEdgeApp(tele *types.Telegram, call *application.EdgeCall) (resp []byte, err error) {
+	from, err := p.signer.Sender(tele)
+	if err != nil {
+		return nil, ErrExtractSignature
+	}
+
+	requestHash := tele.Hash
+	if requestHash == types.ZeroHash {
+		requestHash = tele.Copy().ComputeHash().Hash
+	}
+
+	if p.callLog != nil {
+		start := time.Now()
+
+		defer func() {
+			p.logCall(&calllog.Entry{
+				Hash:      requestHash,
+				Caller:    from,
+				PeerID:    call.PeerId,
+				Request:   tele.Input,
+				Response:  resp,
+				StartTime: uint64(start.UnixMilli()),
+				Duration:  uint64(time.Since(start).Milliseconds()),
+			}, err)
+		}()
+	}
+
+	if call.Redundancy > 0 {
+		result, err := p.callRedundant(call, from, requestHash)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.MarshalRLP(), nil
+	}
+
+	resp, usage, err := p.callApp(call, from, requestHash)
+	if usage != nil {
+		p.recordUsage(usage, call.PeerId, from, requestHash)
+	}
+
+	if err == nil && call.Async && p.jobs != nil {
+		if err := p.jobs.Track(&jobs.Job{ID: requestHash, PeerID: call.PeerId, Caller: from}); err != nil {
+			p.logger.Error("failed to track edge call job", "id", requestHash, "err", err)
+		}
+	}
+
+	return resp, err
+}
+
+// setEdgeCallResponse sets the signed edge response on the edge call telegram and returns the response content.
+// The redundant edge call responds the base64 encoded verification result with the signed responses of all
+// the edge nodes, and the telegram keeps the response of their majority
+func setEdgeCallResponse(tele *types.Telegram, call *application.EdgeCall, respBuf []byte) (string, error) {
+	resp := &application.EdgeResponse{}
+	respString := ""
+
+	if call.Redundancy > 0 {
+		result := &verification.Result{}
+		if err := result.UnmarshalRLP(respBuf); err != nil {
+			return "", err
+		}
+
+		resp = result.Response()
+		respString = base64.StdEncoding.EncodeToString(respBuf)
+	} else {
+		if err := resp.UnmarshalRLP(respBuf); err != nil {
+			return "", err
+		}
+
+		respString = resp.RespString
+	}
+
+	tele.RespFrom = resp.From
+	tele.RespR = resp.R
+	tele.RespV = resp.V
+	tele.RespS = resp.S
+	tele.RespHash = resp.Hash
+
+	return respString, nil
+}
+
+// FetchJob fetches the job routed by the node from the edge node running it
+func (p *TelegramPool) FetchJob(job *jobs.Job) (*jobs.Job, error) {
+	input, err := json.Marshal(&struct {
+		ID types.Hash `json:"id"`
+	}{
+		ID: job.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	respBuf, _, err := p.callApp(&application.EdgeCall{PeerId: job.PeerID, Endpoint: "/job", Input: input}, job.Caller, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &application.EdgeResponse{}
+	if err := resp.UnmarshalRLP(respBuf); err != nil {
+		return nil, err
+	}
+
+	if err := p.verifyResponse(resp); err != nil {
+		return nil, err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(resp.RespString)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := &jobs.Job{}
+	if err := fetched.UnmarshalRLP(raw); err != nil {
+		return nil, err
+	}
+
+	if fetched.ID != job.ID {
+		return nil, fmt.Errorf("%w: job %s fetched for %s", ErrInvalidEdgeResponse, fetched.ID, job.ID)
+	}
+
+	return fetched, nil
+}
+
+// verifyResponse checks the edge response is signed by the provider it is sent from
+func (p *TelegramPool) verifyResponse(resp *application.EdgeResponse) error {
+	if p.respSigner == nil {
+		return fmt.Errorf("%w: no signer of the edge responses", ErrInvalidEdgeResponse)
+	}
+
+	provider, err := p.respSigner.Provider(resp)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEdgeResponse, err)
+	}
+
+	if provider != resp.From {
+		return ErrInvalidEdgeResponse
+	}
+
+	return nil
+}
+
+func (p *TelegramPool) callApp(
+	call *application.EdgeCall,
+	from types.Address,
+	requestHash types.Hash,
+) ([]byte, *metering.UsageRecord, error) {
+	if err := p.checkBlobs(call.Blobs); err != nil {
+		return nil, nil, err
+	}
+
+	host, closeHost, err := p.appPeerHost(call.PeerId)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeHost()
+
+	p.logger.Debug("edge call", "PeerId", call.PeerId, "Endpoint", call.Endpoint)
+
+	if err := p.checkAppPeerAlive(host, call.PeerId); err != nil {
+		return nil, nil, err
+	}
+
+	return application.CallWithUsage(host, application.ProtoTagEcApp, call, from, requestHash)
+}
+
+// appPeerHost returns the host reaching the app peer, a temporary host through its relay or its address if known.
+// The temporary host serves the blobs of the node, as the edge network host does
+func (p *TelegramPool) appPeerHost(peerId string) (host.Host, func(), error) {
+	relayAddr, addr := p.getAppPeerAddr(peerId)
+	if relayAddr == "" && addr == "" {
+		return p.edgeNetwork.GetHost(), func() {}, nil
+	}
+
+	clientHost, err := p.newTempHost()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.addAddrToHost(peerId, clientHost, addr, relayAddr); err != nil {
+		clientHost.Close()
+
+		return nil, nil, err
+	}
+
+	if p.blobStore != nil {
+		blob.Serve(p.logger, clientHost, p.blobStore)
+	}
+
+	return clientHost, func() { clientHost.Close() }, nil
+}
+
+// logCall stores the edge call with its error in the audit log, the call does not fail with the log
+func (p *TelegramPool) logCall(entry *calllog.Entry, err error) {
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if err := p.callLog.Put(entry); err != nil {
+		p.logger.Error("failed to log edge call", "hash", entry.Hash, "err", err)
+	}
+}
+
+// recordUsage countersigns the usage record of the edge call and stores it, the records which do not match
+// the call, the provider proven by the edge node the call was routed to or the provider signature are dropped
+func (p *TelegramPool) recordUsage(usage *metering.UsageRecord, peerId string, from types.Address, requestHash types.Hash) {
+	if p.usageStore == nil || p.usageKey == nil {
+		return
+	}
+
+	if usage.Caller != from || usage.RequestHash != requestHash {
+		p.logger.Debug("dropping usage record of another call", "RequestHash", usage.RequestHash, "expected", requestHash)
+
+		return
+	}
+
+	if err := usage.Verify(); err != nil {
+		p.logger.Debug("dropping invalid usage record", "RequestHash", requestHash, "err", err)
+
+		return
+	}
+
+	if provider, err := p.peerProvider(peerId); err != nil || usage.Provider != provider {
+		p.logger.Debug("dropping usage record of another provider", "RequestHash", requestHash, "PeerId", peerId, "err", err)
+
+		return
+	}
+
+	if err := usage.Countersign(p.usageKey); err != nil {
+		p.logger.Error("failed to countersign usage record", "err", err)
+
+		return
+	}
+
+	if err := p.usageStore.Put(usage); err != nil {
+		p.logger.Error("failed to store usage record", "err", err)
+	}
+}
+
+// checkAppPeerAlive probes the stale app peer before routing a call to it,
+// the app peer is marked offline if it does not respond
+func (p *TelegramPool) checkAppPeerAlive(host host.Host, peerId string) error {
+	if p.appSyncer == nil {
+		return nil
+	}
+
+	appPeer := p.appSyncer.GetAppPeer(peerId)
+	if appPeer == nil || !appPeer.IsStale(time.Now(), application.DefaultAppPeerStaleDuration) {
+		return nil
+	}
+
+	if err := application.Alive(host, application.ProtoTagEcApp, peerId, appPeerProbeTimeout); err != nil {
+		p.logger.Debug("app peer failed the liveness probe", "PeerId", peerId, "err", err)
+		p.appSyncer.MarkOffline(peerId)
+
+		return fmt.Errorf("%w: %s", ErrAppPeerOffline, peerId)
+	}
+
+	p.appSyncer.MarkAlive(peerId)
+
+	return nil
+}
+
 func (p *TelegramPool) getAppPeerAddr(peerId string) (relayAddr string, addr string) {
 	if p.appSyncer != nil {
 		appPeer := p.appSyncer.GetAppPeer(peerId)
@@ -423,7 +771,9 @@ func (p *TelegramPool) addTele(origin teleOrigin, tele *types.Telegram) (string,
 		return "", err
 	}
 
-	if p.gauge.highPressure() {
+	teleLane := p.lanes[laneOf(tele)]
+
+	if p.gauge.highPressure() || teleLane.gauge.highPressure() {
 		p.signalPruning()
 
 		//	only accept transactions with expected nonce
@@ -433,15 +783,19 @@ func (p *TelegramPool) addTele(origin teleOrigin, tele *types.Telegram) (string,
 		}
 	}
 
-	// check for overflow
-	if p.gauge.read()+slotsRequired(tele) > p.gauge.max {
-		return "", ErrTxPoolOverflow
+	// check for overflow, the telegram of the same nonce it replaces releases its slots
+	var replaced *types.Telegram
+	if account := p.accounts.get(tele.From); account != nil {
+		replaced = account.getByNonce(tele.Nonce)
+	}
+
+	if err := p.checkSlots(tele, replaced); err != nil {
+		return "", err
 	}
 
 	respString := ""
 	// telegram for edge call
 	if origin == local {
-		resp := &application.EdgeResponse{}
 		if tele.To != nil && *tele.To == contracts.EdgeCallPrecompile {
 			input := tele.Input
 			call := &application.EdgeCall{}
@@ -449,43 +803,17 @@ func (p *TelegramPool) addTele(origin teleOrigin, tele *types.Telegram) (string,
 				return "", err
 			}
 
-			host := p.edgeNetwork.GetHost()
-
-			relayAddr, addr := p.getAppPeerAddr(call.PeerId)
-			p.logger.Info("edge call", "PeerId", call.PeerId, "Endpoint", call.Endpoint, "addr", addr, "Relay", relayAddr)
-			if relayAddr != "" || addr != "" {
-				clientHost, err2 := p.newTempHost()
-				if err2 != nil {
-					return "", err2
-				}
-				defer clientHost.Close()
-
-				host = clientHost
-				err := p.addAddrToHost(call.PeerId, host, addr, relayAddr)
-				if err != nil {
-					return "", err
-				}
-			}
-
-			// TODO relpace Call to CallWithFrom
-			//respBuf, callErr := application.CallWithFrom(p.edgeNetwork.GetHost(), application.ProtoTagEcApp, call, tele.From)
-			respBuf, callErr := application.Call(host, application.ProtoTagEcApp, call)
+			respBuf, callErr := p.callEdgeApp(tele, call)
 			if callErr != nil {
 				return "", callErr
 			}
 
-			err := resp.UnmarshalRLP(respBuf)
+			callResp, err := setEdgeCallResponse(tele, call, respBuf)
 			if err != nil {
 				return "", err
 			}
-			tele.RespFrom = resp.From
-			tele.RespR = resp.R
-			tele.RespV = resp.V
-			tele.RespS = resp.S
-			tele.RespHash = resp.Hash
-			if len(resp.RespString) > 0 {
-				respString = resp.RespString
-			}
+
+			respString = callResp
 		}
 	}
 	tele.ComputeHash()
@@ -560,12 +888,40 @@ func (p *TelegramPool) validateTele(tele *types.Telegram) error {
 
 	// Check max nonce
 	teleAcct := p.accounts.get(tele.From)
-	if teleAcct != nil && tele.Nonce >= (p.store.GetNonce(stateRoot, tele.From)+teleAcct.maxEnqueued) {
+	if teleAcct != nil && tele.Nonce >= (p.store.GetNonce(stateRoot, tele.From)+teleAcct.getMaxEnqueued()) {
 		// don't signal promotion for
 		// higher nonce txs
 		return ErrNonceTooHigh
 	}
 
+	// Check the typed telegrams are accepted at the next block
+	if tele.Type.IsEnvelope() && (p.forks == nil || !p.forks.IsLondon(p.store.Header().Number+1)) {
+		return ErrTxTypeNotSupported
+	}
+
+	// Check the gas covers the access list of the typed telegram
+	if accessListGas, err := state.AccessListGasCost(tele); err != nil || tele.Gas < accessListGas {
+		return ErrIntrinsicGas
+	}
+
+	if tele.Type == types.DynamicFeeTx &&
+		(tele.GasTipCap == nil || tele.GasFeeCap == nil || tele.GasTipCap.Cmp(tele.GasFeeCap) > 0) {
+		return ErrTipAboveFeeCap
+	}
+
+	// Check the gas price against the minimum of the lane
+	if tele.GasPrice == nil || tele.IsUnderpriced(p.lanes[laneOf(tele)].getMinGasPrice()) {
+		return ErrUnderpriced
+	}
+
+	// Check the replacement of the telegram with the same nonce
+	if teleAcct != nil {
+		if existing := teleAcct.getByNonce(tele.Nonce); existing != nil &&
+			!isReplacement(existing, tele, p.priceBump) {
+			return ErrReplacementUnderpriced
+		}
+	}
+
 	return nil
 }
 
@@ -685,7 +1041,7 @@ func (p *TelegramPool) pruneAccountsWithNonceHoles() {
 			removed := account.enqueued.clear()
 
 			p.index.remove(removed...)
-			p.gauge.decrease(slotsRequired(removed...))
+			p.decreaseGauge(removed...)
 
 			return true
 		},
@@ -705,6 +1061,34 @@ func (p *TelegramPool) handleEnqueueRequest(req enqueueRequest) {
 
 	p.logger.Debug(fmt.Sprintf("handleEnqueueRequest From:%s", addr.String()))
 
+	// replace the telegram with the same nonce
+	replaced, promoted, err := account.replace(tele, p.priceBump, func(replaced *types.Telegram) error {
+		return p.checkSlots(tele, replaced)
+	})
+	if err != nil {
+		p.logger.Error("replace request", "err", err)
+
+		p.index.remove(tele)
+
+		return
+	}
+
+	if replaced != nil {
+		p.logger.Debug("replace request", "hash", tele.Hash.String(), "replaced", replaced.Hash.String())
+
+		p.index.remove(replaced)
+		p.decreaseGauge(replaced)
+		p.increaseGauge(tele)
+
+		// the replaced primary of the account is swapped in the executables of the block being built,
+		// which are ordered by the price of the replacement
+		if promoted && p.executables[laneOf(replaced)].remove(replaced) {
+			p.executables[laneOf(tele)].push(tele)
+		}
+
+		return
+	}
+
 	// enqueue telegram
 	if err := account.enqueue(tele); err != nil {
 		p.logger.Error("enqueue request", "err", err)
@@ -716,7 +1100,7 @@ func (p *TelegramPool) handleEnqueueRequest(req enqueueRequest) {
 
 	p.logger.Debug("enqueue request", "hash", tele.Hash.String())
 
-	p.gauge.increase(slotsRequired(tele))
+	p.increaseGauge(tele)
 
 	//p.eventManager.signalEvent(proto.EventType_ENQUEUED, tele.Hash)
 
@@ -741,7 +1125,7 @@ func (p *TelegramPool) handlePromoteRequest(req promoteRequest) {
 	p.logger.Debug("promote request", "promoted", promoted, "addr", addr.String())
 
 	p.index.remove(pruned...)
-	p.gauge.decrease(slotsRequired(pruned...))
+	p.decreaseGauge(pruned...)
 
 	// update metrics
 	p.updatePending(int64(len(promoted)))
@@ -750,24 +1134,26 @@ func (p *TelegramPool) handlePromoteRequest(req promoteRequest) {
 }
 
 // Prepare generates all the transactions
-// ready for execution. (primaries)
-func (p *TelegramPool) Prepare() {
+// ready for execution (primaries), ordered by
+// the effective tip above the given base fee.
+func (p *TelegramPool) Prepare(baseFee uint64) {
 	// clear from previous round
-	if p.executables.length() != 0 {
-		p.executables.clear()
+	for _, executables := range p.executables {
+		executables.clear(baseFee)
 	}
 
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
-	// push primaries to the executables queue
+	// push primaries to the executables queue of their lanes
 	for _, tx := range primaries {
-		p.executables.push(tx)
+		p.executables[laneOf(tx)].push(tx)
 	}
 }
 
 // Peek returns the best-price selected
-// transaction ready for execution.
+// transaction ready for execution
+// from the lane of the highest priority.
 func (p *TelegramPool) Peek() *types.Telegram {
 	// Popping the executables queue
 	// does not remove the actual tx
@@ -775,7 +1161,13 @@ func (p *TelegramPool) Peek() *types.Telegram {
 	// The executables queue just provides
 	// insight into which account has the
 	// highest priced tx (head of promoted queue)
-	return p.executables.pop()
+	for _, executables := range p.executables {
+		if tx := executables.pop(); tx != nil {
+			return tx
+		}
+	}
+
+	return nil
 }
 
 // Pop removes the given transaction from the
@@ -796,14 +1188,14 @@ func (p *TelegramPool) Pop(tx *types.Telegram) {
 	account.resetDemotions()
 
 	// update state
-	p.gauge.decrease(slotsRequired(tx))
+	p.decreaseGauge(tx)
 
 	// update metrics
 	p.updatePending(-1)
 
 	// update executables
 	if tx := account.promoted.peek(); tx != nil {
-		p.executables.push(tx)
+		p.executables[laneOf(tx)].push(tx)
 	}
 }
 
@@ -822,7 +1214,7 @@ func (p *TelegramPool) Drop(tx *types.Telegram) {
 	// pool resource cleanup
 	clearAccountQueue := func(txs []*types.Telegram) {
 		p.index.remove(txs...)
-		p.gauge.decrease(slotsRequired(txs...))
+		p.decreaseGauge(txs...)
 
 		// increase counter
 		droppedCount += len(txs)
@@ -941,6 +1333,9 @@ func (p *TelegramPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	// follow the lane occupancy with the minimum gas prices
+	p.adjustLanePrices()
+
 	if !p.getSealing() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
@@ -1017,7 +1412,7 @@ func (p *TelegramPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	// pool cleanup callback
 	cleanup := func(stale []*types.Telegram) {
 		p.index.remove(stale...)
-		p.gauge.decrease(slotsRequired(stale...))
+		p.decreaseGauge(stale...)
 	}
 
 	// prune pool state
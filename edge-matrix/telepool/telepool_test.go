@@ -0,0 +1,298 @@
+package telepool
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/emc-protocol/edge-matrix/application"
+	"github.com/emc-protocol/edge-matrix/application/metering"
+	"github.com/emc-protocol/edge-matrix/application/verification"
+	"github.com/emc-protocol/edge-matrix/chain"
+	"github.com/emc-protocol/edge-matrix/crypto"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramPool_VerifyResponse(t *testing.T) {
+	t.Parallel()
+
+	signer := application.NewEIP155Signer(chain.AllForksEnabled.At(0), 2)
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	resp, err := signer.SignEdgeResp(&application.EdgeResponse{RespString: "job"}, key)
+	require.NoError(t, err)
+
+	resp.From = crypto.PubKeyToAddress(&key.PublicKey)
+
+	p := &TelegramPool{}
+	assert.ErrorIs(t, p.verifyResponse(resp), ErrInvalidEdgeResponse)
+
+	p.SetResponseSigner(signer)
+	assert.NoError(t, p.verifyResponse(resp))
+
+	forged := resp.Copy()
+	forged.From = types.StringToAddress("0x1")
+	assert.ErrorIs(t, p.verifyResponse(forged), ErrInvalidEdgeResponse)
+
+	tampered := resp.Copy()
+	tampered.RespString = "other job"
+	assert.ErrorIs(t, p.verifyResponse(tampered), ErrInvalidEdgeResponse)
+}
+
+func TestSetEdgeCallResponse_Redundant(t *testing.T) {
+	t.Parallel()
+
+	signer := application.NewEIP155Signer(chain.AllForksEnabled.At(0), 2)
+
+	attest := func(peerID string, content string) *verification.Attestation {
+		key, err := crypto.GenerateECDSAKey()
+		require.NoError(t, err)
+
+		resp, err := signer.SignEdgeResp(&application.EdgeResponse{RespString: content}, key)
+		require.NoError(t, err)
+
+		resp.From = crypto.PubKeyToAddress(&key.PublicKey)
+
+		return &verification.Attestation{PeerID: peerID, Provider: resp.From, Response: resp}
+	}
+
+	result, err := verification.Tally(types.StringToHash("0x1"), types.ZeroAddress, []*verification.Attestation{
+		attest("peer0", "b"),
+		attest("peer1", "a"),
+		attest("peer2", "a"),
+	})
+	require.NoError(t, err)
+
+	tele := &types.Telegram{}
+
+	respString, err := setEdgeCallResponse(tele, &application.EdgeCall{Redundancy: 3}, result.MarshalRLP())
+	require.NoError(t, err)
+
+	// the caller receives all the signed responses, the telegram keeps the one of the majority
+	raw, err := base64.StdEncoding.DecodeString(respString)
+	require.NoError(t, err)
+
+	decoded := &verification.Result{}
+	require.NoError(t, decoded.UnmarshalRLP(raw))
+	assert.Len(t, decoded.Attestations, 3)
+	assert.Equal(t, result.Attestations[0].Provider, decoded.Attestations[0].Provider)
+	assert.Equal(t, result.Attestations[1].Response.From, tele.RespFrom)
+
+	single := result.Attestations[0].Response
+
+	respString, err = setEdgeCallResponse(tele, &application.EdgeCall{}, single.MarshalRLP())
+	require.NoError(t, err)
+	assert.Equal(t, "b", respString)
+	assert.Equal(t, single.From, tele.RespFrom)
+}
+
+func TestTelegramPool_RecordUsage(t *testing.T) {
+	t.Parallel()
+
+	nodeKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	providerKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	store := metering.NewMemoryStore()
+	defer store.Close()
+
+	p := &TelegramPool{logger: hclog.NewNullLogger()}
+	p.SetUsageStore(store, nodeKey)
+
+	// the provider proven by the edge node the calls are routed to
+	p.providers.set("peer0", crypto.PubKeyToAddress(&providerKey.PublicKey))
+
+	caller := types.StringToAddress("0x1")
+	requestHash := types.StringToHash("0x2")
+
+	record := func(key *ecdsa.PrivateKey) *metering.UsageRecord {
+		usage := &metering.UsageRecord{RequestHash: requestHash, Caller: caller, Path: "/v1/run", StartTime: 1000}
+		require.NoError(t, usage.SignProvider(key))
+
+		return usage
+	}
+
+	stored := func(usage *metering.UsageRecord) bool {
+		_, ok, err := store.Get(usage.Hash())
+		require.NoError(t, err)
+
+		return ok
+	}
+
+	// the record signed by another provider key is not countersigned
+	other := record(otherKey)
+	p.recordUsage(other, "peer0", caller, requestHash)
+	assert.False(t, other.IsCountersigned())
+	assert.False(t, stored(other))
+
+	usage := record(providerKey)
+	p.recordUsage(usage, "peer0", caller, requestHash)
+	assert.True(t, usage.IsCountersigned())
+	assert.True(t, stored(usage))
+}
+
+type mockStore struct{}
+
+func (m *mockStore) Header() *types.Header {
+	return &types.Header{}
+}
+
+func (m *mockStore) GetNonce(types.Hash, types.Address) uint64 {
+	return 0
+}
+
+func (m *mockStore) GetBalance(types.Hash, types.Address) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (m *mockStore) GetBlockByHash(types.Hash, bool) (*types.Block, bool) {
+	return nil, false
+}
+
+// mockSigner trusts the senders and providers set on the telegrams
+type mockSigner struct{}
+
+func (s *mockSigner) Sender(tele *types.Telegram) (types.Address, error) {
+	return tele.From, nil
+}
+
+func (s *mockSigner) Provider(tele *types.Telegram) (types.Address, error) {
+	return tele.RespFrom, nil
+}
+
+func newTestPool(t *testing.T, maxSlots uint64, lanes LaneConfig) *TelegramPool {
+	t.Helper()
+
+	pool, err := NewTelegramPool(hclog.NewNullLogger(), &mockStore{}, nil, nil, &Config{
+		MaxSlots:           maxSlots,
+		MaxAccountEnqueued: 16,
+		PriceLimit:         1,
+		PriceBump:          DefaultPriceBump,
+		Lanes:              lanes,
+	}, "")
+	require.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+	pool.Start()
+
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+func newTestTele(from types.Address, nonce uint64, gasPrice int64, to *types.Address) *types.Telegram {
+	return &types.Telegram{
+		From:     from,
+		Nonce:    nonce,
+		GasPrice: big.NewInt(gasPrice),
+		Gas:      21000,
+		To:       to,
+		Value:    big.NewInt(0),
+		V:        big.NewInt(1),
+		R:        big.NewInt(1),
+		S:        big.NewInt(1),
+	}
+}
+
+// addTestTele adds the gossiped telegram to the pool and waits for the pool to hold it
+func addTestTele(t *testing.T, pool *TelegramPool, tele *types.Telegram) error {
+	t.Helper()
+
+	if _, err := pool.addTele(gossip, tele); err != nil {
+		return err
+	}
+
+	require.Eventually(t, func() bool {
+		account := pool.accounts.get(tele.From)
+
+		return account != nil && account.getByNonce(tele.Nonce) == tele
+	}, time.Second, time.Millisecond)
+
+	return nil
+}
+
+func TestTelegramPool_Replace(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestPool(t, 10, LaneConfig{})
+	sender := types.StringToAddress("0x1")
+
+	require.NoError(t, addTestTele(t, pool, newTestTele(sender, 0, 100, nil)))
+
+	cases := []struct {
+		name     string
+		gasPrice int64
+		err      error
+	}{
+		{"same price", 100, ErrReplacementUnderpriced},
+		{"bumped too little", 109, ErrReplacementUnderpriced},
+		{"bumped enough", 110, nil},
+		{"lower price", 50, ErrReplacementUnderpriced},
+	}
+
+	for _, c := range cases {
+		err := addTestTele(t, pool, newTestTele(sender, 0, c.gasPrice, nil))
+		if c.err != nil {
+			assert.ErrorIs(t, err, c.err, c.name)
+
+			continue
+		}
+
+		assert.NoError(t, err, c.name)
+	}
+
+	// the replaced telegram releases its slot and its index entry
+	assert.Equal(t, uint64(1), pool.gauge.read())
+	assert.Equal(t, int64(110), pool.accounts.get(sender).getByNonce(0).GasPrice.Int64())
+	assert.Len(t, pool.index.all, 1)
+}
+
+func TestTelegramPool_PriceOrderedPromotion(t *testing.T) {
+	t.Parallel()
+
+	pool := newTestPool(t, 10, LaneConfig{})
+
+	prices := map[types.Address]int64{
+		types.StringToAddress("0x1"): 10,
+		types.StringToAddress("0x2"): 30,
+		types.StringToAddress("0x3"): 20,
+	}
+
+	for sender, price := range prices {
+		require.NoError(t, addTestTele(t, pool, newTestTele(sender, 0, price, nil)))
+	}
+
+	require.Eventually(t, func() bool {
+		return pool.accounts.promoted() == 3
+	}, time.Second, time.Millisecond)
+
+	pool.Prepare(0)
+
+	// the replacement of a primary during the block building is ordered by its price
+	replacement := newTestTele(types.StringToAddress("0x1"), 0, 40, nil)
+	require.NoError(t, addTestTele(t, pool, replacement))
+
+	expected := []int64{40, 30, 20}
+	for _, price := range expected {
+		tele := pool.Peek()
+		require.NotNil(t, tele)
+		assert.Equal(t, price, tele.GasPrice.Int64())
+
+		pool.Pop(tele)
+	}
+
+	assert.Nil(t, pool.Peek())
+	assert.Equal(t, uint64(0), pool.gauge.read())
+}
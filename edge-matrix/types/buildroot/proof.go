@@ -0,0 +1,132 @@
+package buildroot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	itrie "github.com/emc-protocol/edge-matrix/state/immutable-trie"
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrBlockHashMismatch = errors.New("block hash doesn't match header")
+	ErrTelegramMismatch  = errors.New("telegram doesn't match proof")
+	ErrReceiptMismatch   = errors.New("receipt doesn't match proof")
+)
+
+// CalculateTelegramsProof returns the Merkle proof of the telegram
+// at the given index against the telegrams root
+func CalculateTelegramsProof(telegrams []*types.Telegram, index int) ([][]byte, error) {
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	return calculateProofWithRlp(len(telegrams), index, func(i int) *fastrlp.Value {
+		ar.Reset()
+
+		return telegrams[i].MarshalRLPWith(ar)
+	})
+}
+
+// CalculateReceiptsProof returns the Merkle proof of the receipt
+// at the given index against the receipts root
+func CalculateReceiptsProof(receipts []*types.Receipt, index int) ([][]byte, error) {
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	return calculateProofWithRlp(len(receipts), index, func(i int) *fastrlp.Value {
+		ar.Reset()
+
+		return receipts[i].MarshalRLPWith(ar)
+	})
+}
+
+// VerifyProof checks the Merkle proof of the list item at the given index
+// against the root and returns the RLP encoded item
+func VerifyProof(root types.Hash, index uint64, proof [][]byte) ([]byte, error) {
+	ar := numArenaPool.Get()
+	defer numArenaPool.Put(ar)
+
+	return itrie.VerifyProof(root, ar.NewUint(index).MarshalTo(nil), proof)
+}
+
+// VerifyTelegramProof checks the telegram and the receipt in the proof
+// are included in the block of the given header
+func VerifyTelegramProof(header *types.Header, proof *types.TelegramProof) error {
+	if header.Hash != proof.BlockHash || header.Number != proof.BlockNumber {
+		return ErrBlockHashMismatch
+	}
+
+	if err := VerifyTelegramInclusion(header.TeleRoot, proof.Index, proof.Telegram, proof.TelegramProof); err != nil {
+		return err
+	}
+
+	return VerifyReceiptInclusion(header.ReceiptsRoot, proof.Index, proof.Receipt, proof.ReceiptProof)
+}
+
+// VerifyTelegramInclusion checks the telegram is at the given index
+// of the telegrams with the given root
+func VerifyTelegramInclusion(root types.Hash, index uint64, telegram *types.Telegram, proof [][]byte) error {
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	value, err := VerifyProof(root, index, proof)
+	if err != nil {
+		return fmt.Errorf("failed to verify telegram proof: %w", err)
+	}
+
+	if !bytes.Equal(value, telegram.MarshalRLPWith(ar).MarshalTo(nil)) {
+		return ErrTelegramMismatch
+	}
+
+	return nil
+}
+
+// VerifyReceiptInclusion checks the receipt is at the given index
+// of the receipts with the given root
+func VerifyReceiptInclusion(root types.Hash, index uint64, receipt *types.Receipt, proof [][]byte) error {
+	ar := arenaPool.Get()
+	defer arenaPool.Put(ar)
+
+	value, err := VerifyProof(root, index, proof)
+	if err != nil {
+		return fmt.Errorf("failed to verify receipt proof: %w", err)
+	}
+
+	if !bytes.Equal(value, receipt.MarshalRLPWith(ar).MarshalTo(nil)) {
+		return ErrReceiptMismatch
+	}
+
+	return nil
+}
+
+func calculateProofWithRlp(num, index int, h func(indx int) *fastrlp.Value) ([][]byte, error) {
+	if index < 0 || index >= num {
+		return nil, fmt.Errorf("index %d out of range of %d items", index, num)
+	}
+
+	var (
+		keys   = make([][]byte, num)
+		values = make([][]byte, num)
+	)
+
+	ar := numArenaPool.Get()
+	defer numArenaPool.Put(ar)
+
+	for i := 0; i < num; i++ {
+		keys[i] = ar.NewUint(uint64(i)).MarshalTo(nil)
+		values[i] = h(i).MarshalTo(nil)
+
+		ar.Reset()
+	}
+
+	storage := itrie.NewMemoryStorage()
+
+	root, err := itrie.WriteTrie(storage, keys, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return itrie.Prove(storage, root, keys[index])
+}
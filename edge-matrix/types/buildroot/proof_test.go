@@ -0,0 +1,119 @@
+package buildroot
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/emc-protocol/edge-matrix/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildTestBlock(num int) (*types.Header, []*types.Telegram, []*types.Receipt) {
+	telegrams := make([]*types.Telegram, num)
+	receipts := make([]*types.Receipt, num)
+
+	for i := 0; i < num; i++ {
+		to := types.StringToAddress("1")
+
+		telegrams[i] = (&types.Telegram{
+			Nonce:    uint64(i),
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(int64(i)),
+			Input:    []byte{0x1, 0x2, 0x3},
+			V:        big.NewInt(27),
+			R:        big.NewInt(1),
+			S:        big.NewInt(2),
+		}).ComputeHash()
+
+		receipts[i] = &types.Receipt{
+			CumulativeGasUsed: uint64(21000 * (i + 1)),
+			Logs: []*types.Log{
+				{
+					Address: to,
+					Topics:  []types.Hash{types.StringToHash("1")},
+					Data:    []byte{byte(i)},
+				},
+			},
+			TxHash: telegrams[i].Hash,
+		}
+		receipts[i].SetStatus(types.ReceiptSuccess)
+	}
+
+	header := (&types.Header{
+		Number:       10,
+		TeleRoot:     CalculateTelegramsRoot(telegrams),
+		ReceiptsRoot: CalculateReceiptsRoot(receipts),
+	}).ComputeHash()
+
+	return header, telegrams, receipts
+}
+
+func TestTelegramProof(t *testing.T) {
+	t.Parallel()
+
+	for _, num := range []int{1, 5, 200} {
+		header, telegrams, receipts := buildTestBlock(num)
+
+		for _, index := range []int{0, num / 2, num - 1} {
+			telegramProof, err := CalculateTelegramsProof(telegrams, index)
+			assert.NoError(t, err)
+
+			receiptProof, err := CalculateReceiptsProof(receipts, index)
+			assert.NoError(t, err)
+
+			proof := &types.TelegramProof{
+				BlockHash:     header.Hash,
+				BlockNumber:   header.Number,
+				Index:         uint64(index),
+				Telegram:      telegrams[index],
+				Receipt:       receipts[index],
+				TelegramProof: telegramProof,
+				ReceiptProof:  receiptProof,
+			}
+
+			assert.NoError(t, VerifyTelegramProof(header, proof))
+
+			// the telegram at the another index
+			proof.Telegram = telegrams[(index+1)%num]
+			if num > 1 {
+				assert.True(t, errors.Is(VerifyTelegramProof(header, proof), ErrTelegramMismatch))
+			}
+		}
+	}
+
+	t.Run("should verify the inclusion of the receipt", func(t *testing.T) {
+		t.Parallel()
+
+		header, _, receipts := buildTestBlock(5)
+
+		proof, err := CalculateReceiptsProof(receipts, 2)
+		assert.NoError(t, err)
+
+		assert.NoError(t, VerifyReceiptInclusion(header.ReceiptsRoot, 2, receipts[2], proof))
+		assert.True(t, errors.Is(VerifyReceiptInclusion(header.ReceiptsRoot, 2, receipts[3], proof), ErrReceiptMismatch))
+		assert.Error(t, VerifyReceiptInclusion(header.ReceiptsRoot, 3, receipts[2], proof))
+	})
+
+	t.Run("should return error for the index out of range", func(t *testing.T) {
+		t.Parallel()
+
+		_, telegrams, _ := buildTestBlock(3)
+
+		_, err := CalculateTelegramsProof(telegrams, 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("should return ErrBlockHashMismatch for the other block", func(t *testing.T) {
+		t.Parallel()
+
+		header, _, _ := buildTestBlock(3)
+
+		assert.True(t, errors.Is(VerifyTelegramProof(header, &types.TelegramProof{
+			BlockHash:   types.StringToHash("1"),
+			BlockNumber: header.Number,
+		}), ErrBlockHashMismatch))
+	})
+}
@@ -25,6 +25,9 @@ type Header struct {
 
 	GasLimit uint64
 	GasUsed  uint64
+
+	// BaseFee is the EIP-1559 base fee per gas, zero when the dynamic fees are not active
+	BaseFee uint64
 }
 
 func (h *Header) Equal(hh *Header) bool {
@@ -71,6 +74,7 @@ func (h *Header) Copy() *Header {
 		Timestamp:    h.Timestamp,
 		GasLimit:     h.GasLimit,
 		GasUsed:      h.GasUsed,
+		BaseFee:      h.BaseFee,
 	}
 
 	newHeader.Miner = make([]byte, len(h.Miner))
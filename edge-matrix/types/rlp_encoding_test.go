@@ -169,6 +169,78 @@ func TestRLPMarshall_And_Unmarshall_TypedTelegram(t *testing.T) {
 	}
 }
 
+func TestRLPMarshall_And_Unmarshall_EnvelopeTelegram(t *testing.T) {
+	addrTo := StringToAddress("11")
+	accessList := TxAccessList{
+		{Address: StringToAddress("33"), StorageKeys: []Hash{StringToHash("1"), StringToHash("2")}},
+		{Address: StringToAddress("44"), StorageKeys: []Hash{}},
+	}
+
+	testTable := []*Telegram{
+		{
+			Type:       AccessListTx,
+			ChainID:    big.NewInt(100),
+			Nonce:      1,
+			GasPrice:   big.NewInt(11),
+			Gas:        11,
+			To:         &addrTo,
+			Value:      big.NewInt(1),
+			Input:      []byte{1, 2},
+			AccessList: accessList,
+			V:          big.NewInt(1),
+			S:          big.NewInt(26),
+			R:          big.NewInt(27),
+		},
+		{
+			Type:       DynamicFeeTx,
+			ChainID:    big.NewInt(100),
+			Nonce:      2,
+			GasTipCap:  big.NewInt(2),
+			GasFeeCap:  big.NewInt(20),
+			Gas:        11,
+			Value:      big.NewInt(1),
+			Input:      []byte{3},
+			AccessList: accessList,
+			V:          big.NewInt(0),
+			S:          big.NewInt(26),
+			R:          big.NewInt(27),
+		},
+	}
+
+	for _, originalTx := range testTable {
+		originalTx.ComputeHash()
+
+		txRLP := originalTx.MarshalRLP()
+		assert.Equal(t, byte(originalTx.Type), txRLP[0])
+
+		unmarshalledTx := new(Telegram)
+		assert.NoError(t, unmarshalledTx.UnmarshalRLP(txRLP))
+
+		assert.Equal(t, originalTx.Type, unmarshalledTx.Type)
+		assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+		assert.Equal(t, originalTx.ChainID, unmarshalledTx.ChainID)
+		assert.Equal(t, originalTx.AccessList, unmarshalledTx.AccessList)
+		assert.Equal(t, originalTx.To, unmarshalledTx.To)
+		assert.Equal(t, originalTx.GetGasFeeCap(), unmarshalledTx.GetGasFeeCap())
+		assert.Equal(t, originalTx.GetGasTipCap(), unmarshalledTx.GetGasTipCap())
+	}
+}
+
+func TestTelegram_EffectiveGasTip(t *testing.T) {
+	legacy := &Telegram{GasPrice: big.NewInt(10)}
+	dynamic := &Telegram{Type: DynamicFeeTx, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(10)}
+
+	// the legacy telegram tips the gas price above the base fee
+	assert.Equal(t, big.NewInt(6), legacy.EffectiveGasTip(4))
+	assert.Equal(t, big.NewInt(10), legacy.EffectiveGasPrice(4))
+
+	// the dynamic fee telegram tips at most its tip cap
+	assert.Equal(t, big.NewInt(2), dynamic.EffectiveGasTip(4))
+	assert.Equal(t, big.NewInt(6), dynamic.EffectiveGasPrice(4))
+	assert.Equal(t, big.NewInt(1), dynamic.EffectiveGasTip(9))
+	assert.Equal(t, big.NewInt(10), dynamic.EffectiveGasPrice(9))
+}
+
 func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	t.Parallel()
 
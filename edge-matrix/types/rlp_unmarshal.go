@@ -218,6 +218,14 @@ func (h *Header) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// baseFee
+	h.BaseFee = 0
+	if len(elems) > 12 {
+		if h.BaseFee, err = elems[12].GetUint64(); err != nil {
+			return err
+		}
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -376,30 +384,76 @@ func (t *Telegram) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		return err
 	}
 
-	if len(elems) < 9 {
-		return fmt.Errorf("incorrect number of elements to decode transaction, expected 9 but found %d", len(elems))
+	// the typed envelopes have the chain ID and the access list,
+	// and DynamicFeeTx has two fee caps in place of the gas price
+	minElems := 9
+
+	switch t.Type {
+	case AccessListTx:
+		minElems = 11
+	case DynamicFeeTx:
+		minElems = 12
+	}
+
+	if len(elems) < minElems {
+		return fmt.Errorf("incorrect number of elements to decode transaction, expected %d but found %d", minElems, len(elems))
 	}
 
 	p.Hash(t.Hash[:0], v)
 
+	i := 0
+
+	getBigInt := func() (*big.Int, error) {
+		b := new(big.Int)
+		err := elems[i].GetBigInt(b)
+		i++
+
+		return b, err
+	}
+
+	if t.Type.IsEnvelope() {
+		// chainID
+		if t.ChainID, err = getBigInt(); err != nil {
+			return err
+		}
+	}
+
 	// nonce
-	if t.Nonce, err = elems[0].GetUint64(); err != nil {
+	if t.Nonce, err = elems[i].GetUint64(); err != nil {
 		return err
 	}
 
-	// gasPrice
-	t.GasPrice = new(big.Int)
-	if err = elems[1].GetBigInt(t.GasPrice); err != nil {
-		return err
+	i++
+
+	if t.Type == DynamicFeeTx {
+		// gasTipCap
+		if t.GasTipCap, err = getBigInt(); err != nil {
+			return err
+		}
+
+		// gasFeeCap
+		if t.GasFeeCap, err = getBigInt(); err != nil {
+			return err
+		}
+
+		// gasPrice is the fee cap for the code not aware of the dynamic fees
+		t.GasPrice = new(big.Int).Set(t.GasFeeCap)
+	} else {
+		// gasPrice
+		if t.GasPrice, err = getBigInt(); err != nil {
+			return err
+		}
 	}
 
 	// gas
-	if t.Gas, err = elems[2].GetUint64(); err != nil {
+	if t.Gas, err = elems[i].GetUint64(); err != nil {
 		return err
 	}
 
+	i++
+
 	// to
-	if vv, _ := v.Get(3).Bytes(); len(vv) == 20 {
+	if vv, _ := elems[i].Bytes(); len(vv) == 20 {
 		// address
 		addr := BytesToAddress(vv)
 		t.To = &addr
@@ -408,54 +462,61 @@ func (t *Telegram) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		t.To = nil
 	}
 
+	i++
+
 	// value
-	t.Value = new(big.Int)
-	if err = elems[4].GetBigInt(t.Value); err != nil {
+	if t.Value, err = getBigInt(); err != nil {
 		return err
 	}
 
 	// input
-	if t.Input, err = elems[5].GetBytes(t.Input[:0]); err != nil {
+	if t.Input, err = elems[i].GetBytes(t.Input[:0]); err != nil {
 		return err
 	}
 
+	i++
+
+	if t.Type.IsEnvelope() {
+		// accessList
+		t.AccessList = nil
+		if err = t.AccessList.unmarshalRLPFrom(p, elems[i]); err != nil {
+			return err
+		}
+
+		i++
+	}
+
 	// V
-	t.V = new(big.Int)
-	if err = elems[6].GetBigInt(t.V); err != nil {
+	if t.V, err = getBigInt(); err != nil {
 		return err
 	}
 
 	// R
-	t.R = new(big.Int)
-	if err = elems[7].GetBigInt(t.R); err != nil {
+	if t.R, err = getBigInt(); err != nil {
 		return err
 	}
 
 	// S
-	t.S = new(big.Int)
-	if err = elems[8].GetBigInt(t.S); err != nil {
+	if t.S, err = getBigInt(); err != nil {
 		return err
 	}
 
-	if len(elems) > 9 {
+	if len(elems) > i {
 		// edge call response signature values
-		t.RespV = new(big.Int)
-		if err = elems[9].GetBigInt(t.RespV); err != nil {
+		if t.RespV, err = getBigInt(); err != nil {
 			return err
 		}
 
-		t.RespR = new(big.Int)
-		if err = elems[10].GetBigInt(t.RespR); err != nil {
+		if t.RespR, err = getBigInt(); err != nil {
 			return err
 		}
 
-		t.RespS = new(big.Int)
-		if err = elems[11].GetBigInt(t.RespS); err != nil {
+		if t.RespS, err = getBigInt(); err != nil {
 			return err
 		}
 
 		// edge call response hash
-		if vv, _ := v.Get(12).Bytes(); len(vv) == 32 {
+		if vv, _ := elems[i].Bytes(); len(vv) == 32 {
 			// address
 			respHash := BytesToHash(vv)
 			t.RespHash = respHash
@@ -464,8 +525,10 @@ func (t *Telegram) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 			t.RespHash = ZeroHash
 		}
 
+		i++
+
 		// edge call response address
-		if vv, _ := v.Get(13).Bytes(); len(vv) == 20 {
+		if vv, _ := elems[i].Bytes(); len(vv) == 20 {
 			// address
 			respAddr := BytesToAddress(vv)
 			t.RespFrom = respAddr
@@ -473,7 +536,10 @@ func (t *Telegram) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 			// reset To
 			t.RespFrom = ZeroAddress
 		}
+
+		i++
 	}
+
 	if t.Type == StateTx {
 		// set From with default value
 		t.From = ZeroAddress
@@ -481,13 +547,59 @@ func (t *Telegram) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 		// We need to set From field for state transaction,
 		// because we are using unique, predefined address, for sending such transactions
 		// From
-		if len(elems) > 14 {
-			if vv, err := v.Get(14).Bytes(); err == nil && len(vv) == AddressLength {
+		if len(elems) > i {
+			if vv, err := elems[i].Bytes(); err == nil && len(vv) == AddressLength {
 				// address
 				t.From = BytesToAddress(vv)
 			}
 		}
 	}
 
+	if t.Type.IsEnvelope() {
+		// the typed envelopes are hashed with their type prefix
+		t.ComputeHash()
+	}
+
+	return nil
+}
+
+// unmarshalRLPFrom unmarshals the access list in RLP format
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	tuples, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, tv := range tuples {
+		elems, err := tv.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(elems) != 2 {
+			return fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d", len(elems))
+		}
+
+		tuple := AccessTuple{}
+
+		if err = elems[0].GetAddr(tuple.Address[:]); err != nil {
+			return err
+		}
+
+		keys, err := elems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		tuple.StorageKeys = make([]Hash, len(keys))
+		for j, key := range keys {
+			if err = key.GetHash(tuple.StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+
+		*al = append(*al, tuple)
+	}
+
 	return nil
 }
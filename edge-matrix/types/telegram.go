@@ -11,8 +11,10 @@ import (
 type TeleType byte
 
 const (
-	LegacyTx TeleType = 0x0
-	StateTx  TeleType = 0x7f
+	LegacyTx     TeleType = 0x0
+	AccessListTx TeleType = 0x01 // EIP-2930
+	DynamicFeeTx TeleType = 0x02 // EIP-1559
+	StateTx      TeleType = 0x7f
 
 	StateTransactionGasLimit = 1000000 // some arbitrary default gas limit for state transactions
 )
@@ -21,7 +23,7 @@ func txTypeFromByte(b byte) (TeleType, error) {
 	tt := TeleType(b)
 
 	switch tt {
-	case LegacyTx, StateTx:
+	case LegacyTx, AccessListTx, DynamicFeeTx, StateTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -32,6 +34,10 @@ func (t TeleType) String() (s string) {
 	switch t {
 	case LegacyTx:
 		return "LegacyTx"
+	case AccessListTx:
+		return "AccessListTx"
+	case DynamicFeeTx:
+		return "DynamicFeeTx"
 	case StateTx:
 		return "StateTx"
 	}
@@ -39,6 +45,48 @@ func (t TeleType) String() (s string) {
 	return
 }
 
+// IsEnvelope checks the type is the EIP-2718 envelope signed by the users,
+// which is hashed and signed with its type prefix
+func (t TeleType) IsEnvelope() bool {
+	return t == AccessListTx || t == DynamicFeeTx
+}
+
+// AccessTuple is the address and the storage keys the telegram plans to access
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the EIP-2930 access list
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of the storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+
+	return sum
+}
+
+// Copy returns a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	cpy := make(TxAccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return cpy
+}
+
 type Telegram struct {
 	Nonce    uint64
 	GasPrice *big.Int
@@ -61,6 +109,15 @@ type Telegram struct {
 
 	Type TeleType
 
+	// ChainID and AccessList are the fields of the typed envelopes
+	ChainID    *big.Int
+	AccessList TxAccessList
+
+	// GasTipCap and GasFeeCap are the fields of DynamicFeeTx, whose GasPrice
+	// is set to GasFeeCap on decoding and is not encoded
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+
 	// Cache
 	size atomic.Value
 }
@@ -70,11 +127,16 @@ func (t *Telegram) IsContractCreation() bool {
 	return t.To == nil
 }
 
-// ComputeHash computes the hash of the transaction
+// ComputeHash computes the hash of the transaction,
+// the typed envelopes are hashed with their type prefix
 func (t *Telegram) ComputeHash() *Telegram {
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
+	if t.Type.IsEnvelope() {
+		_, _ = hash.Write([]byte{byte(t.Type)})
+	}
+
 	v := t.MarshalRLPWith(ar)
 	hash.WriteRlp(t.Hash[:0], v)
 
@@ -111,17 +173,77 @@ func (t *Telegram) Copy() *Telegram {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
+	if t.GasTipCap != nil {
+		tt.GasTipCap = new(big.Int).Set(t.GasTipCap)
+	}
+
+	if t.GasFeeCap != nil {
+		tt.GasFeeCap = new(big.Int).Set(t.GasFeeCap)
+	}
+
+	tt.AccessList = t.AccessList.Copy()
+
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// Cost returns gas * gasFeeCap + value
 func (t *Telegram) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	total := new(big.Int).Mul(t.GetGasFeeCap(), new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
 	return total
 }
 
+// GetGasFeeCap returns the max gas price the sender pays,
+// which is the gas price of the telegrams other than DynamicFeeTx
+func (t *Telegram) GetGasFeeCap() *big.Int {
+	if t.Type == DynamicFeeTx && t.GasFeeCap != nil {
+		return t.GasFeeCap
+	}
+
+	if t.GasPrice == nil {
+		return big.NewInt(0)
+	}
+
+	return t.GasPrice
+}
+
+// GetGasTipCap returns the max gas price paid to the block creator above the base fee,
+// which is the gas price of the telegrams other than DynamicFeeTx
+func (t *Telegram) GetGasTipCap() *big.Int {
+	if t.Type == DynamicFeeTx && t.GasTipCap != nil {
+		return t.GasTipCap
+	}
+
+	return t.GetGasFeeCap()
+}
+
+// EffectiveGasTip returns the gas price paid to the block creator with the given base fee,
+// which is negative if the fee cap is below the base fee
+func (t *Telegram) EffectiveGasTip(baseFee uint64) *big.Int {
+	tip := new(big.Int).Sub(t.GetGasFeeCap(), new(big.Int).SetUint64(baseFee))
+	if tipCap := t.GetGasTipCap(); tip.Cmp(tipCap) > 0 {
+		tip.Set(tipCap)
+	}
+
+	return tip
+}
+
+// EffectiveGasPrice returns the gas price the sender pays with the given base fee
+func (t *Telegram) EffectiveGasPrice(baseFee uint64) *big.Int {
+	if t.Type != DynamicFeeTx {
+		return new(big.Int).Set(t.GetGasFeeCap())
+	}
+
+	price := t.EffectiveGasTip(baseFee)
+
+	return price.Add(price, new(big.Int).SetUint64(baseFee))
+}
+
 func (t *Telegram) Size() uint64 {
 	if size := t.size.Load(); size != nil {
 		sizeVal, ok := size.(uint64)
@@ -143,5 +265,5 @@ func (t *Telegram) ExceedsBlockGasLimit(blockGasLimit uint64) bool {
 }
 
 func (t *Telegram) IsUnderpriced(priceLimit uint64) bool {
-	return t.GasPrice.Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+	return t.GetGasFeeCap().Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
 }
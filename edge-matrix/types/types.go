@@ -158,3 +158,16 @@ type Proof struct {
 	Data     []Hash // the proof himself
 	Metadata map[string]interface{}
 }
+
+// TelegramProof is a telegram and its receipt included in a block,
+// along with the Merkle proofs of both against the telegrams root
+// and the receipts root of the block header
+type TelegramProof struct {
+	BlockHash     Hash
+	BlockNumber   uint64
+	Index         uint64
+	Telegram      *Telegram
+	Receipt       *Receipt
+	TelegramProof [][]byte
+	ReceiptProof  [][]byte
+}